@@ -0,0 +1,112 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlxx"
+)
+
+// Actor describes on whose behalf an audit event was recorded.
+//
+// swagger:enum Actor
+type Actor string
+
+const (
+	// ActorIdentity is used for events caused by a signed in identity, or an
+	// identity signing in or up, through the public API.
+	ActorIdentity Actor = "identity"
+
+	// ActorAnonymous is used for events caused through the public API which
+	// can not be attributed to an identity, such as failed sign-ins.
+	ActorAnonymous Actor = "anonymous"
+
+	// ActorAdmin is used for events caused through the admin API.
+	ActorAdmin Actor = "admin"
+
+	// ActorSystem is used for events caused by background workers and the
+	// command line.
+	ActorSystem Actor = "system"
+)
+
+func ToActor(s string) (Actor, error) {
+	switch a := Actor(s); a {
+	case ActorIdentity, ActorAnonymous, ActorAdmin, ActorSystem:
+		return a, nil
+	default:
+		return "", errors.WithStack(herodot.ErrBadRequest().WithReasonf("Audit event actor %q is not valid.", s))
+	}
+}
+
+// An Audit Event
+//
+// Audit events record security relevant activity, such as sign-ins, identity
+// changes, and revoked sessions.
+//
+// swagger:model auditEvent
+type Event struct {
+	// required: true
+	ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+	NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+
+	// EventType is the name of the event, for example `LoginSucceeded`.
+	//
+	// required: true
+	EventType string `json:"event_type" db:"event_type"`
+
+	// required: true
+	Actor Actor `json:"actor" db:"actor"`
+
+	// IdentityID is the ID of the identity the event relates to.
+	IdentityID uuid.NullUUID `json:"identity_id,omitempty" faker:"-" db:"identity_id"`
+
+	// SessionID is the ID of the session the event relates to.
+	SessionID uuid.NullUUID `json:"session_id,omitempty" faker:"-" db:"session_id"`
+
+	// FlowID is the ID of the self-service flow the event relates to.
+	FlowID uuid.NullUUID `json:"flow_id,omitempty" faker:"-" db:"flow_id"`
+
+	// IPAddress is the IP address of the client which caused the event.
+	IPAddress string `json:"ip_address" db:"ip_address"`
+
+	// UserAgent is the user agent of the client which caused the event.
+	UserAgent string `json:"user_agent" db:"user_agent"`
+
+	// Attributes contains further details of the event.
+	//
+	// required: true
+	Attributes sqlxx.MapStringInterface `json:"attributes" faker:"-" db:"attributes"`
+
+	// CreatedAt is the time the event was recorded.
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+}
+
+func (e Event) PageToken() keysetpagination.PageToken {
+	return keysetpagination.NewPageToken(
+		keysetpagination.Column{
+			Name:  "created_at",
+			Order: keysetpagination.OrderDescending,
+			Value: e.CreatedAt,
+		}, keysetpagination.Column{
+			Name:  "id",
+			Value: e.ID,
+		},
+	)
+}
+
+func (e Event) DefaultPageToken() keysetpagination.PageToken {
+	return Event{ID: uuid.Nil, CreatedAt: time.Date(2200, 12, 31, 23, 59, 59, 0, time.UTC)}.PageToken()
+}
+
+func (e Event) TableName() string { return "audit_events" }
+func (e *Event) GetID() uuid.UUID { return e.ID }
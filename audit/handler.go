@@ -0,0 +1,216 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/logrusx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+const AdminRouteListEvents = "/audit-events"
+
+type (
+	handlerDependencies interface {
+		httpx.WriterProvider
+		logrusx.Provider
+		nosurfx.CSRFProvider
+		PersistenceProvider
+		config.Provider
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+	HandlerProvider interface {
+		AuditHandler() *Handler
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlobs(httprouterx.AdminPrefix+AdminRouteListEvents, AdminRouteListEvents)
+	public.GET(httprouterx.AdminPrefix+AdminRouteListEvents, redir.RedirectToAdminRoute(h.r))
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(AdminRouteListEvents, h.listAuditEvents)
+}
+
+// Paginated Audit Event List Response
+//
+// swagger:response listAuditEvents
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listAuditEventsResponse struct {
+	keysetpagination.ResponseHeaders
+
+	// List of audit events
+	//
+	// in:body
+	Body []Event
+}
+
+// Paginated List Audit Event Parameters
+//
+// swagger:parameters listAuditEvents
+type ListAuditEventsParameters struct {
+	keysetpagination.RequestParameters
+
+	// IdentityID filters events by the identity they relate to.
+	//
+	// required: false
+	// in: query
+	IdentityID *uuid.UUID `json:"identity_id"`
+
+	// SessionID filters events by the session they relate to.
+	//
+	// required: false
+	// in: query
+	SessionID *uuid.UUID `json:"session_id"`
+
+	// FlowID filters events by the self-service flow they relate to.
+	//
+	// required: false
+	// in: query
+	FlowID *uuid.UUID `json:"flow_id"`
+
+	// EventType filters events by their name, for example `LoginSucceeded`.
+	//
+	// required: false
+	// in: query
+	EventType string `json:"event_type"`
+
+	// Actor filters events by their actor.
+	//
+	// required: false
+	// in: query
+	Actor *Actor `json:"actor"`
+
+	// CreatedAfter only returns events recorded at or after this time.
+	//
+	// required: false
+	// in: query
+	CreatedAfter *time.Time `json:"created_after"`
+
+	// CreatedBefore only returns events recorded before this time.
+	//
+	// required: false
+	// in: query
+	CreatedBefore *time.Time `json:"created_before"`
+}
+
+// swagger:route GET /admin/audit-events audit listAuditEvents
+//
+// # List Audit Events
+//
+// Lists the stored audit events, newest first. Events are only stored if the
+// audit log is enabled.
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: listAuditEvents
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) listAuditEvents(w http.ResponseWriter, r *http.Request) {
+	keys := h.r.Config().SecretsPagination(r.Context())
+	filter, paginator, err := parseEventsFilter(r, keys)
+	if err != nil {
+		h.r.Writer().WriteErrorCode(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	auditEvents, nextPage, err := h.r.AuditPersister().ListAuditEvents(r.Context(), filter, paginator)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	u := *r.URL
+	keysetpagination.SetLinkHeader(w, keys, &u, nextPage)
+	h.r.Writer().Write(w, r, auditEvents)
+}
+
+func parseEventsFilter(r *http.Request, keys [][32]byte) (filter ListAuditEventsParameters, _ []keysetpagination.Option, err error) {
+	q := r.URL.Query()
+
+	parseID := func(key string) (*uuid.UUID, error) {
+		if !q.Has(key) {
+			return nil, nil
+		}
+		id, err := uuid.FromString(q.Get(key))
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Parameter %s must be a valid UUID.", key).WithError(err.Error()))
+		}
+		return &id, nil
+	}
+
+	parseTime := func(key string) (*time.Time, error) {
+		if !q.Has(key) {
+			return nil, nil
+		}
+		t, err := time.Parse(time.RFC3339, q.Get(key))
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Parameter %s must be a RFC 3339 timestamp.", key).WithError(err.Error()))
+		}
+		return &t, nil
+	}
+
+	if filter.IdentityID, err = parseID("identity_id"); err != nil {
+		return filter, nil, err
+	}
+	if filter.SessionID, err = parseID("session_id"); err != nil {
+		return filter, nil, err
+	}
+	if filter.FlowID, err = parseID("flow_id"); err != nil {
+		return filter, nil, err
+	}
+	if filter.CreatedAfter, err = parseTime("created_after"); err != nil {
+		return filter, nil, err
+	}
+	if filter.CreatedBefore, err = parseTime("created_before"); err != nil {
+		return filter, nil, err
+	}
+
+	if q.Has("actor") {
+		actor, err := ToActor(q.Get("actor"))
+		if err != nil {
+			return filter, nil, err
+		}
+		filter.Actor = &actor
+	}
+
+	filter.EventType = q.Get("event_type")
+
+	opts, err := keysetpagination.ParseQueryParams(keys, q)
+	if err != nil {
+		return filter, nil, errors.WithStack(err)
+	}
+
+	return filter, opts, nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/httprouterx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlxx"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+	publicTS, adminTS := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	get := func(t *testing.T, ts *httptest.Server, href string, expectCode int) (gjson.Result, *http.Response) {
+		t.Helper()
+		res, err := ts.Client().Get(ts.URL + href)
+		require.NoError(t, err)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+
+		assert.EqualValuesf(t, expectCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body), res
+	}
+
+	identityID := uuid.Must(uuid.NewV4())
+	for _, eventType := range []string{"LoginSucceeded", "SessionRevoked", "IdentityDeleted"} {
+		require.NoError(t, reg.AuditPersister().CreateAuditEvent(ctx, &audit.Event{
+			EventType:  eventType,
+			Actor:      audit.ActorIdentity,
+			IdentityID: uuid.NullUUID{UUID: identityID, Valid: true},
+			Attributes: sqlxx.MapStringInterface{},
+		}))
+	}
+
+	t.Run("case=lists events", func(t *testing.T) {
+		for _, tc := range []struct {
+			name string
+			ts   *httptest.Server
+			href string
+		}{
+			{name: "admin", ts: adminTS, href: audit.AdminRouteListEvents},
+			{name: "public", ts: publicTS, href: httprouterx.AdminPrefix + audit.AdminRouteListEvents},
+		} {
+			t.Run("endpoint="+tc.name, func(t *testing.T) {
+				parsed, _ := get(t, tc.ts, tc.href+"?identity_id="+identityID.String(), http.StatusOK)
+				require.Len(t, parsed.Array(), 3, "%s", parsed.Raw)
+				assert.Equal(t, identityID.String(), parsed.Get("0.identity_id").String())
+				assert.Equal(t, "identity", parsed.Get("0.actor").String())
+			})
+		}
+	})
+
+	t.Run("case=filters events", func(t *testing.T) {
+		parsed, _ := get(t, adminTS, audit.AdminRouteListEvents+"?event_type=SessionRevoked&identity_id="+identityID.String(), http.StatusOK)
+		require.Len(t, parsed.Array(), 1, "%s", parsed.Raw)
+		assert.Equal(t, "SessionRevoked", parsed.Get("0.event_type").String())
+
+		parsed, _ = get(t, adminTS, audit.AdminRouteListEvents+"?actor=admin&identity_id="+identityID.String(), http.StatusOK)
+		assert.Empty(t, parsed.Array())
+	})
+
+	t.Run("case=paginates events", func(t *testing.T) {
+		parsed, res := get(t, adminTS, fmt.Sprintf("%s?page_size=2&identity_id=%s", audit.AdminRouteListEvents, identityID), http.StatusOK)
+		require.Len(t, parsed.Array(), 2)
+
+		_, next, isLast := keysetpagination.ParseHeader(res)
+		require.False(t, isLast)
+
+		parsed, _ = get(t, adminTS, fmt.Sprintf("%s?page_size=2&page_token=%s&identity_id=%s", audit.AdminRouteListEvents, url.QueryEscape(next), identityID), http.StatusOK)
+		require.Len(t, parsed.Array(), 1)
+	})
+
+	t.Run("case=rejects invalid filters", func(t *testing.T) {
+		for _, qs := range []string{"identity_id=foo", "actor=foo", "created_after=yesterday"} {
+			t.Run("query="+qs, func(t *testing.T) {
+				_, _ = get(t, adminTS, audit.AdminRouteListEvents+"?"+qs, http.StatusBadRequest)
+			})
+		}
+	})
+}
@@ -0,0 +1,29 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+)
+
+type (
+	Persister interface {
+		// CreateAuditEvent stores the event. The ID, network ID, and creation
+		// time are set by the persister.
+		CreateAuditEvent(context.Context, *Event) error
+
+		// ListAuditEvents lists the events matching the filter, newest first.
+		ListAuditEvents(context.Context, ListAuditEventsParameters, []keysetpagination.Option) ([]Event, *keysetpagination.Paginator, error)
+
+		// DeleteExpiredAuditEvents deletes up to limit events which were
+		// recorded before the given time.
+		DeleteExpiredAuditEvents(ctx context.Context, before time.Time, limit int) error
+	}
+	PersistenceProvider interface {
+		AuditPersister() Persister
+	}
+)
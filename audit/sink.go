@@ -0,0 +1,162 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx/semconv"
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/stringsx"
+)
+
+// DefaultEvents are stored if no events are configured. Session checks and
+// webhook deliveries are left out because they are frequent and carry little
+// information about the security of an account.
+var DefaultEvents = []semconv.Event{
+	events.IdentityCreated,
+	events.IdentityDeleted,
+	events.IdentityUpdated,
+	events.LoginFailed,
+	events.LoginFromNewDevice,
+	events.LoginLockedOut,
+	events.LoginSucceeded,
+	events.RecoveryFailed,
+	events.RecoveryInitiatedByAdmin,
+	events.RecoverySucceeded,
+	events.RegistrationFailed,
+	events.RegistrationSucceeded,
+	events.SessionBindingViolated,
+	events.SessionChanged,
+	events.SessionImpersonated,
+	events.SessionIssued,
+	events.SessionLifespanExtended,
+	events.SessionReportedByUser,
+	events.SessionRevoked,
+	events.SessionTokenizedAsJWT,
+	events.SettingsFailed,
+	events.SettingsSucceeded,
+	events.VerificationFailed,
+	events.VerificationSucceeded,
+}
+
+type (
+	sinkDependencies interface {
+		config.Provider
+		logrusx.Provider
+		PersistenceProvider
+	}
+
+	// Sink stores the events of a context in the audit log.
+	Sink struct {
+		r sinkDependencies
+	}
+	SinkProvider interface {
+		AuditSink() *Sink
+	}
+
+	originContextKey struct{}
+	origin           struct {
+		actor     Actor
+		userAgent string
+	}
+)
+
+var _ events.Sink = new(Sink)
+
+func NewSink(r sinkDependencies) *Sink {
+	return &Sink{r: r}
+}
+
+// WithContext returns a context whose events are stored on behalf of the
+// actor. Events of the identity actor which do not relate to an identity are
+// stored on behalf of the anonymous actor.
+func (s *Sink) WithContext(ctx context.Context, actor Actor, userAgent string) context.Context {
+	ctx = context.WithValue(ctx, originContextKey{}, origin{actor: actor, userAgent: userAgent})
+	return events.ContextWithSink(ctx, s)
+}
+
+// Middleware stores the events of each request on behalf of the actor.
+func (s *Sink) Middleware(actor Actor) func(http.ResponseWriter, *http.Request, http.HandlerFunc) {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		next(w, r.WithContext(s.WithContext(r.Context(), actor, r.UserAgent())))
+	}
+}
+
+// RecordEvent stores the event if the audit log is enabled and the event is
+// configured to be stored. Storing happens in the transaction of the context,
+// if any, so that events of rolled back changes are discarded as well.
+// Failures are logged but do not fail the operation which caused the event.
+func (s *Sink) RecordEvent(ctx context.Context, name string, attrs []attribute.KeyValue) {
+	c := s.r.Config().SecurityAuditLog(ctx)
+	if !c.Enabled || !isStored(c.Events, name) {
+		return
+	}
+
+	if err := s.r.AuditPersister().CreateAuditEvent(ctx, newEvent(ctx, name, attrs)); err != nil {
+		s.r.Logger().WithError(err).WithField("event_type", name).Error("Unable to store audit event.")
+	}
+}
+
+func isStored(configured []string, name string) bool {
+	if len(configured) == 0 {
+		return slices.Contains(DefaultEvents, semconv.Event(name))
+	}
+	return slices.Contains(configured, name)
+}
+
+func newEvent(ctx context.Context, name string, attrs []attribute.KeyValue) *Event {
+	o, ok := ctx.Value(originContextKey{}).(origin)
+	if !ok {
+		o.actor = ActorSystem
+	}
+
+	e := &Event{
+		EventType:  name,
+		Actor:      o.actor,
+		UserAgent:  stringsx.TruncateByteLen(o.userAgent, 512),
+		Attributes: sqlxx.MapStringInterface{},
+	}
+
+	for _, a := range attrs {
+		switch string(a.Key) {
+		case semconv.AttributeKeyNID.String():
+			// The network is stored in its own column.
+		case semconv.AttributeKeyIdentityID.String():
+			e.IdentityID = nullUUID(a.Value.AsString())
+		case events.AttributeKeySessionID.String():
+			e.SessionID = nullUUID(a.Value.AsString())
+		case events.AttributeKeyFlowID.String():
+			e.FlowID = nullUUID(a.Value.AsString())
+		case semconv.AttributeKeyClientIP.String():
+			e.IPAddress = stringsx.TruncateByteLen(a.Value.AsString(), 50)
+		default:
+			e.Attributes[string(a.Key)] = a.Value.AsInterface()
+		}
+	}
+
+	if e.Actor == ActorIdentity && !e.IdentityID.Valid {
+		e.Actor = ActorAnonymous
+	}
+
+	return e
+}
+
+// nullUUID parses the ID. Failed events carry the nil UUID if the flow is
+// unknown, which is stored as NULL.
+func nullUUID(s string) uuid.NullUUID {
+	id, err := uuid.FromString(s)
+	if err != nil || id == uuid.Nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: id, Valid: true}
+}
@@ -0,0 +1,114 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/otelx/semconv"
+)
+
+func TestSink(t *testing.T) {
+	t.Parallel()
+
+	newRegistry := func(t *testing.T, values map[string]any) *driver.RegistryDefault {
+		_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(values))
+		testhelpers.SetDefaultIdentitySchema(reg.Config(), "file://../identity/stub/identity.schema.json")
+		return reg
+	}
+
+	listEvents := func(t *testing.T, reg *driver.RegistryDefault, filter audit.ListAuditEventsParameters) []audit.Event {
+		auditEvents, _, err := reg.AuditPersister().ListAuditEvents(context.Background(), filter, nil)
+		require.NoError(t, err)
+		return auditEvents
+	}
+
+	t.Run("case=stores events of the context", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newRegistry(t, map[string]any{config.ViperKeySecurityAuditLogEnabled: true})
+		ctx := reg.AuditSink().WithContext(context.Background(), audit.ActorAdmin, "kratos-test")
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+		actual := listEvents(t, reg, audit.ListAuditEventsParameters{IdentityID: &i.ID})
+		require.Len(t, actual, 1)
+		assert.Equal(t, events.IdentityCreated.String(), actual[0].EventType)
+		assert.Equal(t, audit.ActorAdmin, actual[0].Actor)
+		assert.Equal(t, "kratos-test", actual[0].UserAgent)
+	})
+
+	t.Run("case=attributes events to the anonymous actor without an identity", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newRegistry(t, map[string]any{config.ViperKeySecurityAuditLogEnabled: true})
+		ctx := semconv.ContextWithAttributes(context.Background(), semconv.AttrClientIP("192.0.2.1"))
+		ctx = reg.AuditSink().WithContext(ctx, audit.ActorIdentity, "Mozilla/5.0")
+
+		events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewLoginFailed(ctx, uuid.Nil, "browser", "password", "aal1", false, errors.New("invalid credentials")))
+
+		actual := listEvents(t, reg, audit.ListAuditEventsParameters{EventType: events.LoginFailed.String()})
+		require.Len(t, actual, 1)
+		assert.Equal(t, audit.ActorAnonymous, actual[0].Actor)
+		assert.Equal(t, "192.0.2.1", actual[0].IPAddress)
+		assert.False(t, actual[0].IdentityID.Valid)
+		assert.False(t, actual[0].FlowID.Valid, "the nil flow ID must not be stored")
+		assert.Equal(t, "password", actual[0].Attributes[events.AttributeKeySelfServiceMethodUsed.String()])
+	})
+
+	t.Run("case=stores only the configured events", func(t *testing.T) {
+		t.Parallel()
+
+		sessionID, identityID := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+		record := func(reg *driver.RegistryDefault) {
+			ctx := reg.AuditSink().WithContext(context.Background(), audit.ActorIdentity, "")
+			events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionChecked(ctx, sessionID, identityID))
+			events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionRevoked(ctx, sessionID, identityID))
+		}
+
+		reg := newRegistry(t, map[string]any{config.ViperKeySecurityAuditLogEnabled: true})
+		record(reg)
+		actual := listEvents(t, reg, audit.ListAuditEventsParameters{SessionID: &sessionID})
+		require.Len(t, actual, 1, "session checks must not be stored by default")
+		assert.Equal(t, events.SessionRevoked.String(), actual[0].EventType)
+		assert.Equal(t, audit.ActorIdentity, actual[0].Actor)
+
+		reg = newRegistry(t, map[string]any{
+			config.ViperKeySecurityAuditLogEnabled: true,
+			config.ViperKeySecurityAuditLogEvents:  []string{events.SessionChecked.String()},
+		})
+		record(reg)
+		actual = listEvents(t, reg, audit.ListAuditEventsParameters{SessionID: &sessionID})
+		require.Len(t, actual, 1)
+		assert.Equal(t, events.SessionChecked.String(), actual[0].EventType)
+	})
+
+	t.Run("case=stores nothing if disabled", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newRegistry(t, map[string]any{})
+		ctx := reg.AuditSink().WithContext(context.Background(), audit.ActorAdmin, "")
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+		assert.Empty(t, listEvents(t, reg, audit.ListAuditEventsParameters{}))
+	})
+}
@@ -0,0 +1,162 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/pop/v6"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlxx"
+)
+
+func TestPersister(ctx context.Context, p interface {
+	persistence.Persister
+},
+) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, p := testhelpers.NewNetworkUnlessExisting(t, ctx, p)
+
+		newEvent := func(t *testing.T, p audit.Persister, eventType string, actor audit.Actor, identityID uuid.UUID) *audit.Event {
+			e := &audit.Event{
+				EventType:  eventType,
+				Actor:      actor,
+				IdentityID: uuid.NullUUID{UUID: identityID, Valid: true},
+				FlowID:     uuid.NullUUID{UUID: uuid.Must(uuid.NewV4()), Valid: true},
+				IPAddress:  "127.0.0.1",
+				UserAgent:  "Mozilla/5.0",
+				Attributes: sqlxx.MapStringInterface{"SelfServiceMethodUsed": "password"},
+			}
+			require.NoError(t, p.CreateAuditEvent(ctx, e))
+			return e
+		}
+
+		list := func(t *testing.T, p audit.Persister, filter audit.ListAuditEventsParameters, opts ...keysetpagination.Option) ([]audit.Event, *keysetpagination.Paginator) {
+			events, next, err := p.ListAuditEvents(ctx, filter, opts)
+			require.NoError(t, err)
+			return events, next
+		}
+
+		identityID := uuid.Must(uuid.NewV4())
+		created := []*audit.Event{
+			newEvent(t, p, "LoginSucceeded", audit.ActorIdentity, identityID),
+			newEvent(t, p, "SessionRevoked", audit.ActorIdentity, identityID),
+			newEvent(t, p, "IdentityDeleted", audit.ActorAdmin, identityID),
+		}
+
+		t.Run("case=stores the event", func(t *testing.T) {
+			events, _ := list(t, p, audit.ListAuditEventsParameters{FlowID: &created[0].FlowID.UUID})
+			require.Len(t, events, 1)
+
+			actual := events[0]
+			assert.Equal(t, created[0].ID, actual.ID)
+			assert.Equal(t, "LoginSucceeded", actual.EventType)
+			assert.Equal(t, audit.ActorIdentity, actual.Actor)
+			assert.Equal(t, identityID, actual.IdentityID.UUID)
+			assert.False(t, actual.SessionID.Valid)
+			assert.Equal(t, "127.0.0.1", actual.IPAddress)
+			assert.Equal(t, "Mozilla/5.0", actual.UserAgent)
+			assert.Equal(t, "password", actual.Attributes["SelfServiceMethodUsed"])
+			assert.WithinDuration(t, time.Now(), actual.CreatedAt, time.Minute)
+		})
+
+		t.Run("case=filters events", func(t *testing.T) {
+			events, _ := list(t, p, audit.ListAuditEventsParameters{IdentityID: &identityID})
+			assert.Len(t, events, 3)
+
+			events, _ = list(t, p, audit.ListAuditEventsParameters{IdentityID: &identityID, EventType: "SessionRevoked"})
+			require.Len(t, events, 1)
+			assert.Equal(t, created[1].ID, events[0].ID)
+
+			actor := audit.ActorAdmin
+			events, _ = list(t, p, audit.ListAuditEventsParameters{IdentityID: &identityID, Actor: &actor})
+			require.Len(t, events, 1)
+			assert.Equal(t, created[2].ID, events[0].ID)
+
+			future := time.Now().Add(time.Hour)
+			events, _ = list(t, p, audit.ListAuditEventsParameters{IdentityID: &identityID, CreatedAfter: &future})
+			assert.Empty(t, events)
+
+			events, _ = list(t, p, audit.ListAuditEventsParameters{IdentityID: &identityID, CreatedBefore: &future})
+			assert.Len(t, events, 3)
+		})
+
+		t.Run("case=paginates newest first", func(t *testing.T) {
+			events, next := list(t, p, audit.ListAuditEventsParameters{IdentityID: &identityID}, keysetpagination.WithSize(2))
+			require.Len(t, events, 2)
+			assert.False(t, next.IsLast())
+			assert.False(t, events[0].CreatedAt.Before(events[1].CreatedAt))
+
+			rest, next := list(t, p, audit.ListAuditEventsParameters{IdentityID: &identityID}, next.ToOptions()...)
+			require.Len(t, rest, 1)
+			assert.True(t, next.IsLast())
+
+			seen := map[uuid.UUID]bool{}
+			for _, e := range append(events, rest...) {
+				seen[e.ID] = true
+			}
+			assert.Len(t, seen, 3)
+		})
+
+		t.Run("case=deletes expired events", func(t *testing.T) {
+			otherID := uuid.Must(uuid.NewV4())
+			expired := newEvent(t, p, "LoginFailed", audit.ActorAnonymous, otherID)
+			recent := newEvent(t, p, "LoginFailed", audit.ActorAnonymous, otherID)
+			require.NoError(t, p.GetConnection(ctx).RawQuery(
+				"UPDATE audit_events SET created_at = ? WHERE id = ?", time.Now().UTC().Add(-48*time.Hour), expired.ID,
+			).Exec())
+
+			require.NoError(t, p.DeleteExpiredAuditEvents(ctx, time.Now().Add(-24*time.Hour), 100))
+
+			events, _ := list(t, p, audit.ListAuditEventsParameters{IdentityID: &otherID})
+			require.Len(t, events, 1)
+			assert.Equal(t, recent.ID, events[0].ID)
+		})
+
+		t.Run("case=failed inserts do not abort the transaction", func(t *testing.T) {
+			otherID := uuid.Must(uuid.NewV4())
+			require.NoError(t, p.Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+				// Not every database enforces the column length, so the
+				// insert is allowed to succeed.
+				_ = p.CreateAuditEvent(ctx, &audit.Event{
+					EventType:  strings.Repeat("a", 128),
+					Actor:      audit.ActorAnonymous,
+					IdentityID: uuid.NullUUID{UUID: otherID, Valid: true},
+					Attributes: sqlxx.MapStringInterface{},
+				})
+				return p.CreateAuditEvent(ctx, &audit.Event{
+					EventType:  "LoginFailed",
+					Actor:      audit.ActorAnonymous,
+					IdentityID: uuid.NullUUID{UUID: otherID, Valid: true},
+					Attributes: sqlxx.MapStringInterface{},
+				})
+			}))
+
+			events, _ := list(t, p, audit.ListAuditEventsParameters{IdentityID: &otherID, EventType: "LoginFailed"})
+			assert.Len(t, events, 1)
+		})
+
+		t.Run("case=network isolation", func(t *testing.T) {
+			_, other := testhelpers.NewNetwork(t, ctx, p)
+
+			events, _ := list(t, other, audit.ListAuditEventsParameters{IdentityID: &identityID})
+			assert.Empty(t, events)
+
+			require.NoError(t, other.DeleteExpiredAuditEvents(ctx, time.Now().Add(time.Hour), 100))
+
+			events, _ = list(t, p, audit.ListAuditEventsParameters{IdentityID: &identityID})
+			assert.Len(t, events, 3)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/configx"
+)
+
+// NewCleanupIdentitiesCmd represents the identities command
+func NewCleanupIdentitiesCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "identities [<database-url>]",
+		Short: "Apply the dormant identity policy",
+		Long: `Warns, deactivates, and deletes dormant identities as configured in "identity.dormancy".
+Each run handles at most "identity.dormancy.batch_size" identities per step, so run this command periodically,
+or start "kratos serve" with the "--watch-dormant-identities" flag instead.
+You can read in the database URL using the -e flag, for example:
+	export DSN=...
+	kratos cleanup identities -e --dry-run
+### WARNING ###
+Deleted identities can not be restored. Use --dry-run to review the affected identities first!
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := cliclient.NewCleanupHandler().CleanupIdentities(cmd, args)
+			if err != nil {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), err)
+				return cmdx.FailSilently(cmd)
+			}
+			return nil
+		},
+	}
+
+	configx.RegisterFlags(c.PersistentFlags())
+	c.Flags().BoolP("read-from-env", "e", true, "If set, reads the database connection string from the environment variable DSN or config file key dsn.")
+	c.Flags().Bool("dry-run", false, "Only report the identities which would be warned, deactivated, and deleted without changing them.")
+	return c
+}
@@ -0,0 +1,20 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteCleanupIdentitiesFailedDSN(t *testing.T) {
+	cmd := NewCleanupIdentitiesCmd()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--read-from-env=false", "--dry-run"})
+	_ = cmd.Execute()
+	assert.Contains(t, b.String(), "expected to get the DSN as an argument")
+}
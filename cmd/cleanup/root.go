@@ -22,4 +22,5 @@ func RegisterCommandRecursive(parent *cobra.Command) {
 	c := NewCleanupCmd()
 	parent.AddCommand(c)
 	c.AddCommand(NewCleanupSQLCmd())
+	c.AddCommand(NewCleanupIdentitiesCmd())
 }
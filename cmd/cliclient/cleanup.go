@@ -4,6 +4,9 @@
 package cliclient
 
 import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
 	"github.com/ory/x/contextx"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/driver"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/x/flagx"
@@ -23,7 +27,7 @@ func NewCleanupHandler() *CleanupHandler {
 	return &CleanupHandler{}
 }
 
-func (h *CleanupHandler) CleanupSQL(cmd *cobra.Command, args []string) error {
+func (h *CleanupHandler) newRegistry(cmd *cobra.Command, args []string) (driver.Registry, error) {
 	opts := []configx.OptionModifier{
 		configx.WithFlags(cmd.Flags()),
 		configx.SkipValidation(),
@@ -31,7 +35,7 @@ func (h *CleanupHandler) CleanupSQL(cmd *cobra.Command, args []string) error {
 
 	if !flagx.MustGetBool(cmd, "read-from-env") {
 		if len(args) != 1 {
-			return errors.New(`expected to get the DSN as an argument, or the "read-from-env" flag`)
+			return nil, errors.New(`expected to get the DSN as an argument, or the "read-from-env" flag`)
 		}
 		opts = append(opts, configx.WithValue(config.ViperKeyDSN, args[0]))
 	}
@@ -42,14 +46,23 @@ func (h *CleanupHandler) CleanupSQL(cmd *cobra.Command, args []string) error {
 		driver.WithConfigOptions(opts...),
 	)
 	if len(d.Config().DSN(cmd.Context())) == 0 {
-		return errors.New(`required config value "dsn" was not set`)
+		return nil, errors.New(`required config value "dsn" was not set`)
 	} else if err != nil {
-		return errors.Wrap(err, "An error occurred initializing cleanup")
+		return nil, errors.Wrap(err, "An error occurred initializing cleanup")
 	}
 
 	err = d.Init(cmd.Context(), &contextx.Default{})
 	if err != nil {
-		return errors.Wrap(err, "An error occurred initializing cleanup")
+		return nil, errors.Wrap(err, "An error occurred initializing cleanup")
+	}
+
+	return d, nil
+}
+
+func (h *CleanupHandler) CleanupSQL(cmd *cobra.Command, args []string) error {
+	d, err := h.newRegistry(cmd, args)
+	if err != nil {
+		return err
 	}
 
 	keepLast := flagx.MustGetDuration(cmd, "keep-last")
@@ -65,3 +78,42 @@ func (h *CleanupHandler) CleanupSQL(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func (h *CleanupHandler) CleanupIdentities(cmd *cobra.Command, args []string) error {
+	d, err := h.newRegistry(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	if !d.Config().IdentityDormancy(cmd.Context()).Enabled {
+		return errors.Errorf(`the dormant identity policy is disabled, set config value "%s" to true to enable it`, config.ViperKeyIdentityDormancyEnabled)
+	}
+
+	ctx := d.AuditSink().WithContext(cmd.Context(), audit.ActorSystem, "")
+	report, err := d.DormancyManager().Run(ctx, flagx.MustGetBool(cmd, "dry-run"))
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while applying the dormant identity policy")
+	}
+
+	prefix := ""
+	if report.DryRun {
+		prefix = "[dry-run] would have "
+	}
+
+	w := cmd.OutOrStdout()
+	for _, step := range []struct {
+		verb string
+		ids  []uuid.UUID
+	}{
+		{verb: "warned", ids: report.Warned},
+		{verb: "deactivated", ids: report.Deactivated},
+		{verb: "deleted", ids: report.Deleted},
+	} {
+		_, _ = fmt.Fprintf(w, "%s%s %d identities\n", prefix, step.verb, len(step.ids))
+		for _, id := range step.ids {
+			_, _ = fmt.Fprintf(w, "\t%s\n", id)
+		}
+	}
+
+	return nil
+}
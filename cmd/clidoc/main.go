@@ -119,6 +119,7 @@ func init() {
 		"NewErrorValidationDeviceAuthnVerifierWrong":                   text.NewErrorValidationDeviceAuthnVerifierWrong(),
 		"NewErrorValidationDeviceAuthnRelaxedAttestationNoLongerValid": text.NewErrorValidationDeviceAuthnRelaxedAttestationNoLongerValid(),
 		"NewErrorValidationDeviceAuthnKeyReenrollmentRequired":         text.NewErrorValidationDeviceAuthnKeyReenrollmentRequired(),
+		"NewErrorValidationTooManyMessages":                            text.NewErrorValidationTooManyMessages(),
 		"NewErrorValidationLookupAlreadyUsed":                          text.NewErrorValidationLookupAlreadyUsed(),
 		"NewErrorValidationLookupInvalid":                              text.NewErrorValidationLookupInvalid(),
 		"NewErrorValidationIdentifierMissing":                          text.NewErrorValidationIdentifierMissing(),
@@ -205,6 +206,8 @@ func init() {
 		"NewErrorValidationPhone":                                      text.NewErrorValidationPhone("{value}"),
 		"NewErrorValidationIdentityDisabled":                           text.NewErrorValidationIdentityDisabled(),
 		"NewErrorValidationSettingsTooManyAddressChanges":              text.NewErrorValidationSettingsTooManyAddressChanges(),
+		"NewErrorValidationLoginLockedOut":                             text.NewErrorValidationLoginLockedOut(docUntilClock, inAMinute),
+		"NewErrorValidationLoginTooManyAttempts":                       text.NewErrorValidationLoginTooManyAttempts(docUntilClock, inAMinute),
 	}
 }
 
@@ -0,0 +1,184 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/flagx"
+)
+
+const (
+	flagRecipient     = "recipient"
+	flagTemplateType  = "template-type"
+	flagCreatedAfter  = "created-after"
+	flagCreatedBefore = "created-before"
+	flagOlderThan     = "older-than"
+	flagStatus        = "status"
+	flagAll           = "all"
+)
+
+func NewRequeueCmd(dOpts []driver.RegistryOption) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "requeue [message-id...]",
+		Short: "Queue abandoned messages again",
+		Long: `Queue abandoned messages again and reset their send count, so that the courier retries to deliver them.
+
+Pass the IDs of the messages to requeue, or use the filter flags to requeue all abandoned messages matching them. Use --all to requeue all abandoned messages.`,
+		Example: `kratos courier requeue 3f8a5a5c-4b1e-4a3e-9d0e-2c5a8a0b8f11
+kratos courier requeue --template-type recovery_code_valid --created-after 2026-01-01T00:00:00Z`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return changeMessages(cmd, args, dOpts, "Requeued", courier.Persister.RequeueMessages)
+		},
+	}
+	registerFilterFlags(c.Flags())
+	return c
+}
+
+func NewCancelCmd(dOpts []driver.RegistryOption) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "cancel [message-id...]",
+		Short: "Cancel queued messages",
+		Long: `Cancel queued messages, so that the courier does not deliver them.
+
+Pass the IDs of the messages to cancel, or use the filter flags to cancel all queued messages matching them. Use --all to cancel all queued messages.`,
+		Example: `kratos courier cancel 3f8a5a5c-4b1e-4a3e-9d0e-2c5a8a0b8f11
+kratos courier cancel --recipient foo@example.com`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return changeMessages(cmd, args, dOpts, "Cancelled", courier.Persister.CancelMessages)
+		},
+	}
+	registerFilterFlags(c.Flags())
+	return c
+}
+
+func NewPurgeCmd(dOpts []driver.RegistryOption) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete old messages",
+		Long: `Delete sent, delivered, bounced, complained, abandoned, and cancelled messages which were created longer ago than the given duration, together with their dispatches.
+
+Queued and processing messages are never purged.`,
+		Example: `kratos courier purge --older-than 720h
+kratos courier purge --older-than 168h --status abandoned --status cancelled`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			olderThan := flagx.MustGetDuration(cmd, flagOlderThan)
+			if olderThan <= 0 {
+				return errors.Errorf("flag --%s must be a positive duration", flagOlderThan)
+			}
+
+			statuses, err := courier.ParsePurgeableStatuses(flagx.MustGetStringSlice(cmd, flagStatus))
+			if err != nil {
+				return err
+			}
+
+			r, err := newRegistry(cmd, dOpts)
+			if err != nil {
+				return err
+			}
+
+			count, err := r.CourierPersister().PurgeMessages(cmd.Context(), time.Now().Add(-olderThan), statuses)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Purged %d messages.\n", count)
+			return nil
+		},
+	}
+	c.Flags().Duration(flagOlderThan, 0, "Purge messages created longer ago than this duration, for example 720h (required)")
+	c.Flags().StringSlice(flagStatus, nil, "Purge only messages with this status: sent, delivered, bounced, complained, abandoned, or cancelled (default all of them)")
+	return c
+}
+
+func registerFilterFlags(flags *pflag.FlagSet) {
+	flags.String(flagRecipient, "", "Only change messages sent to this recipient")
+	flags.String(flagTemplateType, "", "Only change messages of this template type")
+	flags.String(flagCreatedAfter, "", "Only change messages created after this time (RFC 3339)")
+	flags.String(flagCreatedBefore, "", "Only change messages created before this time (RFC 3339)")
+	flags.Bool(flagAll, false, "Change all messages if no message IDs or other filter flags are given")
+}
+
+func newRegistry(cmd *cobra.Command, dOpts []driver.RegistryOption) (driver.Registry, error) {
+	return driver.New(cmd.Context(), cmd.ErrOrStderr(), append(dOpts, driver.WithConfigOptions(configx.WithFlags(cmd.Flags())))...)
+}
+
+func changeMessages(cmd *cobra.Command, args []string, dOpts []driver.RegistryOption, verb string, change func(courier.Persister, context.Context, courier.MessagesFilter) (int, error)) error {
+	filter, err := filterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 && filter.IsEmpty() && !filter.All {
+		return errors.Errorf("pass message IDs, at least one filter flag, or --%s to change all messages", flagAll)
+	}
+
+	filters := []courier.MessagesFilter{filter}
+	if len(args) > 0 {
+		filters = make([]courier.MessagesFilter, len(args))
+		for i, arg := range args {
+			id, err := uuid.FromString(arg)
+			if err != nil {
+				return errors.Wrapf(err, "could not parse message ID %q", arg)
+			}
+			filters[i] = filter
+			filters[i].ID = id
+		}
+	}
+
+	r, err := newRegistry(cmd, dOpts)
+	if err != nil {
+		return err
+	}
+
+	var total int
+	for _, f := range filters {
+		count, err := change(r.CourierPersister(), cmd.Context(), f)
+		if err != nil {
+			return err
+		}
+		if f.ID != uuid.Nil && count == 0 {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Skipped message %s because it does not exist or has the wrong status.\n", f.ID)
+		}
+		total += count
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s %d messages.\n", verb, total)
+	return nil
+}
+
+func filterFromFlags(cmd *cobra.Command) (filter courier.MessagesFilter, err error) {
+	filter.Recipient = flagx.MustGetString(cmd, flagRecipient)
+	filter.TemplateType = template.TemplateType(flagx.MustGetString(cmd, flagTemplateType))
+	filter.All = flagx.MustGetBool(cmd, flagAll)
+
+	for flag, target := range map[string]**time.Time{
+		flagCreatedAfter:  &filter.CreatedAfter,
+		flagCreatedBefore: &filter.CreatedBefore,
+	} {
+		v := flagx.MustGetString(cmd, flag)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.Wrapf(err, "could not parse flag --%s", flag)
+		}
+		*target = &t
+	}
+
+	return filter, nil
+}
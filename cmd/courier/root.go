@@ -24,4 +24,8 @@ func RegisterCommandRecursive(parent *cobra.Command, dOpts []driver.RegistryOpti
 	c := NewCourierCmd()
 	parent.AddCommand(c)
 	c.AddCommand(NewWatchCmd(dOpts))
+	c.AddCommand(NewRequeueCmd(dOpts))
+	c.AddCommand(NewCancelCmd(dOpts))
+	c.AddCommand(NewPurgeCmd(dOpts))
+	c.AddCommand(NewRenderCmd(dOpts))
 }
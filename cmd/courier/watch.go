@@ -13,6 +13,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/ory/graceful"
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/driver"
 	"github.com/ory/x/configx"
 	"github.com/ory/x/prometheusx"
@@ -86,7 +87,7 @@ func ServeMetrics(ctx context.Context, r driver.Registry, port int) error {
 }
 
 func Watch(ctx context.Context, r driver.Registry) error {
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel := context.WithCancel(r.AuditSink().WithContext(ctx, audit.ActorSystem, ""))
 
 	r.Logger().Println("Courier worker started.")
 	if err := graceful.Graceful(func() error {
@@ -18,7 +18,9 @@ import (
 
 	"github.com/ory/analytics-go/v5"
 	"github.com/ory/graceful"
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/cmd/outbox"
 	"github.com/ory/kratos/driver"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
@@ -32,6 +34,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/strategy/link"
 	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/selfservice/strategy/saml"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/nosurfx"
@@ -77,6 +80,7 @@ func servePublic(ctx context.Context, r *driver.RegistryDefault, cmd *cobra.Comm
 	}
 
 	n.UseFunc(semconv.Middleware)
+	n.UseFunc(r.AuditSink().Middleware(audit.ActorIdentity))
 	n.Use(publicLogger)
 	n.Use(x.HTTPLoaderContextMiddleware(r))
 	n.UseFunc(httprouterx.NoCacheNegroni)
@@ -180,6 +184,7 @@ func serveAdmin(ctx context.Context, r *driver.RegistryDefault, cmd *cobra.Comma
 		)
 	}
 	n.UseFunc(semconv.Middleware)
+	n.UseFunc(r.AuditSink().Middleware(audit.ActorAdmin))
 	n.Use(adminLogger)
 	n.UseFunc(httprouterx.AddAdminPrefixIfNotPresentNegroni)
 	n.UseFunc(httprouterx.NoCacheNegroni)
@@ -275,6 +280,7 @@ func sqa(ctx context.Context, cmd *cobra.Command, d driver.Registry) *metricsx.S
 				healthx.VersionPath,
 
 				oidc.RouteBase,
+				saml.RouteBase,
 
 				login.RouteInitBrowserFlow,
 				login.RouteInitAPIFlow,
@@ -339,6 +345,24 @@ func courierTask(ctx context.Context, d driver.Registry) func() error {
 	}
 }
 
+func dormancyTask(ctx context.Context, d driver.Registry) func() error {
+	return func() error {
+		if d.Config().IsBackgroundDormancyWorkerEnabled(ctx) {
+			return d.DormancyManager().Watch(d.AuditSink().WithContext(ctx, audit.ActorSystem, ""))
+		}
+		return nil
+	}
+}
+
+func outboxTask(ctx context.Context, d driver.Registry) func() error {
+	return func() error {
+		if d.Config().IsBackgroundOutboxWorkerEnabled(ctx) {
+			return outbox.Watch(ctx, d)
+		}
+		return nil
+	}
+}
+
 func ServeAll(d *driver.RegistryDefault) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, _ []string) error {
 		ctx := cmd.Context()
@@ -362,6 +386,8 @@ func ServeAll(d *driver.RegistryDefault) func(cmd *cobra.Command, args []string)
 			publicSrv,
 			adminSrv,
 			courierTask(ctx, d),
+			dormancyTask(ctx, d),
+			outboxTask(ctx, d),
 		}
 		for _, task := range tasks {
 			g.Go(task)
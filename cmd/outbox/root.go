@@ -0,0 +1,27 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/x/configx"
+)
+
+// NewOutboxCmd creates a new outbox command
+func NewOutboxCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "outbox",
+		Short: "Commands related to the Ory Kratos transactional outbox",
+	}
+	configx.RegisterFlags(c.PersistentFlags())
+	return c
+}
+
+func RegisterCommandRecursive(parent *cobra.Command, dOpts []driver.RegistryOption) {
+	c := NewOutboxCmd()
+	parent.AddCommand(c)
+	c.AddCommand(NewWatchCmd(dOpts))
+}
@@ -0,0 +1,48 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/graceful"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/x/configx"
+)
+
+func NewWatchCmd(dOpts []driver.RegistryOption) *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch",
+		Short: "Starts the Ory Kratos outbox worker",
+		Long:  "Starts the worker which delivers the identity and session events of the transactional outbox to the configured HTTP endpoint.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := driver.New(cmd.Context(), cmd.ErrOrStderr(), append(dOpts, driver.WithConfigOptions(configx.WithFlags(cmd.Flags())))...)
+			if err != nil {
+				return err
+			}
+
+			return Watch(cmd.Context(), r)
+		},
+	}
+}
+
+func Watch(ctx context.Context, r driver.Registry) error {
+	ctx, cancel := context.WithCancel(ctx)
+
+	r.Logger().Println("Outbox worker started.")
+	if err := graceful.Graceful(func() error {
+		return r.OutboxDispatcher().Work(ctx)
+	}, func(_ context.Context) error {
+		cancel()
+		return nil
+	}); err != nil {
+		r.Logger().WithError(err).Error("Failed to run outbox worker.")
+		return err
+	}
+
+	r.Logger().Println("Outbox worker was shutdown gracefully.")
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/ory/kratos/cmd/identities"
 	"github.com/ory/kratos/cmd/jsonnet"
 	"github.com/ory/kratos/cmd/migrate"
+	"github.com/ory/kratos/cmd/outbox"
 	"github.com/ory/kratos/cmd/remote"
 	"github.com/ory/kratos/cmd/serve"
 	"github.com/ory/kratos/driver"
@@ -32,6 +33,7 @@ func NewRootCmd(driverOpts ...driver.RegistryOption) (cmd *cobra.Command) {
 	cmdx.EnableUsageTemplating(cmd)
 
 	courier.RegisterCommandRecursive(cmd, driverOpts)
+	outbox.RegisterCommandRecursive(cmd, driverOpts)
 	cmd.AddCommand(identities.NewGetCmd())
 	cmd.AddCommand(identities.NewDeleteCmd())
 	cmd.AddCommand(jsonnet.NewFormatCmd())
@@ -50,6 +50,8 @@ DON'T DO THIS IN PRODUCTION!
 	serveCmd.PersistentFlags().Bool("sqa-opt-out", false, "Disable anonymized telemetry reports - for more information please visit https://www.ory.com/docs/ecosystem/sqa")
 	serveCmd.PersistentFlags().Bool("dev", false, "Disables critical security features to make development easier")
 	serveCmd.PersistentFlags().Bool("watch-courier", false, "Run the message courier as a background task, to simplify single-instance setup")
+	serveCmd.PersistentFlags().Bool("watch-outbox", false, "Run the transactional outbox worker as a background task, to simplify single-instance setup")
+	serveCmd.PersistentFlags().Bool("watch-dormant-identities", false, "Apply the dormant identity policy as a background task, instead of running \"kratos cleanup identities\" periodically")
 	return serveCmd
 }
 
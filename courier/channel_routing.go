@@ -0,0 +1,102 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/driver/config"
+)
+
+// channelConfig returns the configuration of the channel with the given ID.
+func channelConfig(cs []*config.CourierChannel, id string) (*config.CourierChannel, error) {
+	for _, channel := range cs {
+		if channel.ID == id {
+			return channel, nil
+		}
+	}
+	return nil, errors.Errorf("no courier channels configured for: %s", id)
+}
+
+// channelIDs returns the IDs of the channels a message addressed to the given
+// channel is dispatched with, in the order in which they are tried: the routed
+// channel, if any route matches, the addressed channel, and its fallbacks.
+func channelIDs(addressed *config.CourierChannel, recipient string) []string {
+	ids := make([]string, 0, len(addressed.Fallback)+2)
+	if route := selectRoute(addressed.Routes, recipient); route != nil {
+		ids = append(ids, route.Channel)
+	}
+	ids = append(ids, addressed.ID)
+	ids = append(ids, addressed.Fallback...)
+
+	seen := make(map[string]bool, len(ids))
+	unique := ids[:0]
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// selectRoute returns the route with the longest recipient prefix matching the
+// recipient, where routes without prefixes match every recipient. Equally
+// matching routes are picked at random according to their weight.
+func selectRoute(routes []config.CourierChannelRoute, recipient string) *config.CourierChannelRoute {
+	var candidates []*config.CourierChannelRoute
+	longest := -1
+	for i := range routes {
+		length := matchingPrefixLength(routes[i].RecipientPrefixes, recipient)
+		switch {
+		case length > longest:
+			longest = length
+			candidates = []*config.CourierChannelRoute{&routes[i]}
+		case length == longest && length >= 0:
+			candidates = append(candidates, &routes[i])
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil
+	case 1:
+		return candidates[0]
+	}
+
+	var total int
+	for _, route := range candidates {
+		total += routeWeight(route)
+	}
+	//#nosec G404 -- routing does not need a cryptographically secure source
+	pick := rand.IntN(total)
+	for _, route := range candidates {
+		if pick -= routeWeight(route); pick < 0 {
+			return route
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// matchingPrefixLength returns the length of the longest prefix matching the
+// recipient, 0 if there are no prefixes, and -1 if no prefix matches.
+func matchingPrefixLength(prefixes []string, recipient string) int {
+	if len(prefixes) == 0 {
+		return 0
+	}
+	longest := -1
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(recipient, prefix) && len(prefix) > longest {
+			longest = len(prefix)
+		}
+	}
+	return longest
+}
+
+func routeWeight(route *config.CourierChannelRoute) int {
+	return max(route.Weight, 1)
+}
@@ -5,6 +5,7 @@ package courier
 
 import (
 	"context"
+	"os"
 	"time"
 
 	"github.com/cenkalti/backoff"
@@ -15,6 +16,7 @@ import (
 	"github.com/ory/x/jsonnetsecure"
 	"github.com/ory/x/logrusx"
 	"github.com/ory/x/otelx"
+	"github.com/ory/x/randx"
 
 	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/driver/config"
@@ -53,6 +55,9 @@ type (
 		failOnDispatchError         bool
 		backoff                     backoff.BackOff
 		newEmailTemplateFromMessage func(d template.Dependencies, msg Message) (EmailTemplate, error)
+		// workerID identifies this worker in the message leases unless a
+		// worker ID is configured.
+		workerID string
 	}
 )
 
@@ -65,9 +70,27 @@ func NewCourierWithCustomTemplates(_ context.Context, deps Dependencies, newEmai
 		deps:                        deps,
 		backoff:                     backoff.NewExponentialBackOff(),
 		newEmailTemplateFromMessage: newEmailTemplateFromMessage,
+		workerID:                    newWorkerID(),
 	}, nil
 }
 
+// newWorkerID returns the host name with a random suffix, so that several
+// workers on the same host have different IDs.
+func newWorkerID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "courier"
+	}
+	return hostname + "-" + randx.MustString(8, randx.AlphaLowerNum)
+}
+
+func (c *courier) leaseWorkerID(ctx context.Context) string {
+	if id := c.deps.CourierConfig().CourierWorkerID(ctx); id != "" {
+		return id
+	}
+	return c.workerID
+}
+
 func (c *courier) FailOnDispatchError() {
 	c.failOnDispatchError = true
 }
@@ -11,36 +11,55 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/logrusx"
 	"github.com/ory/x/otelx"
 	"github.com/ory/x/otelx/semconv"
 )
 
-func (c *courier) channels(ctx context.Context, id string) (Channel, error) {
+// channels returns the channels the message is dispatched with, in the order
+// in which they are tried.
+func (c *courier) channels(ctx context.Context, msg Message) ([]Channel, error) {
 	cs, err := c.deps.CourierConfig().CourierChannels(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, channel := range cs {
-		if channel.ID != id {
-			continue
+	addressed, err := channelConfig(cs, msg.Channel.String())
+	if err != nil {
+		return nil, err
+	}
+
+	ids := channelIDs(addressed, msg.Recipient)
+	channels := make([]Channel, len(ids))
+	for i, id := range ids {
+		channel, err := channelConfig(cs, id)
+		if err != nil {
+			return nil, err
 		}
-		switch channel.Type {
-		case "smtp":
-			courierChannel, err := NewSMTPChannelWithCustomTemplates(c.deps, channel.SMTPConfig, c.newEmailTemplateFromMessage)
-			if err != nil {
-				return nil, err
-			}
-			return courierChannel, nil
-		case "http":
-			return newHttpChannel(channel.ID, &channel.RequestConfig, c.deps), nil
-		default:
-			return nil, errors.Errorf("unknown courier channel type: %s", channel.Type)
+		if channels[i], err = c.newChannel(channel); err != nil {
+			return nil, err
 		}
 	}
+	return channels, nil
+}
 
-	return nil, errors.Errorf("no courier channels configured for: %s", id)
+func (c *courier) newChannel(channel *config.CourierChannel) (Channel, error) {
+	switch channel.Type {
+	case "smtp":
+		return NewSMTPChannelWithCustomTemplates(c.deps, channel.SMTPConfig, c.newEmailTemplateFromMessage)
+	case "http":
+		return newHttpChannel(channel.ID, &channel.RequestConfig, c.deps), nil
+	case "twilio":
+		return newTwilioChannel(channel.ID, channel.TwilioConfig, c.deps)
+	case "vonage":
+		return newVonageChannel(channel.ID, channel.VonageConfig, c.deps)
+	case "sns":
+		return newSNSChannel(channel.ID, channel.SNSConfig, c.deps)
+	default:
+		return nil, errors.Errorf("unknown courier channel type: %s", channel.Type)
+	}
 }
 
 func (c *courier) DispatchMessage(ctx context.Context, msg Message) (err error) {
@@ -69,21 +88,35 @@ func (c *courier) DispatchMessage(ctx context.Context, msg Message) (err error)
 		return err
 	}
 
-	channel, err := c.channels(ctx, msg.Channel.String())
+	channels, err := c.channels(ctx, msg)
 	if err != nil {
+		c.recordDispatch(ctx, logger, msg, "", err)
 		return err
 	}
 
-	span.SetAttributes(attribute.String("channel.id", channel.ID()))
-	logger = logger.
-		WithField("channel", channel.ID())
+	for i, channel := range channels {
+		logger := logger.WithField("channel", channel.ID())
 
-	if err := channel.Dispatch(ctx, msg); err != nil {
-		return err
+		err = channel.Dispatch(ctx, msg)
+		c.recordDispatch(ctx, logger, msg, channel.ID(), err)
+		if err == nil {
+			span.SetAttributes(attribute.String("channel.id", channel.ID()))
+			events.Audit(ctx, span).AddEvent(events.NewCourierMessageDispatched(ctx, msg.ID, channel.ID(), string(msg.TemplateType)))
+			break
+		}
+
+		// Messages which the provider rejected permanently would be rejected
+		// by the other channels, too.
+		if IsPermanentError(err) || i == len(channels)-1 {
+			return err
+		}
+		logger.
+			WithError(err).
+			WithField("fallback_channel", channels[i+1].ID()).
+			Warn("Unable to dispatch message, trying the next channel.")
 	}
-	span.AddEvent(events.NewCourierMessageDispatched(ctx, msg.ID, msg.Channel.String(), string(msg.TemplateType)))
 
-	if err := c.deps.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusSent); err != nil {
+	if err := setMessageStatus(ctx, c.deps.CourierPersister(), msg, MessageStatusSent); err != nil {
 		logger.
 			WithError(err).
 			Error(`Unable to set the message status to "sent".`)
@@ -103,7 +136,7 @@ func (c *courier) DispatchQueue(ctx context.Context) (err error) {
 	pullCount := c.deps.CourierConfig().CourierWorkerPullCount(ctx)
 
 	//nolint:gosec // disable G115
-	messages, err := c.deps.CourierPersister().NextMessages(ctx, uint8(pullCount))
+	messages, err := c.deps.CourierPersister().LeaseMessages(ctx, uint8(pullCount), c.leaseWorkerID(ctx), c.deps.CourierConfig().CourierWorkerLeaseDuration(ctx))
 	if err != nil {
 		if errors.Is(err, ErrQueueEmpty) {
 			return nil
@@ -120,8 +153,17 @@ func (c *courier) DispatchQueue(ctx context.Context) (err error) {
 			WithField("message_template_type", msg.TemplateType).
 			WithField("message_subject", msg.Subject)
 
+		if msg.LockedUntil != nil && time.Now().After(time.Time(*msg.LockedUntil)) {
+			// Another worker may have reclaimed the remaining messages, so
+			// dispatching them could send them twice.
+			logger.
+				WithField("worker_id", msg.WorkerID.String()).
+				Warn(`The lease of the pulled messages expired before they were dispatched. Consider increasing "courier.worker.lease_duration" or decreasing "courier.worker.pull_count".`)
+			return nil
+		}
+
 		if msg.SendCount > maxRetries {
-			if err := c.deps.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusAbandoned); err != nil {
+			if err := setMessageStatus(ctx, c.deps.CourierPersister(), msg, MessageStatusAbandoned); err != nil {
 				logger.
 					WithError(err).
 					Error(`Unable to set the retried message's status to "abandoned".`)
@@ -129,7 +171,7 @@ func (c *courier) DispatchQueue(ctx context.Context) (err error) {
 			}
 
 			msgCtx := semconv.ContextWithAttributes(ctx, semconv.AttrNID(msg.NID))
-			span.AddEvent(events.NewCourierMessageAbandoned(msgCtx, msg.ID, msg.Channel.String(), string(msg.TemplateType)))
+			events.Audit(msgCtx, span).AddEvent(events.NewCourierMessageAbandoned(msgCtx, msg.ID, msg.Channel.String(), string(msg.TemplateType)))
 
 			// Skip the message
 			logger.
@@ -138,17 +180,30 @@ func (c *courier) DispatchQueue(ctx context.Context) (err error) {
 			logger.
 				WithError(err).
 				Warn(`Unable to dispatch message.`)
-			if err := c.deps.CourierPersister().RecordDispatch(ctx, msg.ID, CourierMessageDispatchStatusFailed, err); err != nil {
-				logger.
-					WithError(err).
-					Error(`Unable to record failure log entry.`)
-				if c.failOnDispatchError {
-					return err
+			requeue := messages[k:]
+			if IsPermanentError(err) {
+				// The provider rejected the message itself, so retrying it would fail again.
+				if err := setMessageStatus(ctx, c.deps.CourierPersister(), msg, MessageStatusAbandoned); err != nil {
+					logger.
+						WithError(err).
+						Error(`Unable to set the rejected message's status to "abandoned".`)
+					if c.failOnDispatchError {
+						return err
+					}
+				} else {
+					msgCtx := semconv.ContextWithAttributes(ctx, semconv.AttrNID(msg.NID))
+					events.Audit(msgCtx, span).AddEvent(events.NewCourierMessageAbandoned(msgCtx, msg.ID, msg.Channel.String(), string(msg.TemplateType)))
+					logger.Warn(`Message was abandoned because the provider rejected it permanently`)
+					requeue = messages[k+1:]
 				}
 			}
 
-			for _, replace := range messages[k:] {
-				if err := c.deps.CourierPersister().SetMessageStatus(ctx, replace.ID, MessageStatusQueued); err != nil {
+			for _, replace := range requeue {
+				if err := setMessageStatus(ctx, c.deps.CourierPersister(), replace, MessageStatusQueued); errors.Is(err, ErrMessageLeaseLost) {
+					// The channel already abandoned the message, or another
+					// worker reclaimed it after the lease expired.
+					continue
+				} else if err != nil {
 					logger.
 						WithError(err).
 						Error(`Unable to reset the failed message's status to "queued".`)
@@ -161,13 +216,22 @@ func (c *courier) DispatchQueue(ctx context.Context) (err error) {
 			if c.failOnDispatchError {
 				return err
 			}
-		} else if err := c.deps.CourierPersister().RecordDispatch(ctx, msg.ID, CourierMessageDispatchStatusSuccess, nil); err != nil {
-			logger.
-				WithError(err).
-				Error(`Unable to record success log entry.`)
-			// continue with execution, as the message was successfully dispatched
 		}
 	}
 
 	return nil
 }
+
+// recordDispatch records the attempt of dispatching the message via the
+// channel. Failing to record it does not fail the dispatch.
+func (c *courier) recordDispatch(ctx context.Context, logger *logrusx.Logger, msg Message, channel string, err error) {
+	status := CourierMessageDispatchStatusSuccess
+	if err != nil {
+		status = CourierMessageDispatchStatusFailed
+	}
+	if err := c.deps.CourierPersister().RecordDispatch(ctx, msg.ID, channel, status, err); err != nil {
+		logger.
+			WithError(err).
+			Errorf(`Unable to record %s log entry.`, status)
+	}
+}
@@ -8,7 +8,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
@@ -20,6 +24,7 @@ import (
 
 	"github.com/ory/kratos/courier"
 	templates "github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/courier/template/sms"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/pkg"
 	"github.com/ory/kratos/pkg/testhelpers"
@@ -124,6 +129,98 @@ func TestDispatchQueue(t *testing.T) {
 	require.Contains(t, gjson.GetBytes(message.Dispatches[1].Error, "reason").String(), "failed to send email via smtp")
 }
 
+func TestDispatchQueueLeases(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+		config.ViperKeyCourierDeliveryStrategy:  "http",
+		config.ViperKeyCourierHTTPRequestConfig: fmt.Sprintf(`{"url": "%s", "method": "POST", "body": "file://./stub/request.config.mailer.jsonnet"}`, srv.URL),
+		config.ViperKeyCourierWorkerPullCount:   3,
+	}))
+
+	t.Run("case=concurrent workers send every message once", func(t *testing.T) {
+		calls.Store(0)
+		workers := make([]courier.Courier, 3)
+		for i := range workers {
+			var err error
+			workers[i], err = courier.NewCourier(t.Context(), reg)
+			require.NoError(t, err)
+			workers[i].FailOnDispatchError()
+		}
+
+		const count = 20
+		ids := make([]uuid.UUID, count)
+		for i := range ids {
+			ids[i] = queueNewMessage(t, workers[0])
+		}
+
+		var wg sync.WaitGroup
+		for _, w := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range count {
+					assert.NoError(t, w.DispatchQueue(t.Context()))
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.EqualValues(t, count, calls.Load())
+		for _, id := range ids {
+			m, err := reg.CourierPersister().FetchMessage(t.Context(), id)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusSent, m.Status)
+			assert.Len(t, m.Dispatches, 1)
+		}
+	})
+
+	t.Run("case=messages of a crashed worker are reclaimed after the lease expired", func(t *testing.T) {
+		calls.Store(0)
+		c, err := courier.NewCourier(t.Context(), reg)
+		require.NoError(t, err)
+		c.FailOnDispatchError()
+
+		id := queueNewMessage(t, c)
+		leased, err := reg.CourierPersister().LeaseMessages(t.Context(), 10, "crashed-worker", time.Millisecond)
+		require.NoError(t, err)
+		require.Len(t, leased, 1)
+
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, c.DispatchQueue(t.Context()))
+
+		assert.EqualValues(t, 1, calls.Load())
+		m, err := reg.CourierPersister().FetchMessage(t.Context(), id)
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessageStatusSent, m.Status)
+	})
+
+	t.Run("case=messages leased by another worker are not dispatched", func(t *testing.T) {
+		calls.Store(0)
+		c, err := courier.NewCourier(t.Context(), reg)
+		require.NoError(t, err)
+		c.FailOnDispatchError()
+
+		id := queueNewMessage(t, c)
+		_, err = reg.CourierPersister().LeaseMessages(t.Context(), 10, "other-worker", time.Hour)
+		require.NoError(t, err)
+
+		require.NoError(t, c.DispatchQueue(t.Context()))
+
+		assert.EqualValues(t, 0, calls.Load())
+		m, err := reg.CourierPersister().FetchMessage(t.Context(), id)
+		require.NoError(t, err)
+		assert.Equal(t, courier.MessageStatusProcessing, m.Status)
+	})
+}
+
 func TestDispatchMessageEmitsEventWithNID(t *testing.T) {
 	t.Parallel()
 
@@ -174,3 +271,133 @@ func TestDispatchMessageEmitsEventWithNID(t *testing.T) {
 	require.GreaterOrEqual(t, k, 0, "NID attribute not found on event")
 	assert.NotEmpty(t, attrs[k].Value.AsString())
 }
+
+func TestDispatchMessageFailoverAndRouting(t *testing.T) {
+	t.Parallel()
+
+	provider := func(t *testing.T, status int, body string) (*httptest.Server, *atomic.Int32) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(srv.Close)
+		return srv, &calls
+	}
+
+	channel := func(id, url string, extra string) string {
+		return fmt.Sprintf(`{"id": %q, "type": "twilio", "twilio_config": {"url": %q, "account_sid": "AC123", "auth_token": "secret", "from": "+12065550100"}%s}`, id, url, extra)
+	}
+
+	send := func(t *testing.T, recipient string, channels ...string) (*courier.Message, error) {
+		_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+			config.ViperKeyCourierChannels: "[" + strings.Join(channels, ",") + "]",
+			config.ViperKeyCourierSMTPURL:  "http://foo.url",
+		}))
+
+		c, err := reg.Courier(t.Context())
+		require.NoError(t, err)
+		c.FailOnDispatchError()
+
+		id, err := c.QueueSMS(t.Context(), sms.NewTestStub(&sms.TestStubModel{To: recipient, Body: "test-sms-body"}))
+		require.NoError(t, err)
+
+		dispatchErr := c.DispatchQueue(t.Context())
+
+		m, err := reg.CourierPersister().FetchMessage(t.Context(), id)
+		require.NoError(t, err)
+		return m, dispatchErr
+	}
+
+	dispatchedVia := func(m *courier.Message) (channels []string) {
+		for _, d := range slices.Backward(m.Dispatches) {
+			channels = append(channels, fmt.Sprintf("%s:%s", d.Channel.String(), d.Status))
+		}
+		return channels
+	}
+
+	t.Run("case=falls back to the next channel", func(t *testing.T) {
+		down, downCalls := provider(t, http.StatusServiceUnavailable, `{"code": 20503, "message": "Service Unavailable"}`)
+		up, upCalls := provider(t, http.StatusCreated, `{"sid": "SM123"}`)
+
+		m, err := send(t, "+12065550101",
+			channel("sms", down.URL, `, "fallback": ["sms-backup"]`),
+			channel("sms-backup", up.URL, ""),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, courier.MessageStatusSent, m.Status)
+		assert.Equal(t, []string{"sms:failed", "sms-backup:success"}, dispatchedVia(m))
+		assert.EqualValues(t, 1, downCalls.Load())
+		assert.EqualValues(t, 1, upCalls.Load())
+	})
+
+	t.Run("case=queues the message again if all channels fail", func(t *testing.T) {
+		down, _ := provider(t, http.StatusServiceUnavailable, `{"code": 20503, "message": "Service Unavailable"}`)
+
+		m, err := send(t, "+12065550101",
+			channel("sms", down.URL, `, "fallback": ["sms-backup"]`),
+			channel("sms-backup", down.URL, ""),
+		)
+		require.Error(t, err)
+
+		assert.Equal(t, courier.MessageStatusQueued, m.Status)
+		assert.Equal(t, []string{"sms:failed", "sms-backup:failed"}, dispatchedVia(m))
+	})
+
+	t.Run("case=does not fall back on permanent errors", func(t *testing.T) {
+		rejecting, _ := provider(t, http.StatusBadRequest, `{"code": 21211, "message": "The 'To' number is not a valid phone number."}`)
+		up, upCalls := provider(t, http.StatusCreated, `{"sid": "SM123"}`)
+
+		m, err := send(t, "+12065550101",
+			channel("sms", rejecting.URL, `, "fallback": ["sms-backup"]`),
+			channel("sms-backup", up.URL, ""),
+		)
+		require.Error(t, err)
+
+		assert.Equal(t, courier.MessageStatusAbandoned, m.Status)
+		assert.Equal(t, []string{"sms:failed"}, dispatchedVia(m))
+		assert.Zero(t, upCalls.Load())
+	})
+
+	t.Run("case=routes by recipient prefix", func(t *testing.T) {
+		us, usCalls := provider(t, http.StatusCreated, `{"sid": "SM123"}`)
+		de, deCalls := provider(t, http.StatusCreated, `{"sid": "SM123"}`)
+		channels := []string{
+			channel("sms", us.URL, `, "routes": [{"channel": "sms-de", "recipient_prefixes": ["+49", "+43"]}]`),
+			channel("sms-de", de.URL, ""),
+		}
+
+		m, err := send(t, "+4915555554570", channels...)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sms-de:success"}, dispatchedVia(m))
+
+		m, err = send(t, "+12065550101", channels...)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sms:success"}, dispatchedVia(m))
+
+		assert.EqualValues(t, 1, deCalls.Load())
+		assert.EqualValues(t, 1, usCalls.Load())
+	})
+
+	t.Run("case=tries the addressed channel after the routed channel", func(t *testing.T) {
+		down, _ := provider(t, http.StatusServiceUnavailable, `{"code": 20503, "message": "Service Unavailable"}`)
+		up, _ := provider(t, http.StatusCreated, `{"sid": "SM123"}`)
+
+		m, err := send(t, "+4915555554570",
+			channel("sms", up.URL, `, "routes": [{"channel": "sms-de", "recipient_prefixes": ["+49"]}]`),
+			channel("sms-de", down.URL, ""),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sms-de:failed", "sms:success"}, dispatchedVia(m))
+	})
+
+	t.Run("case=fails if a fallback channel is not configured", func(t *testing.T) {
+		up, _ := provider(t, http.StatusCreated, `{"sid": "SM123"}`)
+
+		m, err := send(t, "+12065550101", channel("sms", up.URL, `, "fallback": ["sms-missing"]`))
+		require.ErrorContains(t, err, "no courier channels configured for: sms-missing")
+		assert.Equal(t, []string{":failed"}, dispatchedVia(m))
+	})
+}
@@ -0,0 +1,464 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"  //#nosec G501 -- Vonage signs delivery receipts with MD5 by default
+	"crypto/sha1" //#nosec G505 -- Twilio signs requests with HMAC-SHA1
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	RouteDeliveryStatus = "/courier/delivery-status/{provider}"
+
+	// DeliveryStatusHeader is the email header which carries the message ID,
+	// so that bounce processors can report the delivery status of the
+	// message.
+	DeliveryStatusHeader = "X-Kratos-Message-Id"
+)
+
+// deliveryStatusTransitions lists the statuses a message may have when a
+// delivery status is reported. Duplicate and out-of-order reports are ignored.
+// Messages are allowed to be processing, because the provider may report the
+// status before the courier marked the message as sent.
+var deliveryStatusTransitions = map[MessageStatus][]MessageStatus{
+	MessageStatusDelivered:  {MessageStatusProcessing, MessageStatusSent},
+	MessageStatusBounced:    {MessageStatusProcessing, MessageStatusSent, MessageStatusDelivered},
+	MessageStatusComplained: {MessageStatusProcessing, MessageStatusSent, MessageStatusDelivered},
+}
+
+var deliveryStatusDispatches = map[MessageStatus]CourierMessageDispatchStatus{
+	MessageStatusDelivered:  CourierMessageDispatchStatusDelivered,
+	MessageStatusBounced:    CourierMessageDispatchStatusBounced,
+	MessageStatusComplained: CourierMessageDispatchStatusComplained,
+}
+
+// deliveryStatus is a delivery status reported by a provider. A zero Status
+// means that the report does not change the message's status, for example
+// because the provider only reports that it queued the message.
+type deliveryStatus struct {
+	MessageID uuid.UUID
+	Status    MessageStatus
+	Code      string
+	Reason    string
+}
+
+// deliveryStatusCallbackURL returns the URL to which the provider reports the
+// delivery status of a message sent through the channel. The provider signs
+// its callbacks with the channel's credentials, so the URL carries no secret.
+func deliveryStatusCallbackURL(ctx context.Context, c config.CourierConfigs, provider, channelID string, msgID uuid.UUID) string {
+	u := urlx.AppendPaths(c.SelfPublicURL(ctx), strings.Replace(RouteDeliveryStatus, "{provider}", provider, 1))
+	q := url.Values{"channel": {channelID}}
+	if msgID != uuid.Nil {
+		q.Set("message_id", msgID.String())
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// Generic Delivery Status
+//
+// swagger:model courierDeliveryStatus
+type genericDeliveryStatus struct {
+	// MessageID is the ID of the courier message. For emails it is sent in
+	// the X-Kratos-Message-Id header.
+	//
+	// required: true
+	MessageID uuid.UUID `json:"message_id"`
+
+	// Status is the delivery status of the message.
+	//
+	// required: true
+	// enum: delivered,bounced,complained
+	Status string `json:"status"`
+
+	// Code is an optional provider specific error code.
+	Code string `json:"code,omitempty"`
+
+	// Reason optionally explains why the message bounced.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Update Delivery Status Parameters
+//
+// swagger:parameters updateCourierDeliveryStatus
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type updateCourierDeliveryStatus struct {
+	// Provider is the provider which reports the delivery status, one of
+	// twilio, vonage, or generic.
+	//
+	// required: true
+	// in: path
+	Provider string `json:"provider"`
+
+	// Channel is the ID of the courier channel which sent the message. It is
+	// set by the twilio and vonage providers.
+	//
+	// in: query
+	Channel string `json:"channel"`
+
+	// in: body
+	Body genericDeliveryStatus
+}
+
+// swagger:route POST /courier/delivery-status/{provider} courier updateCourierDeliveryStatus
+//
+// # Report the Delivery Status of a Message
+//
+// Receives delivery receipts of SMS providers and bounce notifications for
+// emails, and updates the status of the message accordingly. The twilio and
+// vonage providers are configured automatically when delivery status callbacks
+// are enabled, and are authenticated by the request signatures of Twilio and
+// Vonage. Bounce processors use the generic provider with a JSON body, or the
+// dsn provider with a delivery status notification (RFC 3464) as body, and
+// authenticate with the configured secret as bearer token.
+//
+//	Consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//	- message/rfc822
+//
+//	Schemes: http, https
+//
+//	Responses:
+//		204: emptyResponse
+//		400: errorGeneric
+//		401: errorGeneric
+//		404: errorGeneric
+//		default: errorGeneric
+func (h *Handler) updateDeliveryStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !h.r.Config().CourierDeliveryStatusEnabled(ctx) {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound().WithReason("Delivery status callbacks are disabled.")))
+		return
+	}
+
+	var (
+		status  *deliveryStatus
+		channel string
+		err     error
+	)
+	provider := r.PathValue("provider")
+	switch provider {
+	case "twilio":
+		status, channel, err = h.parseTwilioDeliveryStatus(r)
+	case "vonage":
+		status, channel, err = h.parseVonageDeliveryStatus(r)
+	case "generic":
+		if err = h.authenticateBearer(r); err == nil {
+			status, err = parseGenericDeliveryStatus(r)
+		}
+	case "dsn":
+		if err = h.authenticateBearer(r); err == nil {
+			status, err = parseDSNDeliveryStatus(r)
+		}
+	default:
+		err = errors.WithStack(herodot.ErrNotFound().WithReasonf("Unknown delivery status provider %q.", provider))
+	}
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if status.Status != 0 {
+		if err := h.applyDeliveryStatus(ctx, provider, channel, status); err != nil {
+			h.r.Writer().WriteError(w, r, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+var errDeliveryStatusUnauthenticated = herodot.ErrUnauthorized().WithReason("The delivery status callback could not be authenticated.")
+
+// authenticateBearer checks the configured secret, which bounce processors
+// send as bearer token.
+func (h *Handler) authenticateBearer(r *http.Request) error {
+	secret := h.r.Config().CourierDeliveryStatusSecret(r.Context())
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if secret == "" || !ok || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return errors.WithStack(errDeliveryStatusUnauthenticated)
+	}
+	return nil
+}
+
+// courierChannel returns the configuration of the channel which sent the
+// message the callback reports on.
+func (h *Handler) courierChannel(ctx context.Context, id string) (*config.CourierChannel, error) {
+	channels, err := h.r.Config().CourierChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range channels {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, errors.WithStack(errDeliveryStatusUnauthenticated)
+}
+
+func (h *Handler) applyDeliveryStatus(ctx context.Context, provider, channel string, status *deliveryStatus) error {
+	p := h.r.CourierPersister()
+
+	message, err := p.FetchMessage(ctx, status.MessageID)
+	if err != nil {
+		return err
+	}
+	if channel == "" {
+		channel = lastDispatchChannel(message)
+	}
+
+	if !slices.Contains(deliveryStatusTransitions[status.Status], message.Status) {
+		h.r.Logger().
+			WithField("message_id", message.ID).
+			WithField("message_status", message.Status.String()).
+			WithField("delivery_status", status.Status.String()).
+			Debug("Ignoring delivery status report which does not apply to the message status.")
+		return nil
+	}
+
+	if err := p.SetMessageStatus(ctx, message.ID, status.Status); err != nil {
+		return err
+	}
+
+	var dispatchErr error
+	if status.Status != MessageStatusDelivered {
+		dispatchErr = &ProviderError{Provider: provider, Code: status.Code, Message: status.Reason, Permanent: true}
+	}
+	if err := p.RecordDispatch(ctx, message.ID, channel, deliveryStatusDispatches[status.Status], dispatchErr); err != nil {
+		return err
+	}
+
+	if status.Status != MessageStatusDelivered && h.r.Config().CourierDeliveryStatusMarkUndeliverable(ctx) {
+		if err := p.MarkAddressUndeliverable(ctx, message.Type.String(), message.Recipient); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lastDispatchChannel returns the channel which sent the message, for reports
+// which do not name the channel.
+func lastDispatchChannel(m *Message) string {
+	var last *MessageDispatch
+	for k := range m.Dispatches {
+		d := &m.Dispatches[k]
+		if d.Status != CourierMessageDispatchStatusSuccess || d.Channel.IsZero() {
+			continue
+		}
+		if last == nil || d.CreatedAt.After(last.CreatedAt) {
+			last = d
+		}
+	}
+	if last == nil {
+		return ""
+	}
+	return last.Channel.String()
+}
+
+func parseMessageID(v string) (uuid.UUID, error) {
+	id, err := uuid.FromString(v)
+	if err != nil {
+		return uuid.Nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The message ID %q is not valid.", v).WithWrap(err))
+	}
+	return id, nil
+}
+
+// parseTwilioDeliveryStatus parses a Twilio status callback and verifies its
+// signature with the auth token of the channel which sent the message.
+//
+// See https://www.twilio.com/docs/messaging/guides/track-outbound-message-status
+// and https://www.twilio.com/docs/usage/webhooks/webhooks-security
+func (h *Handler) parseTwilioDeliveryStatus(r *http.Request) (*deliveryStatus, string, error) {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		return nil, "", errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()))
+	}
+
+	channelID := r.URL.Query().Get("channel")
+	channel, err := h.courierChannel(ctx, channelID)
+	if err != nil {
+		return nil, "", err
+	}
+	if channel.TwilioConfig == nil || channel.TwilioConfig.AuthToken == "" {
+		return nil, "", errors.WithStack(errDeliveryStatusUnauthenticated)
+	}
+
+	id, err := parseMessageID(r.URL.Query().Get("message_id"))
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Twilio signs the callback URL which was sent with the message.
+	expected := twilioSignature(channel.TwilioConfig.AuthToken, deliveryStatusCallbackURL(ctx, h.r.Config(), "twilio", channelID, id), r.PostForm)
+	if !hmac.Equal([]byte(r.Header.Get("X-Twilio-Signature")), []byte(expected)) {
+		return nil, "", errors.WithStack(errDeliveryStatusUnauthenticated)
+	}
+
+	status := &deliveryStatus{MessageID: id, Code: r.PostForm.Get("ErrorCode")}
+	switch r.PostForm.Get("MessageStatus") {
+	case "delivered":
+		status.Status = MessageStatusDelivered
+	case "undelivered", "failed":
+		status.Status = MessageStatusBounced
+		status.Reason = "Twilio reported the message as " + r.PostForm.Get("MessageStatus") + "."
+	}
+	return status, channelID, nil
+}
+
+// twilioSignature returns the X-Twilio-Signature of a request to the URL with
+// the form parameters.
+func twilioSignature(authToken, u string, form url.Values) string {
+	mac := hmac.New(sha1.New, []byte(authToken))
+	_, _ = mac.Write([]byte(u))
+	for _, k := range slices.Sorted(maps.Keys(form)) {
+		values := slices.Clone(form[k])
+		slices.Sort(values)
+		for _, v := range values {
+			_, _ = mac.Write([]byte(k + v))
+		}
+	}
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// parseVonageDeliveryStatus parses a Vonage SMS API delivery receipt, which is
+// either sent as query parameters or as a JSON body, and verifies its
+// signature with the signature secret of the channel which sent the message.
+//
+// See https://developer.vonage.com/en/messaging/sms/guides/delivery-receipts
+// and https://developer.vonage.com/en/getting-started/concepts/signing-messages
+func (h *Handler) parseVonageDeliveryStatus(r *http.Request) (*deliveryStatus, string, error) {
+	ctx := r.Context()
+	params := map[string]string{}
+	for k := range r.URL.Query() {
+		params[k] = r.URL.Query().Get(k)
+	}
+	if r.Method == http.MethodPost {
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				return nil, "", errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()))
+			}
+			for k, v := range body {
+				if s, ok := v.(string); ok {
+					params[k] = s
+				}
+			}
+		} else {
+			if err := r.ParseForm(); err != nil {
+				return nil, "", errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()))
+			}
+			for k := range r.PostForm {
+				params[k] = r.PostForm.Get(k)
+			}
+		}
+	}
+
+	// The channel is part of the callback URL and not signed by Vonage.
+	channelID := params["channel"]
+	delete(params, "channel")
+	channel, err := h.courierChannel(ctx, channelID)
+	if err != nil {
+		return nil, "", err
+	}
+	if channel.VonageConfig == nil || channel.VonageConfig.SignatureSecret == "" {
+		return nil, "", errors.WithStack(errDeliveryStatusUnauthenticated)
+	}
+
+	sig := params["sig"]
+	delete(params, "sig")
+	expected, err := vonageSignature(channel.VonageConfig.SignatureSecret, channel.VonageConfig.SignatureMethod, params)
+	if err != nil {
+		return nil, "", err
+	}
+	if !hmac.Equal([]byte(strings.ToLower(sig)), []byte(expected)) {
+		return nil, "", errors.WithStack(errDeliveryStatusUnauthenticated)
+	}
+
+	id, err := parseMessageID(params["client-ref"])
+	if err != nil {
+		return nil, "", err
+	}
+
+	status := &deliveryStatus{MessageID: id, Code: params["err-code"]}
+	switch params["status"] {
+	case "delivered":
+		status.Status = MessageStatusDelivered
+	case "failed", "rejected", "expired":
+		status.Status = MessageStatusBounced
+		status.Reason = "Vonage reported the message as " + params["status"] + "."
+	}
+	return status, channelID, nil
+}
+
+// vonageSignature returns the lower case hex signature of the parameters of a
+// signed Vonage request.
+func vonageSignature(secret, method string, params map[string]string) (string, error) {
+	var signed strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(params)) {
+		signed.WriteString("&" + k + "=" + strings.NewReplacer("&", "_", "=", "_").Replace(params[k]))
+	}
+
+	var h func() hash.Hash
+	switch method {
+	case "", "md5hash":
+		sum := md5.Sum([]byte(signed.String() + secret)) //#nosec G401 -- required by Vonage
+		return hex.EncodeToString(sum[:]), nil
+	case "md5":
+		h = md5.New
+	case "sha1":
+		h = sha1.New
+	case "sha256":
+		h = sha256.New
+	case "sha512":
+		h = sha512.New
+	default:
+		return "", errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unknown Vonage signature method %q.", method))
+	}
+	mac := hmac.New(h, []byte(secret))
+	_, _ = mac.Write([]byte(signed.String()))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func parseGenericDeliveryStatus(r *http.Request) (*deliveryStatus, error) {
+	var body genericDeliveryStatus
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()))
+	}
+
+	status, err := ToMessageStatus(body.Status)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := deliveryStatusTransitions[status]; !ok {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The delivery status must be delivered, bounced, or complained, but got %q.", body.Status))
+	}
+
+	return &deliveryStatus{MessageID: body.MessageID, Status: status, Code: body.Code, Reason: body.Reason}, nil
+}
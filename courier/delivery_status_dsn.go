@@ -0,0 +1,119 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+)
+
+// maxDSNSize limits the size of delivery status notifications, which may
+// include the complete bounced message.
+const maxDSNSize = 1 << 20
+
+// parseDSNDeliveryStatus parses a delivery status notification (DSN) which the
+// mail server sent back for an email of the courier. Bounce processors forward
+// the DSN unchanged as request body.
+//
+// See https://www.rfc-editor.org/rfc/rfc3464 and
+// https://www.rfc-editor.org/rfc/rfc6522
+func parseDSNDeliveryStatus(r *http.Request) (*deliveryStatus, error) {
+	invalid := func(reason string, args ...any) error {
+		return errors.WithStack(herodot.ErrBadRequest().WithReasonf(reason, args...))
+	}
+
+	msg, err := mail.ReadMessage(io.LimitReader(r.Body, maxDSNSize))
+	if err != nil {
+		return nil, invalid("The delivery status notification is not a valid email: %s", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/report" || !strings.EqualFold(params["report-type"], "delivery-status") {
+		return nil, invalid("The email is not a delivery status notification.")
+	}
+
+	var (
+		status    *deliveryStatus
+		messageID string
+	)
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, invalid("The delivery status notification is malformed: %s", err)
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch partType {
+		case "message/delivery-status", "message/global-delivery-status":
+			if status, err = parseDSNRecipientStatus(part); err != nil {
+				return nil, invalid("The delivery status notification is malformed: %s", err)
+			}
+		case "message/rfc822", "message/global", "text/rfc822-headers", "message/global-headers":
+			// Headers only parts may lack the empty line which ends the header.
+			header, err := textproto.NewReader(bufio.NewReader(io.MultiReader(part, bytes.NewReader([]byte("\r\n\r\n"))))).ReadMIMEHeader()
+			if err != nil {
+				return nil, invalid("The returned message of the delivery status notification is malformed: %s", err)
+			}
+			messageID = header.Get(DeliveryStatusHeader)
+		}
+	}
+
+	if status == nil {
+		return nil, invalid("The delivery status notification does not contain a delivery status.")
+	}
+	if messageID == "" {
+		return nil, invalid("The delivery status notification does not contain the %s header of the returned message.", DeliveryStatusHeader)
+	}
+	if status.MessageID, err = parseMessageID(messageID); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// parseDSNRecipientStatus returns the status of the first recipient of the
+// delivery-status part. The courier sends every email to a single recipient.
+func parseDSNRecipientStatus(part io.Reader) (*deliveryStatus, error) {
+	fields := textproto.NewReader(bufio.NewReader(part))
+
+	// The per-message fields come first and are not needed.
+	if _, err := fields.ReadMIMEHeader(); err != nil {
+		return nil, err
+	}
+
+	recipient, err := fields.ReadMIMEHeader()
+	if err != nil && !(errors.Is(err, io.EOF) && len(recipient) > 0) {
+		return nil, err
+	}
+
+	status := &deliveryStatus{Code: recipient.Get("Status")}
+	switch action := strings.ToLower(recipient.Get("Action")); action {
+	case "delivered":
+		status.Status = MessageStatusDelivered
+	case "failed":
+		status.Status = MessageStatusBounced
+		status.Reason = recipient.Get("Diagnostic-Code")
+		if status.Reason == "" {
+			status.Reason = "The mail server reported the message as undeliverable."
+		}
+	case "delayed", "relayed", "expanded":
+		// The message may still be delivered.
+	default:
+		return nil, errors.Errorf("unknown action %q", action)
+	}
+	return status, nil
+}
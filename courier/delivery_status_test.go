@@ -0,0 +1,378 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/urlx"
+)
+
+func TestDeliveryStatusSignatures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("provider=twilio", func(t *testing.T) {
+		// The example of https://www.twilio.com/docs/usage/webhooks/webhooks-security
+		assert.Equal(t, "RSOYDt4T1cUTdK1PDd93/VVr8B8=", courier.TwilioSignature("12345", "https://mycompany.com/myapp.php?foo=1&bar=2", url.Values{
+			"CallSid": {"CA1234567890ABCDE"},
+			"Caller":  {"+14158675309"},
+			"Digits":  {"1234"},
+			"From":    {"+14158675309"},
+			"To":      {"+18005551212"},
+		}))
+	})
+
+	t.Run("provider=vonage", func(t *testing.T) {
+		// The expected signatures were computed with md5sum and openssl.
+		params := map[string]string{"client-ref": "abc", "status": "delivered", "timestamp": "1700000000", "to": "x&y=z"}
+		for method, expected := range map[string]string{
+			"md5hash": "3522a920548f7f65ab37177984786f38",
+			"sha256":  "e7de47ac2dd7f2aa7ebee54b6f45f2189cdb217f3d1e25da72987da20682b481",
+		} {
+			actual, err := courier.VonageSignature("secret", method, params)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual, method)
+		}
+
+		_, err := courier.VonageSignature("secret", "crc32", params)
+		require.Error(t, err)
+	})
+}
+
+func TestDeliveryStatus(t *testing.T) {
+	const (
+		secret                = "a-very-secret-callback-token"
+		twilioAuthToken       = "twilio-auth-token"
+		vonageSignatureSecret = "vonage-signature-secret"
+	)
+
+	ctx := t.Context()
+	conf, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+		config.ViperKeyCourierSMTPURL:               "http://foo.url",
+		config.ViperKeyCourierDeliveryStatusEnabled: true,
+		config.ViperKeyCourierDeliveryStatusSecret:  secret,
+		config.ViperKeyCourierChannels: `[
+			{"id": "twilio-sms", "type": "twilio", "twilio_config": {"account_sid": "AC123", "auth_token": "` + twilioAuthToken + `", "from": "+12065550100"}},
+			{"id": "vonage-sms", "type": "vonage", "vonage_config": {"api_key": "key", "api_secret": "secret", "from": "Ory", "signature_secret": "` + vonageSignatureSecret + `", "signature_method": "sha256"}}
+		]`,
+	}))
+	publicTS, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	report := func(t *testing.T, method, path string, header http.Header, body string, expectCode int) gjson.Result {
+		t.Helper()
+		req, err := http.NewRequest(method, publicTS.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		for k := range header {
+			req.Header.Set(k, header.Get(k))
+		}
+		res, err := publicTS.Client().Do(req)
+		require.NoError(t, err)
+		b, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+
+		assert.EqualValuesf(t, expectCode, res.StatusCode, "%s", b)
+		return gjson.ParseBytes(b)
+	}
+
+	bearer := func(token, contentType string) http.Header {
+		return http.Header{"Authorization": {"Bearer " + token}, "Content-Type": {contentType}}
+	}
+
+	addMessage := func(t *testing.T, typ courier.MessageType, recipient string) uuid.UUID {
+		m := courier.Message{Type: typ, Recipient: recipient, Subject: "subject", Body: "body"}
+		require.NoError(t, reg.CourierPersister().AddMessage(ctx, &m))
+		require.NoError(t, reg.CourierPersister().SetMessageStatus(ctx, m.ID, courier.MessageStatusSent))
+		return m.ID
+	}
+
+	assertMessage := func(t *testing.T, id uuid.UUID, status courier.MessageStatus, dispatch courier.CourierMessageDispatchStatus) *courier.Message {
+		t.Helper()
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, status, m.Status)
+		require.NotEmpty(t, m.Dispatches)
+		assert.Equal(t, dispatch, m.Dispatches[0].Status)
+		return m
+	}
+
+	generic := func(id uuid.UUID, status string) string {
+		return `{"message_id": "` + id.String() + `", "status": "` + status + `", "code": "5.1.1", "reason": "mailbox does not exist"}`
+	}
+
+	t.Run("provider=generic", func(t *testing.T) {
+		const path = "/courier/delivery-status/generic"
+
+		t.Run("case=marks the message as delivered", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeEmail, "delivered@ory.sh")
+			require.NoError(t, reg.CourierPersister().RecordDispatch(ctx, id, "email", courier.CourierMessageDispatchStatusSuccess, nil))
+
+			report(t, http.MethodPost, path, bearer(secret, "application/json"), generic(id, "delivered"), http.StatusNoContent)
+			m := assertMessage(t, id, courier.MessageStatusDelivered, courier.CourierMessageDispatchStatusDelivered)
+			assert.Equal(t, "email", m.Dispatches[0].Channel.String(), "the status is recorded for the channel which sent the message")
+		})
+
+		t.Run("case=marks the message as bounced", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeEmail, "bounced@ory.sh")
+			report(t, http.MethodPost, path, bearer(secret, "application/json"), generic(id, "delivered"), http.StatusNoContent)
+			report(t, http.MethodPost, path, bearer(secret, "application/json"), generic(id, "bounced"), http.StatusNoContent)
+
+			m := assertMessage(t, id, courier.MessageStatusBounced, courier.CourierMessageDispatchStatusBounced)
+			assert.Equal(t, "5.1.1", gjson.GetBytes(m.Dispatches[0].Error, "details.code").String())
+			assert.Equal(t, "mailbox does not exist", gjson.GetBytes(m.Dispatches[0].Error, "reason").String())
+
+			t.Run("case=ignores a late delivery receipt", func(t *testing.T) {
+				report(t, http.MethodPost, path, bearer(secret, "application/json"), generic(id, "delivered"), http.StatusNoContent)
+				assertMessage(t, id, courier.MessageStatusBounced, courier.CourierMessageDispatchStatusBounced)
+			})
+		})
+
+		t.Run("case=marks the message as complained", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeEmail, "complained@ory.sh")
+			report(t, http.MethodPost, path, bearer(secret, "application/json"), generic(id, "complained"), http.StatusNoContent)
+			assertMessage(t, id, courier.MessageStatusComplained, courier.CourierMessageDispatchStatusComplained)
+		})
+
+		t.Run("case=rejects invalid statuses", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeEmail, "invalid@ory.sh")
+			report(t, http.MethodPost, path, bearer(secret, "application/json"), generic(id, "queued"), http.StatusBadRequest)
+		})
+
+		t.Run("case=returns an error if no message is found", func(t *testing.T) {
+			report(t, http.MethodPost, path, bearer(secret, "application/json"), generic(uuid.Must(uuid.NewV4()), "delivered"), http.StatusNotFound)
+		})
+
+		t.Run("case=rejects unauthenticated reports", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeEmail, "unauthenticated@ory.sh")
+			report(t, http.MethodPost, path, http.Header{"Content-Type": {"application/json"}}, generic(id, "bounced"), http.StatusUnauthorized)
+			report(t, http.MethodPost, path, bearer("wrong", "application/json"), generic(id, "bounced"), http.StatusUnauthorized)
+			// The secret is not accepted in the URL, where it would end up in logs.
+			report(t, http.MethodPost, path+"?token="+secret, http.Header{"Content-Type": {"application/json"}}, generic(id, "bounced"), http.StatusUnauthorized)
+
+			m, err := reg.CourierPersister().FetchMessage(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusSent, m.Status)
+		})
+	})
+
+	t.Run("provider=dsn", func(t *testing.T) {
+		const path = "/courier/delivery-status/dsn"
+
+		dsn := func(action, messageID string) string {
+			return strings.NewReplacer("{action}", action, "{id}", messageID).Replace(`From: MAILER-DAEMON@mail.example.org
+To: noreply@ory.sh
+Subject: Undelivered Mail Returned to Sender
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=delivery-status; boundary="dsn-boundary"
+
+--dsn-boundary
+Content-Type: text/plain
+
+The mail system could not deliver your message.
+
+--dsn-boundary
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mail.example.org
+Arrival-Date: Mon, 12 Oct 2026 10:00:00 +0000
+
+Final-Recipient: rfc822; dsn@ory.sh
+Original-Recipient: rfc822;dsn@ory.sh
+Action: {action}
+Status: 5.1.1
+Diagnostic-Code: smtp; 550 5.1.1 <dsn@ory.sh>: Recipient address rejected: User unknown
+
+--dsn-boundary
+Content-Type: text/rfc822-headers
+
+From: Ory <noreply@ory.sh>
+To: dsn@ory.sh
+Subject: Recover access to your account
+X-Kratos-Message-Id: {id}
+
+--dsn-boundary--
+`)
+		}
+
+		t.Run("case=marks the message as bounced", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeEmail, "dsn@ory.sh")
+			report(t, http.MethodPost, path, bearer(secret, "message/rfc822"), dsn("failed", id.String()), http.StatusNoContent)
+
+			m := assertMessage(t, id, courier.MessageStatusBounced, courier.CourierMessageDispatchStatusBounced)
+			assert.Equal(t, "5.1.1", gjson.GetBytes(m.Dispatches[0].Error, "details.code").String())
+			assert.Contains(t, gjson.GetBytes(m.Dispatches[0].Error, "reason").String(), "User unknown")
+		})
+
+		t.Run("case=ignores delayed messages", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeEmail, "dsn@ory.sh")
+			report(t, http.MethodPost, path, bearer(secret, "message/rfc822"), dsn("delayed", id.String()), http.StatusNoContent)
+
+			m, err := reg.CourierPersister().FetchMessage(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusSent, m.Status)
+		})
+
+		t.Run("case=rejects notifications without message ID", func(t *testing.T) {
+			report(t, http.MethodPost, path, bearer(secret, "message/rfc822"), dsn("failed", ""), http.StatusBadRequest)
+		})
+
+		t.Run("case=rejects other emails", func(t *testing.T) {
+			report(t, http.MethodPost, path, bearer(secret, "message/rfc822"), "From: foo@ory.sh\nSubject: hi\n\nhello", http.StatusBadRequest)
+		})
+
+		t.Run("case=rejects unauthenticated reports", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeEmail, "dsn@ory.sh")
+			report(t, http.MethodPost, path, http.Header{"Content-Type": {"message/rfc822"}}, dsn("failed", id.String()), http.StatusUnauthorized)
+		})
+	})
+
+	t.Run("provider=twilio", func(t *testing.T) {
+		callback := func(id uuid.UUID) (path, signedURL string) {
+			query := url.Values{"channel": {"twilio-sms"}, "message_id": {id.String()}}.Encode()
+			u := urlx.AppendPaths(conf.SelfPublicURL(ctx), "/courier/delivery-status/twilio")
+			u.RawQuery = query
+			return "/courier/delivery-status/twilio?" + query, u.String()
+		}
+		signed := func(token, signedURL string, form url.Values) http.Header {
+			return http.Header{
+				"Content-Type":       {"application/x-www-form-urlencoded"},
+				"X-Twilio-Signature": {courier.TwilioSignature(token, signedURL, form)},
+			}
+		}
+
+		t.Run("case=updates the status of signed callbacks", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeSMS, "+12065550101")
+			path, signedURL := callback(id)
+
+			sent := url.Values{"MessageSid": {"SM123"}, "MessageStatus": {"sent"}}
+			report(t, http.MethodPost, path, signed(twilioAuthToken, signedURL, sent), sent.Encode(), http.StatusNoContent)
+			m, err := reg.CourierPersister().FetchMessage(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusSent, m.Status)
+
+			undelivered := url.Values{"MessageSid": {"SM123"}, "MessageStatus": {"undelivered"}, "ErrorCode": {"30003"}}
+			report(t, http.MethodPost, path, signed(twilioAuthToken, signedURL, undelivered), undelivered.Encode(), http.StatusNoContent)
+			m = assertMessage(t, id, courier.MessageStatusBounced, courier.CourierMessageDispatchStatusBounced)
+			assert.Equal(t, "30003", gjson.GetBytes(m.Dispatches[0].Error, "details.code").String())
+			assert.Equal(t, "twilio-sms", m.Dispatches[0].Channel.String())
+		})
+
+		t.Run("case=rejects callbacks with invalid signatures", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeSMS, "+12065550102")
+			path, signedURL := callback(id)
+			form := url.Values{"MessageSid": {"SM123"}, "MessageStatus": {"undelivered"}}
+
+			report(t, http.MethodPost, path, http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}, form.Encode(), http.StatusUnauthorized)
+			report(t, http.MethodPost, path, signed("wrong", signedURL, form), form.Encode(), http.StatusUnauthorized)
+			report(t, http.MethodPost, path, signed(twilioAuthToken, signedURL, url.Values{"MessageStatus": {"delivered"}}), form.Encode(), http.StatusUnauthorized)
+
+			// A signature of another message's callback can not be reused.
+			otherPath, _ := callback(addMessage(t, courier.MessageTypeSMS, "+12065550103"))
+			report(t, http.MethodPost, otherPath, signed(twilioAuthToken, signedURL, form), form.Encode(), http.StatusUnauthorized)
+
+			// Only twilio channels are accepted.
+			report(t, http.MethodPost, strings.Replace(path, "twilio-sms", "vonage-sms", 1), signed(twilioAuthToken, signedURL, form), form.Encode(), http.StatusUnauthorized)
+
+			m, err := reg.CourierPersister().FetchMessage(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusSent, m.Status)
+		})
+	})
+
+	t.Run("provider=vonage", func(t *testing.T) {
+		signed := func(t *testing.T, params map[string]string) map[string]string {
+			sig, err := courier.VonageSignature(vonageSignatureSecret, "sha256", params)
+			require.NoError(t, err)
+			params["sig"] = strings.ToUpper(sig)
+			return params
+		}
+
+		t.Run("case=updates the status of signed receipts", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeSMS, "+12065550104")
+
+			query := url.Values{"channel": {"vonage-sms"}}
+			for k, v := range signed(t, map[string]string{"client-ref": id.String(), "status": "delivered", "err-code": "0", "timestamp": "1700000000"}) {
+				query.Set(k, v)
+			}
+			report(t, http.MethodGet, "/courier/delivery-status/vonage?"+query.Encode(), nil, "", http.StatusNoContent)
+			m := assertMessage(t, id, courier.MessageStatusDelivered, courier.CourierMessageDispatchStatusDelivered)
+			assert.Equal(t, "vonage-sms", m.Dispatches[0].Channel.String())
+
+			body, err := json.Marshal(signed(t, map[string]string{"client-ref": id.String(), "status": "expired", "err-code": "5", "timestamp": "1700000001"}))
+			require.NoError(t, err)
+			report(t, http.MethodPost, "/courier/delivery-status/vonage?channel=vonage-sms", http.Header{"Content-Type": {"application/json"}}, string(body), http.StatusNoContent)
+			assertMessage(t, id, courier.MessageStatusBounced, courier.CourierMessageDispatchStatusBounced)
+		})
+
+		t.Run("case=rejects receipts with invalid signatures", func(t *testing.T) {
+			id := addMessage(t, courier.MessageTypeSMS, "+12065550105")
+
+			for name, params := range map[string]url.Values{
+				"unsigned":      {"channel": {"vonage-sms"}, "client-ref": {id.String()}, "status": {"failed"}},
+				"wrong secret":  {"channel": {"vonage-sms"}, "client-ref": {id.String()}, "status": {"failed"}, "sig": {"0000"}},
+				"other channel": {"channel": {"twilio-sms"}, "client-ref": {id.String()}, "status": {"failed"}},
+			} {
+				t.Run("case="+name, func(t *testing.T) {
+					report(t, http.MethodGet, "/courier/delivery-status/vonage?"+params.Encode(), nil, "", http.StatusUnauthorized)
+				})
+			}
+
+			tampered := url.Values{"channel": {"vonage-sms"}}
+			for k, v := range signed(t, map[string]string{"client-ref": id.String(), "status": "delivered"}) {
+				tampered.Set(k, v)
+			}
+			tampered.Set("status", "failed")
+			report(t, http.MethodGet, "/courier/delivery-status/vonage?"+tampered.Encode(), nil, "", http.StatusUnauthorized)
+
+			m, err := reg.CourierPersister().FetchMessage(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusSent, m.Status)
+		})
+	})
+
+	t.Run("case=rejects unknown providers", func(t *testing.T) {
+		report(t, http.MethodPost, "/courier/delivery-status/unknown", bearer(secret, "application/json"), "{}", http.StatusNotFound)
+	})
+
+	t.Run("case=marks the address as undeliverable", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyCourierDeliveryStatusMarkUndeliverable, true)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierDeliveryStatusMarkUndeliverable, false) })
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"dead@ory.sh"}`)
+		i.VerifiableAddresses = []identity.VerifiableAddress{*identity.NewVerifiableEmailAddress("dead@ory.sh", i.ID)}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+		id := addMessage(t, courier.MessageTypeEmail, "dead@ory.sh")
+		report(t, http.MethodPost, "/courier/delivery-status/generic", bearer(secret, "application/json"), generic(id, "bounced"), http.StatusNoContent)
+
+		address, err := reg.PrivilegedIdentityPool().FindVerifiableAddressByValue(ctx, identity.AddressTypeEmail, "dead@ory.sh")
+		require.NoError(t, err)
+		assert.True(t, address.Undeliverable)
+	})
+
+	t.Run("case=returns not found if disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyCourierDeliveryStatusEnabled, false)
+		t.Cleanup(func() { conf.MustSet(ctx, config.ViperKeyCourierDeliveryStatusEnabled, true) })
+
+		id := addMessage(t, courier.MessageTypeEmail, "disabled@ory.sh")
+		report(t, http.MethodPost, "/courier/delivery-status/generic", bearer(secret, "application/json"), generic(id, "bounced"), http.StatusNotFound)
+	})
+}
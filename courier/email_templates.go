@@ -114,6 +114,48 @@ func NewEmailTemplateFromMessage(d template.Dependencies, msg Message) (EmailTem
 			return nil, err
 		}
 		return email.NewAuthenticatorKeyAdded(d, &t), nil
+	case template.TypeDormantAccountWarning:
+		var t email.DormantAccountWarningModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewDormantAccountWarning(d, &t), nil
+	case template.TypeLoginNewDevice:
+		var t email.LoginNewDeviceModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewLoginNewDevice(d, &t), nil
+	case template.TypePasswordChanged:
+		var t email.PasswordChangedModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewPasswordChanged(d, &t), nil
+	case template.TypeTOTPRemoved:
+		var t email.TOTPRemovedModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewTOTPRemoved(d, &t), nil
+	case template.TypeLookupSecretsRegenerated:
+		var t email.LookupSecretsRegeneratedModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewLookupSecretsRegenerated(d, &t), nil
+	case template.TypeOIDCProviderUnlinked:
+		var t email.OIDCProviderUnlinkedModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewOIDCProviderUnlinked(d, &t), nil
+	case template.TypeSessionsRevoked:
+		var t email.SessionsRevokedModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewSessionsRevoked(d, &t), nil
 	default:
 		return nil, errors.Errorf("received unexpected message template type: %s", msg.TemplateType)
 	}
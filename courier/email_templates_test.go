@@ -35,6 +35,13 @@ func TestNewEmailTemplateFromMessage(t *testing.T) {
 		template.TypeRegistrationCodeValid:    email.NewRegistrationCodeValid(reg, &email.RegistrationCodeValidModel{To: "far", RegistrationCode: "123456"}),
 		template.TypeVerifiableAddressChanged: email.NewVerifiableAddressChanged(reg, &email.VerifiableAddressChangedModel{To: "far", ChangedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"ID": "00000000-0000-0000-0000-000000000001"}}),
 		template.TypeAuthenticatorKeyAdded:    email.NewAuthenticatorKeyAdded(reg, &email.AuthenticatorKeyAddedModel{To: "far", AddedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"ID": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeDormantAccountWarning:    email.NewDormantAccountWarning(reg, &email.DormantAccountWarningModel{To: "far", DeactivatesAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"ID": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeLoginNewDevice:           email.NewLoginNewDevice(reg, &email.LoginNewDeviceModel{To: "far", LoggedInAt: "2026-04-21T12:00:00Z", IPAddress: "54.155.246.232", UserAgent: "chrome/windows", Location: "Munich, DE", NotMeURL: "https://www.ory.sh/sessions/revoke-unrecognized?token=abc"}),
+		template.TypePasswordChanged:          email.NewPasswordChanged(reg, &email.PasswordChangedModel{To: "far", ChangedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"id": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeTOTPRemoved:              email.NewTOTPRemoved(reg, &email.TOTPRemovedModel{To: "far", RemovedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"id": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeLookupSecretsRegenerated: email.NewLookupSecretsRegenerated(reg, &email.LookupSecretsRegeneratedModel{To: "far", RegeneratedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"id": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeOIDCProviderUnlinked:     email.NewOIDCProviderUnlinked(reg, &email.OIDCProviderUnlinkedModel{To: "far", Provider: "github", UnlinkedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"id": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeSessionsRevoked:          email.NewSessionsRevoked(reg, &email.SessionsRevokedModel{To: "far", RevokedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"id": "00000000-0000-0000-0000-000000000001"}}),
 	} {
 		t.Run(fmt.Sprintf("case=%s", tmplType), func(t *testing.T) {
 			tmplData, err := json.Marshal(expectedTmpl)
@@ -0,0 +1,9 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+var (
+	TwilioSignature = twilioSignature
+	VonageSignature = vonageSignature
+)
@@ -4,25 +4,36 @@
 package courier
 
 import (
+	"context"
+	"io"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
 	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/x/nosurfx"
 	"github.com/ory/kratos/x/redir"
 	"github.com/ory/x/httprouterx"
 	"github.com/ory/x/httpx"
+	"github.com/ory/x/jsonx"
 	"github.com/ory/x/logrusx"
 	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
 )
 
 const (
-	AdminRouteCourier      = "/courier"
-	AdminRouteListMessages = AdminRouteCourier + "/messages"
-	AdminRouteGetMessage   = AdminRouteCourier + "/messages/{msgID}"
+	AdminRouteCourier         = "/courier"
+	AdminRouteListMessages    = AdminRouteCourier + "/messages"
+	AdminRouteGetMessage      = AdminRouteCourier + "/messages/{msgID}"
+	AdminRouteRequeueMessage  = AdminRouteGetMessage + "/requeue"
+	AdminRouteCancelMessage   = AdminRouteGetMessage + "/cancel"
+	AdminRouteRequeueMessages = AdminRouteListMessages + "/requeue"
+	AdminRouteCancelMessages  = AdminRouteListMessages + "/cancel"
+	AdminRouteRenderTemplate  = AdminRouteCourier + "/templates/render"
 )
 
 type (
@@ -31,6 +42,7 @@ type (
 		logrusx.Provider
 		nosurfx.CSRFProvider
 		PersistenceProvider
+		PreviewDependencies
 		config.Provider
 	}
 	Handler struct {
@@ -46,14 +58,37 @@ func NewHandler(r handlerDependencies) *Handler {
 }
 
 func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
-	h.r.CSRFHandler().IgnoreGlobs(httprouterx.AdminPrefix+AdminRouteListMessages, AdminRouteListMessages)
+	h.r.CSRFHandler().IgnoreGlobs(
+		httprouterx.AdminPrefix+AdminRouteListMessages,
+		httprouterx.AdminPrefix+AdminRouteListMessages+"/*",
+		httprouterx.AdminPrefix+AdminRouteListMessages+"/*/*",
+		httprouterx.AdminPrefix+AdminRouteRenderTemplate,
+		AdminRouteListMessages,
+		"/courier/delivery-status/*",
+	)
 	public.GET(httprouterx.AdminPrefix+AdminRouteListMessages, redir.RedirectToAdminRoute(h.r))
 	public.GET(httprouterx.AdminPrefix+AdminRouteGetMessage, redir.RedirectToAdminRoute(h.r))
+	public.DELETE(httprouterx.AdminPrefix+AdminRouteListMessages, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+AdminRouteRequeueMessage, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+AdminRouteCancelMessage, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+AdminRouteRequeueMessages, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+AdminRouteCancelMessages, redir.RedirectToAdminRoute(h.r))
+	public.POST(httprouterx.AdminPrefix+AdminRouteRenderTemplate, redir.RedirectToAdminRoute(h.r))
+
+	// Vonage sends delivery receipts as GET requests by default.
+	public.GET(RouteDeliveryStatus, h.updateDeliveryStatus)
+	public.POST(RouteDeliveryStatus, h.updateDeliveryStatus)
 }
 
 func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
 	admin.GET(AdminRouteListMessages, h.listCourierMessages)
 	admin.GET(AdminRouteGetMessage, h.getCourierMessage)
+	admin.DELETE(AdminRouteListMessages, h.purgeCourierMessages)
+	admin.POST(AdminRouteRequeueMessage, h.requeueCourierMessage)
+	admin.POST(AdminRouteCancelMessage, h.cancelCourierMessage)
+	admin.POST(AdminRouteRequeueMessages, h.requeueCourierMessages)
+	admin.POST(AdminRouteCancelMessages, h.cancelCourierMessages)
+	admin.POST(AdminRouteRenderTemplate, h.renderCourierTemplate)
 }
 
 // Paginated Courier Message List Response
@@ -210,6 +245,130 @@ func (h *Handler) getCourierMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.writeMessage(w, r, message)
+}
+
+// Requeue Courier Message Parameters
+//
+// swagger:parameters requeueCourierMessage
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type requeueCourierMessage struct {
+	// MessageID is the ID of the message.
+	//
+	// required: true
+	// in: path
+	MessageID string `json:"id"`
+}
+
+// swagger:route POST /admin/courier/messages/{id}/requeue courier requeueCourierMessage
+//
+// # Requeue an Abandoned Message
+//
+// Queues an abandoned message again and resets its send count, so that the
+// courier retries to deliver it. Only abandoned messages can be requeued.
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//		oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//		200: message
+//		400: errorGeneric
+//		404: errorGeneric
+//		409: errorGeneric
+//		default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) requeueCourierMessage(w http.ResponseWriter, r *http.Request) {
+	h.changeCourierMessage(w, r, MessageStatusAbandoned, h.r.CourierPersister().RequeueMessages)
+}
+
+// Cancel Courier Message Parameters
+//
+// swagger:parameters cancelCourierMessage
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type cancelCourierMessage struct {
+	// MessageID is the ID of the message.
+	//
+	// required: true
+	// in: path
+	MessageID string `json:"id"`
+}
+
+// swagger:route POST /admin/courier/messages/{id}/cancel courier cancelCourierMessage
+//
+// # Cancel a Queued Message
+//
+// Cancels a queued message, so that the courier does not deliver it. Only
+// queued messages can be cancelled.
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//		oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//		200: message
+//		400: errorGeneric
+//		404: errorGeneric
+//		409: errorGeneric
+//		default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) cancelCourierMessage(w http.ResponseWriter, r *http.Request) {
+	h.changeCourierMessage(w, r, MessageStatusQueued, h.r.CourierPersister().CancelMessages)
+}
+
+func (h *Handler) changeCourierMessage(w http.ResponseWriter, r *http.Request, from MessageStatus, change func(context.Context, MessagesFilter) (int, error)) {
+	msgID, err := uuid.FromString(r.PathValue("msgID"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest().WithError(err.Error()).WithDebugf("could not parse parameter {id} as UUID, got %s", r.PathValue("msgID")))
+		return
+	}
+
+	message, err := h.r.CourierPersister().FetchMessage(r.Context(), msgID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	// The status is checked again when the message is changed, in case the
+	// courier picked it up in the meantime.
+	if message.Status == from {
+		count, err := change(r.Context(), MessagesFilter{ID: msgID})
+		if err != nil {
+			h.r.Writer().WriteError(w, r, err)
+			return
+		}
+		if count == 1 {
+			message, err = h.r.CourierPersister().FetchMessage(r.Context(), msgID)
+			if err != nil {
+				h.r.Writer().WriteError(w, r, err)
+				return
+			}
+			h.writeMessage(w, r, message)
+			return
+		}
+	}
+
+	h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict().
+		WithReasonf("The message has status %q but only %s messages can be changed.", message.Status.String(), from.String())))
+}
+
+func (h *Handler) writeMessage(w http.ResponseWriter, r *http.Request, message *Message) {
 	if !h.r.Config().IsInsecureDevMode(r.Context()) {
 		message.Body = "<redacted-unless-dev-mode>"
 		message.Subject = "<redacted-unless-dev-mode>"
@@ -217,3 +376,301 @@ func (h *Handler) getCourierMessage(w http.ResponseWriter, r *http.Request) {
 
 	h.r.Writer().Write(w, r, message)
 }
+
+// Filter of a bulk courier message operation
+//
+// Selects the messages which are changed. Fields which are not set match all
+// messages. At least one field must be set, or `all` to change all messages.
+//
+// swagger:model courierMessagesFilter
+type MessagesFilter struct {
+	// ID selects a single message.
+	ID uuid.UUID `json:"-"`
+
+	// Recipient selects the messages sent to this recipient.
+	Recipient string `json:"recipient,omitempty"`
+
+	// TemplateType selects the messages of this template type.
+	TemplateType template.TemplateType `json:"template_type,omitempty"`
+
+	// CreatedAfter selects the messages created after this time.
+	CreatedAfter *time.Time `json:"created_after,omitempty"`
+
+	// CreatedBefore selects the messages created before this time.
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+
+	// All must be set to change all messages if no other field is set. It
+	// prevents changing all messages by accident.
+	All bool `json:"all,omitempty"`
+}
+
+// IsEmpty returns true if no field which narrows down the messages is set.
+func (f MessagesFilter) IsEmpty() bool {
+	return f.ID == uuid.Nil && f.Recipient == "" && f.TemplateType == "" && f.CreatedAfter == nil && f.CreatedBefore == nil
+}
+
+// ErrMessagesFilterEmpty is returned by bulk operations if the filter selects
+// all messages without setting All.
+var ErrMessagesFilterEmpty = herodot.ErrBadRequest().WithReason("Set at least one filter, or set all to true to change all messages.")
+
+// Result of a bulk courier message operation
+//
+// swagger:model courierMessagesBulkResult
+type MessagesBulkResult struct {
+	// Count is the number of messages which were changed.
+	//
+	// required: true
+	Count int `json:"count"`
+}
+
+// Bulk Courier Message Operation Parameters
+//
+// swagger:parameters requeueCourierMessages cancelCourierMessages
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type bulkCourierMessages struct {
+	// in: body
+	Body MessagesFilter
+}
+
+// Bulk Courier Message Operation Response
+//
+// swagger:response courierMessagesBulkResult
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type courierMessagesBulkResultResponse struct {
+	// in: body
+	Body MessagesBulkResult
+}
+
+// swagger:route POST /admin/courier/messages/requeue courier requeueCourierMessages
+//
+// # Requeue Abandoned Messages
+//
+// Queues all abandoned messages which match the filter again and resets their
+// send count, so that the courier retries to deliver them. The filter must not
+// be empty unless `all` is set.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//		oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//		200: courierMessagesBulkResult
+//		400: errorGeneric
+//		default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) requeueCourierMessages(w http.ResponseWriter, r *http.Request) {
+	h.changeCourierMessages(w, r, h.r.CourierPersister().RequeueMessages)
+}
+
+// swagger:route POST /admin/courier/messages/cancel courier cancelCourierMessages
+//
+// # Cancel Queued Messages
+//
+// Cancels all queued messages which match the filter, so that the courier does
+// not deliver them. The filter must not be empty unless `all` is set.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//		oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//		200: courierMessagesBulkResult
+//		400: errorGeneric
+//		default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) cancelCourierMessages(w http.ResponseWriter, r *http.Request) {
+	h.changeCourierMessages(w, r, h.r.CourierPersister().CancelMessages)
+}
+
+func (h *Handler) changeCourierMessages(w http.ResponseWriter, r *http.Request, change func(context.Context, MessagesFilter) (int, error)) {
+	var filter MessagesFilter
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&filter); err != nil && !errors.Is(err, io.EOF) {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+	if filter.IsEmpty() && !filter.All {
+		h.r.Writer().WriteError(w, r, errors.WithStack(ErrMessagesFilterEmpty))
+		return
+	}
+
+	count, err := change(r.Context(), filter)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, &MessagesBulkResult{Count: count})
+}
+
+// Purge Courier Messages Parameters
+//
+// swagger:parameters purgeCourierMessages
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type purgeCourierMessages struct {
+	// OlderThan purges the messages created longer ago than this duration,
+	// for example "720h".
+	//
+	// required: true
+	// in: query
+	OlderThan string `json:"older_than"`
+
+	// Status purges only messages with this status. Defaults to all messages
+	// the courier is done with. Queued and processing messages can not be
+	// purged.
+	//
+	// required: false
+	// in: query
+	Status []MessageStatus `json:"status"`
+}
+
+// swagger:route DELETE /admin/courier/messages courier purgeCourierMessages
+//
+// # Purge Old Messages
+//
+// Deletes sent, delivered, bounced, complained, abandoned, and cancelled
+// messages which were created longer ago than the given duration, together
+// with their dispatches.
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//		oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//		200: courierMessagesBulkResult
+//		400: errorGeneric
+//		default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) purgeCourierMessages(w http.ResponseWriter, r *http.Request) {
+	olderThan, err := time.ParseDuration(r.URL.Query().Get("older_than"))
+	if err == nil && olderThan < 0 {
+		err = errors.New("duration must not be negative")
+	}
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()).WithReason(`The "older_than" parameter must be a duration such as "720h".`)))
+		return
+	}
+
+	statuses, err := ParsePurgeableStatuses(r.URL.Query()["status"])
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	count, err := h.r.CourierPersister().PurgeMessages(r.Context(), time.Now().Add(-olderThan), statuses)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, &MessagesBulkResult{Count: count})
+}
+
+// PurgeableStatuses are the statuses of messages which the courier is done
+// with, and which can therefore be purged.
+var PurgeableStatuses = []MessageStatus{MessageStatusSent, MessageStatusAbandoned, MessageStatusCancelled, MessageStatusDelivered, MessageStatusBounced, MessageStatusComplained}
+
+// ParsePurgeableStatuses parses the statuses of messages to purge. It returns
+// all PurgeableStatuses if none are given.
+func ParsePurgeableStatuses(in []string) ([]MessageStatus, error) {
+	if len(in) == 0 {
+		return PurgeableStatuses, nil
+	}
+
+	statuses := make([]MessageStatus, 0, len(in))
+	for _, v := range in {
+		status, err := ToMessageStatus(v)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(PurgeableStatuses, status) {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Messages with status %q can not be purged.", v))
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Render Courier Template Parameters
+//
+// swagger:parameters renderCourierTemplate
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type renderCourierTemplate struct {
+	// in: body
+	// required: true
+	Body RenderTemplateBody
+}
+
+// swagger:route POST /admin/courier/templates/render courier renderCourierTemplate
+//
+// # Render a Template
+//
+// Renders the email and SMS templates of a template type with a sample model,
+// so that template authors can preview them without triggering a flow. If
+// `send_to` is set, a test message is queued to that recipient as well.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Security:
+//		oryAccessToken:
+//
+//	Schemes: http, https
+//
+//	Responses:
+//		200: courierRenderedTemplate
+//		400: errorGeneric
+//		404: errorGeneric
+//		default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) renderCourierTemplate(w http.ResponseWriter, r *http.Request) {
+	var body RenderTemplateBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+
+	rendered, err := RenderTemplate(r.Context(), h.r, &body)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, rendered)
+}
@@ -10,7 +10,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-faker/faker/v4"
 	"github.com/gofrs/uuid"
@@ -20,6 +22,7 @@ import (
 
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/pkg"
 	"github.com/ory/kratos/pkg/testhelpers"
 	"github.com/ory/x/httprouterx"
@@ -227,7 +230,7 @@ func TestHandler(t *testing.T) {
 		message.Type = courier.MessageTypeEmail
 		message.Body = "body content"
 		require.NoError(t, reg.CourierPersister().AddMessage(context.Background(), &message))
-		require.NoError(t, reg.CourierPersister().RecordDispatch(ctx, message.ID, courier.CourierMessageDispatchStatusSuccess, errors.New("some error")))
+		require.NoError(t, reg.CourierPersister().RecordDispatch(ctx, message.ID, "", courier.CourierMessageDispatchStatusSuccess, errors.New("some error")))
 
 		getCourierMessag := func(s *httptest.Server, id string) gjson.Result {
 
@@ -282,4 +285,197 @@ func TestHandler(t *testing.T) {
 			}
 		})
 	})
+
+	do := func(t *testing.T, method, href, body string, expectCode int) gjson.Result {
+		t.Helper()
+		req, err := http.NewRequest(method, adminTS.URL+href, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		b, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+
+		assert.EqualValuesf(t, expectCode, res.StatusCode, "%s", b)
+		return gjson.ParseBytes(b)
+	}
+
+	addMessage := func(t *testing.T, recipient string, status courier.MessageStatus) uuid.UUID {
+		m := courier.Message{Recipient: recipient, Subject: "subject", Body: "body"}
+		require.NoError(t, reg.CourierPersister().AddMessage(ctx, &m))
+		require.NoError(t, reg.CourierPersister().SetMessageStatus(ctx, m.ID, status))
+		return m.ID
+	}
+
+	messageHref := func(id uuid.UUID) string {
+		return strings.ReplaceAll(courier.AdminRouteGetMessage, "{msgID}", id.String())
+	}
+
+	t.Run("handler=requeueCourierMessage", func(t *testing.T) {
+		id := addMessage(t, "requeue@ory.sh", courier.MessageStatusAbandoned)
+
+		body := do(t, http.MethodPost, messageHref(id)+"/requeue", "", http.StatusOK)
+		assert.Equal(t, id.String(), body.Get("id").String())
+		assert.Equal(t, "queued", body.Get("status").String())
+		assert.Equal(t, "<redacted-unless-dev-mode>", body.Get("body").String())
+		assert.Equal(t, "requeued", body.Get("dispatches.0.status").String(), "%s", body.Raw)
+
+		t.Run("case=conflicts if the message is not abandoned", func(t *testing.T) {
+			body := do(t, http.MethodPost, messageHref(id)+"/requeue", "", http.StatusConflict)
+			assert.Contains(t, body.Get("error.reason").String(), `"queued"`)
+		})
+
+		t.Run("case=returns an error if no message is found", func(t *testing.T) {
+			do(t, http.MethodPost, messageHref(uuid.Must(uuid.NewV4()))+"/requeue", "", http.StatusNotFound)
+		})
+	})
+
+	t.Run("handler=cancelCourierMessage", func(t *testing.T) {
+		id := addMessage(t, "cancel@ory.sh", courier.MessageStatusQueued)
+
+		body := do(t, http.MethodPost, messageHref(id)+"/cancel", "", http.StatusOK)
+		assert.Equal(t, "cancelled", body.Get("status").String())
+		assert.Equal(t, "cancelled", body.Get("dispatches.0.status").String(), "%s", body.Raw)
+
+		t.Run("case=conflicts if the message is not queued", func(t *testing.T) {
+			do(t, http.MethodPost, messageHref(id)+"/cancel", "", http.StatusConflict)
+		})
+
+		t.Run("case=returns an error if parameter is malformed", func(t *testing.T) {
+			do(t, http.MethodPost, strings.ReplaceAll(courier.AdminRouteCancelMessage, "{msgID}", "not-a-uuid"), "", http.StatusBadRequest)
+		})
+	})
+
+	t.Run("handler=bulk", func(t *testing.T) {
+		abandoned := []uuid.UUID{addMessage(t, "bulk@ory.sh", courier.MessageStatusAbandoned), addMessage(t, "bulk@ory.sh", courier.MessageStatusAbandoned)}
+		queued := addMessage(t, "bulk@ory.sh", courier.MessageStatusQueued)
+		other := addMessage(t, "other@ory.sh", courier.MessageStatusQueued)
+
+		t.Run("case=requeues abandoned messages", func(t *testing.T) {
+			body := do(t, http.MethodPost, courier.AdminRouteRequeueMessages, `{"recipient":"bulk@ory.sh"}`, http.StatusOK)
+			assert.EqualValues(t, 2, body.Get("count").Int())
+			for _, id := range abandoned {
+				m, err := reg.CourierPersister().FetchMessage(ctx, id)
+				require.NoError(t, err)
+				assert.Equal(t, courier.MessageStatusQueued, m.Status)
+			}
+		})
+
+		t.Run("case=cancels queued messages", func(t *testing.T) {
+			body := do(t, http.MethodPost, courier.AdminRouteCancelMessages, `{"recipient":"bulk@ory.sh"}`, http.StatusOK)
+			assert.EqualValues(t, 3, body.Get("count").Int())
+
+			m, err := reg.CourierPersister().FetchMessage(ctx, queued)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusCancelled, m.Status)
+
+			m, err = reg.CourierPersister().FetchMessage(ctx, other)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusQueued, m.Status)
+		})
+
+		t.Run("case=rejects unknown filters", func(t *testing.T) {
+			do(t, http.MethodPost, courier.AdminRouteCancelMessages, `{"foo":"bar"}`, http.StatusBadRequest)
+		})
+
+		t.Run("case=rejects empty filters", func(t *testing.T) {
+			for _, route := range []string{courier.AdminRouteRequeueMessages, courier.AdminRouteCancelMessages} {
+				do(t, http.MethodPost, route, "", http.StatusBadRequest)
+				do(t, http.MethodPost, route, `{}`, http.StatusBadRequest)
+			}
+
+			m, err := reg.CourierPersister().FetchMessage(ctx, other)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusQueued, m.Status)
+		})
+
+		t.Run("case=cancels all queued messages if requested", func(t *testing.T) {
+			body := do(t, http.MethodPost, courier.AdminRouteCancelMessages, `{"all":true}`, http.StatusOK)
+			assert.GreaterOrEqual(t, body.Get("count").Int(), int64(1))
+
+			m, err := reg.CourierPersister().FetchMessage(ctx, other)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusCancelled, m.Status)
+		})
+	})
+
+	t.Run("handler=purgeCourierMessages", func(t *testing.T) {
+		id := addMessage(t, "purge@ory.sh", courier.MessageStatusSent)
+
+		t.Run("case=requires a duration", func(t *testing.T) {
+			do(t, http.MethodDelete, courier.AdminRouteListMessages, "", http.StatusBadRequest)
+		})
+
+		t.Run("case=rejects negative durations", func(t *testing.T) {
+			do(t, http.MethodDelete, courier.AdminRouteListMessages+"?older_than=-1h", "", http.StatusBadRequest)
+		})
+
+		t.Run("case=rejects unpurgeable statuses", func(t *testing.T) {
+			do(t, http.MethodDelete, courier.AdminRouteListMessages+"?older_than=1h&status=queued", "", http.StatusBadRequest)
+		})
+
+		t.Run("case=keeps recent messages", func(t *testing.T) {
+			do(t, http.MethodDelete, courier.AdminRouteListMessages+"?older_than=1h", "", http.StatusOK)
+			_, err := reg.CourierPersister().FetchMessage(ctx, id)
+			require.NoError(t, err)
+		})
+
+		t.Run("case=purges old messages", func(t *testing.T) {
+			require.NoError(t, reg.Persister().GetConnection(ctx).RawQuery(
+				"UPDATE courier_messages SET created_at = ? WHERE id = ?", time.Now().UTC().Add(-2*time.Hour), id).Exec())
+
+			body := do(t, http.MethodDelete, courier.AdminRouteListMessages+"?older_than=1h&status=sent", "", http.StatusOK)
+			assert.GreaterOrEqual(t, body.Get("count").Int(), int64(1))
+			_, err := reg.CourierPersister().FetchMessage(ctx, id)
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("handler=renderCourierTemplate", func(t *testing.T) {
+		t.Run("case=renders email and sms templates", func(t *testing.T) {
+			body := do(t, http.MethodPost, courier.AdminRouteRenderTemplate, `{"template_type":"login_code_valid","model":{"login_code":"123456","expires_in_minutes":15}}`, http.StatusOK)
+			assert.Equal(t, "login_code_valid", body.Get("template_type").String())
+			assert.Equal(t, "Use code 123456 to log in", body.Get("email_subject").String())
+			assert.Contains(t, body.Get("email_body_plaintext").String(), "It expires in 15 minutes.")
+			assert.Contains(t, body.Get("email_body_html").String(), "123456")
+			assert.Contains(t, body.Get("sms_body").String(), "Your login code is: 123456")
+			assert.False(t, body.Get("message_id").Exists())
+		})
+
+		t.Run("case=renders templates for an identity", func(t *testing.T) {
+			testhelpers.SetDefaultIdentitySchema(conf, "file://../identity/stub/identity.schema.json")
+			i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			i.Traits = identity.Traits(`{"email":"render@ory.sh"}`)
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+			body := do(t, http.MethodPost, courier.AdminRouteRenderTemplate, fmt.Sprintf(`{"template_type":"stub","identity_id":%q,"model":{"subject":"Hi","body":"body"}}`, i.ID), http.StatusOK)
+			assert.Equal(t, "Hi", body.Get("email_subject").String())
+
+			do(t, http.MethodPost, courier.AdminRouteRenderTemplate, fmt.Sprintf(`{"template_type":"stub","identity_id":%q}`, uuid.Must(uuid.NewV4())), http.StatusNotFound)
+		})
+
+		t.Run("case=queues a test message", func(t *testing.T) {
+			body := do(t, http.MethodPost, courier.AdminRouteRenderTemplate, `{"template_type":"login_code_valid","model":{"login_code":"654321"},"send_to":"render-test@ory.sh"}`, http.StatusOK)
+			id, err := uuid.FromString(body.Get("message_id").String())
+			require.NoError(t, err)
+
+			m, err := reg.CourierPersister().FetchMessage(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, "render-test@ory.sh", m.Recipient)
+			assert.Equal(t, "Use code 654321 to log in", m.Subject)
+		})
+
+		for _, tc := range []struct{ name, body string }{
+			{name: "missing template type", body: `{}`},
+			{name: "unknown template type", body: `{"template_type":"unknown"}`},
+			{name: "model does not match", body: `{"template_type":"login_code_valid","model":{"login_code":5}}`},
+			{name: "invalid locale", body: `{"template_type":"login_code_valid","locale":"not a locale"}`},
+			{name: "no sms template", body: `{"template_type":"recovery_valid","send_to":"+12065550101"}`},
+		} {
+			t.Run("case="+tc.name, func(t *testing.T) {
+				do(t, http.MethodPost, courier.AdminRouteRenderTemplate, tc.body, http.StatusBadRequest)
+			})
+		}
+	})
 }
@@ -27,6 +27,10 @@ const (
 	MessageStatusSent
 	MessageStatusProcessing
 	MessageStatusAbandoned
+	MessageStatusCancelled
+	MessageStatusDelivered
+	MessageStatusBounced
+	MessageStatusComplained
 )
 
 const (
@@ -34,6 +38,10 @@ const (
 	messageStatusSentText       = "sent"
 	messageStatusProcessingText = "processing"
 	messageStatusAbandonedText  = "abandoned"
+	messageStatusCancelledText  = "cancelled"
+	messageStatusDeliveredText  = "delivered"
+	messageStatusBouncedText    = "bounced"
+	messageStatusComplainedText = "complained"
 )
 
 func ToMessageStatus(str string) (MessageStatus, error) {
@@ -46,6 +54,14 @@ func ToMessageStatus(str string) (MessageStatus, error) {
 		return MessageStatusProcessing, nil
 	case s.AddCase(MessageStatusAbandoned.String()):
 		return MessageStatusAbandoned, nil
+	case s.AddCase(MessageStatusCancelled.String()):
+		return MessageStatusCancelled, nil
+	case s.AddCase(MessageStatusDelivered.String()):
+		return MessageStatusDelivered, nil
+	case s.AddCase(MessageStatusBounced.String()):
+		return MessageStatusBounced, nil
+	case s.AddCase(MessageStatusComplained.String()):
+		return MessageStatusComplained, nil
 	default:
 		return 0, errors.WithStack(herodot.ErrBadRequest().WithWrap(s.ToUnknownCaseErr()).WithReason("Message status is not valid"))
 	}
@@ -61,6 +77,14 @@ func (ms MessageStatus) String() string {
 		return messageStatusProcessingText
 	case MessageStatusAbandoned:
 		return messageStatusAbandonedText
+	case MessageStatusCancelled:
+		return messageStatusCancelledText
+	case MessageStatusDelivered:
+		return messageStatusDeliveredText
+	case MessageStatusBounced:
+		return messageStatusBouncedText
+	case MessageStatusComplained:
+		return messageStatusComplainedText
 	default:
 		return ""
 	}
@@ -68,7 +92,8 @@ func (ms MessageStatus) String() string {
 
 func (ms MessageStatus) IsValid() error {
 	switch ms {
-	case MessageStatusQueued, MessageStatusSent, MessageStatusProcessing, MessageStatusAbandoned:
+	case MessageStatusQueued, MessageStatusSent, MessageStatusProcessing, MessageStatusAbandoned, MessageStatusCancelled,
+		MessageStatusDelivered, MessageStatusBounced, MessageStatusComplained:
 		return nil
 	default:
 		return errors.WithStack(herodot.ErrBadRequest().WithReason("Message status is not valid"))
@@ -193,6 +218,15 @@ type Message struct {
 	// required: true
 	SendCount int `json:"send_count" db:"send_count"`
 
+	// WorkerID identifies the courier worker which leased the message for
+	// dispatching.
+	WorkerID sqlxx.NullString `json:"-" faker:"-" db:"worker_id"`
+
+	// LockedUntil is the time at which the lease of the worker expires. A
+	// message which is still processing after this time is reclaimed by the
+	// next worker.
+	LockedUntil *sqlxx.NullTime `json:"-" faker:"-" db:"locked_until"`
+
 	// Dispatches store information about the attempts of delivering a message
 	// May contain an error if any happened, or just the `success` state.
 	Dispatches []MessageDispatch `json:"dispatches,omitempty" has_many:"courier_message_dispatches" order_by:"created_at desc" faker:"-"`
@@ -17,6 +17,23 @@ type CourierMessageDispatchStatus string
 const (
 	CourierMessageDispatchStatusFailed  CourierMessageDispatchStatus = "failed"
 	CourierMessageDispatchStatusSuccess CourierMessageDispatchStatus = "success"
+
+	// CourierMessageDispatchStatusRequeued records that an operator queued an
+	// abandoned message again.
+	CourierMessageDispatchStatusRequeued CourierMessageDispatchStatus = "requeued"
+	// CourierMessageDispatchStatusCancelled records that an operator cancelled
+	// a queued message.
+	CourierMessageDispatchStatusCancelled CourierMessageDispatchStatus = "cancelled"
+
+	// CourierMessageDispatchStatusDelivered records a delivery receipt of the
+	// provider.
+	CourierMessageDispatchStatusDelivered CourierMessageDispatchStatus = "delivered"
+	// CourierMessageDispatchStatusBounced records that the provider or the
+	// recipient's server could not deliver the message.
+	CourierMessageDispatchStatusBounced CourierMessageDispatchStatus = "bounced"
+	// CourierMessageDispatchStatusComplained records that the recipient
+	// marked the message as spam.
+	CourierMessageDispatchStatusComplained CourierMessageDispatchStatus = "complained"
 )
 
 // MessageDispatch represents an attempt of sending a courier message
@@ -33,10 +50,13 @@ type MessageDispatch struct {
 	MessageID uuid.UUID `json:"message_id" db:"message_id"`
 
 	// The status of this dispatch
-	// Either "failed" or "success"
+	// Either "failed", "success", "requeued", "cancelled", "delivered", "bounced", or "complained"
 	// required: true
 	Status CourierMessageDispatchStatus `json:"status" db:"status"`
 
+	// The ID of the channel which was used for this dispatch
+	Channel sqlxx.NullString `json:"channel,omitempty" db:"channel"`
+
 	// An optional error
 	Error sqlxx.JSONRawMessage `json:"error,omitempty" db:"error"`
 
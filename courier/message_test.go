@@ -24,6 +24,10 @@ func TestToMessageStatus(t *testing.T) {
 			"sent":       courier.MessageStatusSent,
 			"processing": courier.MessageStatusProcessing,
 			"abandoned":  courier.MessageStatusAbandoned,
+			"cancelled":  courier.MessageStatusCancelled,
+			"delivered":  courier.MessageStatusDelivered,
+			"bounced":    courier.MessageStatusBounced,
+			"complained": courier.MessageStatusComplained,
 		} {
 			result, err := courier.ToMessageStatus(str)
 			require.NoError(t, err)
@@ -5,23 +5,48 @@ package courier
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
+	"github.com/ory/herodot"
 	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
 )
 
 var ErrQueueEmpty = errors.New("queue is empty")
 
+// ErrMessageLeaseLost is returned if a worker changes the status of a message
+// which it no longer leases.
+var ErrMessageLeaseLost = herodot.ErrConflict().WithReason("The courier message is no longer leased by this worker.")
+
+// setMessageStatus sets the status of a message which is dispatched. Leased
+// messages are only changed while the worker still holds the lease.
+func setMessageStatus(ctx context.Context, p Persister, msg Message, status MessageStatus) error {
+	if msg.WorkerID.IsZero() {
+		return p.SetMessageStatus(ctx, msg.ID, status)
+	}
+	return p.SetLeasedMessageStatus(ctx, msg.ID, msg.WorkerID.String(), status)
+}
+
 type (
 	Persister interface {
 		AddMessage(context.Context, *Message) error
 
 		NextMessages(context.Context, uint8) ([]Message, error)
 
+		// LeaseMessages marks up to limit queued messages, and processing
+		// messages whose lease expired, as processing by the worker until the
+		// lease expires. Messages leased by other workers are skipped.
+		LeaseMessages(ctx context.Context, limit uint8, workerID string, lease time.Duration) ([]Message, error)
+
 		SetMessageStatus(context.Context, uuid.UUID, MessageStatus) error
 
+		// SetLeasedMessageStatus sets the status of a message the worker
+		// leased and ends the lease. Returns ErrMessageLeaseLost if the
+		// message is no longer leased by the worker.
+		SetLeasedMessageStatus(ctx context.Context, id uuid.UUID, workerID string, status MessageStatus) error
+
 		LatestQueuedMessage(ctx context.Context) (*Message, error)
 
 		IncrementMessageSendCount(context.Context, uuid.UUID) error
@@ -35,7 +60,45 @@ type (
 
 		// Records an attempt of sending out a courier message
 		// Returns an error if it fails
-		RecordDispatch(ctx context.Context, msgID uuid.UUID, status CourierMessageDispatchStatus, err error) error
+		RecordDispatch(ctx context.Context, msgID uuid.UUID, channel string, status CourierMessageDispatchStatus, err error) error
+
+		// RequeueMessages queues the abandoned messages matching the filter
+		// again and resets their send count. Returns the number of requeued
+		// messages.
+		RequeueMessages(context.Context, MessagesFilter) (int, error)
+
+		// CancelMessages cancels the queued messages matching the filter.
+		// Returns the number of cancelled messages.
+		CancelMessages(context.Context, MessagesFilter) (int, error)
+
+		// PurgeMessages deletes the messages with one of the statuses which
+		// were created before the given time, together with their dispatches.
+		// Returns the number of deleted messages.
+		PurgeMessages(ctx context.Context, createdBefore time.Time, statuses []MessageStatus) (int, error)
+
+		// MarkAddressUndeliverable flags the verifiable address with the value
+		// as undeliverable. It is not an error if no such address exists.
+		MarkAddressUndeliverable(ctx context.Context, via, address string) error
+
+		// CountMessages returns the number of messages matching the filter,
+		// regardless of their status.
+		CountMessages(context.Context, MessagesFilter) (int, error)
+
+		// FindQueuedDuplicate returns the latest queued message with the same
+		// type, recipient, and template type as the given message. Returns an
+		// error if there is no such message.
+		FindQueuedDuplicate(context.Context, *Message) (*Message, error)
+
+		// UpdateQueuedMessage replaces the subject, body, template data, and
+		// request headers of the queued message with the ID by the ones of the
+		// given message. Returns an error if the message is no longer queued.
+		UpdateQueuedMessage(ctx context.Context, id uuid.UUID, m *Message) error
+
+		// QueueMessage adds the message unless admit, which runs in the same
+		// transaction while no other message can be queued for the
+		// recipient, returns an error or the ID of an existing message.
+		// Returns the ID of the queued or the existing message.
+		QueueMessage(ctx context.Context, m *Message, admit func(context.Context) (uuid.UUID, error)) (uuid.UUID, error)
 	}
 	PersistenceProvider interface {
 		CourierPersister() Persister
@@ -0,0 +1,91 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/httpx"
+)
+
+// ProviderError is returned by channels when a message provider, such as
+// Twilio, rejects a message. The error is recorded in the message's dispatch
+// log.
+type ProviderError struct {
+	// Provider is the name of the provider, for example "twilio".
+	Provider string
+	// Code is the provider specific error code.
+	Code string
+	// Message is the error message returned by the provider.
+	Message string
+	// HTTPStatus is the HTTP status code of the provider's response.
+	HTTPStatus int
+	// Permanent is true if the provider rejected the message itself, for
+	// example because the recipient is invalid. Such messages are not retried.
+	Permanent bool
+}
+
+var _ error = new(ProviderError)
+
+func (e *ProviderError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%s rejected the message: %s", e.Provider, e.Message)
+	}
+	return fmt.Sprintf("%s rejected the message with error code %s: %s", e.Provider, e.Code, e.Message)
+}
+
+func (e *ProviderError) Reason() string {
+	return e.Message
+}
+
+func (e *ProviderError) Details() map[string]any {
+	return map[string]any{
+		"provider":    e.Provider,
+		"code":        e.Code,
+		"http_status": e.HTTPStatus,
+		"permanent":   e.Permanent,
+	}
+}
+
+// IsPermanentError returns true if the error is a ProviderError which can not
+// be resolved by retrying to send the message.
+func IsPermanentError(err error) bool {
+	var e *ProviderError
+	return errors.As(err, &e) && e.Permanent
+}
+
+// doProviderRequest sends a request to a message provider and returns the
+// status code and the (truncated) body of the response.
+func doProviderRequest(ctx context.Context, d channelDependencies, req *retryablehttp.Request) (int, []byte, error) {
+	res, err := d.HTTPClient(ctx,
+		// fail fast and let the courier retry if needed instead of blocking the queue
+		httpx.ResilientClientWithMaxRetry(0),
+		httpx.ResilientClientWithConnectionTimeout(10*time.Second),
+	).Do(req)
+	if err != nil {
+		return 0, nil, errors.WithStack(err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 64*1024))
+	if err != nil {
+		return 0, nil, errors.WithStack(err)
+	}
+
+	return res.StatusCode, body, nil
+}
+
+// smsOnly returns an error if the message is not an SMS.
+func smsOnly(provider string, msg Message) error {
+	if msg.Type != MessageTypeSMS {
+		return errors.Errorf("the %s channel can only send SMS messages, but the message has type %s", provider, msg.Type)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/x/sqlcon"
+)
+
+// queueMessage queues the message unless admitMessage refuses it. Messages for
+// the same recipient are admitted and queued one after the other, so that
+// concurrent requests can not exceed the recipient's quotas. Returns the ID of
+// the queued message, or of the queued duplicate.
+func (c *courier) queueMessage(ctx context.Context, m *Message) (uuid.UUID, error) {
+	p := c.deps.CourierPersister()
+
+	rl, err := c.deps.CourierConfig().CourierRateLimit(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !rl.Enabled && !c.deps.CourierConfig().CourierDeduplicationEnabled(ctx) {
+		if err := p.AddMessage(ctx, m); err != nil {
+			return uuid.Nil, err
+		}
+		return m.ID, nil
+	}
+
+	return p.QueueMessage(ctx, m, func(ctx context.Context) (uuid.UUID, error) {
+		return c.admitMessage(ctx, m)
+	})
+}
+
+type testMessageContextKey struct{}
+
+// withTestMessage marks the messages queued with the returned context as test
+// messages, which are never deduplicated: they contain sample data and must not
+// replace a message queued for the recipient.
+func withTestMessage(ctx context.Context) context.Context {
+	return context.WithValue(ctx, testMessageContextKey{}, true)
+}
+
+func isTestMessage(ctx context.Context) bool {
+	isTest, _ := ctx.Value(testMessageContextKey{}).(bool)
+	return isTest
+}
+
+// admitMessage checks whether the message may be queued. If deduplication is
+// enabled and a message of the same template is still queued for the
+// recipient, that message is updated to the content of the new message, its
+// ID is returned, and the message must not be queued again. If queueing the
+// message would exceed one of the recipient's quotas, a validation error is
+// returned.
+func (c *courier) admitMessage(ctx context.Context, m *Message) (uuid.UUID, error) {
+	p := c.deps.CourierPersister()
+
+	if c.deps.CourierConfig().CourierDeduplicationEnabled(ctx) && !isTestMessage(ctx) {
+		duplicate, err := p.FindQueuedDuplicate(ctx, m)
+		if err == nil {
+			// The queued message's codes and links may no longer be valid, so
+			// the recipient gets the content of the latest message instead.
+			err = p.UpdateQueuedMessage(ctx, duplicate.ID, m)
+			if err == nil {
+				return duplicate.ID, nil
+			}
+		}
+		// There is no duplicate, or it was leased for sending in the
+		// meantime.
+		if !errors.Is(err, sqlcon.ErrNoRows()) {
+			return uuid.Nil, err
+		}
+	}
+
+	rl, err := c.deps.CourierConfig().CourierRateLimit(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !rl.Enabled {
+		return uuid.Nil, nil
+	}
+
+	now := time.Now().UTC()
+	for _, quota := range recipientQuotas(rl, m.TemplateType) {
+		since := now.Add(-quota.Window)
+		count, err := p.CountMessages(ctx, MessagesFilter{
+			Recipient:    m.Recipient,
+			TemplateType: template.TemplateType(quota.TemplateType),
+			CreatedAfter: &since,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		if count >= quota.Max {
+			c.deps.Logger().
+				WithField("message_type", m.Type.String()).
+				WithField("message_template_type", m.TemplateType).
+				WithField("quota_template_type", quota.TemplateType).
+				WithField("quota_max", quota.Max).
+				WithField("quota_window", quota.Window.String()).
+				Warn("Refusing to queue courier message because the recipient's quota is exhausted.")
+			return uuid.Nil, schema.NewTooManyMessagesError()
+		}
+	}
+
+	return uuid.Nil, nil
+}
+
+// recipientQuotas returns the quotas which apply to a message of the given
+// template type: the quota for all messages, and the quotas configured for the
+// template type. The quota for all messages has an empty template type.
+func recipientQuotas(rl *config.CourierRateLimit, tt template.TemplateType) []config.CourierTemplateQuota {
+	quotas := []config.CourierTemplateQuota{{Max: rl.MaxPerRecipient, Window: rl.Window}}
+	for _, quota := range rl.TemplateTypes {
+		if quota.TemplateType == string(tt) {
+			quotas = append(quotas, quota)
+		}
+	}
+	return quotas
+}
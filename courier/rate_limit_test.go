@@ -0,0 +1,176 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier_test
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/configx"
+)
+
+func assertTooManyMessages(t *testing.T, err error) {
+	t.Helper()
+	var ve *schema.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.EqualValues(t, text.ErrorValidationTooManyMessages, ve.Messages[0].ID)
+}
+
+func TestQueueRateLimit(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("case=limits messages per recipient", func(t *testing.T) {
+		_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+			config.ViperKeyCourierRateLimitEnabled:         true,
+			config.ViperKeyCourierRateLimitMaxPerRecipient: 2,
+		}))
+		c, err := reg.Courier(ctx)
+		require.NoError(t, err)
+
+		recipient := x.NewUUID().String() + "@ory.sh"
+		for range 2 {
+			_, err := c.QueueEmail(ctx, email.NewTestStub(&email.TestStubModel{To: recipient, Subject: "subject", Body: x.NewUUID().String()}))
+			require.NoError(t, err)
+		}
+
+		_, err = c.QueueEmail(ctx, email.NewTestStub(&email.TestStubModel{To: recipient, Subject: "subject", Body: "body"}))
+		assertTooManyMessages(t, err)
+
+		_, err = c.QueueEmail(ctx, email.NewTestStub(&email.TestStubModel{To: "other-" + recipient, Subject: "subject", Body: "body"}))
+		require.NoError(t, err, "other recipients are not affected")
+	})
+
+	t.Run("case=limits messages per template type", func(t *testing.T) {
+		_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+			config.ViperKeyCourierRateLimitEnabled: true,
+			config.ViperKeyCourierRateLimitTemplateTypes: []map[string]any{
+				{"template_type": "stub", "max": 1, "window": "10m"},
+			},
+		}))
+		c, err := reg.Courier(ctx)
+		require.NoError(t, err)
+
+		_, err = c.QueueSMS(ctx, sms.NewTestStub(&sms.TestStubModel{To: "+12065550101", Body: "first"}))
+		require.NoError(t, err)
+
+		_, err = c.QueueSMS(ctx, sms.NewTestStub(&sms.TestStubModel{To: "+12065550101", Body: "second"}))
+		assertTooManyMessages(t, err)
+	})
+
+	t.Run("case=disabled", func(t *testing.T) {
+		_, reg := pkg.NewFastRegistryWithMocks(t)
+		c, err := reg.Courier(ctx)
+		require.NoError(t, err)
+
+		for range 15 {
+			_, err := c.QueueSMS(ctx, sms.NewTestStub(&sms.TestStubModel{To: "+12065550102", Body: "body"}))
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestQueueDeduplication(t *testing.T) {
+	ctx := t.Context()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+		config.ViperKeyCourierDeduplicationEnabled:     true,
+		config.ViperKeyCourierRateLimitEnabled:         true,
+		config.ViperKeyCourierRateLimitMaxPerRecipient: 2,
+	}))
+	c, err := reg.Courier(ctx)
+	require.NoError(t, err)
+
+	recipient := x.NewUUID().String() + "@ory.sh"
+	first, err := c.QueueEmail(ctx, email.NewTestStub(&email.TestStubModel{To: recipient, Subject: "subject", Body: "code 0"}))
+	require.NoError(t, err)
+
+	for i := range 3 {
+		id, err := c.QueueEmail(ctx, email.NewTestStub(&email.TestStubModel{To: recipient, Subject: "subject", Body: fmt.Sprintf("code %d", i+1)}))
+		require.NoError(t, err, "duplicates do not count towards the rate limit")
+		assert.Equal(t, first, id)
+	}
+
+	m, err := reg.CourierPersister().FetchMessage(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, "code 3", m.Body, "the queued message has the content of the latest message")
+
+	t.Run("case=does not deduplicate sent messages", func(t *testing.T) {
+		require.NoError(t, reg.CourierPersister().SetMessageStatus(ctx, first, courier.MessageStatusSent))
+
+		second, err := c.QueueEmail(ctx, email.NewTestStub(&email.TestStubModel{To: recipient, Subject: "subject", Body: "code 4"}))
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+
+		require.NoError(t, reg.CourierPersister().SetMessageStatus(ctx, second, courier.MessageStatusSent))
+		_, err = c.QueueEmail(ctx, email.NewTestStub(&email.TestStubModel{To: recipient, Subject: "subject", Body: "code 5"}))
+		assertTooManyMessages(t, err)
+	})
+
+	t.Run("case=does not replace queued messages with test messages", func(t *testing.T) {
+		recipient := x.NewUUID().String() + "@ory.sh"
+		queued, err := c.QueueEmail(ctx, email.NewTestStub(&email.TestStubModel{To: recipient, Subject: "subject", Body: "code 6"}))
+		require.NoError(t, err)
+
+		rendered, err := courier.RenderTemplate(ctx, reg, &courier.RenderTemplateBody{
+			TemplateType: "stub",
+			Model:        []byte(`{"subject":"subject","body":"sample"}`),
+			SendTo:       recipient,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, rendered.MessageID)
+		assert.NotEqual(t, queued, *rendered.MessageID)
+
+		m, err := reg.CourierPersister().FetchMessage(ctx, queued)
+		require.NoError(t, err)
+		assert.Equal(t, "code 6", m.Body)
+	})
+}
+
+func TestQueueRateLimitConcurrency(t *testing.T) {
+	ctx := t.Context()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+		config.ViperKeyCourierRateLimitEnabled:         true,
+		config.ViperKeyCourierRateLimitMaxPerRecipient: 2,
+	}))
+	c, err := reg.Courier(ctx)
+	require.NoError(t, err)
+
+	recipient := x.NewUUID().String() + "@ory.sh"
+	var queued atomic.Int32
+	var eg errgroup.Group
+	for range 10 {
+		eg.Go(func() error {
+			_, err := c.QueueEmail(ctx, email.NewTestStub(&email.TestStubModel{To: recipient, Subject: "subject", Body: x.NewUUID().String()}))
+			var ve *schema.ValidationError
+			if errors.As(err, &ve) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			queued.Add(1)
+			return nil
+		})
+	}
+	require.NoError(t, eg.Wait())
+	assert.EqualValues(t, 2, queued.Load(), "concurrent requests must not exceed the quota")
+
+	count, err := reg.CourierPersister().CountMessages(ctx, courier.MessagesFilter{Recipient: recipient})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
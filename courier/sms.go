@@ -44,9 +44,10 @@ func (c *courier) QueueSMS(ctx context.Context, t SMSTemplate) (uuid.UUID, error
 		RequestHeaders: requestHeaders,
 		Body:           body,
 	}
-	if err := c.deps.CourierPersister().AddMessage(ctx, message); err != nil {
+	id, err := c.queueMessage(ctx, message)
+	if err != nil {
 		return uuid.Nil, err
 	}
 
-	return message.ID, nil
+	return id, nil
 }
@@ -0,0 +1,207 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/x/configx"
+)
+
+func TestSMSProviderChannels(t *testing.T) {
+	type response struct {
+		status int
+		body   string
+	}
+
+	newProvider := func(t *testing.T, res response) (*httptest.Server, chan *http.Request) {
+		requests := make(chan *http.Request, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			requests <- r
+			w.WriteHeader(res.status)
+			_, _ = w.Write([]byte(res.body))
+		}))
+		t.Cleanup(srv.Close)
+		return srv, requests
+	}
+
+	send := func(t *testing.T, channel string) (driver.Registry, uuid.UUID, error) {
+		_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+			config.ViperKeyCourierChannels: "[" + channel + "]",
+			config.ViperKeyCourierSMTPURL:  "http://foo.url",
+		}))
+
+		c, err := reg.Courier(t.Context())
+		require.NoError(t, err)
+		c.(interface{ FailOnDispatchError() }).FailOnDispatchError()
+
+		id, err := c.QueueSMS(t.Context(), sms.NewTestStub(&sms.TestStubModel{To: "+12065550101", Body: "test-sms-body"}))
+		require.NoError(t, err)
+
+		return reg, id, c.DispatchQueue(t.Context())
+	}
+
+	assertStatus := func(t *testing.T, reg driver.Registry, id uuid.UUID, expected courier.MessageStatus) *courier.Message {
+		m, err := reg.CourierPersister().FetchMessage(t.Context(), id)
+		require.NoError(t, err)
+		assert.Equal(t, expected, m.Status)
+		return m
+	}
+
+	t.Run("provider=twilio", func(t *testing.T) {
+		channel := func(url string) string {
+			return fmt.Sprintf(`{"id": "sms", "type": "twilio", "twilio_config": {"url": %q, "account_sid": "AC123", "auth_token": "secret", "from": "+12065550100"}}`, url)
+		}
+
+		t.Run("case=sends the message", func(t *testing.T) {
+			srv, requests := newProvider(t, response{http.StatusCreated, `{"sid": "SM123", "status": "queued"}`})
+			reg, id, err := send(t, channel(srv.URL))
+			require.NoError(t, err)
+
+			r := <-requests
+			assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", r.URL.Path)
+			user, password, ok := r.BasicAuth()
+			require.True(t, ok)
+			assert.Equal(t, "AC123", user)
+			assert.Equal(t, "secret", password)
+			assert.Equal(t, "+12065550101", r.PostForm.Get("To"))
+			assert.Equal(t, "+12065550100", r.PostForm.Get("From"))
+			assert.Contains(t, r.PostForm.Get("Body"), "test-sms-body")
+
+			assertStatus(t, reg, id, courier.MessageStatusSent)
+		})
+
+		t.Run("case=abandons messages with permanent errors", func(t *testing.T) {
+			srv, _ := newProvider(t, response{http.StatusBadRequest, `{"code": 21211, "message": "The 'To' number is not a valid phone number.", "status": 400}`})
+			reg, id, err := send(t, channel(srv.URL))
+			require.Error(t, err)
+			assert.True(t, courier.IsPermanentError(err))
+
+			m := assertStatus(t, reg, id, courier.MessageStatusAbandoned)
+			require.Len(t, m.Dispatches, 1)
+			assert.Equal(t, "21211", gjson.GetBytes(m.Dispatches[0].Error, "details.code").String())
+			assert.Equal(t, "twilio", gjson.GetBytes(m.Dispatches[0].Error, "details.provider").String())
+			assert.Equal(t, "The 'To' number is not a valid phone number.", gjson.GetBytes(m.Dispatches[0].Error, "reason").String())
+		})
+
+		t.Run("case=retries messages with temporary errors", func(t *testing.T) {
+			srv, _ := newProvider(t, response{http.StatusTooManyRequests, `{"code": 20429, "message": "Too Many Requests", "status": 429}`})
+			reg, id, err := send(t, channel(srv.URL))
+			require.Error(t, err)
+			assert.False(t, courier.IsPermanentError(err))
+
+			assertStatus(t, reg, id, courier.MessageStatusQueued)
+		})
+	})
+
+	t.Run("provider=vonage", func(t *testing.T) {
+		channel := func(url string) string {
+			return fmt.Sprintf(`{"id": "sms", "type": "vonage", "vonage_config": {"url": %q, "api_key": "key", "api_secret": "secret", "from": "Ory"}}`, url)
+		}
+
+		t.Run("case=sends the message", func(t *testing.T) {
+			srv, requests := newProvider(t, response{http.StatusOK, `{"message-count": "1", "messages": [{"status": "0", "message-id": "123"}]}`})
+			reg, id, err := send(t, channel(srv.URL))
+			require.NoError(t, err)
+
+			r := <-requests
+			assert.Equal(t, "/sms/json", r.URL.Path)
+			assert.Equal(t, "key", r.PostForm.Get("api_key"))
+			assert.Equal(t, "secret", r.PostForm.Get("api_secret"))
+			assert.Equal(t, "12065550101", r.PostForm.Get("to"))
+			assert.Equal(t, "Ory", r.PostForm.Get("from"))
+
+			assertStatus(t, reg, id, courier.MessageStatusSent)
+		})
+
+		t.Run("case=abandons messages with permanent errors", func(t *testing.T) {
+			srv, _ := newProvider(t, response{http.StatusOK, `{"message-count": "1", "messages": [{"status": "7", "error-text": "Number barred"}]}`})
+			reg, id, err := send(t, channel(srv.URL))
+			require.Error(t, err)
+			assert.True(t, courier.IsPermanentError(err))
+
+			assertStatus(t, reg, id, courier.MessageStatusAbandoned)
+		})
+
+		t.Run("case=retries messages with temporary errors", func(t *testing.T) {
+			srv, _ := newProvider(t, response{http.StatusOK, `{"message-count": "1", "messages": [{"status": "1", "error-text": "Throttled"}]}`})
+			reg, id, err := send(t, channel(srv.URL))
+			require.Error(t, err)
+			assert.False(t, courier.IsPermanentError(err))
+
+			assertStatus(t, reg, id, courier.MessageStatusQueued)
+		})
+	})
+
+	t.Run("provider=sns", func(t *testing.T) {
+		channel := func(url string) string {
+			return fmt.Sprintf(`{"id": "sms", "type": "sns", "sns_config": {"url": %q, "region": "eu-central-1", "access_key_id": "AKID", "secret_access_key": "secret", "sender_id": "Ory"}}`, url)
+		}
+
+		t.Run("case=sends the message", func(t *testing.T) {
+			srv, requests := newProvider(t, response{http.StatusOK, `<PublishResponse><PublishResult><MessageId>123</MessageId></PublishResult></PublishResponse>`})
+			reg, id, err := send(t, channel(srv.URL))
+			require.NoError(t, err)
+
+			r := <-requests
+			assert.Equal(t, "Publish", r.PostForm.Get("Action"))
+			assert.Equal(t, "+12065550101", r.PostForm.Get("PhoneNumber"))
+			assert.Contains(t, r.PostForm.Get("Message"), "test-sms-body")
+			assert.Equal(t, url.Values{
+				"MessageAttributes.entry.1.Name":              {"AWS.SNS.SMS.SMSType"},
+				"MessageAttributes.entry.1.Value.StringValue": {"Transactional"},
+				"MessageAttributes.entry.2.Name":              {"AWS.SNS.SMS.SenderID"},
+				"MessageAttributes.entry.2.Value.StringValue": {"Ory"},
+			}, url.Values{
+				"MessageAttributes.entry.1.Name":              r.PostForm["MessageAttributes.entry.1.Name"],
+				"MessageAttributes.entry.1.Value.StringValue": r.PostForm["MessageAttributes.entry.1.Value.StringValue"],
+				"MessageAttributes.entry.2.Name":              r.PostForm["MessageAttributes.entry.2.Name"],
+				"MessageAttributes.entry.2.Value.StringValue": r.PostForm["MessageAttributes.entry.2.Value.StringValue"],
+			})
+
+			auth := r.Header.Get("Authorization")
+			assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/"), auth)
+			assert.Contains(t, auth, "/eu-central-1/sns/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=")
+			assert.NotEmpty(t, r.Header.Get("X-Amz-Date"))
+
+			assertStatus(t, reg, id, courier.MessageStatusSent)
+		})
+
+		t.Run("case=abandons messages with permanent errors", func(t *testing.T) {
+			srv, _ := newProvider(t, response{http.StatusBadRequest, `<ErrorResponse><Error><Type>Sender</Type><Code>InvalidParameter</Code><Message>Invalid parameter: PhoneNumber</Message></Error></ErrorResponse>`})
+			reg, id, err := send(t, channel(srv.URL))
+			require.Error(t, err)
+			assert.True(t, courier.IsPermanentError(err))
+
+			m := assertStatus(t, reg, id, courier.MessageStatusAbandoned)
+			require.Len(t, m.Dispatches, 1)
+			assert.Equal(t, "InvalidParameter", gjson.GetBytes(m.Dispatches[0].Error, "details.code").String())
+		})
+
+		t.Run("case=retries messages with temporary errors", func(t *testing.T) {
+			srv, _ := newProvider(t, response{http.StatusBadRequest, `<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code><Message>Rate exceeded</Message></Error></ErrorResponse>`})
+			reg, id, err := send(t, channel(srv.URL))
+			require.Error(t, err)
+			assert.False(t, courier.IsPermanentError(err))
+
+			assertStatus(t, reg, id, courier.MessageStatusQueued)
+		})
+	})
+}
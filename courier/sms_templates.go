@@ -63,6 +63,42 @@ func NewSMSTemplateFromMessage(d template.Dependencies, m Message) (SMSTemplate,
 			return nil, err
 		}
 		return sms.NewAuthenticatorKeyAdded(d, &t), nil
+	case template.TypeLoginNewDevice:
+		var t sms.LoginNewDeviceModel
+		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return sms.NewLoginNewDevice(d, &t), nil
+	case template.TypePasswordChanged:
+		var t sms.PasswordChangedModel
+		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return sms.NewPasswordChanged(d, &t), nil
+	case template.TypeTOTPRemoved:
+		var t sms.TOTPRemovedModel
+		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return sms.NewTOTPRemoved(d, &t), nil
+	case template.TypeLookupSecretsRegenerated:
+		var t sms.LookupSecretsRegeneratedModel
+		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return sms.NewLookupSecretsRegenerated(d, &t), nil
+	case template.TypeOIDCProviderUnlinked:
+		var t sms.OIDCProviderUnlinkedModel
+		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return sms.NewOIDCProviderUnlinked(d, &t), nil
+	case template.TypeSessionsRevoked:
+		var t sms.SessionsRevokedModel
+		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return sms.NewSessionsRevoked(d, &t), nil
 	default:
 		return nil, errors.Errorf("received unexpected message template type: %s", m.TemplateType)
 	}
@@ -37,6 +37,12 @@ func TestNewSMSTemplateFromMessage(t *testing.T) {
 		template.TypeTestStub:                 sms.NewTestStub(&sms.TestStubModel{To: "+12345678901", Body: "test body"}),
 		template.TypeVerifiableAddressChanged: sms.NewVerifiableAddressChanged(reg, &sms.VerifiableAddressChangedModel{To: "+12345678901", ChangedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"ID": "00000000-0000-0000-0000-000000000001"}}),
 		template.TypeAuthenticatorKeyAdded:    sms.NewAuthenticatorKeyAdded(reg, &sms.AuthenticatorKeyAddedModel{To: "+12345678901", AddedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"ID": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeLoginNewDevice:           sms.NewLoginNewDevice(reg, &sms.LoginNewDeviceModel{To: "+12345678901", LoggedInAt: "2026-04-21T12:00:00Z", Location: "Munich, DE", NotMeURL: "https://www.ory.sh/sessions/revoke-unrecognized?token=abc"}),
+		template.TypePasswordChanged:          sms.NewPasswordChanged(reg, &sms.PasswordChangedModel{To: "+12345678901", ChangedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"id": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeTOTPRemoved:              sms.NewTOTPRemoved(reg, &sms.TOTPRemovedModel{To: "+12345678901", RemovedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"id": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeLookupSecretsRegenerated: sms.NewLookupSecretsRegenerated(reg, &sms.LookupSecretsRegeneratedModel{To: "+12345678901", RegeneratedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"id": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeOIDCProviderUnlinked:     sms.NewOIDCProviderUnlinked(reg, &sms.OIDCProviderUnlinkedModel{To: "+12345678901", Provider: "github", UnlinkedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"id": "00000000-0000-0000-0000-000000000001"}}),
+		template.TypeSessionsRevoked:          sms.NewSessionsRevoked(reg, &sms.SessionsRevokedModel{To: "+12345678901", RevokedAt: "2026-04-21T12:00:00Z", Identity: map[string]any{"id": "00000000-0000-0000-0000-000000000001"}}),
 	} {
 		t.Run(fmt.Sprintf("case=%s", tmplType), func(t *testing.T) {
 			tmplData, err := json.Marshal(expectedTmpl)
@@ -151,9 +151,10 @@ func (c *courier) QueueEmail(ctx context.Context, t EmailTemplate) (uuid.UUID, e
 		RequestHeaders: requestHeaders,
 	}
 
-	if err := c.deps.CourierPersister().AddMessage(ctx, message); err != nil {
+	id, err := c.queueMessage(ctx, message)
+	if err != nil {
 		return uuid.Nil, errors.WithStack(err)
 	}
 
-	return message.ID, nil
+	return id, nil
 }
@@ -4,10 +4,13 @@
 package courier
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net"
 	stdmail "net/mail"
 	"net/textproto"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +30,7 @@ import (
 type (
 	SMTPChannel struct {
 		smtpClient *SMTPClient
+		signer     *messageSigner
 		d          Dependencies
 
 		newEmailTemplateFromMessage func(d template.Dependencies, msg Message) (EmailTemplate, error)
@@ -44,8 +48,13 @@ func NewSMTPChannelWithCustomTemplates(deps Dependencies, cfg *config.SMTPConfig
 	if err != nil {
 		return nil, err
 	}
+	signer, err := newMessageSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
 	return &SMTPChannel{
 		smtpClient:                  smtpClient,
+		signer:                      signer,
 		d:                           deps,
 		newEmailTemplateFromMessage: newEmailTemplateFromMessage,
 	}, nil
@@ -85,7 +94,7 @@ func (c *SMTPChannel) Dispatch(ctx context.Context, msg Message) (err error) {
 	// rather than placed in an SMTP command or header.
 	_, rfcErr := stdmail.ParseAddress(msg.Recipient)
 	if rfcErr != nil && !x.IsEmailAddress(msg.Recipient) {
-		if err := c.d.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusAbandoned); err != nil {
+		if err := setMessageStatus(ctx, c.d.CourierPersister(), msg, MessageStatusAbandoned); err != nil {
 			c.d.Logger().WithError(err).Error(`Unable to set the message status to "abandoned".`)
 			return errors.WithStack(err)
 		}
@@ -107,6 +116,9 @@ func (c *SMTPChannel) Dispatch(ctx context.Context, msg Message) (err error) {
 	for k, v := range headers {
 		gm.SetHeader(k, v)
 	}
+	if c.d.CourierConfig().CourierDeliveryStatusEnabled(ctx) {
+		gm.SetHeader(DeliveryStatusHeader, msg.ID.String())
+	}
 
 	gm.SetBody("text/plain", msg.Body)
 
@@ -132,6 +144,21 @@ func (c *SMTPChannel) Dispatch(ctx context.Context, msg Message) (err error) {
 		gm.AddAlternative("text/html", htmlBody)
 	}
 
+	var signed signedMessage
+	if c.signer != nil {
+		var raw bytes.Buffer
+		if _, err := gm.WriteTo(&raw); err != nil {
+			return errors.WithStack(err)
+		}
+		if signed, err = c.signer.sign(raw.Bytes()); err != nil {
+			logger.
+				WithError(err).
+				Error("Unable to sign email.")
+			return errors.WithStack(herodot.ErrInternalServerError().
+				WithError(err.Error()).WithReason("failed to sign email"))
+		}
+	}
+
 	dialCtx, dialSpan := c.d.Tracer(ctx).Tracer().Start(ctx, "courier.SMTPChannel.Dispatch.Dial", trace.WithAttributes(
 		semconv.NetPeerName(c.smtpClient.Host),
 		semconv.NetPeerPort(c.smtpClient.Port),
@@ -152,10 +179,17 @@ func (c *SMTPChannel) Dispatch(ctx context.Context, msg Message) (err error) {
 	defer func() { _ = snd.Close() }()
 
 	sendCtx, sendSpan := c.d.Tracer(ctx).Tracer().Start(ctx, "courier.SMTPChannel.Dispatch.Send")
-	if rfcErr == nil {
+	if rfcErr == nil && signed == nil {
 		// mail.Send derives the envelope from the To/Cc/Bcc headers, so Cc/Bcc
 		// configured via courier.smtp.headers are delivered.
 		err = mail.Send(sendCtx, snd, gm)
+	} else if rfcErr == nil {
+		// mail.Send would serialize the message again and invalidate the
+		// signatures, so the envelope is derived from the headers here.
+		var to []string
+		if to, err = envelopeRecipients(gm); err == nil {
+			err = snd.Send(sendCtx, cfg.FromAddress, to, signed)
+		}
 	} else {
 		// A non-RFC recipient bypasses mail.Send's strict header re-parse with
 		// an explicit envelope; Cc/Bcc headers are not delivered on this path.
@@ -164,7 +198,11 @@ func (c *SMTPChannel) Dispatch(ctx context.Context, msg Message) (err error) {
 				logger.Warnf("Configured %q recipients are not delivered for a non-RFC email address.", k)
 			}
 		}
-		err = snd.Send(sendCtx, cfg.FromAddress, []string{msg.Recipient}, gm)
+		var wt io.WriterTo = gm
+		if signed != nil {
+			wt = signed
+		}
+		err = snd.Send(sendCtx, cfg.FromAddress, []string{msg.Recipient}, wt)
 	}
 	otelx.End(sendSpan, &err)
 
@@ -182,7 +220,7 @@ func (c *SMTPChannel) Dispatch(ctx context.Context, msg Message) (err error) {
 		case errors.As(err, &protoErr) && protoErr.Code >= 500:
 			// See https://en.wikipedia.org/wiki/List_of_SMTP_server_return_codes
 			// If the SMTP server responds with 5xx, sending the message should not be retried (without changing something about the request)
-			if err := c.d.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusAbandoned); err != nil {
+			if err := setMessageStatus(ctx, c.d.CourierPersister(), msg, MessageStatusAbandoned); err != nil {
 				logger.
 					WithError(err).
 					Error(`Unable to reset the retried message's status to "abandoned".`)
@@ -199,3 +237,23 @@ func (c *SMTPChannel) Dispatch(ctx context.Context, msg Message) (err error) {
 
 	return nil
 }
+
+// envelopeRecipients returns the addresses of the To, Cc, and Bcc header
+// fields of the message.
+func envelopeRecipients(gm *mail.Message) ([]string, error) {
+	var to []string
+	for _, field := range []string{"To", "Cc", "Bcc"} {
+		for _, value := range gm.GetHeader(field) {
+			addresses, err := stdmail.ParseAddressList(value)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			for _, a := range addresses {
+				if !slices.Contains(to, a.Address) {
+					to = append(to, a.Address)
+				}
+			}
+		}
+	}
+	return to, nil
+}
@@ -0,0 +1,74 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"slices"
+	"strings"
+
+	"github.com/emersion/go-msgauth/dkim"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+)
+
+// defaultDKIMHeaders are the header fields which are signed if no header
+// fields are configured.
+var defaultDKIMHeaders = []string{"From", "Reply-To", "Subject", "Date", "To", "Cc", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"}
+
+// dkimSigner signs emails with DKIM (RFC 6376) using the relaxed header and
+// body canonicalization.
+type dkimSigner struct {
+	options *dkim.SignOptions
+}
+
+func newDKIMSigner(cfg *config.SMTPDKIMConfig) (*dkimSigner, error) {
+	if cfg.Domain == "" || cfg.Selector == "" {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReason("The DKIM domain and selector must be set."))
+	}
+
+	key, err := loadSigningKey(cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *rsa.PrivateKey, ed25519.PrivateKey:
+	default:
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("The DKIM private key must be an RSA or Ed25519 key, but it is a %T.", key))
+	}
+
+	headers := cfg.Headers
+	if len(headers) == 0 {
+		headers = defaultDKIMHeaders
+	}
+	// The From header field must always be signed (RFC 6376, section 5.4).
+	if !slices.ContainsFunc(headers, func(h string) bool { return strings.EqualFold(h, "From") }) {
+		headers = append([]string{"From"}, headers...)
+	}
+
+	return &dkimSigner{options: &dkim.SignOptions{
+		Domain:                 cfg.Domain,
+		Selector:               cfg.Selector,
+		Signer:                 key,
+		Hash:                   crypto.SHA256,
+		HeaderCanonicalization: dkim.CanonicalizationRelaxed,
+		BodyCanonicalization:   dkim.CanonicalizationRelaxed,
+		HeaderKeys:             headers,
+	}}, nil
+}
+
+// sign prepends the DKIM-Signature header field to the serialized email.
+func (s *dkimSigner) sign(msg []byte) ([]byte, error) {
+	var signed bytes.Buffer
+	if err := dkim.Sign(&signed, bytes.NewReader(msg), s.options); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return signed.Bytes(), nil
+}
@@ -0,0 +1,155 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+)
+
+// messageSigner signs the serialized emails of an SMTP channel with S/MIME
+// and DKIM.
+type messageSigner struct {
+	smime *smimeSigner
+	dkim  *dkimSigner
+}
+
+// messageSigners caches the signers by their configuration, so that the keys
+// are loaded once instead of for every email, and again when the
+// configuration changes.
+var messageSigners, _ = lru.New[string, *messageSigner](16)
+
+// newMessageSigner returns the signer which is configured for the SMTP
+// channel, or nil if the emails are not signed.
+func newMessageSigner(cfg *config.SMTPConfig) (*messageSigner, error) {
+	if cfg.DKIM == nil && cfg.SMIME == nil {
+		return nil, nil
+	}
+
+	key, err := json.Marshal([]any{cfg.DKIM, cfg.SMIME})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if s, ok := messageSigners.Get(string(key)); ok {
+		return s, nil
+	}
+
+	s := new(messageSigner)
+	if cfg.SMIME != nil {
+		if s.smime, err = newSMIMESigner(cfg.SMIME); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.DKIM != nil {
+		if s.dkim, err = newDKIMSigner(cfg.DKIM); err != nil {
+			return nil, err
+		}
+	}
+	messageSigners.Add(string(key), s)
+	return s, nil
+}
+
+// sign signs the serialized email. The S/MIME signature is added first, so
+// that the DKIM signature covers the signed MIME structure.
+func (s *messageSigner) sign(msg []byte) (_ signedMessage, err error) {
+	if s.smime != nil {
+		if msg, err = s.smime.sign(msg); err != nil {
+			return nil, err
+		}
+	}
+	if s.dkim != nil {
+		if msg, err = s.dkim.sign(msg); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+// signedMessage is a serialized email which must be sent as is, because
+// serializing it again would invalidate its signatures.
+type signedMessage []byte
+
+func (m signedMessage) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(m)
+	return int64(n), err
+}
+
+// headerField is a header field of a serialized email. The raw header field
+// includes the name and keeps its folding, but not the final line break.
+type headerField struct {
+	name string
+	raw  string
+}
+
+// splitMessage splits a serialized email into its header fields and its body.
+func splitMessage(msg []byte) ([]headerField, []byte) {
+	header, body, found := bytes.Cut(msg, []byte("\r\n\r\n"))
+	if !found {
+		header, body = bytes.TrimSuffix(msg, []byte("\r\n")), nil
+	}
+
+	var fields []headerField
+	for _, line := range strings.Split(string(header), "\r\n") {
+		if len(fields) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			fields[len(fields)-1].raw += "\r\n" + line
+			continue
+		}
+		name, _, _ := strings.Cut(line, ":")
+		fields = append(fields, headerField{name: strings.TrimSpace(name), raw: line})
+	}
+	return fields, body
+}
+
+// loadSigningKey loads a PEM encoded PKCS #8, PKCS #1, or SEC 1 private key.
+func loadSigningKey(path string) (crypto.Signer, error) {
+	raw, err := os.ReadFile(path) // #nosec G304 -- the path is set by the operator
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to read the email signing key: %s", err))
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("The email signing key %s is not PEM encoded.", path))
+	}
+
+	var key any
+	if key, err = x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+		if key, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			if key, err = x509.ParseECPrivateKey(block.Bytes); err != nil {
+				return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to parse the email signing key %s: %s", path, err))
+			}
+		}
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("The email signing key %s has an unsupported type %T.", path, key))
+	}
+	return signer, nil
+}
+
+// foldBase64 base64 encodes the data into lines of the given length, which are
+// separated by sep.
+func foldBase64(data []byte, length int, sep string) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var lines []string
+	for len(encoded) > length {
+		lines = append(lines, encoded[:length])
+		encoded = encoded[length:]
+	}
+	return strings.Join(append(lines, encoded), sep)
+}
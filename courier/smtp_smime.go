@@ -0,0 +1,114 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/smallstep/pkcs7"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/randx"
+)
+
+// smimeSigner signs emails with S/MIME (RFC 8551) using the multipart/signed
+// format, so that email clients which do not support S/MIME still show the
+// email.
+type smimeSigner struct {
+	certificates []*x509.Certificate
+	key          crypto.Signer
+}
+
+func newSMIMESigner(cfg *config.SMTPSMIMEConfig) (*smimeSigner, error) {
+	pair, err := tls.LoadX509KeyPair(cfg.CertificatePath, cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to load the S/MIME certificate and private key: %s", err))
+	}
+
+	s := new(smimeSigner)
+	switch key := pair.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		s.key = key
+	case *ecdsa.PrivateKey:
+		s.key = key
+	default:
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("The S/MIME private key must be an RSA or ECDSA key, but it is a %T.", pair.PrivateKey))
+	}
+
+	for _, raw := range pair.Certificate {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to parse the S/MIME certificate: %s", err))
+		}
+		s.certificates = append(s.certificates, cert)
+	}
+	return s, nil
+}
+
+// sign moves the content header fields and the body of the serialized email
+// into the first part of a multipart/signed entity, and adds the detached
+// signature of that part as the second part.
+func (s *smimeSigner) sign(msg []byte) ([]byte, error) {
+	fields, body := splitMessage(msg)
+
+	var outer, content []string
+	for _, f := range fields {
+		if strings.EqualFold(f.name, "Content-Type") || strings.EqualFold(f.name, "Content-Transfer-Encoding") {
+			content = append(content, f.raw)
+		} else {
+			outer = append(outer, f.raw)
+		}
+	}
+	if len(content) == 0 {
+		content = []string{"Content-Type: text/plain; charset=us-ascii"}
+	}
+
+	signed := []byte(strings.Join(content, "\r\n") + "\r\n\r\n" + string(body))
+	signature, err := s.signature(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	boundary := randx.MustString(32, randx.AlphaNum)
+	var b bytes.Buffer
+	for _, f := range outer {
+		b.WriteString(f + "\r\n")
+	}
+	b.WriteString("Content-Type: multipart/signed; protocol=\"application/pkcs7-signature\"; micalg=sha-256;\r\n boundary=\"" + boundary + "\"\r\n\r\n")
+	b.WriteString("This is a cryptographically signed message in MIME format.\r\n\r\n")
+	b.WriteString("--" + boundary + "\r\n")
+	b.Write(signed)
+	b.WriteString("\r\n--" + boundary + "\r\n")
+	b.WriteString("Content-Type: application/pkcs7-signature; name=\"smime.p7s\"\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n")
+	b.WriteString("Content-Disposition: attachment; filename=\"smime.p7s\"\r\n\r\n")
+	b.WriteString(foldBase64(signature, 76, "\r\n"))
+	b.WriteString("\r\n--" + boundary + "--\r\n")
+	return b.Bytes(), nil
+}
+
+// signature returns the DER encoded detached CMS signature of the content.
+// The certificate chain is embedded, so that recipients can verify it.
+func (s *smimeSigner) signature(content []byte) ([]byte, error) {
+	signedData, err := pkcs7.NewSignedData(content)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	signedData.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+	if err := signedData.AddSignerChain(s.certificates[0], s.key, s.certificates[1:], pkcs7.SignerInfoConfig{}); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	signedData.Detach()
+
+	signature, err := signedData.Finish()
+	return signature, errors.WithStack(err)
+}
@@ -5,13 +5,30 @@ package courier_test
 
 import (
 	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"math/big"
+	"mime"
 	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/emersion/go-msgauth/dkim"
+	"github.com/smallstep/pkcs7"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -135,6 +152,126 @@ func newWireCourier(t *testing.T, smtpURL string, extra map[string]any) (courier
 	return c, conf
 }
 
+// writeSigningKey writes the PEM encoded private key and a self-signed
+// certificate for it to a temporary directory and returns their paths.
+func writeSigningKey(t *testing.T, key crypto.Signer) (keyPath, certPath string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPath = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "from@ory.sh"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	certPath = filepath.Join(dir, "cert.pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0o600))
+	return keyPath, certPath
+}
+
+// verifyDKIM verifies the DKIM signature of the email with the public key of
+// the kratos._domainkey.ory.sh selector.
+func verifyDKIM(t *testing.T, msg []byte, pub crypto.PublicKey) error {
+	t.Helper()
+
+	record := "v=DKIM1; k=rsa; p="
+	if key, ok := pub.(ed25519.PublicKey); ok {
+		record = "v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(key)
+	} else {
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		require.NoError(t, err)
+		record += base64.StdEncoding.EncodeToString(der)
+	}
+
+	verifications, err := dkim.VerifyWithOptions(bytes.NewReader(msg), &dkim.VerifyOptions{
+		LookupTXT: func(domain string) ([]string, error) {
+			if domain != "kratos._domainkey.ory.sh" {
+				return nil, fmt.Errorf("unexpected DKIM record lookup of %s", domain)
+			}
+			return []string{record}, nil
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, verifications, 1)
+	return verifications[0].Err
+}
+
+// verifySMIME verifies the detached S/MIME signature of the email and returns
+// the signed MIME entity and the signing certificate.
+func verifySMIME(t *testing.T, msg []byte) ([]byte, *x509.Certificate, error) {
+	t.Helper()
+
+	m, err := mail.ReadMessage(bytes.NewReader(msg))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/signed", mediaType)
+	require.Equal(t, "application/pkcs7-signature", params["protocol"])
+	body, err := io.ReadAll(m.Body)
+	require.NoError(t, err)
+
+	// The line break before a delimiter belongs to the delimiter, so the
+	// parts are the preamble, the signed entity, the signature, and the
+	// closing "--" (RFC 2046, section 5.1.1).
+	parts := strings.Split("\r\n"+string(body), "\r\n--"+params["boundary"])
+	require.Len(t, parts, 4)
+	content := []byte(strings.TrimPrefix(parts[1], "\r\n"))
+
+	signature, err := mail.ReadMessage(strings.NewReader(strings.TrimPrefix(parts[2], "\r\n")))
+	require.NoError(t, err)
+	require.Equal(t, "application/pkcs7-signature", strings.Split(signature.Header.Get("Content-Type"), ";")[0])
+	encoded, err := io.ReadAll(signature.Body)
+	require.NoError(t, err)
+	der, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	require.NoError(t, err)
+
+	p7, err := pkcs7.Parse(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	p7.Content = content
+	return content, p7.GetOnlySigner(), p7.Verify()
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+// dispatchSignedEmail sends a test email through the recording SMTP server and
+// returns the message as it was sent on the wire.
+func dispatchSignedEmail(t *testing.T, extra map[string]any) []byte {
+	t.Helper()
+	smtpURL, rec := newRecordingSMTPServer(t)
+	c, _ := newWireCourier(t, smtpURL, extra)
+
+	_, err := c.QueueEmail(t.Context(), templates.NewTestStub(&templates.TestStubModel{
+		To: "user@example.org", Subject: "signed", Body: "body\n",
+	}))
+	require.NoError(t, err)
+	require.NoError(t, c.DispatchQueue(t.Context()))
+
+	var data []string
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		var cmds []string
+		cmds, data = rec.snapshot()
+		assert.Equal(t, []string{"RCPT TO:<user@example.org>"}, rcptLines(cmds))
+		assert.Contains(t, cmds, "QUIT")
+	}, 5*time.Second, 50*time.Millisecond)
+	return []byte(strings.Join(data, "\r\n") + "\r\n")
+}
+
 func TestDispatchSMTPWireProtocol(t *testing.T) {
 	t.Run("case=rfc recipient keeps Cc and Bcc header recipients in the envelope", func(t *testing.T) {
 		smtpURL, rec := newRecordingSMTPServer(t)
@@ -213,4 +350,119 @@ func TestDispatchSMTPWireProtocol(t *testing.T) {
 			assert.NotContains(t, line, "attacker@evil.com", "attacker address must never reach the wire")
 		}
 	})
+	t.Run("case=signs the email with DKIM", func(t *testing.T) {
+		for _, tc := range []struct {
+			name string
+			key  crypto.Signer
+		}{
+			{name: "rsa", key: newRSAKey(t)},
+			{name: "ed25519", key: ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))},
+		} {
+			t.Run("algorithm="+tc.name, func(t *testing.T) {
+				keyPath, _ := writeSigningKey(t, tc.key)
+				msg := dispatchSignedEmail(t, map[string]any{
+					config.ViperKeyCourierSMTPDKIM: map[string]any{
+						"domain":           "ory.sh",
+						"selector":         "kratos",
+						"private_key_path": keyPath,
+					},
+				})
+
+				require.NoError(t, verifyDKIM(t, msg, tc.key.Public()))
+				assert.Contains(t, string(msg), "d=ory.sh;")
+				assert.Contains(t, string(msg), "s=kratos;")
+
+				tampered := []byte(strings.Replace(string(msg), "Subject: signed", "Subject: tampered", 1))
+				assert.Error(t, verifyDKIM(t, tampered, tc.key.Public()))
+			})
+		}
+	})
+
+	t.Run("case=signs the email with S/MIME", func(t *testing.T) {
+		key := newRSAKey(t)
+		keyPath, certPath := writeSigningKey(t, key)
+		msg := dispatchSignedEmail(t, map[string]any{
+			config.ViperKeyCourierSMTPSMIME: map[string]any{
+				"certificate_path": certPath,
+				"private_key_path": keyPath,
+			},
+		})
+
+		content, cert, err := verifySMIME(t, msg)
+		require.NoError(t, err)
+		assert.Equal(t, "from@ory.sh", cert.Subject.CommonName)
+		assert.Contains(t, string(content), "Content-Type: text/plain")
+		assert.Equal(t, []string{"To: user@example.org"}, headerLines(strings.Split(string(msg), "\r\n"), "To:"))
+
+		tampered := []byte(strings.Replace(string(msg), "body", "tampered", 1))
+		_, _, err = verifySMIME(t, tampered)
+		assert.Error(t, err)
+	})
+
+	t.Run("case=signs the S/MIME signed email with DKIM", func(t *testing.T) {
+		key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+		dkimKeyPath, _ := writeSigningKey(t, key)
+		smimeKey := newRSAKey(t)
+		smimeKeyPath, certPath := writeSigningKey(t, smimeKey)
+		msg := dispatchSignedEmail(t, map[string]any{
+			config.ViperKeyCourierSMTPDKIM: map[string]any{
+				"domain":           "ory.sh",
+				"selector":         "kratos",
+				"private_key_path": dkimKeyPath,
+			},
+			config.ViperKeyCourierSMTPSMIME: map[string]any{
+				"certificate_path": certPath,
+				"private_key_path": smimeKeyPath,
+			},
+		})
+
+		require.NoError(t, verifyDKIM(t, msg, key.Public()))
+		_, _, err := verifySMIME(t, msg)
+		require.NoError(t, err)
+	})
+
+	t.Run("case=loads the signing key once per configuration", func(t *testing.T) {
+		dkimConfig := func(keyPath string) map[string]any {
+			return map[string]any{
+				config.ViperKeyCourierSMTPDKIM: map[string]any{
+					"domain":           "ory.sh",
+					"selector":         "kratos",
+					"private_key_path": keyPath,
+				},
+			}
+		}
+
+		first, second := newRSAKey(t), newRSAKey(t)
+		keyPath, _ := writeSigningKey(t, first)
+		require.NoError(t, verifyDKIM(t, dispatchSignedEmail(t, dkimConfig(keyPath)), first.Public()))
+
+		// The key file is not read again for the same configuration.
+		rotatedPath, _ := writeSigningKey(t, second)
+		require.NoError(t, os.Rename(rotatedPath, keyPath))
+		require.NoError(t, verifyDKIM(t, dispatchSignedEmail(t, dkimConfig(keyPath)), first.Public()))
+
+		// A changed configuration loads the key again.
+		rotatedPath, _ = writeSigningKey(t, second)
+		require.NoError(t, verifyDKIM(t, dispatchSignedEmail(t, dkimConfig(rotatedPath)), second.Public()))
+	})
+
+	t.Run("case=fails to dispatch with a missing signing key", func(t *testing.T) {
+		smtpURL, rec := newRecordingSMTPServer(t)
+		c, _ := newWireCourier(t, smtpURL, map[string]any{
+			config.ViperKeyCourierSMTPDKIM: map[string]any{
+				"domain":           "ory.sh",
+				"selector":         "kratos",
+				"private_key_path": filepath.Join(t.TempDir(), "missing.pem"),
+			},
+		})
+
+		_, err := c.QueueEmail(t.Context(), templates.NewTestStub(&templates.TestStubModel{
+			To: "user@example.org", Subject: "s", Body: "b",
+		}))
+		require.NoError(t, err)
+		require.Error(t, c.DispatchQueue(t.Context()))
+
+		cmds, _ := rec.snapshot()
+		assert.Empty(t, rcptLines(cmds))
+	})
 }
@@ -0,0 +1,186 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/otelx"
+)
+
+// snsPermanentErrors are the Amazon SNS error codes which are caused by the
+// message or the recipient, and which therefore will not go away on retry.
+//
+// See https://docs.aws.amazon.com/sns/latest/api/API_Publish.html#API_Publish_Errors
+var snsPermanentErrors = map[string]bool{
+	"InvalidParameter":      true,
+	"InvalidParameterValue": true,
+	"ParameterValueInvalid": true,
+	"EndpointDisabled":      true,
+	"ValidationError":       true,
+}
+
+type snsChannel struct {
+	id     string
+	config *config.SNSConfig
+	d      channelDependencies
+}
+
+var _ Channel = new(snsChannel)
+
+func newSNSChannel(id string, c *config.SNSConfig, d channelDependencies) (*snsChannel, error) {
+	if c == nil {
+		return nil, errors.Errorf("courier channel %s has type sns but no sns_config", id)
+	}
+	return &snsChannel{id: id, config: c, d: d}, nil
+}
+
+func (c *snsChannel) ID() string {
+	return c.id
+}
+
+func (c *snsChannel) Dispatch(ctx context.Context, msg Message) (err error) {
+	ctx, span := c.d.Tracer(ctx).Tracer().Start(ctx, "courier.snsChannel.Dispatch")
+	defer otelx.End(span, &err)
+
+	if err := smsOnly("sns", msg); err != nil {
+		return err
+	}
+
+	endpoint := cmp.Or(c.config.URL, fmt.Sprintf("https://sns.%s.amazonaws.com/", c.config.Region))
+
+	form := url.Values{
+		"Action":                         {"Publish"},
+		"Version":                        {"2010-03-31"},
+		"PhoneNumber":                    {msg.Recipient},
+		"Message":                        {msg.Body},
+		"MessageAttributes.entry.1.Name": {"AWS.SNS.SMS.SMSType"},
+		"MessageAttributes.entry.1.Value.DataType":    {"String"},
+		"MessageAttributes.entry.1.Value.StringValue": {cmp.Or(c.config.SMSType, "Transactional")},
+	}
+	if c.config.SenderID != "" {
+		form.Set("MessageAttributes.entry.2.Name", "AWS.SNS.SMS.SenderID")
+		form.Set("MessageAttributes.entry.2.Value.DataType", "String")
+		form.Set("MessageAttributes.entry.2.Value.StringValue", c.config.SenderID)
+	}
+	body := form.Encode()
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	c.sign(req.Request, []byte(body))
+
+	status, resBody, err := doProviderRequest(ctx, c.d, req)
+	if err != nil {
+		return err
+	}
+	if status >= 200 && status < 300 {
+		c.d.Logger().WithField("message_id", msg.ID).Debug("Courier sent out message via Amazon SNS.")
+		return nil
+	}
+
+	return errors.WithStack(snsError(status, resBody))
+}
+
+func snsError(status int, body []byte) *ProviderError {
+	var res struct {
+		Error struct {
+			Code    string `xml:"Code"`
+			Message string `xml:"Message"`
+		} `xml:"Error"`
+	}
+	_ = xml.Unmarshal(body, &res)
+
+	return &ProviderError{
+		Provider:   "sns",
+		Code:       cmp.Or(res.Error.Code, strconv.Itoa(status)),
+		Message:    cmp.Or(res.Error.Message, http.StatusText(status)),
+		HTTPStatus: status,
+		Permanent:  snsPermanentErrors[res.Error.Code],
+	}
+}
+
+// sign signs the request with AWS Signature Version 4.
+//
+// See https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html
+func (c *snsChannel) sign(req *http.Request, body []byte) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if c.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.config.SessionToken)
+	}
+
+	signedHeaders := []string{"content-type", "host", "x-amz-date"}
+	if c.config.SessionToken != "" {
+		signedHeaders = append(signedHeaders, "x-amz-security-token")
+	}
+	var canonicalHeaders strings.Builder
+	for _, h := range signedHeaders {
+		canonicalHeaders.WriteString(h + ":" + strings.TrimSpace(req.Header.Get(h)) + "\n")
+	}
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		hexSHA256(body),
+	}, "\n")
+
+	scope := date + "/" + c.config.Region + "/sns/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := []byte("AWS4" + c.config.SecretAccessKey)
+	for _, v := range []string{date, c.config.Region, "sns", "aws4_request"} {
+		key = hmacSHA256(key, v)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.config.AccessKeyID, scope, strings.Join(signedHeaders, ";"), hex.EncodeToString(hmacSHA256(key, stringToSign)),
+	))
+}
+
+func hexSHA256(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -22,6 +22,7 @@ type (
 		Identity         map[string]any `json:"identity"`
 		AddedAt          string         `json:"added_at"`
 		TransientPayload map[string]any `json:"transient_payload"`
+		Locale           string         `json:"locale,omitempty"`
 	}
 )
 
@@ -34,16 +35,16 @@ func (t *AuthenticatorKeyAdded) EmailRecipient() (string, error) {
 }
 
 func (t *AuthenticatorKeyAdded) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "authenticator_key_added/email.subject.gotmpl", "authenticator_key_added/email.subject*", t.m, t.d.CourierConfig().CourierTemplatesAuthenticatorKeyAdded(ctx).Subject)
+	subject, err := template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "authenticator_key_added/email.subject.gotmpl", "authenticator_key_added/email.subject*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesAuthenticatorKeyAdded(ctx), t.m.Locale).Subject)
 	return strings.TrimSpace(subject), err
 }
 
 func (t *AuthenticatorKeyAdded) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "authenticator_key_added/email.body.gotmpl", "authenticator_key_added/email.body*", t.m, t.d.CourierConfig().CourierTemplatesAuthenticatorKeyAdded(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "authenticator_key_added/email.body.gotmpl", "authenticator_key_added/email.body*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesAuthenticatorKeyAdded(ctx), t.m.Locale).Body.HTML)
 }
 
 func (t *AuthenticatorKeyAdded) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "authenticator_key_added/email.body.plaintext.gotmpl", "authenticator_key_added/email.body.plaintext*", t.m, t.d.CourierConfig().CourierTemplatesAuthenticatorKeyAdded(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "authenticator_key_added/email.body.plaintext.gotmpl", "authenticator_key_added/email.body.plaintext*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesAuthenticatorKeyAdded(ctx), t.m.Locale).Body.PlainText)
 }
 
 func (t *AuthenticatorKeyAdded) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,56 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	DormantAccountWarning struct {
+		d template.Dependencies
+		m *DormantAccountWarningModel
+	}
+	DormantAccountWarningModel struct {
+		To               string         `json:"to"`
+		Identity         map[string]any `json:"identity"`
+		DeactivatesAt    string         `json:"deactivates_at"`
+		TransientPayload map[string]any `json:"transient_payload"`
+		Locale           string         `json:"locale,omitempty"`
+	}
+)
+
+func NewDormantAccountWarning(d template.Dependencies, m *DormantAccountWarningModel) *DormantAccountWarning {
+	return &DormantAccountWarning{d: d, m: m}
+}
+
+func (t *DormantAccountWarning) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *DormantAccountWarning) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "dormant_account_warning/email.subject.gotmpl", "dormant_account_warning/email.subject*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesDormantAccountWarning(ctx), t.m.Locale).Subject)
+	return strings.TrimSpace(subject), err
+}
+
+func (t *DormantAccountWarning) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedHTML(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "dormant_account_warning/email.body.gotmpl", "dormant_account_warning/email.body*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesDormantAccountWarning(ctx), t.m.Locale).Body.HTML)
+}
+
+func (t *DormantAccountWarning) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "dormant_account_warning/email.body.plaintext.gotmpl", "dormant_account_warning/email.body.plaintext*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesDormantAccountWarning(ctx), t.m.Locale).Body.PlainText)
+}
+
+func (t *DormantAccountWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
+
+func (t *DormantAccountWarning) TemplateType() template.TemplateType {
+	return template.TypeDormantAccountWarning
+}
@@ -27,6 +27,7 @@ type (
 		ExpiresInMinutes   int                          `json:"expires_in_minutes"`
 		OAuth2LoginRequest *template.OAuth2LoginRequest `json:"oauth2_login_request,omitempty"`
 		UserRequestHeaders http.Header                  `json:"-"`
+		Locale             string                       `json:"locale,omitempty"`
 	}
 )
 
@@ -39,17 +40,17 @@ func (t *LoginCodeValid) EmailRecipient() (string, error) {
 }
 
 func (t *LoginCodeValid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.subject.gotmpl", "login_code/valid/email.subject*", t.model, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Subject)
+	subject, err := template.LoadLocalizedText(ctx, t.deps, t.model.Locale, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.subject.gotmpl", "login_code/valid/email.subject*", t.model, template.LocalizedEmailTemplate(t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx), t.model.Locale).Subject)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *LoginCodeValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.body.gotmpl", "login_code/valid/email.body*", t.model, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.deps, t.model.Locale, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.body.gotmpl", "login_code/valid/email.body*", t.model, template.LocalizedEmailTemplate(t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx), t.model.Locale).Body.HTML)
 }
 
 func (t *LoginCodeValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.body.plaintext.gotmpl", "login_code/valid/email.body.plaintext*", t.model, t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.deps, t.model.Locale, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "login_code/valid/email.body.plaintext.gotmpl", "login_code/valid/email.body.plaintext*", t.model, template.LocalizedEmailTemplate(t.deps.CourierConfig().CourierTemplatesLoginCodeValid(ctx), t.model.Locale).Body.PlainText)
 }
 
 func (t *LoginCodeValid) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,60 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	LoginNewDevice struct {
+		d template.Dependencies
+		m *LoginNewDeviceModel
+	}
+	LoginNewDeviceModel struct {
+		To               string         `json:"to"`
+		Identity         map[string]any `json:"identity"`
+		LoggedInAt       string         `json:"logged_in_at"`
+		IPAddress        string         `json:"ip_address"`
+		UserAgent        string         `json:"user_agent"`
+		Location         string         `json:"location"`
+		NotMeURL         string         `json:"not_me_url"`
+		TransientPayload map[string]any `json:"transient_payload"`
+		Locale           string         `json:"locale,omitempty"`
+	}
+)
+
+func NewLoginNewDevice(d template.Dependencies, m *LoginNewDeviceModel) *LoginNewDevice {
+	return &LoginNewDevice{d: d, m: m}
+}
+
+func (t *LoginNewDevice) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *LoginNewDevice) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "login_new_device/email.subject.gotmpl", "login_new_device/email.subject*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesLoginNewDevice(ctx), t.m.Locale).Subject)
+	return strings.TrimSpace(subject), err
+}
+
+func (t *LoginNewDevice) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedHTML(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "login_new_device/email.body.gotmpl", "login_new_device/email.body*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesLoginNewDevice(ctx), t.m.Locale).Body.HTML)
+}
+
+func (t *LoginNewDevice) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "login_new_device/email.body.plaintext.gotmpl", "login_new_device/email.body.plaintext*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesLoginNewDevice(ctx), t.m.Locale).Body.PlainText)
+}
+
+func (t *LoginNewDevice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
+
+func (t *LoginNewDevice) TemplateType() template.TemplateType {
+	return template.TypeLoginNewDevice
+}
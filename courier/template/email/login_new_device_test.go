@@ -0,0 +1,49 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/pkg"
+)
+
+func TestLoginNewDevice(t *testing.T) {
+	ctx := t.Context()
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+
+	notMeURL := "https://www.ory.sh/sessions/revoke-unrecognized?token=abc"
+	tpl := email.NewLoginNewDevice(reg, &email.LoginNewDeviceModel{
+		To:         "owner@example.com",
+		LoggedInAt: "2026-04-21T12:00:00Z",
+		IPAddress:  "54.155.246.232",
+		UserAgent:  "Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+		Location:   "Munich, Bavaria, DE",
+		NotMeURL:   notMeURL,
+	})
+
+	recipient, err := tpl.EmailRecipient()
+	require.NoError(t, err)
+	assert.Equal(t, "owner@example.com", recipient)
+
+	subject, err := tpl.EmailSubject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "New sign-in to your account", subject)
+
+	body, err := tpl.EmailBody(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, body, "Munich, Bavaria, DE")
+	assert.Contains(t, body, `href="https://www.ory.sh/sessions/revoke-unrecognized?token=abc"`)
+
+	plain, err := tpl.EmailBodyPlaintext(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, plain, "54.155.246.232")
+	assert.Contains(t, plain, "Firefox/125.0")
+	assert.Contains(t, plain, "2026-04-21T12:00:00Z")
+	assert.Contains(t, plain, notMeURL)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	LookupSecretsRegenerated struct {
+		d template.Dependencies
+		m *LookupSecretsRegeneratedModel
+	}
+	LookupSecretsRegeneratedModel struct {
+		To               string         `json:"to"`
+		Identity         map[string]any `json:"identity"`
+		RegeneratedAt    string         `json:"regenerated_at"`
+		TransientPayload map[string]any `json:"transient_payload"`
+		Locale           string         `json:"locale,omitempty"`
+	}
+)
+
+func NewLookupSecretsRegenerated(d template.Dependencies, m *LookupSecretsRegeneratedModel) *LookupSecretsRegenerated {
+	return &LookupSecretsRegenerated{d: d, m: m}
+}
+
+func (t *LookupSecretsRegenerated) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *LookupSecretsRegenerated) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "lookup_secrets_regenerated/email.subject.gotmpl", "lookup_secrets_regenerated/email.subject*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesLookupSecretsRegenerated(ctx), t.m.Locale).Subject)
+	return strings.TrimSpace(subject), err
+}
+
+func (t *LookupSecretsRegenerated) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedHTML(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "lookup_secrets_regenerated/email.body.gotmpl", "lookup_secrets_regenerated/email.body*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesLookupSecretsRegenerated(ctx), t.m.Locale).Body.HTML)
+}
+
+func (t *LookupSecretsRegenerated) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "lookup_secrets_regenerated/email.body.plaintext.gotmpl", "lookup_secrets_regenerated/email.body.plaintext*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesLookupSecretsRegenerated(ctx), t.m.Locale).Body.PlainText)
+}
+
+func (t *LookupSecretsRegenerated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
+
+func (t *LookupSecretsRegenerated) TemplateType() template.TemplateType {
+	return template.TypeLookupSecretsRegenerated
+}
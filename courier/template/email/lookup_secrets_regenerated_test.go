@@ -0,0 +1,48 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/x"
+)
+
+func TestLookupSecretsRegenerated(t *testing.T) {
+	ctx := t.Context()
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+
+	idMap, err := x.StructToMap(&identity.Identity{ID: uuid.Must(uuid.FromString("00000000-0000-0000-0000-000000000001"))})
+	require.NoError(t, err)
+
+	tpl := email.NewLookupSecretsRegenerated(reg, &email.LookupSecretsRegeneratedModel{
+		To:            "owner@example.com",
+		Identity:      idMap,
+		RegeneratedAt: "2026-04-21T12:00:00Z",
+	})
+
+	recipient, err := tpl.EmailRecipient()
+	require.NoError(t, err)
+	assert.Equal(t, "owner@example.com", recipient)
+
+	subject, err := tpl.EmailSubject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "New backup recovery codes were generated", subject)
+
+	body, err := tpl.EmailBody(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, body, "00000000-0000-0000-0000-000000000001")
+
+	plain, err := tpl.EmailBodyPlaintext(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, plain, "00000000-0000-0000-0000-000000000001")
+	assert.Contains(t, plain, "2026-04-21T12:00:00Z")
+}
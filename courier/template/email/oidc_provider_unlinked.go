@@ -0,0 +1,57 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	OIDCProviderUnlinked struct {
+		d template.Dependencies
+		m *OIDCProviderUnlinkedModel
+	}
+	OIDCProviderUnlinkedModel struct {
+		To               string         `json:"to"`
+		Identity         map[string]any `json:"identity"`
+		Provider         string         `json:"provider"`
+		UnlinkedAt       string         `json:"unlinked_at"`
+		TransientPayload map[string]any `json:"transient_payload"`
+		Locale           string         `json:"locale,omitempty"`
+	}
+)
+
+func NewOIDCProviderUnlinked(d template.Dependencies, m *OIDCProviderUnlinkedModel) *OIDCProviderUnlinked {
+	return &OIDCProviderUnlinked{d: d, m: m}
+}
+
+func (t *OIDCProviderUnlinked) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *OIDCProviderUnlinked) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "oidc_provider_unlinked/email.subject.gotmpl", "oidc_provider_unlinked/email.subject*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesOIDCProviderUnlinked(ctx), t.m.Locale).Subject)
+	return strings.TrimSpace(subject), err
+}
+
+func (t *OIDCProviderUnlinked) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedHTML(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "oidc_provider_unlinked/email.body.gotmpl", "oidc_provider_unlinked/email.body*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesOIDCProviderUnlinked(ctx), t.m.Locale).Body.HTML)
+}
+
+func (t *OIDCProviderUnlinked) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "oidc_provider_unlinked/email.body.plaintext.gotmpl", "oidc_provider_unlinked/email.body.plaintext*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesOIDCProviderUnlinked(ctx), t.m.Locale).Body.PlainText)
+}
+
+func (t *OIDCProviderUnlinked) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
+
+func (t *OIDCProviderUnlinked) TemplateType() template.TemplateType {
+	return template.TypeOIDCProviderUnlinked
+}
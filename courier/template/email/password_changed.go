@@ -0,0 +1,56 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	PasswordChanged struct {
+		d template.Dependencies
+		m *PasswordChangedModel
+	}
+	PasswordChangedModel struct {
+		To               string         `json:"to"`
+		Identity         map[string]any `json:"identity"`
+		ChangedAt        string         `json:"changed_at"`
+		TransientPayload map[string]any `json:"transient_payload"`
+		Locale           string         `json:"locale,omitempty"`
+	}
+)
+
+func NewPasswordChanged(d template.Dependencies, m *PasswordChangedModel) *PasswordChanged {
+	return &PasswordChanged{d: d, m: m}
+}
+
+func (t *PasswordChanged) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *PasswordChanged) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "password_changed/email.subject.gotmpl", "password_changed/email.subject*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesPasswordChanged(ctx), t.m.Locale).Subject)
+	return strings.TrimSpace(subject), err
+}
+
+func (t *PasswordChanged) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedHTML(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "password_changed/email.body.gotmpl", "password_changed/email.body*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesPasswordChanged(ctx), t.m.Locale).Body.HTML)
+}
+
+func (t *PasswordChanged) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "password_changed/email.body.plaintext.gotmpl", "password_changed/email.body.plaintext*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesPasswordChanged(ctx), t.m.Locale).Body.PlainText)
+}
+
+func (t *PasswordChanged) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
+
+func (t *PasswordChanged) TemplateType() template.TemplateType {
+	return template.TypePasswordChanged
+}
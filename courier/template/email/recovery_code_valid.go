@@ -26,6 +26,7 @@ type (
 		TransientPayload   map[string]interface{} `json:"transient_payload"`
 		ExpiresInMinutes   int                    `json:"expires_in_minutes"`
 		UserRequestHeaders http.Header            `json:"-"`
+		Locale             string                 `json:"locale,omitempty"`
 	}
 )
 
@@ -38,17 +39,17 @@ func (t *RecoveryCodeValid) EmailRecipient() (string, error) {
 }
 
 func (t *RecoveryCodeValid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "recovery_code/valid/email.subject.gotmpl", "recovery_code/valid/email.subject*", t.model, t.deps.CourierConfig().CourierTemplatesRecoveryCodeValid(ctx).Subject)
+	subject, err := template.LoadLocalizedText(ctx, t.deps, t.model.Locale, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "recovery_code/valid/email.subject.gotmpl", "recovery_code/valid/email.subject*", t.model, template.LocalizedEmailTemplate(t.deps.CourierConfig().CourierTemplatesRecoveryCodeValid(ctx), t.model.Locale).Subject)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *RecoveryCodeValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "recovery_code/valid/email.body.gotmpl", "recovery_code/valid/email.body*", t.model, t.deps.CourierConfig().CourierTemplatesRecoveryCodeValid(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.deps, t.model.Locale, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "recovery_code/valid/email.body.gotmpl", "recovery_code/valid/email.body*", t.model, template.LocalizedEmailTemplate(t.deps.CourierConfig().CourierTemplatesRecoveryCodeValid(ctx), t.model.Locale).Body.HTML)
 }
 
 func (t *RecoveryCodeValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "recovery_code/valid/email.body.plaintext.gotmpl", "recovery_code/valid/email.body.plaintext*", t.model, t.deps.CourierConfig().CourierTemplatesRecoveryCodeValid(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.deps, t.model.Locale, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "recovery_code/valid/email.body.plaintext.gotmpl", "recovery_code/valid/email.body.plaintext*", t.model, template.LocalizedEmailTemplate(t.deps.CourierConfig().CourierTemplatesRecoveryCodeValid(ctx), t.model.Locale).Body.PlainText)
 }
 
 func (t *RecoveryCodeValid) MarshalJSON() ([]byte, error) {
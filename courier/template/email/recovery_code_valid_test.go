@@ -5,12 +5,19 @@ package email_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/courier/template/email"
 	"github.com/ory/kratos/courier/template/testhelpers"
+	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/pkg"
+	"github.com/ory/x/configx"
 )
 
 func TestRecoveryCodeValid(t *testing.T) {
@@ -24,6 +31,41 @@ func TestRecoveryCodeValid(t *testing.T) {
 		testhelpers.TestRendered(t, ctx, tpl)
 	})
 
+	t.Run("test=with localized templates", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "recovery_code/valid/de"), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "recovery_code/valid/de/email.subject.gotmpl"), []byte("Konto wiederherstellen"), 0o600))
+
+		_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+			config.ViperKeyCourierTemplatesPath: dir,
+			config.ViperKeyCourierTemplatesRecoveryCodeValidEmail: map[string]any{
+				"locales": map[string]any{
+					// Wiederherstellungscode: {{ .RecoveryCode }}
+					"de": map[string]any{"body": map[string]any{"plaintext": "base64://V2llZGVyaGVyc3RlbGx1bmdzY29kZToge3sgLlJlY292ZXJ5Q29kZSB9fQ=="}},
+				},
+			},
+		}))
+
+		tpl := email.NewRecoveryCodeValid(reg, &email.RecoveryCodeValidModel{RecoveryCode: "123456", Locale: "de-AT"})
+		subject, err := tpl.EmailSubject(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Konto wiederherstellen", subject)
+
+		body, err := tpl.EmailBodyPlaintext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Wiederherstellungscode: 123456", body)
+
+		// Templates which are not localized fall back to the default ones.
+		html, err := tpl.EmailBody(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, html, "123456")
+
+		tpl = email.NewRecoveryCodeValid(reg, &email.RecoveryCodeValidModel{RecoveryCode: "123456", Locale: "fr"})
+		subject, err = tpl.EmailSubject(ctx)
+		require.NoError(t, err)
+		assert.NotEqual(t, "Konto wiederherstellen", subject)
+	})
+
 	t.Run("test=with remote resources", func(t *testing.T) {
 		testhelpers.TestRemoteTemplates(t, "../courier/builtin/templates/recovery_code/valid", template.TypeRecoveryCodeValid)
 	})
@@ -27,6 +27,7 @@ type (
 		ExpiresInMinutes   int                          `json:"expires_in_minutes"`
 		OAuth2LoginRequest *template.OAuth2LoginRequest `json:"oauth2_login_request,omitempty"`
 		UserRequestHeaders http.Header                  `json:"-"`
+		Locale             string                       `json:"locale,omitempty"`
 	}
 )
 
@@ -39,17 +40,17 @@ func (t *RegistrationCodeValid) EmailRecipient() (string, error) {
 }
 
 func (t *RegistrationCodeValid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.subject.gotmpl", "registration_code/valid/email.subject*", t.model, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Subject)
+	subject, err := template.LoadLocalizedText(ctx, t.deps, t.model.Locale, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.subject.gotmpl", "registration_code/valid/email.subject*", t.model, template.LocalizedEmailTemplate(t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx), t.model.Locale).Subject)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *RegistrationCodeValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.body.gotmpl", "registration_code/valid/email.body*", t.model, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Body.HTML)
+	return template.LoadLocalizedHTML(ctx, t.deps, t.model.Locale, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.body.gotmpl", "registration_code/valid/email.body*", t.model, template.LocalizedEmailTemplate(t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx), t.model.Locale).Body.HTML)
 }
 
 func (t *RegistrationCodeValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.deps, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.body.plaintext.gotmpl", "registration_code/valid/email.body.plaintext*", t.model, t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx).Body.PlainText)
+	return template.LoadLocalizedText(ctx, t.deps, t.model.Locale, os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)), "registration_code/valid/email.body.plaintext.gotmpl", "registration_code/valid/email.body.plaintext*", t.model, template.LocalizedEmailTemplate(t.deps.CourierConfig().CourierTemplatesRegistrationCodeValid(ctx), t.model.Locale).Body.PlainText)
 }
 
 func (t *RegistrationCodeValid) MarshalJSON() ([]byte, error) {
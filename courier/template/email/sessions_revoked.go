@@ -0,0 +1,56 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	SessionsRevoked struct {
+		d template.Dependencies
+		m *SessionsRevokedModel
+	}
+	SessionsRevokedModel struct {
+		To               string         `json:"to"`
+		Identity         map[string]any `json:"identity"`
+		RevokedAt        string         `json:"revoked_at"`
+		TransientPayload map[string]any `json:"transient_payload"`
+		Locale           string         `json:"locale,omitempty"`
+	}
+)
+
+func NewSessionsRevoked(d template.Dependencies, m *SessionsRevokedModel) *SessionsRevoked {
+	return &SessionsRevoked{d: d, m: m}
+}
+
+func (t *SessionsRevoked) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *SessionsRevoked) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "sessions_revoked/email.subject.gotmpl", "sessions_revoked/email.subject*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesSessionsRevoked(ctx), t.m.Locale).Subject)
+	return strings.TrimSpace(subject), err
+}
+
+func (t *SessionsRevoked) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedHTML(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "sessions_revoked/email.body.gotmpl", "sessions_revoked/email.body*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesSessionsRevoked(ctx), t.m.Locale).Body.HTML)
+}
+
+func (t *SessionsRevoked) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "sessions_revoked/email.body.plaintext.gotmpl", "sessions_revoked/email.body.plaintext*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesSessionsRevoked(ctx), t.m.Locale).Body.PlainText)
+}
+
+func (t *SessionsRevoked) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
+
+func (t *SessionsRevoked) TemplateType() template.TemplateType {
+	return template.TypeSessionsRevoked
+}
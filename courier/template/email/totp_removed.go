@@ -0,0 +1,56 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	TOTPRemoved struct {
+		d template.Dependencies
+		m *TOTPRemovedModel
+	}
+	TOTPRemovedModel struct {
+		To               string         `json:"to"`
+		Identity         map[string]any `json:"identity"`
+		RemovedAt        string         `json:"removed_at"`
+		TransientPayload map[string]any `json:"transient_payload"`
+		Locale           string         `json:"locale,omitempty"`
+	}
+)
+
+func NewTOTPRemoved(d template.Dependencies, m *TOTPRemovedModel) *TOTPRemoved {
+	return &TOTPRemoved{d: d, m: m}
+}
+
+func (t *TOTPRemoved) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *TOTPRemoved) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "totp_removed/email.subject.gotmpl", "totp_removed/email.subject*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesTOTPRemoved(ctx), t.m.Locale).Subject)
+	return strings.TrimSpace(subject), err
+}
+
+func (t *TOTPRemoved) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedHTML(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "totp_removed/email.body.gotmpl", "totp_removed/email.body*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesTOTPRemoved(ctx), t.m.Locale).Body.HTML)
+}
+
+func (t *TOTPRemoved) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(ctx, t.d, t.m.Locale, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "totp_removed/email.body.plaintext.gotmpl", "totp_removed/email.body.plaintext*", t.m, template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesTOTPRemoved(ctx), t.m.Locale).Body.PlainText)
+}
+
+func (t *TOTPRemoved) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
+
+func (t *TOTPRemoved) TemplateType() template.TemplateType {
+	return template.TypeTOTPRemoved
+}
@@ -26,6 +26,7 @@ type (
 		TransientPayload   map[string]any               `json:"transient_payload"`
 		ExpiresInMinutes   int                          `json:"expires_in_minutes"`
 		OAuth2LoginRequest *template.OAuth2LoginRequest `json:"oauth2_login_request,omitempty"`
+		Locale             string                       `json:"locale,omitempty"`
 	}
 )
 
@@ -38,38 +39,41 @@ func (t *VerificationCodeValid) EmailRecipient() (string, error) {
 }
 
 func (t *VerificationCodeValid) EmailSubject(ctx context.Context) (string, error) {
-	subject, err := template.LoadText(
+	subject, err := template.LoadLocalizedText(
 		ctx,
 		t.d,
+		t.m.Locale,
 		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/valid/email.subject.gotmpl",
 		"verification_code/valid/email.subject*",
 		t.m,
-		t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx).Subject,
+		template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx), t.m.Locale).Subject,
 	)
 
 	return strings.TrimSpace(subject), err
 }
 
 func (t *VerificationCodeValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx,
+	return template.LoadLocalizedHTML(ctx,
 		t.d,
+		t.m.Locale,
 		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/valid/email.body.gotmpl",
 		"verification_code/valid/email.body*",
 		t.m,
-		t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx).Body.HTML,
+		template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx), t.m.Locale).Body.HTML,
 	)
 }
 
 func (t *VerificationCodeValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx,
+	return template.LoadLocalizedText(ctx,
 		t.d,
+		t.m.Locale,
 		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/valid/email.body.plaintext.gotmpl",
 		"verification_code/valid/email.body.plaintext*",
 		t.m,
-		t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx).Body.PlainText,
+		template.LocalizedEmailTemplate(t.d.CourierConfig().CourierTemplatesVerificationCodeValid(ctx), t.m.Locale).Body.PlainText,
 	)
 }
 
@@ -10,6 +10,7 @@ import (
 	htemplate "html/template"
 	"io"
 	"io/fs"
+	"path"
 	"path/filepath"
 	"text/template"
 
@@ -145,7 +146,58 @@ func loadTemplate(filesystem fs.FS, name, pattern string, html bool) (Template,
 	return tpl, nil
 }
 
+func fileExists(filesystem fs.FS, name string) bool {
+	if filesystem == nil {
+		return false
+	}
+	_, err := fs.Stat(filesystem, name)
+	return err == nil
+}
+
+// loadLocalizedTemplate loads the template for the locale from the
+// `{dir}/{locale}/{file}` path next to the template, falling back to less
+// specific locales and then to the unlocalized template. Templates in the
+// filesystem take precedence over the bundled ones.
+func loadLocalizedTemplate(filesystem fs.FS, locale, name, pattern string, html bool) (Template, error) {
+	type localized struct{ name, pattern string }
+
+	candidates := localeCandidates(locale)
+	if len(candidates) == 0 {
+		return loadTemplate(filesystem, name, pattern, html)
+	}
+
+	localizedTemplates := make([]localized, 0, len(candidates))
+	for _, candidate := range candidates {
+		l := localized{name: path.Join(path.Dir(name), candidate, path.Base(name))}
+		if pattern != "" {
+			l.pattern = path.Join(path.Dir(pattern), candidate, path.Base(pattern))
+		}
+		localizedTemplates = append(localizedTemplates, l)
+	}
+
+	for _, l := range localizedTemplates {
+		if _, found := Cache.Get(l.name); found || fileExists(filesystem, l.name) {
+			return loadTemplate(filesystem, l.name, l.pattern, html)
+		}
+	}
+	if !fileExists(filesystem, name) {
+		for _, l := range localizedTemplates {
+			if fileExists(templates, path.Join("courier/builtin/templates", l.name)) {
+				return loadTemplate(filesystem, l.name, l.pattern, html)
+			}
+		}
+	}
+
+	return loadTemplate(filesystem, name, pattern, html)
+}
+
 func LoadText(ctx context.Context, d templateDependencies, filesystem fs.FS, name, pattern string, model interface{}, remoteURL string) (string, error) {
+	return LoadLocalizedText(ctx, d, "", filesystem, name, pattern, model, remoteURL)
+}
+
+// LoadLocalizedText is like LoadText, but prefers the template for the given
+// locale. See ResolveLocale.
+func LoadLocalizedText(ctx context.Context, d templateDependencies, locale string, filesystem fs.FS, name, pattern string, model interface{}, remoteURL string) (string, error) {
 	var t Template
 	var err error
 	if remoteURL != "" {
@@ -154,7 +206,7 @@ func LoadText(ctx context.Context, d templateDependencies, filesystem fs.FS, nam
 			return "", err
 		}
 	} else {
-		t, err = loadTemplate(filesystem, name, pattern, false)
+		t, err = loadLocalizedTemplate(filesystem, locale, name, pattern, false)
 		if err != nil {
 			return "", err
 		}
@@ -168,6 +220,12 @@ func LoadText(ctx context.Context, d templateDependencies, filesystem fs.FS, nam
 }
 
 func LoadHTML(ctx context.Context, d templateDependencies, filesystem fs.FS, name, pattern string, model interface{}, remoteURL string) (string, error) {
+	return LoadLocalizedHTML(ctx, d, "", filesystem, name, pattern, model, remoteURL)
+}
+
+// LoadLocalizedHTML is like LoadHTML, but prefers the template for the given
+// locale. See ResolveLocale.
+func LoadLocalizedHTML(ctx context.Context, d templateDependencies, locale string, filesystem fs.FS, name, pattern string, model interface{}, remoteURL string) (string, error) {
 	var t Template
 	var err error
 	if remoteURL != "" {
@@ -176,7 +234,7 @@ func LoadHTML(ctx context.Context, d templateDependencies, filesystem fs.FS, nam
 			return "", err
 		}
 	} else {
-		t, err = loadTemplate(filesystem, name, pattern, true)
+		t, err = loadLocalizedTemplate(filesystem, locale, name, pattern, true)
 		if err != nil {
 			return "", err
 		}
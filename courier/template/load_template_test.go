@@ -92,6 +92,50 @@ func TestLoadTextTemplate(t *testing.T) {
 		assert.Contains(t, executeTextTemplate(t, dir, name, "", nil), "cached stub body")
 	})
 
+	t.Run("method=localized", func(t *testing.T) {
+		_, reg := pkg.NewFastRegistryWithMocks(t)
+		template.Cache, _ = lru.New[string, template.Template](16) // prevent Cache hit
+
+		dir := t.TempDir()
+		for name, body := range map[string]string{
+			"localized/valid/email.body.gotmpl":       "default body",
+			"localized/valid/de/email.body.gotmpl":    "german body",
+			"localized/valid/pt-BR/email.body.gotmpl": "brazilian body",
+		} {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o700))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(body), 0o600))
+		}
+
+		for _, tc := range []struct {
+			locale, expected string
+		}{
+			{"", "default body"},
+			{"de", "german body"},
+			{"de-AT", "german body"},
+			{"pt-BR", "brazilian body"},
+			{"pt", "default body"},
+			{"fr", "default body"},
+		} {
+			t.Run("locale="+tc.locale, func(t *testing.T) {
+				actual, err := template.LoadLocalizedText(t.Context(), reg, tc.locale, os.DirFS(dir), "localized/valid/email.body.gotmpl", "localized/valid/email.body*", nil, "")
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			})
+		}
+
+		t.Run("case=falls back to bundled templates", func(t *testing.T) {
+			actual, err := template.LoadLocalizedText(t.Context(), reg, "de", os.DirFS("some/inexistent/dir"), "test_stub/email.body.gotmpl", "", nil, "")
+			require.NoError(t, err)
+			assert.Contains(t, actual, "stub email")
+		})
+
+		t.Run("case=remote template takes precedence", func(t *testing.T) {
+			actual, err := template.LoadLocalizedText(t.Context(), reg, "de", os.DirFS(dir), "localized/valid/email.body.gotmpl", "", nil, "base64://cmVtb3RlIGJvZHk=")
+			require.NoError(t, err)
+			assert.Equal(t, "remote body", actual)
+		})
+	})
+
 	t.Run("method=remote resource", func(t *testing.T) {
 		_, reg := pkg.NewFastRegistryWithMocks(t)
 
@@ -0,0 +1,149 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"golang.org/x/text/language"
+
+	"github.com/ory/kratos/driver/config"
+)
+
+type localeConfig interface {
+	CourierLocaleIdentityTrait(ctx context.Context) string
+	CourierDefaultLocale(ctx context.Context) string
+}
+
+// ResolveLocale returns the locale in which a message to an identity is sent.
+// The locale is taken from the configured identity trait, then from the
+// Accept-Language header of the request which triggered the message, and
+// falls back to the configured default locale. An empty locale selects the
+// unlocalized templates.
+//
+// Both traits and header may be nil.
+func ResolveLocale(ctx context.Context, c localeConfig, traits []byte, header http.Header) string {
+	if path := c.CourierLocaleIdentityTrait(ctx); path != "" && len(traits) > 0 {
+		if locale := CanonicalLocale(gjson.GetBytes(traits, path).String()); locale != "" {
+			return locale
+		}
+	}
+
+	if header != nil {
+		// The tags are sorted by their quality already. The "*" wildcard is
+		// parsed as "mul" (multiple languages) and does not select a locale.
+		tags, _, _ := language.ParseAcceptLanguage(header.Get("Accept-Language"))
+		for _, tag := range tags {
+			if base, _ := tag.Base(); tag != language.Und && base.String() != "mul" {
+				return tag.String()
+			}
+		}
+	}
+
+	return CanonicalLocale(c.CourierDefaultLocale(ctx))
+}
+
+// CanonicalLocale returns the canonical form of a BCP 47 language tag, or an
+// empty string if the value is not a valid tag. Canonical tags only consist
+// of letters, digits, and hyphens, which makes them safe to use in paths.
+func CanonicalLocale(v string) string {
+	if v == "" {
+		return ""
+	}
+	tag, err := language.Parse(v)
+	if err != nil || tag == language.Und {
+		return ""
+	}
+	return tag.String()
+}
+
+// localeCandidates returns the locales to look up templates for, from the
+// most to the least specific. For "pt-BR" these are "pt-BR" and "pt".
+func localeCandidates(locale string) []string {
+	locale = CanonicalLocale(locale)
+	if locale == "" {
+		return nil
+	}
+
+	candidates := []string{locale}
+	for i := strings.LastIndexByte(locale, '-'); i > 0; i = strings.LastIndexByte(locale, '-') {
+		locale = locale[:i]
+		candidates = append(candidates, locale)
+	}
+	return candidates
+}
+
+// localizedTemplate returns the entry of locales which matches the locale
+// best, or nil if there is none.
+func localizedTemplate[T any](locales map[string]*T, locale string) *T {
+	if len(locales) == 0 {
+		return nil
+	}
+
+	canonical := make(map[string]*T, len(locales))
+	for k, v := range locales {
+		if k = CanonicalLocale(k); k != "" && v != nil {
+			canonical[k] = v
+		}
+	}
+	for _, candidate := range localeCandidates(locale) {
+		if t, ok := canonical[candidate]; ok {
+			return t
+		}
+	}
+	return nil
+}
+
+// LocalizedEmailTemplate returns the remote email templates for the locale.
+// Templates which are not configured for the locale fall back to the default
+// ones.
+func LocalizedEmailTemplate(t *config.CourierEmailTemplate, locale string) *config.CourierEmailTemplate {
+	out := &config.CourierEmailTemplate{Body: &config.CourierEmailBodyTemplate{}}
+	if t == nil {
+		return out
+	}
+
+	out.Subject = t.Subject
+	if t.Body != nil {
+		*out.Body = *t.Body
+	}
+
+	l := localizedTemplate(t.Locales, locale)
+	if l == nil {
+		return out
+	}
+	if l.Subject != "" {
+		out.Subject = l.Subject
+	}
+	if l.Body != nil {
+		if l.Body.HTML != "" {
+			out.Body.HTML = l.Body.HTML
+		}
+		if l.Body.PlainText != "" {
+			out.Body.PlainText = l.Body.PlainText
+		}
+	}
+	return out
+}
+
+// LocalizedSMSTemplate returns the remote SMS template for the locale. If no
+// template is configured for the locale, the default one is returned.
+func LocalizedSMSTemplate(t *config.CourierSMSTemplate, locale string) *config.CourierSMSTemplate {
+	out := &config.CourierSMSTemplate{Body: &config.CourierSMSTemplateBody{}}
+	if t == nil {
+		return out
+	}
+
+	if t.Body != nil {
+		*out.Body = *t.Body
+	}
+
+	if l := localizedTemplate(t.Locales, locale); l != nil && l.Body != nil && l.Body.PlainText != "" {
+		out.Body.PlainText = l.Body.PlainText
+	}
+	return out
+}
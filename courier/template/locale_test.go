@@ -0,0 +1,143 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package template_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/x/contextx"
+)
+
+func TestResolveLocale(t *testing.T) {
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+
+	for _, tc := range []struct {
+		name     string
+		config   map[string]any
+		traits   string
+		header   http.Header
+		expected string
+	}{
+		{
+			name:     "no locale configured",
+			traits:   `{"locale":"de"}`,
+			expected: "",
+		},
+		{
+			name:     "default locale",
+			config:   map[string]any{config.ViperKeyCourierLocaleDefault: "fr"},
+			expected: "fr",
+		},
+		{
+			name:     "identity trait",
+			config:   map[string]any{config.ViperKeyCourierLocaleIdentityTrait: "preferences.language", config.ViperKeyCourierLocaleDefault: "fr"},
+			traits:   `{"preferences":{"language":"de"}}`,
+			header:   http.Header{"Accept-Language": {"es"}},
+			expected: "de",
+		},
+		{
+			name:     "identity trait is canonicalized",
+			config:   map[string]any{config.ViperKeyCourierLocaleIdentityTrait: "locale"},
+			traits:   `{"locale":"pt_br"}`,
+			expected: "pt-BR",
+		},
+		{
+			name:     "invalid identity trait falls back to the header",
+			config:   map[string]any{config.ViperKeyCourierLocaleIdentityTrait: "locale"},
+			traits:   `{"locale":"../../etc"}`,
+			header:   http.Header{"Accept-Language": {"es"}},
+			expected: "es",
+		},
+		{
+			name:     "missing identity trait falls back to the header",
+			config:   map[string]any{config.ViperKeyCourierLocaleIdentityTrait: "locale"},
+			traits:   `{"email":"foo@ory.sh"}`,
+			header:   http.Header{"Accept-Language": {"es"}},
+			expected: "es",
+		},
+		{
+			name:     "header with the highest quality",
+			header:   http.Header{"Accept-Language": {"fr-CH;q=0.5, de-de, en;q=0.8"}},
+			expected: "de-DE",
+		},
+		{
+			name:     "wildcard header falls back to the default",
+			config:   map[string]any{config.ViperKeyCourierLocaleDefault: "fr"},
+			header:   http.Header{"Accept-Language": {"*"}},
+			expected: "fr",
+		},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			ctx := contextx.WithConfigValues(t.Context(), tc.config)
+			var traits []byte
+			if tc.traits != "" {
+				traits = []byte(tc.traits)
+			}
+			assert.Equal(t, tc.expected, template.ResolveLocale(ctx, reg.Config(), traits, tc.header))
+		})
+	}
+}
+
+func TestLocalizedEmailTemplate(t *testing.T) {
+	tpl := &config.CourierEmailTemplate{
+		Subject: "base64://c3ViamVjdA==",
+		Body: &config.CourierEmailBodyTemplate{
+			HTML:      "base64://aHRtbA==",
+			PlainText: "base64://cGxhaW50ZXh0",
+		},
+		Locales: map[string]*config.CourierEmailTemplate{
+			"DE": {Subject: "base64://ZGU="},
+			"pt": {Body: &config.CourierEmailBodyTemplate{HTML: "base64://cHQ="}},
+		},
+	}
+
+	t.Run("case=without locale", func(t *testing.T) {
+		actual := template.LocalizedEmailTemplate(tpl, "")
+		assert.Equal(t, tpl.Subject, actual.Subject)
+		assert.Equal(t, *tpl.Body, *actual.Body)
+	})
+
+	t.Run("case=falls back per template", func(t *testing.T) {
+		actual := template.LocalizedEmailTemplate(tpl, "de")
+		assert.Equal(t, "base64://ZGU=", actual.Subject)
+		assert.Equal(t, *tpl.Body, *actual.Body)
+	})
+
+	t.Run("case=falls back to the base language", func(t *testing.T) {
+		actual := template.LocalizedEmailTemplate(tpl, "pt-BR")
+		assert.Equal(t, tpl.Subject, actual.Subject)
+		assert.Equal(t, "base64://cHQ=", actual.Body.HTML)
+		assert.Equal(t, tpl.Body.PlainText, actual.Body.PlainText)
+	})
+
+	t.Run("case=unknown locale", func(t *testing.T) {
+		actual := template.LocalizedEmailTemplate(tpl, "fr")
+		assert.Equal(t, tpl.Subject, actual.Subject)
+		assert.Equal(t, *tpl.Body, *actual.Body)
+	})
+
+	t.Run("case=does not modify the template", func(t *testing.T) {
+		_ = template.LocalizedEmailTemplate(tpl, "pt")
+		assert.Equal(t, "base64://aHRtbA==", tpl.Body.HTML)
+	})
+}
+
+func TestLocalizedSMSTemplate(t *testing.T) {
+	tpl := &config.CourierSMSTemplate{
+		Body: &config.CourierSMSTemplateBody{PlainText: "base64://cGxhaW50ZXh0"},
+		Locales: map[string]*config.CourierSMSTemplate{
+			"de": {Body: &config.CourierSMSTemplateBody{PlainText: "base64://ZGU="}},
+		},
+	}
+
+	assert.Equal(t, "base64://ZGU=", template.LocalizedSMSTemplate(tpl, "de-AT").Body.PlainText)
+	assert.Equal(t, "base64://cGxhaW50ZXh0", template.LocalizedSMSTemplate(tpl, "fr").Body.PlainText)
+	assert.Equal(t, "", template.LocalizedSMSTemplate(nil, "de").Body.PlainText)
+}
@@ -23,6 +23,7 @@ type (
 		AddedAt            string         `json:"added_at"`
 		TransientPayload   map[string]any `json:"transient_payload"`
 		UserRequestHeaders http.Header    `json:"-"`
+		Locale             string         `json:"locale,omitempty"`
 	}
 )
 
@@ -35,14 +36,15 @@ func (t *AuthenticatorKeyAdded) PhoneNumber() (string, error) {
 }
 
 func (t *AuthenticatorKeyAdded) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(
+	return template.LoadLocalizedText(
 		ctx,
 		t.deps,
+		t.model.Locale,
 		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
 		"authenticator_key_added/sms.body.gotmpl",
 		"authenticator_key_added/sms.body*",
 		t.model,
-		t.deps.CourierConfig().CourierSMSTemplatesAuthenticatorKeyAdded(ctx).Body.PlainText,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesAuthenticatorKeyAdded(ctx), t.model.Locale).Body.PlainText,
 	)
 }
 
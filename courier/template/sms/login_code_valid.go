@@ -27,6 +27,7 @@ type (
 		ExpiresInMinutes   int                          `json:"expires_in_minutes"`
 		OAuth2LoginRequest *template.OAuth2LoginRequest `json:"oauth2_login_request,omitempty"`
 		UserRequestHeaders http.Header                  `json:"-"`
+		Locale             string                       `json:"locale,omitempty"`
 	}
 )
 
@@ -39,14 +40,15 @@ func (t *LoginCodeValid) PhoneNumber() (string, error) {
 }
 
 func (t *LoginCodeValid) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(
+	return template.LoadLocalizedText(
 		ctx,
 		t.deps,
+		t.model.Locale,
 		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
 		"login_code/valid/sms.body.gotmpl",
 		"login_code/valid/sms.body*",
 		t.model,
-		t.deps.CourierConfig().CourierSMSTemplatesLoginCodeValid(ctx).Body.PlainText,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesLoginCodeValid(ctx), t.model.Locale).Body.PlainText,
 	)
 }
 
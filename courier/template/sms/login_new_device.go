@@ -0,0 +1,65 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	LoginNewDevice struct {
+		deps  template.Dependencies
+		model *LoginNewDeviceModel
+	}
+	LoginNewDeviceModel struct {
+		To                 string         `json:"to"`
+		Identity           map[string]any `json:"identity"`
+		LoggedInAt         string         `json:"logged_in_at"`
+		IPAddress          string         `json:"ip_address"`
+		UserAgent          string         `json:"user_agent"`
+		Location           string         `json:"location"`
+		NotMeURL           string         `json:"not_me_url"`
+		TransientPayload   map[string]any `json:"transient_payload"`
+		UserRequestHeaders http.Header    `json:"-"`
+		Locale             string         `json:"locale,omitempty"`
+	}
+)
+
+func NewLoginNewDevice(d template.Dependencies, m *LoginNewDeviceModel) *LoginNewDevice {
+	return &LoginNewDevice{deps: d, model: m}
+}
+
+func (t *LoginNewDevice) PhoneNumber() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *LoginNewDevice) SMSBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(
+		ctx,
+		t.deps,
+		t.model.Locale,
+		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
+		"login_new_device/sms.body.gotmpl",
+		"login_new_device/sms.body*",
+		t.model,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesLoginNewDevice(ctx), t.model.Locale).Body.PlainText,
+	)
+}
+
+func (t *LoginNewDevice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *LoginNewDevice) TemplateType() template.TemplateType {
+	return template.TypeLoginNewDevice
+}
+
+func (t *LoginNewDevice) RequestHeaders() http.Header {
+	return t.model.UserRequestHeaders
+}
@@ -0,0 +1,41 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sms_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/pkg"
+)
+
+func TestLoginNewDeviceSMS(t *testing.T) {
+	ctx := t.Context()
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+
+	notMeURL := "https://www.ory.sh/sessions/revoke-unrecognized?token=abc"
+	tpl := sms.NewLoginNewDevice(reg, &sms.LoginNewDeviceModel{
+		To:         "+15551234567",
+		LoggedInAt: "2026-04-21T12:00:00Z",
+		Location:   "Munich, DE",
+		NotMeURL:   notMeURL,
+	})
+
+	phone, err := tpl.PhoneNumber()
+	require.NoError(t, err)
+	assert.Equal(t, "+15551234567", phone)
+
+	body, err := tpl.SMSBody(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, body, "from Munich, DE")
+	assert.Contains(t, body, notMeURL)
+
+	tpl = sms.NewLoginNewDevice(reg, &sms.LoginNewDeviceModel{To: "+15551234567", NotMeURL: notMeURL})
+	body, err = tpl.SMSBody(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, body, "from", "the location is left out if it is unknown")
+}
@@ -0,0 +1,61 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	LookupSecretsRegenerated struct {
+		deps  template.Dependencies
+		model *LookupSecretsRegeneratedModel
+	}
+	LookupSecretsRegeneratedModel struct {
+		To                 string         `json:"to"`
+		Identity           map[string]any `json:"identity"`
+		RegeneratedAt      string         `json:"regenerated_at"`
+		TransientPayload   map[string]any `json:"transient_payload"`
+		UserRequestHeaders http.Header    `json:"-"`
+		Locale             string         `json:"locale,omitempty"`
+	}
+)
+
+func NewLookupSecretsRegenerated(d template.Dependencies, m *LookupSecretsRegeneratedModel) *LookupSecretsRegenerated {
+	return &LookupSecretsRegenerated{deps: d, model: m}
+}
+
+func (t *LookupSecretsRegenerated) PhoneNumber() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *LookupSecretsRegenerated) SMSBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(
+		ctx,
+		t.deps,
+		t.model.Locale,
+		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
+		"lookup_secrets_regenerated/sms.body.gotmpl",
+		"lookup_secrets_regenerated/sms.body*",
+		t.model,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesLookupSecretsRegenerated(ctx), t.model.Locale).Body.PlainText,
+	)
+}
+
+func (t *LookupSecretsRegenerated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *LookupSecretsRegenerated) TemplateType() template.TemplateType {
+	return template.TypeLookupSecretsRegenerated
+}
+
+func (t *LookupSecretsRegenerated) RequestHeaders() http.Header {
+	return t.model.UserRequestHeaders
+}
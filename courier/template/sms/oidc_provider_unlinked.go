@@ -0,0 +1,62 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	OIDCProviderUnlinked struct {
+		deps  template.Dependencies
+		model *OIDCProviderUnlinkedModel
+	}
+	OIDCProviderUnlinkedModel struct {
+		To                 string         `json:"to"`
+		Identity           map[string]any `json:"identity"`
+		Provider           string         `json:"provider"`
+		UnlinkedAt         string         `json:"unlinked_at"`
+		TransientPayload   map[string]any `json:"transient_payload"`
+		UserRequestHeaders http.Header    `json:"-"`
+		Locale             string         `json:"locale,omitempty"`
+	}
+)
+
+func NewOIDCProviderUnlinked(d template.Dependencies, m *OIDCProviderUnlinkedModel) *OIDCProviderUnlinked {
+	return &OIDCProviderUnlinked{deps: d, model: m}
+}
+
+func (t *OIDCProviderUnlinked) PhoneNumber() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *OIDCProviderUnlinked) SMSBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(
+		ctx,
+		t.deps,
+		t.model.Locale,
+		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
+		"oidc_provider_unlinked/sms.body.gotmpl",
+		"oidc_provider_unlinked/sms.body*",
+		t.model,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesOIDCProviderUnlinked(ctx), t.model.Locale).Body.PlainText,
+	)
+}
+
+func (t *OIDCProviderUnlinked) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *OIDCProviderUnlinked) TemplateType() template.TemplateType {
+	return template.TypeOIDCProviderUnlinked
+}
+
+func (t *OIDCProviderUnlinked) RequestHeaders() http.Header {
+	return t.model.UserRequestHeaders
+}
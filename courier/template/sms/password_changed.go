@@ -0,0 +1,61 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	PasswordChanged struct {
+		deps  template.Dependencies
+		model *PasswordChangedModel
+	}
+	PasswordChangedModel struct {
+		To                 string         `json:"to"`
+		Identity           map[string]any `json:"identity"`
+		ChangedAt          string         `json:"changed_at"`
+		TransientPayload   map[string]any `json:"transient_payload"`
+		UserRequestHeaders http.Header    `json:"-"`
+		Locale             string         `json:"locale,omitempty"`
+	}
+)
+
+func NewPasswordChanged(d template.Dependencies, m *PasswordChangedModel) *PasswordChanged {
+	return &PasswordChanged{deps: d, model: m}
+}
+
+func (t *PasswordChanged) PhoneNumber() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *PasswordChanged) SMSBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(
+		ctx,
+		t.deps,
+		t.model.Locale,
+		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
+		"password_changed/sms.body.gotmpl",
+		"password_changed/sms.body*",
+		t.model,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesPasswordChanged(ctx), t.model.Locale).Body.PlainText,
+	)
+}
+
+func (t *PasswordChanged) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *PasswordChanged) TemplateType() template.TemplateType {
+	return template.TypePasswordChanged
+}
+
+func (t *PasswordChanged) RequestHeaders() http.Header {
+	return t.model.UserRequestHeaders
+}
@@ -27,6 +27,7 @@ type (
 		TransientPayload   map[string]any `json:"transient_payload"`
 		ExpiresInMinutes   int            `json:"expires_in_minutes"`
 		UserRequestHeaders http.Header    `json:"-"`
+		Locale             string         `json:"locale,omitempty"`
 	}
 )
 
@@ -39,14 +40,15 @@ func (t *RecoveryCodeValid) PhoneNumber() (string, error) {
 }
 
 func (t *RecoveryCodeValid) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(
+	return template.LoadLocalizedText(
 		ctx,
 		t.deps,
+		t.model.Locale,
 		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
 		"recovery_code/valid/sms.body.gotmpl",
 		"recovery_code/valid/sms.body*",
 		t.model,
-		t.deps.CourierConfig().CourierSMSTemplatesRecoveryCodeValid(ctx).Body.PlainText,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesRecoveryCodeValid(ctx), t.model.Locale).Body.PlainText,
 	)
 }
 
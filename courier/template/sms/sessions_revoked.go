@@ -0,0 +1,61 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	SessionsRevoked struct {
+		deps  template.Dependencies
+		model *SessionsRevokedModel
+	}
+	SessionsRevokedModel struct {
+		To                 string         `json:"to"`
+		Identity           map[string]any `json:"identity"`
+		RevokedAt          string         `json:"revoked_at"`
+		TransientPayload   map[string]any `json:"transient_payload"`
+		UserRequestHeaders http.Header    `json:"-"`
+		Locale             string         `json:"locale,omitempty"`
+	}
+)
+
+func NewSessionsRevoked(d template.Dependencies, m *SessionsRevokedModel) *SessionsRevoked {
+	return &SessionsRevoked{deps: d, model: m}
+}
+
+func (t *SessionsRevoked) PhoneNumber() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *SessionsRevoked) SMSBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(
+		ctx,
+		t.deps,
+		t.model.Locale,
+		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
+		"sessions_revoked/sms.body.gotmpl",
+		"sessions_revoked/sms.body*",
+		t.model,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesSessionsRevoked(ctx), t.model.Locale).Body.PlainText,
+	)
+}
+
+func (t *SessionsRevoked) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *SessionsRevoked) TemplateType() template.TemplateType {
+	return template.TypeSessionsRevoked
+}
+
+func (t *SessionsRevoked) RequestHeaders() http.Header {
+	return t.model.UserRequestHeaders
+}
@@ -0,0 +1,61 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	TOTPRemoved struct {
+		deps  template.Dependencies
+		model *TOTPRemovedModel
+	}
+	TOTPRemovedModel struct {
+		To                 string         `json:"to"`
+		Identity           map[string]any `json:"identity"`
+		RemovedAt          string         `json:"removed_at"`
+		TransientPayload   map[string]any `json:"transient_payload"`
+		UserRequestHeaders http.Header    `json:"-"`
+		Locale             string         `json:"locale,omitempty"`
+	}
+)
+
+func NewTOTPRemoved(d template.Dependencies, m *TOTPRemovedModel) *TOTPRemoved {
+	return &TOTPRemoved{deps: d, model: m}
+}
+
+func (t *TOTPRemoved) PhoneNumber() (string, error) {
+	return t.model.To, nil
+}
+
+func (t *TOTPRemoved) SMSBody(ctx context.Context) (string, error) {
+	return template.LoadLocalizedText(
+		ctx,
+		t.deps,
+		t.model.Locale,
+		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
+		"totp_removed/sms.body.gotmpl",
+		"totp_removed/sms.body*",
+		t.model,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesTOTPRemoved(ctx), t.model.Locale).Body.PlainText,
+	)
+}
+
+func (t *TOTPRemoved) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.model)
+}
+
+func (t *TOTPRemoved) TemplateType() template.TemplateType {
+	return template.TypeTOTPRemoved
+}
+
+func (t *TOTPRemoved) RequestHeaders() http.Header {
+	return t.model.UserRequestHeaders
+}
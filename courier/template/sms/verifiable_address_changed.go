@@ -23,6 +23,7 @@ type (
 		ChangedAt          string         `json:"changed_at"`
 		TransientPayload   map[string]any `json:"transient_payload"`
 		UserRequestHeaders http.Header    `json:"-"`
+		Locale             string         `json:"locale,omitempty"`
 	}
 )
 
@@ -35,14 +36,15 @@ func (t *VerifiableAddressChanged) PhoneNumber() (string, error) {
 }
 
 func (t *VerifiableAddressChanged) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(
+	return template.LoadLocalizedText(
 		ctx,
 		t.deps,
+		t.model.Locale,
 		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verifiable_address_changed/sms.body.gotmpl",
 		"verifiable_address_changed/sms.body*",
 		t.model,
-		t.deps.CourierConfig().CourierSMSTemplatesVerifiableAddressChanged(ctx).Body.PlainText,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesVerifiableAddressChanged(ctx), t.model.Locale).Body.PlainText,
 	)
 }
 
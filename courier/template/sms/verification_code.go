@@ -26,6 +26,7 @@ type (
 		TransientPayload   map[string]any               `json:"transient_payload"`
 		ExpiresInMinutes   int                          `json:"expires_in_minutes"`
 		OAuth2LoginRequest *template.OAuth2LoginRequest `json:"oauth2_login_request,omitempty"`
+		Locale             string                       `json:"locale,omitempty"`
 	}
 )
 
@@ -38,14 +39,15 @@ func (t *VerificationCodeValid) PhoneNumber() (string, error) {
 }
 
 func (t *VerificationCodeValid) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(
+	return template.LoadLocalizedText(
 		ctx,
 		t.deps,
+		t.model.Locale,
 		os.DirFS(t.deps.CourierConfig().CourierTemplatesRoot(ctx)),
 		"verification_code/valid/sms.body.gotmpl",
 		"verification_code/valid/sms.body*",
 		t.model,
-		t.deps.CourierConfig().CourierSMSTemplatesVerificationCodeValid(ctx).Body.PlainText,
+		template.LocalizedSMSTemplate(t.deps.CourierConfig().CourierSMSTemplatesVerificationCodeValid(ctx), t.model.Locale).Body.PlainText,
 	)
 }
 
@@ -22,4 +22,11 @@ const (
 	TypeRegistrationCodeValid    TemplateType = "registration_code_valid"
 	TypeVerifiableAddressChanged TemplateType = "verifiable_address_changed"
 	TypeAuthenticatorKeyAdded    TemplateType = "authenticator_key_added"
+	TypeDormantAccountWarning    TemplateType = "dormant_account_warning"
+	TypeLoginNewDevice           TemplateType = "login_new_device"
+	TypePasswordChanged          TemplateType = "password_changed"
+	TypeTOTPRemoved              TemplateType = "totp_removed"
+	TypeLookupSecretsRegenerated TemplateType = "lookup_secrets_regenerated"
+	TypeOIDCProviderUnlinked     TemplateType = "oidc_provider_unlinked"
+	TypeSessionsRevoked          TemplateType = "sessions_revoked"
 )
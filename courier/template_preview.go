@@ -0,0 +1,203 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	// TemplateIdentityProvider provides the identities which template
+	// previews are rendered for.
+	TemplateIdentityProvider interface {
+		// CourierTemplateIdentity returns the JSON representation of the
+		// identity with the ID, as it is passed to the templates.
+		CourierTemplateIdentity(ctx context.Context, id uuid.UUID) (json.RawMessage, error)
+	}
+
+	PreviewDependencies interface {
+		template.Dependencies
+		Provider
+		TemplateIdentityProvider
+	}
+)
+
+// Render Courier Template Request Body
+//
+// swagger:model renderCourierTemplateBody
+type RenderTemplateBody struct {
+	// TemplateType is the type of the template to render, for example
+	// `login_code_valid`.
+	//
+	// required: true
+	TemplateType template.TemplateType `json:"template_type"`
+
+	// Locale selects the localized templates. If not set, the locale is taken
+	// from the identity, if any, and falls back to the default locale.
+	Locale string `json:"locale,omitempty"`
+
+	// Model is the sample data which the template is rendered with. It has
+	// the same fields as the data passed to the template when a message is
+	// sent, for example `login_code` and `expires_in_minutes`.
+	Model json.RawMessage `json:"model,omitempty"`
+
+	// IdentityID sets the `identity` field of the model to the identity with
+	// this ID.
+	IdentityID *uuid.UUID `json:"identity_id,omitempty"`
+
+	// SendTo queues a test message to this email address or phone number. It
+	// also sets the `to` field of the model.
+	SendTo string `json:"send_to,omitempty"`
+}
+
+// Rendered Courier Template
+//
+// swagger:model courierRenderedTemplate
+type RenderedTemplate struct {
+	// TemplateType is the type of the rendered template.
+	//
+	// required: true
+	TemplateType template.TemplateType `json:"template_type"`
+
+	// Locale is the locale the template was rendered in. It is empty if the
+	// unlocalized templates were used.
+	Locale string `json:"locale,omitempty"`
+
+	// EmailSubject is the subject of the email. It is empty if the template
+	// type has no email template.
+	EmailSubject string `json:"email_subject,omitempty"`
+
+	// EmailBodyPlaintext is the plaintext body of the email.
+	EmailBodyPlaintext string `json:"email_body_plaintext,omitempty"`
+
+	// EmailBodyHTML is the HTML body of the email.
+	EmailBodyHTML string `json:"email_body_html,omitempty"`
+
+	// SMSBody is the body of the SMS. It is empty if the template type has no
+	// SMS template.
+	SMSBody string `json:"sms_body,omitempty"`
+
+	// MessageID is the ID of the test message, if one was queued.
+	MessageID *uuid.UUID `json:"message_id,omitempty"`
+}
+
+// RenderTemplate renders the email and SMS templates of the template type with
+// the sample model, and queues a test message if the body has a recipient.
+func RenderTemplate(ctx context.Context, d PreviewDependencies, body *RenderTemplateBody) (*RenderedTemplate, error) {
+	if body.TemplateType == "" {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReason("The template type is required."))
+	}
+
+	model := map[string]any{}
+	if len(body.Model) > 0 && string(body.Model) != "null" {
+		if err := json.Unmarshal(body.Model, &model); err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()).WithReason("The model must be a JSON object."))
+		}
+	}
+
+	var traits []byte
+	if body.IdentityID != nil {
+		raw, err := d.CourierTemplateIdentity(ctx, *body.IdentityID)
+		if err != nil {
+			return nil, err
+		}
+		var identity map[string]any
+		if err := json.Unmarshal(raw, &identity); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		model["identity"] = identity
+		traits = []byte(gjson.GetBytes(raw, "traits").Raw)
+	}
+
+	locale := template.ResolveLocale(ctx, d.CourierConfig(), traits, nil)
+	if body.Locale != "" {
+		if locale = template.CanonicalLocale(body.Locale); locale == "" {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The locale %q is not a valid BCP 47 language tag.", body.Locale))
+		}
+	}
+	model["locale"] = locale
+
+	if body.SendTo != "" {
+		model["to"] = body.SendTo
+	}
+
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	msg := Message{TemplateType: body.TemplateType, TemplateData: data}
+
+	// Template types which have no email or SMS template are rejected by the
+	// respective constructor, so that is only an error if both reject it.
+	emailTemplate, emailErr := NewEmailTemplateFromMessage(d, msg)
+	smsTemplate, smsErr := NewSMSTemplateFromMessage(d, msg)
+	for _, err := range []error{emailErr, smsErr} {
+		if typeErr := new(json.UnmarshalTypeError); errors.As(err, &typeErr) {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()).WithReason("The model does not match the template type."))
+		}
+	}
+	if emailErr != nil && smsErr != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The template type %q is unknown.", body.TemplateType))
+	}
+
+	rendered := &RenderedTemplate{TemplateType: body.TemplateType, Locale: locale}
+	if emailTemplate != nil {
+		if rendered.EmailSubject, err = emailTemplate.EmailSubject(ctx); err != nil {
+			return nil, renderError(err)
+		}
+		if rendered.EmailBodyPlaintext, err = emailTemplate.EmailBodyPlaintext(ctx); err != nil {
+			return nil, renderError(err)
+		}
+		if rendered.EmailBodyHTML, err = emailTemplate.EmailBody(ctx); err != nil {
+			return nil, renderError(err)
+		}
+	}
+	if smsTemplate != nil {
+		if rendered.SMSBody, err = smsTemplate.SMSBody(ctx); err != nil {
+			return nil, renderError(err)
+		}
+	}
+
+	if body.SendTo == "" {
+		return rendered, nil
+	}
+
+	c, err := d.Courier(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = withTestMessage(ctx)
+	var id uuid.UUID
+	if strings.Contains(body.SendTo, "@") {
+		if emailTemplate == nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The template type %q has no email template.", body.TemplateType))
+		}
+		id, err = c.QueueEmail(ctx, emailTemplate)
+	} else {
+		if smsTemplate == nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("The template type %q has no SMS template.", body.TemplateType))
+		}
+		id, err = c.QueueSMS(ctx, smsTemplate)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rendered.MessageID = &id
+	return rendered, nil
+}
+
+func renderError(err error) error {
+	return errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()).WithReason("The template could not be rendered."))
+}
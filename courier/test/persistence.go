@@ -18,6 +18,7 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/x"
 	"github.com/ory/pop/v6"
 	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
@@ -353,7 +354,7 @@ func TestPersister(ctx context.Context, newNetworkUnlessExisting NetworkWrapper,
 		t.Run("case=RecordDispatch", func(t *testing.T) {
 			msgID := messages[0].ID
 
-			err := p.RecordDispatch(ctx, msgID, courier.CourierMessageDispatchStatusFailed, errors.New("testerror"))
+			err := p.RecordDispatch(ctx, msgID, "email", courier.CourierMessageDispatchStatusFailed, errors.New("testerror"))
 			require.NoError(t, err)
 
 			message, err := p.FetchMessage(ctx, msgID)
@@ -361,6 +362,7 @@ func TestPersister(ctx context.Context, newNetworkUnlessExisting NetworkWrapper,
 
 			require.Len(t, message.Dispatches, 1)
 			assert.Equal(t, "testerror", gjson.GetBytes(message.Dispatches[0].Error, "message").String())
+			assert.Equal(t, "email", message.Dispatches[0].Channel.String())
 
 			t.Run("can not get on another network", func(t *testing.T) {
 				_, p := newNetwork(t, ctx)
@@ -369,5 +371,350 @@ func TestPersister(ctx context.Context, newNetworkUnlessExisting NetworkWrapper,
 				require.ErrorIs(t, err, sqlcon.ErrNoRows())
 			})
 		})
+
+		addMessages := func(t *testing.T, recipient string, statuses ...courier.MessageStatus) []uuid.UUID {
+			ids := make([]uuid.UUID, len(statuses))
+			for k, status := range statuses {
+				m := courier.Message{Recipient: recipient, TemplateType: "test_stub"}
+				require.NoError(t, p.AddMessage(ctx, &m))
+				require.NoError(t, p.SetMessageStatus(ctx, m.ID, status))
+				ids[k] = m.ID
+			}
+			return ids
+		}
+
+		assertStatus := func(t *testing.T, id uuid.UUID, expected courier.MessageStatus) *courier.Message {
+			m, err := p.FetchMessage(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, expected, m.Status)
+			return m
+		}
+
+		t.Run("case=RequeueMessages", func(t *testing.T) {
+			recipient := x.NewUUID().String() + "@ory.sh"
+			ids := addMessages(t, recipient, courier.MessageStatusAbandoned, courier.MessageStatusAbandoned, courier.MessageStatusSent)
+			require.NoError(t, p.IncrementMessageSendCount(ctx, ids[0]))
+
+			t.Run("can not requeue on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+				count, err := p.RequeueMessages(ctx, courier.MessagesFilter{Recipient: recipient})
+				require.NoError(t, err)
+				assert.Zero(t, count)
+				assertStatus(t, ids[0], courier.MessageStatusAbandoned)
+			})
+
+			t.Run("requeues a single message", func(t *testing.T) {
+				count, err := p.RequeueMessages(ctx, courier.MessagesFilter{ID: ids[0]})
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+
+				m := assertStatus(t, ids[0], courier.MessageStatusQueued)
+				assert.Zero(t, m.SendCount)
+				require.Len(t, m.Dispatches, 1)
+				assert.Equal(t, courier.CourierMessageDispatchStatusRequeued, m.Dispatches[0].Status)
+				assertStatus(t, ids[1], courier.MessageStatusAbandoned)
+			})
+
+			t.Run("requeues only abandoned messages", func(t *testing.T) {
+				count, err := p.RequeueMessages(ctx, courier.MessagesFilter{Recipient: recipient})
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+
+				assertStatus(t, ids[1], courier.MessageStatusQueued)
+				assertStatus(t, ids[2], courier.MessageStatusSent)
+			})
+
+			t.Run("filters by creation time", func(t *testing.T) {
+				ids := addMessages(t, recipient, courier.MessageStatusAbandoned)
+				count, err := p.RequeueMessages(ctx, courier.MessagesFilter{Recipient: recipient, CreatedBefore: new(time.Now().Add(-time.Hour))})
+				require.NoError(t, err)
+				assert.Zero(t, count)
+
+				count, err = p.RequeueMessages(ctx, courier.MessagesFilter{Recipient: recipient, CreatedAfter: new(time.Now().Add(-time.Hour)), TemplateType: "test_stub"})
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+				assertStatus(t, ids[0], courier.MessageStatusQueued)
+			})
+		})
+
+		t.Run("case=CancelMessages", func(t *testing.T) {
+			recipient := x.NewUUID().String() + "@ory.sh"
+			ids := addMessages(t, recipient, courier.MessageStatusQueued, courier.MessageStatusQueued, courier.MessageStatusProcessing)
+
+			t.Run("can not cancel on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+				count, err := p.CancelMessages(ctx, courier.MessagesFilter{ID: ids[0]})
+				require.NoError(t, err)
+				assert.Zero(t, count)
+				assertStatus(t, ids[0], courier.MessageStatusQueued)
+			})
+
+			t.Run("cancels only queued messages", func(t *testing.T) {
+				count, err := p.CancelMessages(ctx, courier.MessagesFilter{Recipient: recipient})
+				require.NoError(t, err)
+				assert.Equal(t, 2, count)
+
+				for _, id := range ids[:2] {
+					m := assertStatus(t, id, courier.MessageStatusCancelled)
+					require.Len(t, m.Dispatches, 1)
+					assert.Equal(t, courier.CourierMessageDispatchStatusCancelled, m.Dispatches[0].Status)
+				}
+				assertStatus(t, ids[2], courier.MessageStatusProcessing)
+			})
+
+			t.Run("does not deliver cancelled messages", func(t *testing.T) {
+				count, err := p.CancelMessages(ctx, courier.MessagesFilter{ID: ids[0]})
+				require.NoError(t, err)
+				assert.Zero(t, count)
+			})
+
+			t.Run("requires a filter to change all messages", func(t *testing.T) {
+				ids := addMessages(t, recipient, courier.MessageStatusQueued)
+
+				_, err := p.CancelMessages(ctx, courier.MessagesFilter{})
+				require.ErrorIs(t, err, courier.ErrMessagesFilterEmpty)
+				assertStatus(t, ids[0], courier.MessageStatusQueued)
+
+				count, err := p.CancelMessages(ctx, courier.MessagesFilter{All: true})
+				require.NoError(t, err)
+				assert.GreaterOrEqual(t, count, 1)
+				assertStatus(t, ids[0], courier.MessageStatusCancelled)
+			})
+		})
+
+		t.Run("case=PurgeMessages", func(t *testing.T) {
+			recipient := x.NewUUID().String() + "@ory.sh"
+			ids := addMessages(t, recipient, courier.MessageStatusSent, courier.MessageStatusAbandoned, courier.MessageStatusCancelled, courier.MessageStatusQueued)
+			require.NoError(t, p.RecordDispatch(ctx, ids[0], "", courier.CourierMessageDispatchStatusSuccess, nil))
+
+			t.Run("keeps recent messages", func(t *testing.T) {
+				_, err := p.PurgeMessages(ctx, time.Now().Add(-time.Hour), courier.PurgeableStatuses)
+				require.NoError(t, err)
+				for _, id := range ids {
+					_, err := p.FetchMessage(ctx, id)
+					require.NoError(t, err)
+				}
+			})
+
+			createdAt := time.Now().UTC().Add(-48 * time.Hour)
+			for _, id := range ids {
+				require.NoError(t, p.GetConnection(ctx).RawQuery(
+					"UPDATE courier_messages SET created_at = ? WHERE id = ? AND nid = ?", createdAt, id, nid).Exec())
+			}
+
+			t.Run("can not purge on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+				count, err := p.PurgeMessages(ctx, time.Now(), courier.PurgeableStatuses)
+				require.NoError(t, err)
+				assert.Zero(t, count)
+			})
+
+			t.Run("purges messages with the statuses", func(t *testing.T) {
+				_, err := p.PurgeMessages(ctx, time.Now().Add(-24*time.Hour), []courier.MessageStatus{courier.MessageStatusSent})
+				require.NoError(t, err)
+
+				_, err = p.FetchMessage(ctx, ids[0])
+				require.ErrorIs(t, err, sqlcon.ErrNoRows())
+				assertStatus(t, ids[1], courier.MessageStatusAbandoned)
+
+				_, err = p.PurgeMessages(ctx, time.Now().Add(-24*time.Hour), courier.PurgeableStatuses)
+				require.NoError(t, err)
+				for _, id := range ids[:3] {
+					_, err = p.FetchMessage(ctx, id)
+					require.ErrorIs(t, err, sqlcon.ErrNoRows())
+				}
+				assertStatus(t, ids[3], courier.MessageStatusQueued)
+			})
+		})
+
+		t.Run("case=CountMessages", func(t *testing.T) {
+			recipient := x.NewUUID().String() + "@ory.sh"
+			addMessages(t, recipient, courier.MessageStatusQueued, courier.MessageStatusSent, courier.MessageStatusCancelled)
+			m := courier.Message{Recipient: recipient, TemplateType: template.TypeLoginCodeValid}
+			require.NoError(t, p.AddMessage(ctx, &m))
+
+			count, err := p.CountMessages(ctx, courier.MessagesFilter{Recipient: recipient})
+			require.NoError(t, err)
+			assert.Equal(t, 4, count)
+
+			count, err = p.CountMessages(ctx, courier.MessagesFilter{Recipient: recipient, TemplateType: template.TypeLoginCodeValid})
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+
+			count, err = p.CountMessages(ctx, courier.MessagesFilter{Recipient: recipient, CreatedAfter: new(time.Now().Add(time.Hour))})
+			require.NoError(t, err)
+			assert.Zero(t, count)
+
+			t.Run("can not count on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+				count, err := p.CountMessages(ctx, courier.MessagesFilter{Recipient: recipient})
+				require.NoError(t, err)
+				assert.Zero(t, count)
+			})
+		})
+
+		t.Run("case=FindQueuedDuplicate", func(t *testing.T) {
+			recipient := x.NewUUID().String() + "@ory.sh"
+			m := courier.Message{Type: courier.MessageTypeEmail, Recipient: recipient, TemplateType: template.TypeLoginCodeValid, Subject: "subject", Body: "body"}
+			require.NoError(t, p.AddMessage(ctx, &m))
+
+			duplicate, err := p.FindQueuedDuplicate(ctx, &courier.Message{Type: courier.MessageTypeEmail, Recipient: recipient, TemplateType: template.TypeLoginCodeValid, Subject: "subject", Body: "body"})
+			require.NoError(t, err)
+			assert.Equal(t, m.ID, duplicate.ID)
+
+			duplicate, err = p.FindQueuedDuplicate(ctx, &courier.Message{Type: courier.MessageTypeEmail, Recipient: recipient, TemplateType: template.TypeLoginCodeValid, Subject: "subject", Body: "other body"})
+			require.NoError(t, err, "the content is not compared")
+			assert.Equal(t, m.ID, duplicate.ID)
+
+			_, err = p.FindQueuedDuplicate(ctx, &courier.Message{Type: courier.MessageTypeEmail, Recipient: recipient, TemplateType: template.TypeRecoveryCodeValid, Subject: "subject", Body: "body"})
+			require.ErrorIs(t, err, sqlcon.ErrNoRows())
+
+			t.Run("can not find on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+				_, err := p.FindQueuedDuplicate(ctx, &m)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows())
+			})
+
+			t.Run("ignores messages which are not queued", func(t *testing.T) {
+				require.NoError(t, p.SetMessageStatus(ctx, m.ID, courier.MessageStatusSent))
+				_, err := p.FindQueuedDuplicate(ctx, &m)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows())
+			})
+		})
+
+		t.Run("case=UpdateQueuedMessage", func(t *testing.T) {
+			m := courier.Message{Type: courier.MessageTypeEmail, Recipient: x.NewUUID().String() + "@ory.sh", Subject: "subject", Body: "body"}
+			require.NoError(t, p.AddMessage(ctx, &m))
+
+			update := courier.Message{Subject: "new subject", Body: "new body", TemplateData: []byte(`{"code":"1234"}`), RequestHeaders: []byte(`{}`)}
+			require.NoError(t, p.UpdateQueuedMessage(ctx, m.ID, &update))
+
+			actual, err := p.FetchMessage(ctx, m.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "new subject", actual.Subject)
+			assert.Equal(t, "new body", actual.Body)
+			assert.JSONEq(t, `{"code":"1234"}`, string(actual.TemplateData))
+			assert.Equal(t, courier.MessageStatusQueued, actual.Status)
+
+			t.Run("can not update on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+				require.ErrorIs(t, p.UpdateQueuedMessage(ctx, m.ID, &update), sqlcon.ErrNoRows())
+			})
+
+			t.Run("can not update messages which are not queued", func(t *testing.T) {
+				require.NoError(t, p.SetMessageStatus(ctx, m.ID, courier.MessageStatusSent))
+				require.ErrorIs(t, p.UpdateQueuedMessage(ctx, m.ID, &update), sqlcon.ErrNoRows())
+			})
+		})
+
+		t.Run("case=QueueMessage", func(t *testing.T) {
+			recipient := x.NewUUID().String() + "@ory.sh"
+
+			m := courier.Message{Type: courier.MessageTypeEmail, Recipient: recipient, Subject: "subject", Body: "body"}
+			id, err := p.QueueMessage(ctx, &m, func(ctx context.Context) (uuid.UUID, error) {
+				count, err := p.CountMessages(ctx, courier.MessagesFilter{Recipient: recipient})
+				require.NoError(t, err)
+				assert.Zero(t, count)
+				return uuid.Nil, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, m.ID, id)
+
+			t.Run("returns the existing message", func(t *testing.T) {
+				other := courier.Message{Type: courier.MessageTypeEmail, Recipient: recipient, Subject: "subject", Body: "body"}
+				id, err := p.QueueMessage(ctx, &other, func(context.Context) (uuid.UUID, error) {
+					return m.ID, nil
+				})
+				require.NoError(t, err)
+				assert.Equal(t, m.ID, id)
+			})
+
+			t.Run("does not queue refused messages", func(t *testing.T) {
+				refused := errors.New("refused")
+				other := courier.Message{Type: courier.MessageTypeEmail, Recipient: recipient, Subject: "subject", Body: "body"}
+				_, err := p.QueueMessage(ctx, &other, func(context.Context) (uuid.UUID, error) {
+					return uuid.Nil, refused
+				})
+				require.ErrorIs(t, err, refused)
+			})
+
+			count, err := p.CountMessages(ctx, courier.MessagesFilter{Recipient: recipient})
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+		})
+
+		t.Run("case=LeaseMessages", func(t *testing.T) {
+			nid, p := newNetwork(t, ctx)
+
+			m := courier.Message{Type: courier.MessageTypeEmail, Recipient: x.NewUUID().String() + "@ory.sh", Subject: "subject", Body: "body"}
+			require.NoError(t, p.AddMessage(ctx, &m))
+			createdAt := time.Now().UTC().Add(-2 * time.Second)
+			require.NoError(t, p.GetConnection(ctx).RawQuery(
+				"UPDATE courier_messages SET created_at = ? WHERE id = ? AND nid = ?",
+				createdAt, m.ID, nid).Exec())
+
+			leased, err := p.LeaseMessages(ctx, 10, "worker-a", time.Minute)
+			require.NoError(t, err)
+			require.Len(t, leased, 1)
+			assert.Equal(t, m.ID, leased[0].ID)
+			assert.Equal(t, courier.MessageStatusProcessing, leased[0].Status)
+			assert.EqualValues(t, "worker-a", leased[0].WorkerID)
+			require.NotNil(t, leased[0].LockedUntil)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), time.Time(*leased[0].LockedUntil), 10*time.Second)
+
+			t.Run("does not lease messages leased by another worker", func(t *testing.T) {
+				_, err := p.LeaseMessages(ctx, 10, "worker-b", time.Minute)
+				require.ErrorIs(t, err, courier.ErrQueueEmpty)
+			})
+
+			t.Run("reclaims messages whose lease expired", func(t *testing.T) {
+				require.NoError(t, p.GetConnection(ctx).RawQuery(
+					"UPDATE courier_messages SET locked_until = ? WHERE id = ? AND nid = ?",
+					time.Now().UTC().Add(-time.Second), m.ID, nid).Exec())
+
+				leased, err := p.LeaseMessages(ctx, 10, "worker-b", time.Minute)
+				require.NoError(t, err)
+				require.Len(t, leased, 1)
+				assert.Equal(t, m.ID, leased[0].ID)
+				assert.EqualValues(t, "worker-b", leased[0].WorkerID)
+			})
+
+			t.Run("only the worker holding the lease changes the status", func(t *testing.T) {
+				err := p.SetLeasedMessageStatus(ctx, m.ID, "worker-a", courier.MessageStatusSent)
+				require.ErrorIs(t, err, courier.ErrMessageLeaseLost)
+
+				actual, err := p.FetchMessage(ctx, m.ID)
+				require.NoError(t, err)
+				assert.Equal(t, courier.MessageStatusProcessing, actual.Status)
+				assert.EqualValues(t, "worker-b", actual.WorkerID)
+
+				require.NoError(t, p.SetLeasedMessageStatus(ctx, m.ID, "worker-b", courier.MessageStatusQueued))
+
+				actual, err = p.FetchMessage(ctx, m.ID)
+				require.NoError(t, err)
+				assert.Equal(t, courier.MessageStatusQueued, actual.Status)
+				assert.Empty(t, actual.WorkerID)
+				assert.Nil(t, actual.LockedUntil)
+
+				err = p.SetLeasedMessageStatus(ctx, m.ID, "worker-b", courier.MessageStatusSent)
+				require.ErrorIs(t, err, courier.ErrMessageLeaseLost)
+
+				leased, err := p.LeaseMessages(ctx, 10, "worker-b", time.Minute)
+				require.NoError(t, err)
+				require.Len(t, leased, 1)
+			})
+
+			t.Run("ends the lease when the status changes", func(t *testing.T) {
+				require.NoError(t, p.SetMessageStatus(ctx, m.ID, courier.MessageStatusSent))
+
+				actual, err := p.FetchMessage(ctx, m.ID)
+				require.NoError(t, err)
+				assert.Empty(t, actual.WorkerID)
+				assert.Nil(t, actual.LockedUntil)
+
+				_, err = p.LeaseMessages(ctx, 10, "worker-b", time.Minute)
+				require.ErrorIs(t, err, courier.ErrQueueEmpty)
+			})
+		})
 	}
 }
@@ -0,0 +1,122 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/urlx"
+)
+
+const defaultTwilioURL = "https://api.twilio.com"
+
+// twilioPermanentErrors are the Twilio error codes which are caused by the
+// message or the recipient, and which therefore will not go away on retry.
+//
+// See https://www.twilio.com/docs/api/errors
+var twilioPermanentErrors = map[int]bool{
+	21211: true, // Invalid 'To' phone number
+	21214: true, // 'To' phone number cannot be reached
+	21217: true, // Phone number does not appear to be valid
+	21408: true, // Permission to send an SMS has not been enabled for the region
+	21610: true, // Attempt to send to unsubscribed recipient
+	21612: true, // The 'To' phone number is not currently reachable
+	21614: true, // 'To' number is not a valid mobile number
+	21617: true, // The concatenated message body exceeds the 1600 character limit
+	21635: true, // 'To' number cannot be a landline
+}
+
+type twilioChannel struct {
+	id     string
+	config *config.TwilioConfig
+	d      channelDependencies
+}
+
+var _ Channel = new(twilioChannel)
+
+func newTwilioChannel(id string, c *config.TwilioConfig, d channelDependencies) (*twilioChannel, error) {
+	if c == nil {
+		return nil, errors.Errorf("courier channel %s has type twilio but no twilio_config", id)
+	}
+	return &twilioChannel{id: id, config: c, d: d}, nil
+}
+
+func (c *twilioChannel) ID() string {
+	return c.id
+}
+
+func (c *twilioChannel) Dispatch(ctx context.Context, msg Message) (err error) {
+	ctx, span := c.d.Tracer(ctx).Tracer().Start(ctx, "courier.twilioChannel.Dispatch")
+	defer otelx.End(span, &err)
+
+	if err := smsOnly("twilio", msg); err != nil {
+		return err
+	}
+
+	base, err := url.Parse(cmp.Or(c.config.URL, defaultTwilioURL))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	endpoint := urlx.AppendPaths(base, "2010-04-01", "Accounts", c.config.AccountSID, "Messages.json")
+
+	form := url.Values{"To": {msg.Recipient}, "Body": {msg.Body}}
+	if c.config.MessagingServiceSID != "" {
+		form.Set("MessagingServiceSid", c.config.MessagingServiceSID)
+	} else {
+		form.Set("From", c.config.From)
+	}
+	if cfg := c.d.CourierConfig(); cfg.CourierDeliveryStatusEnabled(ctx) {
+		form.Set("StatusCallback", deliveryStatusCallbackURL(ctx, cfg, "twilio", c.id, msg.ID))
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.SetBasicAuth(c.config.AccountSID, c.config.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	status, body, err := doProviderRequest(ctx, c.d, req)
+	if err != nil {
+		return err
+	}
+	if status >= 200 && status < 300 {
+		c.d.Logger().WithField("message_id", msg.ID).Debug("Courier sent out message via Twilio.")
+		return nil
+	}
+
+	return errors.WithStack(twilioError(status, body))
+}
+
+func twilioError(status int, body []byte) *ProviderError {
+	var res struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &res)
+
+	e := &ProviderError{
+		Provider:   "twilio",
+		Code:       strconv.Itoa(res.Code),
+		Message:    cmp.Or(res.Message, http.StatusText(status)),
+		HTTPStatus: status,
+		Permanent:  twilioPermanentErrors[res.Code],
+	}
+	if res.Code == 0 {
+		e.Code = strconv.Itoa(status)
+	}
+	return e
+}
@@ -0,0 +1,142 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package courier
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/urlx"
+)
+
+const defaultVonageURL = "https://rest.nexmo.com"
+
+// vonagePermanentErrors are the Vonage SMS API status codes which are caused
+// by the message or the recipient, and which therefore will not go away on
+// retry.
+//
+// See https://developer.vonage.com/en/messaging/sms/guides/troubleshooting-sms
+var vonagePermanentErrors = map[string]bool{
+	"2":  true, // Missing Parameters
+	"3":  true, // Invalid Parameters
+	"6":  true, // Invalid Message
+	"7":  true, // Number Barred
+	"12": true, // Message Too Long
+	"22": true, // Invalid Network Code
+	"29": true, // Non-Whitelisted Destination
+	"33": true, // Number De-activated
+}
+
+type vonageChannel struct {
+	id     string
+	config *config.VonageConfig
+	d      channelDependencies
+}
+
+var _ Channel = new(vonageChannel)
+
+func newVonageChannel(id string, c *config.VonageConfig, d channelDependencies) (*vonageChannel, error) {
+	if c == nil {
+		return nil, errors.Errorf("courier channel %s has type vonage but no vonage_config", id)
+	}
+	return &vonageChannel{id: id, config: c, d: d}, nil
+}
+
+func (c *vonageChannel) ID() string {
+	return c.id
+}
+
+func (c *vonageChannel) Dispatch(ctx context.Context, msg Message) (err error) {
+	ctx, span := c.d.Tracer(ctx).Tracer().Start(ctx, "courier.vonageChannel.Dispatch")
+	defer otelx.End(span, &err)
+
+	if err := smsOnly("vonage", msg); err != nil {
+		return err
+	}
+
+	base, err := url.Parse(cmp.Or(c.config.URL, defaultVonageURL))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	endpoint := urlx.AppendPaths(base, "sms", "json")
+
+	// Vonage expects phone numbers in the international format without the
+	// leading plus sign.
+	form := url.Values{
+		"api_key":    {c.config.APIKey},
+		"api_secret": {c.config.APISecret},
+		"from":       {c.config.From},
+		"to":         {strings.TrimPrefix(msg.Recipient, "+")},
+		"text":       {msg.Body},
+		"type":       {"unicode"},
+	}
+	// Delivery receipts are authenticated by their signature, so they are
+	// only requested if the signature secret is known.
+	if cfg := c.d.CourierConfig(); cfg.CourierDeliveryStatusEnabled(ctx) && c.config.SignatureSecret != "" {
+		// Vonage echoes the client reference in the delivery receipt.
+		form.Set("client-ref", msg.ID.String())
+		form.Set("callback", deliveryStatusCallbackURL(ctx, cfg, "vonage", c.id, uuid.Nil))
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	status, body, err := doProviderRequest(ctx, c.d, req)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return errors.WithStack(&ProviderError{
+			Provider:   "vonage",
+			Code:       strconv.Itoa(status),
+			Message:    http.StatusText(status),
+			HTTPStatus: status,
+		})
+	}
+
+	// The SMS API responds with 200 OK and reports errors per message part.
+	var res struct {
+		Messages []struct {
+			Status    string `json:"status"`
+			ErrorText string `json:"error-text"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return errors.Wrap(err, "unable to decode the response of the Vonage SMS API")
+	}
+	if len(res.Messages) == 0 {
+		return errors.New("the Vonage SMS API did not return a message status")
+	}
+
+	for _, m := range res.Messages {
+		if m.Status == "0" {
+			continue
+		}
+		return errors.WithStack(&ProviderError{
+			Provider:   "vonage",
+			Code:       m.Status,
+			Message:    m.ErrorText,
+			HTTPStatus: status,
+			Permanent:  vonagePermanentErrors[m.Status],
+		})
+	}
+
+	c.d.Logger().WithField("message_id", msg.ID).Debug("Courier sent out message via Vonage.")
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"net/url"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -57,6 +58,8 @@ const (
 	ViperKeyCourierSMTPClientCertPath                        = "courier.smtp.client_cert_path"
 	ViperKeyCourierSMTPClientKeyPath                         = "courier.smtp.client_key_path"
 	ViperKeyCourierTemplatesPath                             = "courier.template_override_path"
+	ViperKeyCourierLocaleIdentityTrait                       = "courier.locale.identity_trait"
+	ViperKeyCourierLocaleDefault                             = "courier.locale.default"
 	ViperKeyCourierTemplatesRecoveryInvalidEmail             = "courier.templates.recovery.invalid.email"
 	ViperKeyCourierTemplatesRecoveryValidEmail               = "courier.templates.recovery.valid.email"
 	ViperKeyCourierTemplatesRecoveryCodeInvalidEmail         = "courier.templates.recovery_code.invalid.email"
@@ -73,6 +76,19 @@ const (
 	ViperKeyCourierTemplatesVerifiableAddressChangedSMS      = "courier.templates.verifiable_address_changed.sms"
 	ViperKeyCourierTemplatesAuthenticatorKeyAddedEmail       = "courier.templates.authenticator_key_added.email"
 	ViperKeyCourierTemplatesAuthenticatorKeyAddedSMS         = "courier.templates.authenticator_key_added.sms"
+	ViperKeyCourierTemplatesDormantAccountWarningEmail       = "courier.templates.dormant_account_warning.email"
+	ViperKeyCourierTemplatesLoginNewDeviceEmail              = "courier.templates.login_new_device.email"
+	ViperKeyCourierTemplatesLoginNewDeviceSMS                = "courier.templates.login_new_device.sms"
+	ViperKeyCourierTemplatesPasswordChangedEmail             = "courier.templates.password_changed.email"
+	ViperKeyCourierTemplatesPasswordChangedSMS               = "courier.templates.password_changed.sms"
+	ViperKeyCourierTemplatesTOTPRemovedEmail                 = "courier.templates.totp_removed.email"
+	ViperKeyCourierTemplatesTOTPRemovedSMS                   = "courier.templates.totp_removed.sms"
+	ViperKeyCourierTemplatesLookupSecretsRegeneratedEmail    = "courier.templates.lookup_secrets_regenerated.email"
+	ViperKeyCourierTemplatesLookupSecretsRegeneratedSMS      = "courier.templates.lookup_secrets_regenerated.sms"
+	ViperKeyCourierTemplatesOIDCProviderUnlinkedEmail        = "courier.templates.oidc_provider_unlinked.email"
+	ViperKeyCourierTemplatesOIDCProviderUnlinkedSMS          = "courier.templates.oidc_provider_unlinked.sms"
+	ViperKeyCourierTemplatesSessionsRevokedEmail             = "courier.templates.sessions_revoked.email"
+	ViperKeyCourierTemplatesSessionsRevokedSMS               = "courier.templates.sessions_revoked.sms"
 	ViperKeyCourierDeliveryStrategy                          = "courier.delivery_strategy"
 	ViperKeyCourierHTTPRequestConfig                         = "courier.http.request_config"
 	ViperKeyCourierTemplatesLoginCodeValidEmail              = "courier.templates.login_code.valid.email"
@@ -82,10 +98,23 @@ const (
 	ViperKeyCourierSMTPFromName                              = "courier.smtp.from_name"
 	ViperKeyCourierSMTPHeaders                               = "courier.smtp.headers"
 	ViperKeyCourierSMTPLocalName                             = "courier.smtp.local_name"
+	ViperKeyCourierSMTPDKIM                                  = "courier.smtp.dkim"
+	ViperKeyCourierSMTPSMIME                                 = "courier.smtp.smime"
 	ViperKeyCourierMessageRetries                            = "courier.message_retries"
 	ViperKeyCourierWorkerPullCount                           = "courier.worker.pull_count"
 	ViperKeyCourierWorkerPullWait                            = "courier.worker.pull_wait"
+	ViperKeyCourierWorkerLeaseDuration                       = "courier.worker.lease_duration"
+	ViperKeyCourierWorkerID                                  = "courier.worker.id"
 	ViperKeyCourierChannels                                  = "courier.channels"
+	ViperKeyCourierDeliveryStatusEnabled                     = "courier.delivery_status.enabled"
+	ViperKeyCourierDeliveryStatusSecret                      = "courier.delivery_status.secret"
+	ViperKeyCourierDeliveryStatusMarkUndeliverable           = "courier.delivery_status.mark_undeliverable"
+	ViperKeyCourierRateLimitEnabled                          = "courier.rate_limit.enabled"
+	ViperKeyCourierRateLimitMaxPerRecipient                  = "courier.rate_limit.max_per_recipient"
+	ViperKeyCourierRateLimitWindow                           = "courier.rate_limit.window"
+	ViperKeyCourierRateLimitTemplateTypes                    = "courier.rate_limit.template_types"
+	ViperKeyCourierDeduplicationEnabled                      = "courier.deduplication.enabled"
+	ViperKeyCourierSecurityNotificationsEnabled              = "courier.security_notifications.enabled"
 	ViperKeySecretsDefault                                   = "secrets.default"
 	ViperKeySecretsCookie                                    = "secrets.cookie"
 	ViperKeySecretsCipher                                    = "secrets.cipher"
@@ -93,6 +122,7 @@ const (
 	ViperKeyPublicBaseURL                                    = "serve.public.base_url"
 	ViperKeyAdminBaseURL                                     = "serve.admin.base_url"
 	ViperKeySessionLifespan                                  = "session.lifespan"
+	ViperKeySessionIdleTimeout                               = "session.idle_timeout"
 	ViperKeySessionSameSite                                  = "session.cookie.same_site"
 	ViperKeySessionSecure                                    = "session.cookie.secure"
 	ViperKeySessionDomain                                    = "session.cookie.domain"
@@ -112,6 +142,13 @@ const (
 	ViperKeyLegacyAllowInsecureOrigins                       = "feature_flags.legacy_allow_insecure_origins"
 	ViperKeyRefreshLoginChooseAddress                        = "feature_flags.refresh_login_choose_address"
 	ViperKeySessionRefreshMinTimeLeft                        = "session.earliest_possible_extend"
+	ViperKeySessionImpersonationLifespan                     = "session.impersonation.lifespan"
+	ViperKeySessionBindingMode                               = "session.binding.mode"
+	ViperKeySessionBindingAttributes                         = "session.binding.attributes"
+	ViperKeySessionGeoIPProvider                             = "session.geoip.provider"
+	ViperKeySessionGeoIPCityDatabase                         = "session.geoip.mmdb.city_database"
+	ViperKeySessionGeoIPASNDatabase                          = "session.geoip.mmdb.asn_database"
+	ViperKeySessionGeoIPHeaderFallback                       = "session.geoip.mmdb.header_fallback"
 	ViperKeyCookieSameSite                                   = "cookies.same_site"
 	ViperKeyCookieDomain                                     = "cookies.domain"
 	ViperKeyCookiePath                                       = "cookies.path"
@@ -131,10 +168,20 @@ const (
 	ViperKeySelfServiceLoginFlowStyle                        = "selfservice.flows.login.style"
 	ViperKeySecurityAccountEnumerationMitigate               = "security.account_enumeration.mitigate"
 	ViperKeySecurityDisallowRefInIdentitySchemas             = "security.disallow_ref_in_identity_schemas"
+	ViperKeySecurityBruteForceProtectionEnabled              = "security.brute_force_protection.enabled"
+	ViperKeySecurityBruteForceProtectionIdentifierAttempts   = "security.brute_force_protection.max_failed_attempts_per_identifier"
+	ViperKeySecurityBruteForceProtectionIPAttempts           = "security.brute_force_protection.max_failed_attempts_per_ip"
+	ViperKeySecurityBruteForceProtectionAttemptWindow        = "security.brute_force_protection.attempt_window"
+	ViperKeySecurityBruteForceProtectionLockoutDuration      = "security.brute_force_protection.lockout_duration"
+	ViperKeySecurityBruteForceProtectionMaxLockoutDuration   = "security.brute_force_protection.max_lockout_duration"
+	ViperKeySecurityAuditLogEnabled                          = "security.audit_log.enabled"
+	ViperKeySecurityAuditLogEvents                           = "security.audit_log.events"
+	ViperKeySecurityAuditLogRetention                        = "security.audit_log.retention"
 	ViperKeySelfServiceLoginRequestLifespan                  = "selfservice.flows.login.lifespan"
 	ViperKeySelfServiceLoginAfter                            = "selfservice.flows.login.after"
 	ViperKeySelfServiceLoginBeforeHooks                      = "selfservice.flows.login.before.hooks"
 	ViperKeySelfServiceErrorUI                               = "selfservice.flows.error.ui_url"
+	ViperKeySelfServiceSessionRevocationUI                   = "selfservice.flows.session_revocation.ui_url"
 	ViperKeySelfServiceLogoutBrowserDefaultReturnTo          = "selfservice.flows.logout.after." + DefaultBrowserReturnURL
 	ViperKeySelfServiceLogoutClearBrowserData                = "selfservice.flows.logout.clear_browser_data"
 	ViperKeySelfServiceSettingsURL                           = "selfservice.flows.settings.ui_url"
@@ -161,6 +208,22 @@ const (
 	ViperKeySelfServiceVerificationNotifyUnknownRecipients   = "selfservice.flows.verification.notify_unknown_recipients"
 	ViperKeyDefaultIdentitySchemaID                          = "identity.default_schema_id"
 	ViperKeyIdentitySchemas                                  = "identity.schemas"
+	ViperKeyIdentityDormancyEnabled                          = "identity.dormancy.enabled"
+	ViperKeyIdentityDormancyDeactivateAfter                  = "identity.dormancy.deactivate_after"
+	ViperKeyIdentityDormancyWarnBefore                       = "identity.dormancy.warn_before"
+	ViperKeyIdentityDormancyDeleteAfter                      = "identity.dormancy.delete_after"
+	ViperKeyIdentityDormancyBatchSize                        = "identity.dormancy.batch_size"
+	ViperKeyIdentityDormancyWorkerInterval                   = "identity.dormancy.worker_interval"
+	ViperKeyOutboxEnabled                                    = "outbox.enabled"
+	ViperKeyOutboxSource                                     = "outbox.source"
+	ViperKeyOutboxHTTPRequestConfig                          = "outbox.http.request_config"
+	ViperKeyOutboxRetryMaxAttempts                           = "outbox.retry.max_attempts"
+	ViperKeyOutboxRetryInitialInterval                       = "outbox.retry.initial_interval"
+	ViperKeyOutboxRetryMaxInterval                           = "outbox.retry.max_interval"
+	ViperKeyOutboxWorkerPullCount                            = "outbox.worker.pull_count"
+	ViperKeyOutboxWorkerPullWait                             = "outbox.worker.pull_wait"
+	ViperKeyOutboxWorkerLeaseDuration                        = "outbox.worker.lease_duration"
+	ViperKeyOutboxRetention                                  = "outbox.retention"
 	ViperKeyHasherAlgorithm                                  = "hashers.algorithm"
 	ViperKeyHasherArgon2ConfigMemory                         = "hashers.argon2.memory"
 	ViperKeyHasherArgon2ConfigIterations                     = "hashers.argon2.iterations"
@@ -187,6 +250,8 @@ const (
 	ViperKeyPasswordRegistrationProfileGroup                 = "selfservice.methods.password.config.password_profile_registration_node_group"
 	ViperKeyTOTPIssuer                                       = "selfservice.methods.totp.config.issuer"
 	ViperKeyOIDCBaseRedirectURL                              = "selfservice.methods.oidc.config.base_redirect_uri"
+	ViperKeyOIDCDiscoveryCacheFreshFor                       = "selfservice.methods.oidc.config.discovery_cache.fresh_for"
+	ViperKeyOIDCDiscoveryCacheExpiresAfter                   = "selfservice.methods.oidc.config.discovery_cache.expires_after"
 	ViperKeySAMLBaseRedirectURL                              = "selfservice.methods.saml.config.base_redirect_uri"
 	ViperKeyWebAuthnRPDisplayName                            = "selfservice.methods.webauthn.config.rp.display_name"
 	ViperKeyWebAuthnRPID                                     = "selfservice.methods.webauthn.config.rp.id"
@@ -277,11 +342,13 @@ type (
 		HTML      string `json:"html"`
 	}
 	CourierEmailTemplate struct {
-		Body    *CourierEmailBodyTemplate `json:"body"`
-		Subject string                    `json:"subject"`
+		Body    *CourierEmailBodyTemplate        `json:"body"`
+		Subject string                           `json:"subject"`
+		Locales map[string]*CourierEmailTemplate `json:"locales,omitempty"`
 	}
 	CourierSMSTemplate struct {
-		Body *CourierSMSTemplateBody `json:"body"`
+		Body    *CourierSMSTemplateBody        `json:"body"`
+		Locales map[string]*CourierSMSTemplate `json:"locales,omitempty"`
 	}
 	CourierSMSTemplateBody struct {
 		PlainText string `json:"plaintext"`
@@ -291,6 +358,56 @@ type (
 		Type          string         `json:"type" koanf:"type"`
 		SMTPConfig    *SMTPConfig    `json:"smtp_config" koanf:"smtp_config"`
 		RequestConfig request.Config `json:"request_config" koanf:"request_config"`
+		TwilioConfig  *TwilioConfig  `json:"twilio_config" koanf:"twilio_config"`
+		VonageConfig  *VonageConfig  `json:"vonage_config" koanf:"vonage_config"`
+		SNSConfig     *SNSConfig     `json:"sns_config" koanf:"sns_config"`
+
+		// Fallback lists the IDs of the channels which are tried in order if
+		// dispatching the message via this channel fails.
+		Fallback []string `json:"fallback" koanf:"fallback"`
+		// Routes optionally route messages addressed to this channel to other
+		// channels, by recipient prefix and weight.
+		Routes []CourierChannelRoute `json:"routes" koanf:"routes"`
+	}
+	CourierChannelRoute struct {
+		Channel           string   `json:"channel" koanf:"channel"`
+		RecipientPrefixes []string `json:"recipient_prefixes" koanf:"recipient_prefixes"`
+		Weight            int      `json:"weight" koanf:"weight"`
+	}
+	CourierRateLimit struct {
+		Enabled         bool                   `json:"enabled" koanf:"enabled"`
+		MaxPerRecipient int                    `json:"max_per_recipient" koanf:"max_per_recipient"`
+		Window          time.Duration          `json:"window" koanf:"window"`
+		TemplateTypes   []CourierTemplateQuota `json:"template_types" koanf:"template_types"`
+	}
+	CourierTemplateQuota struct {
+		TemplateType string        `json:"template_type" koanf:"template_type"`
+		Max          int           `json:"max" koanf:"max"`
+		Window       time.Duration `json:"window" koanf:"window"`
+	}
+	TwilioConfig struct {
+		URL                 string `json:"url" koanf:"url"`
+		AccountSID          string `json:"account_sid" koanf:"account_sid"`
+		AuthToken           string `json:"auth_token" koanf:"auth_token"`
+		From                string `json:"from" koanf:"from"`
+		MessagingServiceSID string `json:"messaging_service_sid" koanf:"messaging_service_sid"`
+	}
+	VonageConfig struct {
+		URL             string `json:"url" koanf:"url"`
+		APIKey          string `json:"api_key" koanf:"api_key"`
+		APISecret       string `json:"api_secret" koanf:"api_secret"`
+		From            string `json:"from" koanf:"from"`
+		SignatureSecret string `json:"signature_secret" koanf:"signature_secret"`
+		SignatureMethod string `json:"signature_method" koanf:"signature_method"`
+	}
+	SNSConfig struct {
+		URL             string `json:"url" koanf:"url"`
+		Region          string `json:"region" koanf:"region"`
+		AccessKeyID     string `json:"access_key_id" koanf:"access_key_id"`
+		SecretAccessKey string `json:"secret_access_key" koanf:"secret_access_key"`
+		SessionToken    string `json:"session_token" koanf:"session_token"`
+		SenderID        string `json:"sender_id" koanf:"sender_id"`
+		SMSType         string `json:"sms_type" koanf:"sms_type"`
 	}
 	SMTPConfig struct {
 		ConnectionURI  string            `json:"connection_uri" koanf:"connection_uri"`
@@ -300,11 +417,68 @@ type (
 		FromName       string            `json:"from_name" koanf:"from_name"`
 		Headers        map[string]string `json:"headers" koanf:"headers"`
 		LocalName      string            `json:"local_name" koanf:"local_name"`
+		DKIM           *SMTPDKIMConfig   `json:"dkim" koanf:"dkim"`
+		SMIME          *SMTPSMIMEConfig  `json:"smime" koanf:"smime"`
+	}
+	SMTPDKIMConfig struct {
+		Domain         string `json:"domain" koanf:"domain"`
+		Selector       string `json:"selector" koanf:"selector"`
+		PrivateKeyPath string `json:"private_key_path" koanf:"private_key_path"`
+		// Headers are the names of the header fields which are signed. If
+		// empty, a default set of header fields is signed.
+		Headers []string `json:"headers" koanf:"headers"`
+	}
+	SMTPSMIMEConfig struct {
+		CertificatePath string `json:"certificate_path" koanf:"certificate_path"`
+		PrivateKeyPath  string `json:"private_key_path" koanf:"private_key_path"`
 	}
 	PasswordMigrationHook struct {
 		Enabled bool           `json:"enabled" koanf:"enabled"`
 		Config  request.Config `json:"config" koanf:"config"`
 	}
+	BruteForceProtection struct {
+		Enabled                        bool          `json:"enabled"`
+		MaxFailedAttemptsPerIdentifier int           `json:"max_failed_attempts_per_identifier"`
+		MaxFailedAttemptsPerIP         int           `json:"max_failed_attempts_per_ip"`
+		AttemptWindow                  time.Duration `json:"attempt_window"`
+		LockoutDuration                time.Duration `json:"lockout_duration"`
+		MaxLockoutDuration             time.Duration `json:"max_lockout_duration"`
+	}
+	SessionBinding struct {
+		Mode       string   `json:"mode"`
+		Attributes []string `json:"attributes"`
+	}
+	SessionGeoIP struct {
+		Provider       string `json:"provider"`
+		CityDatabase   string `json:"city_database"`
+		ASNDatabase    string `json:"asn_database"`
+		HeaderFallback bool   `json:"header_fallback"`
+	}
+	AuditLog struct {
+		Enabled   bool          `json:"enabled"`
+		Events    []string      `json:"events"`
+		Retention time.Duration `json:"retention"`
+	}
+	IdentityDormancy struct {
+		Enabled         bool          `json:"enabled"`
+		DeactivateAfter time.Duration `json:"deactivate_after"`
+		WarnBefore      time.Duration `json:"warn_before"`
+		DeleteAfter     time.Duration `json:"delete_after"`
+		BatchSize       int           `json:"batch_size"`
+		WorkerInterval  time.Duration `json:"worker_interval"`
+	}
+	Outbox struct {
+		Enabled         bool           `json:"enabled"`
+		Source          string         `json:"source"`
+		RequestConfig   request.Config `json:"request_config"`
+		MaxAttempts     int            `json:"max_attempts"`
+		InitialInterval time.Duration  `json:"initial_interval"`
+		MaxInterval     time.Duration  `json:"max_interval"`
+		PullCount       int            `json:"pull_count"`
+		PullWait        time.Duration  `json:"pull_wait"`
+		LeaseDuration   time.Duration  `json:"lease_duration"`
+		Retention       time.Duration  `json:"retention"`
+	}
 	Config struct {
 		l                  *logrusx.Logger
 		p                  *configx.Provider
@@ -317,6 +491,8 @@ type (
 	}
 	CourierConfigs interface {
 		CourierTemplatesRoot(ctx context.Context) string
+		CourierLocaleIdentityTrait(ctx context.Context) string
+		CourierDefaultLocale(ctx context.Context) string
 		CourierTemplatesVerificationInvalid(ctx context.Context) *CourierEmailTemplate
 		CourierTemplatesVerificationValid(ctx context.Context) *CourierEmailTemplate
 		CourierTemplatesRecoveryInvalid(ctx context.Context) *CourierEmailTemplate
@@ -335,11 +511,33 @@ type (
 		CourierSMSTemplatesVerifiableAddressChanged(ctx context.Context) *CourierSMSTemplate
 		CourierTemplatesAuthenticatorKeyAdded(ctx context.Context) *CourierEmailTemplate
 		CourierSMSTemplatesAuthenticatorKeyAdded(ctx context.Context) *CourierSMSTemplate
+		CourierTemplatesDormantAccountWarning(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesLoginNewDevice(ctx context.Context) *CourierEmailTemplate
+		CourierSMSTemplatesLoginNewDevice(ctx context.Context) *CourierSMSTemplate
+		CourierTemplatesPasswordChanged(ctx context.Context) *CourierEmailTemplate
+		CourierSMSTemplatesPasswordChanged(ctx context.Context) *CourierSMSTemplate
+		CourierTemplatesTOTPRemoved(ctx context.Context) *CourierEmailTemplate
+		CourierSMSTemplatesTOTPRemoved(ctx context.Context) *CourierSMSTemplate
+		CourierTemplatesLookupSecretsRegenerated(ctx context.Context) *CourierEmailTemplate
+		CourierSMSTemplatesLookupSecretsRegenerated(ctx context.Context) *CourierSMSTemplate
+		CourierTemplatesOIDCProviderUnlinked(ctx context.Context) *CourierEmailTemplate
+		CourierSMSTemplatesOIDCProviderUnlinked(ctx context.Context) *CourierSMSTemplate
+		CourierTemplatesSessionsRevoked(ctx context.Context) *CourierEmailTemplate
+		CourierSMSTemplatesSessionsRevoked(ctx context.Context) *CourierSMSTemplate
 		CourierMessageRetries(ctx context.Context) int
 		CourierWorkerPullCount(ctx context.Context) int
 		CourierWorkerPullWait(ctx context.Context) time.Duration
+		CourierWorkerLeaseDuration(ctx context.Context) time.Duration
+		CourierWorkerID(ctx context.Context) string
 		CourierChannels(context.Context) ([]*CourierChannel, error)
+		CourierDeliveryStatusEnabled(ctx context.Context) bool
+		CourierDeliveryStatusSecret(ctx context.Context) string
+		CourierDeliveryStatusMarkUndeliverable(ctx context.Context) bool
+		CourierRateLimit(ctx context.Context) (*CourierRateLimit, error)
+		CourierDeduplicationEnabled(ctx context.Context) bool
+		CourierSecurityNotificationsEnabled(ctx context.Context) bool
 		ClientSMTPNoPrivateIPRanges(ctx context.Context) bool
+		SelfPublicURL(ctx context.Context) *url.URL
 	}
 )
 
@@ -641,6 +839,18 @@ func (p *Config) OIDCRedirectURIBase(ctx context.Context) *url.URL {
 	return p.GetProvider(ctx).URIF(ViperKeyOIDCBaseRedirectURL, p.SelfPublicURL(ctx))
 }
 
+// OIDCDiscoveryCacheFreshFor returns how long cached discovery documents and
+// JSON Web Key Sets are used before they are refreshed in the background.
+func (p *Config) OIDCDiscoveryCacheFreshFor(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyOIDCDiscoveryCacheFreshFor, time.Hour)
+}
+
+// OIDCDiscoveryCacheExpiresAfter returns how long cached discovery documents
+// and JSON Web Key Sets are used at most if they can not be refreshed.
+func (p *Config) OIDCDiscoveryCacheExpiresAfter(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyOIDCDiscoveryCacheExpiresAfter, 24*time.Hour)
+}
+
 func (p *Config) SAMLRedirectURIBase(ctx context.Context) *url.URL {
 	return p.GetProvider(ctx).URIF(ViperKeySAMLBaseRedirectURL, p.SelfPublicURL(ctx))
 }
@@ -1034,11 +1244,47 @@ func (p *Config) SelfServiceFlowRecoveryUI(ctx context.Context) *url.URL {
 	return p.ParseAbsoluteOrRelativeURIOrFail(ctx, ViperKeySelfServiceRecoveryUI)
 }
 
+func (p *Config) SelfServiceFlowSessionRevocationUI(ctx context.Context) *url.URL {
+	return p.ParseAbsoluteOrRelativeURIOrFail(ctx, ViperKeySelfServiceSessionRevocationUI)
+}
+
 // SessionLifespan returns time.Hour*24 when the value is not set.
 func (p *Config) SessionLifespan(ctx context.Context) time.Duration {
 	return p.GetProvider(ctx).DurationF(ViperKeySessionLifespan, time.Hour*24)
 }
 
+// SessionIdleTimeout returns how long a session may be unused before it is no
+// longer active. It returns 0, which disables the idle timeout, when the value
+// is not set.
+func (p *Config) SessionIdleTimeout(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySessionIdleTimeout, 0)
+}
+
+// SessionImpersonationLifespan returns how long sessions issued through the
+// admin impersonation endpoint are active. It returns time.Hour when the
+// value is not set.
+func (p *Config) SessionImpersonationLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeySessionImpersonationLifespan, time.Hour)
+}
+
+func (p *Config) SessionBinding(ctx context.Context) *SessionBinding {
+	pp := p.GetProvider(ctx)
+	return &SessionBinding{
+		Mode:       pp.StringF(ViperKeySessionBindingMode, "off"),
+		Attributes: pp.StringsF(ViperKeySessionBindingAttributes, []string{"country", "asn", "user_agent", "client_hints"}),
+	}
+}
+
+func (p *Config) SessionGeoIP(ctx context.Context) *SessionGeoIP {
+	pp := p.GetProvider(ctx)
+	return &SessionGeoIP{
+		Provider:       pp.StringF(ViperKeySessionGeoIPProvider, "headers"),
+		CityDatabase:   pp.String(ViperKeySessionGeoIPCityDatabase),
+		ASNDatabase:    pp.String(ViperKeySessionGeoIPASNDatabase),
+		HeaderFallback: pp.Bool(ViperKeySessionGeoIPHeaderFallback),
+	}
+}
+
 // OrganizationSessionLifespan returns the effective session lifespan for a
 // session issued to the given organization. If orgID is uuid.Nil, or no
 // matching organization is configured, or the organization has no
@@ -1054,6 +1300,22 @@ func (p *Config) OrganizationSessionLifespan(ctx context.Context, orgID uuid.UUI
 	return p.SessionLifespan(ctx)
 }
 
+// OrganizationSessionIdleTimeout returns the effective idle timeout for a
+// session issued to the given organization. If orgID is uuid.Nil, or no
+// matching organization is configured, or the organization has no
+// session_idle_timeout override, the project-level session.idle_timeout is
+// returned.
+func (p *Config) OrganizationSessionIdleTimeout(ctx context.Context, orgID uuid.UUID) time.Duration {
+	if orgID != uuid.Nil {
+		for _, org := range p.Organizations(ctx) {
+			if org.ID == orgID && org.SessionIdleTimeout > 0 {
+				return org.SessionIdleTimeout
+			}
+		}
+	}
+	return p.SessionIdleTimeout(ctx)
+}
+
 func (p *Config) SessionPersistentCookie(ctx context.Context) bool {
 	return p.GetProvider(ctx).Bool(ViperKeySessionPersistentCookie)
 }
@@ -1123,6 +1385,19 @@ func (p *Config) CourierTemplatesRoot(ctx context.Context) string {
 	return p.GetProvider(ctx).StringF(ViperKeyCourierTemplatesPath, "courier/builtin/templates")
 }
 
+// CourierLocaleIdentityTrait returns the path of the identity trait which
+// holds the locale of the identity, for example "locale" or
+// "preferences.language".
+func (p *Config) CourierLocaleIdentityTrait(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeyCourierLocaleIdentityTrait)
+}
+
+// CourierDefaultLocale returns the locale used for messages if the locale of
+// the recipient is unknown. An empty locale selects the unlocalized templates.
+func (p *Config) CourierDefaultLocale(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeyCourierLocaleDefault)
+}
+
 func (p *Config) CourierEmailTemplatesHelper(ctx context.Context, key string) *CourierEmailTemplate {
 	courierTemplate := &CourierEmailTemplate{
 		Body: &CourierEmailBodyTemplate{
@@ -1237,6 +1512,58 @@ func (p *Config) CourierSMSTemplatesAuthenticatorKeyAdded(ctx context.Context) *
 	return p.CourierSMSTemplatesHelper(ctx, ViperKeyCourierTemplatesAuthenticatorKeyAddedSMS)
 }
 
+func (p *Config) CourierTemplatesDormantAccountWarning(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesDormantAccountWarningEmail)
+}
+
+func (p *Config) CourierTemplatesLoginNewDevice(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesLoginNewDeviceEmail)
+}
+
+func (p *Config) CourierSMSTemplatesLoginNewDevice(ctx context.Context) *CourierSMSTemplate {
+	return p.CourierSMSTemplatesHelper(ctx, ViperKeyCourierTemplatesLoginNewDeviceSMS)
+}
+
+func (p *Config) CourierTemplatesPasswordChanged(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesPasswordChangedEmail)
+}
+
+func (p *Config) CourierSMSTemplatesPasswordChanged(ctx context.Context) *CourierSMSTemplate {
+	return p.CourierSMSTemplatesHelper(ctx, ViperKeyCourierTemplatesPasswordChangedSMS)
+}
+
+func (p *Config) CourierTemplatesTOTPRemoved(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesTOTPRemovedEmail)
+}
+
+func (p *Config) CourierSMSTemplatesTOTPRemoved(ctx context.Context) *CourierSMSTemplate {
+	return p.CourierSMSTemplatesHelper(ctx, ViperKeyCourierTemplatesTOTPRemovedSMS)
+}
+
+func (p *Config) CourierTemplatesLookupSecretsRegenerated(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesLookupSecretsRegeneratedEmail)
+}
+
+func (p *Config) CourierSMSTemplatesLookupSecretsRegenerated(ctx context.Context) *CourierSMSTemplate {
+	return p.CourierSMSTemplatesHelper(ctx, ViperKeyCourierTemplatesLookupSecretsRegeneratedSMS)
+}
+
+func (p *Config) CourierTemplatesOIDCProviderUnlinked(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesOIDCProviderUnlinkedEmail)
+}
+
+func (p *Config) CourierSMSTemplatesOIDCProviderUnlinked(ctx context.Context) *CourierSMSTemplate {
+	return p.CourierSMSTemplatesHelper(ctx, ViperKeyCourierTemplatesOIDCProviderUnlinkedSMS)
+}
+
+func (p *Config) CourierTemplatesSessionsRevoked(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesSessionsRevokedEmail)
+}
+
+func (p *Config) CourierSMSTemplatesSessionsRevoked(ctx context.Context) *CourierSMSTemplate {
+	return p.CourierSMSTemplatesHelper(ctx, ViperKeyCourierTemplatesSessionsRevokedSMS)
+}
+
 func (p *Config) CourierTemplatesLoginCodeValid(ctx context.Context) *CourierEmailTemplate {
 	return p.CourierEmailTemplatesHelper(ctx, ViperKeyCourierTemplatesLoginCodeValidEmail)
 }
@@ -1249,6 +1576,58 @@ func (p *Config) CourierMessageRetries(ctx context.Context) int {
 	return p.GetProvider(ctx).IntF(ViperKeyCourierMessageRetries, 5)
 }
 
+// CourierDeliveryStatusEnabled returns true if the courier requests delivery
+// receipts from the providers and accepts them on the public API.
+func (p *Config) CourierDeliveryStatusEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyCourierDeliveryStatusEnabled)
+}
+
+// CourierDeliveryStatusSecret returns the secret which authenticates the
+// delivery status callbacks.
+func (p *Config) CourierDeliveryStatusSecret(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeyCourierDeliveryStatusSecret)
+}
+
+// CourierDeliveryStatusMarkUndeliverable returns true if the verifiable
+// addresses of bounced messages and complaints are flagged as undeliverable.
+func (p *Config) CourierDeliveryStatusMarkUndeliverable(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyCourierDeliveryStatusMarkUndeliverable)
+}
+
+// CourierRateLimit returns the quotas of messages which are queued for a
+// single recipient.
+func (p *Config) CourierRateLimit(ctx context.Context) (*CourierRateLimit, error) {
+	pp := p.GetProvider(ctx)
+	rl := &CourierRateLimit{
+		Enabled:         pp.BoolF(ViperKeyCourierRateLimitEnabled, false),
+		MaxPerRecipient: pp.IntF(ViperKeyCourierRateLimitMaxPerRecipient, 10),
+		Window:          pp.DurationF(ViperKeyCourierRateLimitWindow, time.Hour),
+	}
+	if err := pp.Unmarshal(ViperKeyCourierRateLimitTemplateTypes, &rl.TemplateTypes); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for i := range rl.TemplateTypes {
+		if rl.TemplateTypes[i].Window == 0 {
+			rl.TemplateTypes[i].Window = rl.Window
+		}
+	}
+	return rl, nil
+}
+
+// CourierDeduplicationEnabled returns true if a message of the same template as
+// a message still queued for the same recipient replaces the content of the
+// queued message instead of being queued again.
+func (p *Config) CourierDeduplicationEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyCourierDeduplicationEnabled)
+}
+
+// CourierSecurityNotificationsEnabled returns true if identities are notified
+// when their password, second factors, or linked sign-in providers change, and
+// when all their other sessions are revoked.
+func (p *Config) CourierSecurityNotificationsEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool(ViperKeyCourierSecurityNotificationsEnabled)
+}
+
 func (p *Config) CourierWorkerPullCount(ctx context.Context) int {
 	return p.GetProvider(ctx).Int(ViperKeyCourierWorkerPullCount)
 }
@@ -1257,6 +1636,18 @@ func (p *Config) CourierWorkerPullWait(ctx context.Context) time.Duration {
 	return p.GetProvider(ctx).Duration(ViperKeyCourierWorkerPullWait)
 }
 
+// CourierWorkerLeaseDuration returns how long a worker holds the messages it
+// pulled from the queue before other workers may reclaim them.
+func (p *Config) CourierWorkerLeaseDuration(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyCourierWorkerLeaseDuration, 10*time.Minute)
+}
+
+// CourierWorkerID returns the configured identifier of the courier worker, or
+// an empty string if the worker should generate one.
+func (p *Config) CourierWorkerID(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeyCourierWorkerID)
+}
+
 func (p *Config) CourierSMTPHeaders(ctx context.Context) map[string]string {
 	return p.GetProvider(ctx).StringMap(ViperKeyCourierSMTPHeaders)
 }
@@ -1280,6 +1671,17 @@ func (p *Config) CourierChannels(ctx context.Context) (ccs []*CourierChannel, _
 			return nil, errors.WithStack(err)
 		}
 	}
+
+	// A channel with the ID of the email channel and without a type configures
+	// the fallback channels and routes of the email channel.
+	ccs = slices.DeleteFunc(ccs, func(c *CourierChannel) bool {
+		if c.ID != channel.ID || c.Type != "" {
+			return false
+		}
+		channel.Fallback, channel.Routes = c.Fallback, c.Routes
+		return true
+	})
+
 	ccs = append(ccs, &channel)
 	return ccs, nil
 }
@@ -1347,6 +1749,14 @@ func (p *Config) IsBackgroundCourierEnabled(ctx context.Context) bool {
 	return p.GetProvider(ctx).Bool("watch-courier")
 }
 
+func (p *Config) IsBackgroundDormancyWorkerEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool("watch-dormant-identities")
+}
+
+func (p *Config) IsBackgroundOutboxWorkerEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).Bool("watch-outbox")
+}
+
 func (p *Config) CourierExposeMetricsPort(ctx context.Context) int {
 	return p.GetProvider(ctx).Int("expose-metrics-port")
 }
@@ -1633,10 +2043,11 @@ func (p *Config) PasskeyConfig(ctx context.Context) *webauthn.Config {
 }
 
 type Organization struct {
-	ID              uuid.UUID     `koanf:"id"`
-	Domains         []string      `koanf:"domains"`
-	DefaultRegion   region.Region `koanf:"default_region"`
-	SessionLifespan time.Duration `koanf:"session_lifespan"`
+	ID                 uuid.UUID     `koanf:"id"`
+	Domains            []string      `koanf:"domains"`
+	DefaultRegion      region.Region `koanf:"default_region"`
+	SessionLifespan    time.Duration `koanf:"session_lifespan"`
+	SessionIdleTimeout time.Duration `koanf:"session_idle_timeout"`
 }
 
 func (p *Config) Organizations(ctx context.Context) (orgs []Organization) {
@@ -1730,3 +2141,62 @@ func (p *Config) SecurityAccountEnumerationMitigate(ctx context.Context) bool {
 func (p *Config) SecurityDisallowRefInIdentitySchemas(ctx context.Context) bool {
 	return p.GetProvider(ctx).Bool(ViperKeySecurityDisallowRefInIdentitySchemas)
 }
+
+func (p *Config) SecurityBruteForceProtection(ctx context.Context) *BruteForceProtection {
+	pp := p.GetProvider(ctx)
+	return &BruteForceProtection{
+		Enabled:                        pp.BoolF(ViperKeySecurityBruteForceProtectionEnabled, false),
+		MaxFailedAttemptsPerIdentifier: pp.IntF(ViperKeySecurityBruteForceProtectionIdentifierAttempts, 5),
+		MaxFailedAttemptsPerIP:         pp.IntF(ViperKeySecurityBruteForceProtectionIPAttempts, 100),
+		AttemptWindow:                  pp.DurationF(ViperKeySecurityBruteForceProtectionAttemptWindow, time.Hour),
+		LockoutDuration:                pp.DurationF(ViperKeySecurityBruteForceProtectionLockoutDuration, time.Minute),
+		MaxLockoutDuration:             pp.DurationF(ViperKeySecurityBruteForceProtectionMaxLockoutDuration, 24*time.Hour),
+	}
+}
+
+func (p *Config) SecurityAuditLog(ctx context.Context) *AuditLog {
+	pp := p.GetProvider(ctx)
+	return &AuditLog{
+		Enabled:   pp.BoolF(ViperKeySecurityAuditLogEnabled, false),
+		Events:    pp.StringsF(ViperKeySecurityAuditLogEvents, nil),
+		Retention: pp.DurationF(ViperKeySecurityAuditLogRetention, 90*24*time.Hour),
+	}
+}
+
+func (p *Config) IdentityDormancy(ctx context.Context) *IdentityDormancy {
+	pp := p.GetProvider(ctx)
+	return &IdentityDormancy{
+		Enabled:         pp.BoolF(ViperKeyIdentityDormancyEnabled, false),
+		DeactivateAfter: pp.DurationF(ViperKeyIdentityDormancyDeactivateAfter, 365*24*time.Hour),
+		WarnBefore:      pp.DurationF(ViperKeyIdentityDormancyWarnBefore, 14*24*time.Hour),
+		DeleteAfter:     pp.DurationF(ViperKeyIdentityDormancyDeleteAfter, 0),
+		BatchSize:       pp.IntF(ViperKeyIdentityDormancyBatchSize, 1000),
+		WorkerInterval:  pp.DurationF(ViperKeyIdentityDormancyWorkerInterval, time.Hour),
+	}
+}
+
+func (p *Config) OutboxEnabled(ctx context.Context) bool {
+	return p.GetProvider(ctx).BoolF(ViperKeyOutboxEnabled, false)
+}
+
+func (p *Config) Outbox(ctx context.Context) (*Outbox, error) {
+	pp := p.GetProvider(ctx)
+	c := &Outbox{
+		Enabled:         pp.BoolF(ViperKeyOutboxEnabled, false),
+		Source:          pp.StringF(ViperKeyOutboxSource, p.SelfPublicURL(ctx).String()),
+		MaxAttempts:     pp.IntF(ViperKeyOutboxRetryMaxAttempts, 10),
+		InitialInterval: pp.DurationF(ViperKeyOutboxRetryInitialInterval, 10*time.Second),
+		MaxInterval:     pp.DurationF(ViperKeyOutboxRetryMaxInterval, time.Hour),
+		PullCount:       pp.IntF(ViperKeyOutboxWorkerPullCount, 100),
+		PullWait:        pp.DurationF(ViperKeyOutboxWorkerPullWait, time.Second),
+		LeaseDuration:   pp.DurationF(ViperKeyOutboxWorkerLeaseDuration, 10*time.Minute),
+		Retention:       pp.DurationF(ViperKeyOutboxRetention, 7*24*time.Hour),
+	}
+	if err := pp.Unmarshal(ViperKeyOutboxHTTPRequestConfig, &c.RequestConfig); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if c.RequestConfig.Method == "" {
+		c.RequestConfig.Method = "POST"
+	}
+	return c, nil
+}
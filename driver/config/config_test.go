@@ -1218,6 +1218,79 @@ func TestCourierChannels(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("case=sms providers", func(t *testing.T) {
+		newConfig := func(channels string) (*config.Config, error) {
+			return config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{},
+				configx.WithConfigFiles("stub/.kratos.yaml"),
+				configx.WithValue(config.ViperKeyCourierChannels, channels))
+		}
+
+		conf, err := newConfig(`[
+			{"id": "sms", "type": "twilio", "twilio_config": {"account_sid": "AC123", "auth_token": "secret", "from": "+12065550100"}}
+		]`)
+		require.NoError(t, err)
+		cs, err := conf.CourierChannels(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, cs[0].TwilioConfig)
+		assert.Equal(t, "AC123", cs[0].TwilioConfig.AccountSID)
+		assert.Equal(t, "+12065550100", cs[0].TwilioConfig.From)
+
+		conf, err = newConfig(`[
+			{"id": "sms", "type": "sns", "sns_config": {"region": "eu-central-1", "access_key_id": "AKID", "secret_access_key": "secret", "url": "http://localhost:4566"}}
+		]`)
+		require.NoError(t, err)
+		cs, err = conf.CourierChannels(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, cs[0].SNSConfig)
+		assert.Equal(t, "http://localhost:4566", cs[0].SNSConfig.URL)
+
+		for _, tc := range []string{
+			`[{"id": "sms", "type": "twilio"}]`,
+			`[{"id": "sms", "type": "twilio", "twilio_config": {"account_sid": "AC123", "auth_token": "secret"}}]`,
+			`[{"id": "sms", "type": "vonage", "vonage_config": {"api_key": "key"}}]`,
+			`[{"id": "sms", "type": "http"}]`,
+		} {
+			_, err := newConfig(tc)
+			assert.Errorf(t, err, "%s", tc)
+		}
+	})
+
+	t.Run("case=fallback and routes", func(t *testing.T) {
+		newConfig := func(channels string) (*config.Config, error) {
+			return config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{},
+				configx.WithConfigFiles("stub/.kratos.yaml"),
+				configx.WithValue(config.ViperKeyCourierChannels, channels))
+		}
+
+		conf, err := newConfig(`[
+			{"id": "sms", "type": "twilio", "twilio_config": {"account_sid": "AC123", "auth_token": "secret", "from": "+12065550100"}, "fallback": ["sms-vonage"], "routes": [{"channel": "sms-vonage", "recipient_prefixes": ["+49"], "weight": 3}]},
+			{"id": "sms-vonage", "type": "vonage", "vonage_config": {"api_key": "key", "api_secret": "secret", "from": "Ory"}},
+			{"id": "email-http", "type": "http", "request_config": {"url": "https://example.com/email", "method": "POST"}},
+			{"id": "email", "fallback": ["email-http"]}
+		]`)
+		require.NoError(t, err)
+		cs, err := conf.CourierChannels(ctx)
+		require.NoError(t, err)
+		require.Len(t, cs, 4)
+
+		assert.Equal(t, []string{"sms-vonage"}, cs[0].Fallback)
+		assert.Equal(t, []config.CourierChannelRoute{{Channel: "sms-vonage", RecipientPrefixes: []string{"+49"}, Weight: 3}}, cs[0].Routes)
+
+		email := cs[3]
+		assert.Equal(t, "email", email.ID)
+		assert.Equal(t, "smtp", email.Type)
+		assert.Equal(t, []string{"email-http"}, email.Fallback)
+
+		for _, tc := range []string{
+			`[{"id": "sms", "fallback": ["sms-vonage"]}]`,
+			`[{"id": "email", "request_config": {"url": "https://example.com/email", "method": "POST"}}]`,
+			`[{"id": "sms", "type": "http", "request_config": {"url": "https://example.com/sms", "method": "POST"}, "routes": [{"recipient_prefixes": ["+49"]}]}]`,
+		} {
+			_, err := newConfig(tc)
+			assert.Errorf(t, err, "%s", tc)
+		}
+	})
 }
 
 func TestCourierMessageTTL(t *testing.T) {
@@ -1236,6 +1309,71 @@ func TestCourierMessageTTL(t *testing.T) {
 	})
 }
 
+func TestCourierWorkerLease(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("case=defaults", func(t *testing.T) {
+		conf, _ := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{}, configx.SkipValidation())
+		assert.Equal(t, 10*time.Minute, conf.CourierWorkerLeaseDuration(ctx))
+		assert.Empty(t, conf.CourierWorkerID(ctx))
+	})
+
+	t.Run("case=configs set", func(t *testing.T) {
+		conf, err := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{},
+			configx.WithConfigFiles("stub/.kratos.yaml"),
+			configx.WithValues(map[string]any{
+				config.ViperKeyCourierWorkerLeaseDuration: "30s",
+				config.ViperKeyCourierWorkerID:            "courier-1",
+			}))
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, conf.CourierWorkerLeaseDuration(ctx))
+		assert.Equal(t, "courier-1", conf.CourierWorkerID(ctx))
+	})
+}
+
+func TestCourierRateLimit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("case=defaults", func(t *testing.T) {
+		conf, _ := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{}, configx.SkipValidation())
+		rl, err := conf.CourierRateLimit(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &config.CourierRateLimit{MaxPerRecipient: 10, Window: time.Hour}, rl)
+		assert.False(t, conf.CourierDeduplicationEnabled(ctx))
+	})
+
+	t.Run("case=configs set", func(t *testing.T) {
+		conf, err := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{},
+			configx.WithConfigFiles("stub/.kratos.yaml"),
+			configx.WithValues(map[string]any{
+				config.ViperKeyCourierRateLimitEnabled:         true,
+				config.ViperKeyCourierRateLimitMaxPerRecipient: 5,
+				config.ViperKeyCourierRateLimitWindow:          "30m",
+				config.ViperKeyCourierRateLimitTemplateTypes: []map[string]any{
+					{"template_type": "login_code_valid", "max": 3, "window": "15m"},
+					{"template_type": "recovery_code_valid", "max": 2},
+				},
+				config.ViperKeyCourierDeduplicationEnabled: true,
+			}))
+		require.NoError(t, err)
+
+		rl, err := conf.CourierRateLimit(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &config.CourierRateLimit{
+			Enabled:         true,
+			MaxPerRecipient: 5,
+			Window:          30 * time.Minute,
+			TemplateTypes: []config.CourierTemplateQuota{
+				{TemplateType: "login_code_valid", Max: 3, Window: 15 * time.Minute},
+				{TemplateType: "recovery_code_valid", Max: 2, Window: 30 * time.Minute},
+			},
+		}, rl)
+		assert.True(t, conf.CourierDeduplicationEnabled(ctx))
+	})
+}
+
 func TestTwoStep(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -1454,6 +1592,50 @@ func TestCourierTemplatesConfig(t *testing.T) {
 		}
 		assert.Equal(t, courierTemplateConfig, c.CourierEmailTemplatesHelper(ctx, config.ViperKeyCourierTemplatesRecoveryValidEmail))
 	})
+
+	t.Run("case=localized templates", func(t *testing.T) {
+		c, err := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{},
+			configx.WithConfigFiles("stub/.kratos.yaml"),
+			configx.WithValues(map[string]any{
+				config.ViperKeyCourierLocaleIdentityTrait: "locale",
+				config.ViperKeyCourierLocaleDefault:       "en",
+				config.ViperKeyCourierTemplatesRecoveryCodeValidEmail: map[string]any{
+					"subject": "base64://c3ViamVjdA==",
+					"locales": map[string]any{
+						"de": map[string]any{
+							"subject": "base64://QmV0cmVmZg==",
+						},
+					},
+				},
+				config.ViperKeyCourierTemplatesRecoveryCodeValidSMS: map[string]any{
+					"locales": map[string]any{
+						"pt-BR": map[string]any{
+							"body": map[string]any{"plaintext": "base64://Y29ycG8="},
+						},
+					},
+				},
+			}))
+		require.NoError(t, err)
+
+		assert.Equal(t, "locale", c.CourierLocaleIdentityTrait(ctx))
+		assert.Equal(t, "en", c.CourierDefaultLocale(ctx))
+
+		email := c.CourierTemplatesRecoveryCodeValid(ctx)
+		assert.Equal(t, "base64://c3ViamVjdA==", email.Subject)
+		require.Contains(t, email.Locales, "de")
+		assert.Equal(t, "base64://QmV0cmVmZg==", email.Locales["de"].Subject)
+
+		sms := c.CourierSMSTemplatesRecoveryCodeValid(ctx)
+		require.Contains(t, sms.Locales, "pt-BR")
+		assert.Equal(t, "base64://Y29ycG8=", sms.Locales["pt-BR"].Body.PlainText)
+	})
+
+	t.Run("case=rejects invalid locales", func(t *testing.T) {
+		_, err := config.New(ctx, logrusx.New("", ""), os.Stderr, &contextx.Default{},
+			configx.WithConfigFiles("stub/.kratos.yaml"),
+			configx.WithValue(config.ViperKeyCourierLocaleDefault, "../en"))
+		assert.Error(t, err)
+	})
 }
 
 func TestCleanup(t *testing.T) {
@@ -1533,6 +1715,37 @@ func TestConfigOrganizationSessionLifespan(t *testing.T) {
 	})
 }
 
+func TestConfigOrganizationSessionIdleTimeout(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	orgWithOverride := uuid.Must(uuid.NewV4())
+	orgWithoutOverride := uuid.Must(uuid.NewV4())
+
+	newConfig := func(values map[string]interface{}) *config.Config {
+		values["selfservice.methods.b2b.config.organizations"] = []map[string]interface{}{
+			{"id": orgWithOverride.String(), "domains": []string{"a.com"}, "session_idle_timeout": "5m"},
+			{"id": orgWithoutOverride.String(), "domains": []string{"b.com"}},
+		}
+		return config.MustNew(t, logrusx.New("", ""), &contextx.Default{}, configx.WithValues(values), configx.SkipValidation())
+	}
+
+	t.Run("case=disabled by default", func(t *testing.T) {
+		t.Parallel()
+		conf := newConfig(map[string]interface{}{})
+		assert.Zero(t, conf.SessionIdleTimeout(ctx))
+		assert.Zero(t, conf.OrganizationSessionIdleTimeout(ctx, orgWithoutOverride))
+		assert.Equal(t, 5*time.Minute, conf.OrganizationSessionIdleTimeout(ctx, orgWithOverride))
+	})
+
+	t.Run("case=org override takes precedence", func(t *testing.T) {
+		t.Parallel()
+		conf := newConfig(map[string]interface{}{"session.idle_timeout": "30m"})
+		assert.Equal(t, 30*time.Minute, conf.OrganizationSessionIdleTimeout(ctx, uuid.Nil))
+		assert.Equal(t, 30*time.Minute, conf.OrganizationSessionIdleTimeout(ctx, orgWithoutOverride))
+		assert.Equal(t, 5*time.Minute, conf.OrganizationSessionIdleTimeout(ctx, orgWithOverride))
+	})
+}
+
 func TestSelfServiceBrowserAllowedReturnToDomains(t *testing.T) {
 	t.Parallel()
 
@@ -11,12 +11,16 @@ import (
 
 	"github.com/ory/x/httpx"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/cipher"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/geoip"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/identity/dormancy"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/persistence"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
@@ -26,10 +30,13 @@ import (
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
+	"github.com/ory/kratos/selfservice/lockout"
 	"github.com/ory/kratos/selfservice/sessiontokenexchange"
 	"github.com/ory/kratos/selfservice/strategy/code"
 	"github.com/ory/kratos/selfservice/strategy/link"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
 	password2 "github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/kratos/selfservice/strategy/saml"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/nosurfx"
@@ -102,8 +109,12 @@ type Registry interface {
 	identity.ManagementProvider
 	identity.ActiveCredentialsCounterStrategyProvider
 
+	dormancy.ManagementProvider
+	dormancy.PersistenceProvider
+
 	courier.HandlerProvider
 	courier.PersistenceProvider
+	courier.TemplateIdentityProvider
 
 	schema.HandlerProvider
 	schema.IdentitySchemaProvider
@@ -128,6 +139,7 @@ type Registry interface {
 	login.StrategyProvider
 
 	logout.HandlerProvider
+	logout.StrategyProvider
 
 	registration.FlowPersistenceProvider
 	registration.ErrorHandlerProvider
@@ -143,6 +155,23 @@ type Registry interface {
 
 	sessiontokenexchange.PersistenceProvider
 
+	lockout.HandlerProvider
+	lockout.ManagementProvider
+	lockout.PersistenceProvider
+
+	oidc.PersistenceProvider
+	oidc.DiscoveryCacheProvider
+	saml.PersistenceProvider
+
+	audit.HandlerProvider
+	audit.PersistenceProvider
+	audit.SinkProvider
+
+	outbox.DispatcherProvider
+	outbox.PersistenceProvider
+
+	geoip.Provider
+
 	link.SenderProvider
 	link.VerificationTokenPersistenceProvider
 	link.RecoveryTokenPersistenceProvider
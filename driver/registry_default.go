@@ -6,6 +6,7 @@ package driver
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"net/http"
 	"strings"
 	"sync"
@@ -23,13 +24,17 @@ import (
 	"github.com/urfave/negroni"
 
 	"github.com/ory/herodot"
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/cipher"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/geoip"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/hydra"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/identity/dormancy"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/persistence"
 	"github.com/ory/kratos/persistence/sql"
 	"github.com/ory/kratos/schema"
@@ -42,6 +47,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/selfservice/lockout"
 	"github.com/ory/kratos/selfservice/strategy/code"
 	"github.com/ory/kratos/selfservice/strategy/idfirst"
 	"github.com/ory/kratos/selfservice/strategy/link"
@@ -50,6 +56,7 @@ import (
 	"github.com/ory/kratos/selfservice/strategy/passkey"
 	"github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/selfservice/strategy/profile"
+	"github.com/ory/kratos/selfservice/strategy/saml"
 	"github.com/ory/kratos/selfservice/strategy/totp"
 	"github.com/ory/kratos/selfservice/strategy/webauthn"
 	"github.com/ory/kratos/session"
@@ -110,8 +117,18 @@ type RegistryDefault struct {
 	identityManager        *identity.Manager
 	identitySchemaProvider schema.IdentitySchemaProvider
 
+	dormancyManager *dormancy.Manager
+
 	courierHandler *courier.Handler
 
+	lockoutHandler *lockout.Handler
+	lockoutManager *lockout.Manager
+
+	auditHandler *audit.Handler
+	auditSink    *audit.Sink
+
+	outboxDispatcher *outbox.Dispatcher
+
 	continuityManager *continuity.Manager
 
 	schemaHandler *schema.Handler
@@ -126,6 +143,8 @@ type RegistryDefault struct {
 
 	crypter initOnce[cipher.Cipher]
 
+	geoIPResolver initOnce[geoip.Resolver]
+
 	errorHandler *errorx.Handler
 	errorManager *errorx.Manager
 
@@ -166,6 +185,8 @@ type RegistryDefault struct {
 	jsonnetVMProvider initOnce[jsonnetsecure.VMProvider]
 	jsonnetPool       jsonnetsecure.Pool
 	jwkFetcher        initOnce[*jwksx.FetcherNext]
+
+	oidcDiscoveryCache initOnce[*oidc.DiscoveryCache]
 }
 
 func (m *RegistryDefault) JsonnetVM(ctx context.Context) (jsonnetsecure.VM, error) {
@@ -188,6 +209,8 @@ func (m *RegistryDefault) RegisterPublicRoutes(ctx context.Context, router *http
 	m.SettingsHandler().RegisterPublicRoutes(router)
 	m.IdentityHandler().RegisterPublicRoutes(router)
 	m.CourierHandler().RegisterPublicRoutes(router)
+	m.LockoutHandler().RegisterPublicRoutes(router)
+	m.AuditHandler().RegisterPublicRoutes(router)
 	m.SessionHandler().RegisterPublicRoutes(router)
 	m.SelfServiceErrorHandler().RegisterPublicRoutes(router)
 	m.SchemaHandler().RegisterPublicRoutes(router)
@@ -218,6 +241,8 @@ func (m *RegistryDefault) RegisterAdminRoutes(ctx context.Context, router *httpr
 	m.SettingsHandler().RegisterAdminRoutes(router)
 	m.IdentityHandler().RegisterAdminRoutes(router)
 	m.CourierHandler().RegisterAdminRoutes(router)
+	m.LockoutHandler().RegisterAdminRoutes(router)
+	m.AuditHandler().RegisterAdminRoutes(router)
 	m.SelfServiceErrorHandler().RegisterAdminRoutes(router)
 
 	m.RecoveryHandler().RegisterAdminRoutes(router)
@@ -344,6 +369,7 @@ func (m *RegistryDefault) selfServiceStrategies() []any {
 				profile.NewStrategy(m), // <- should remain first
 				password.NewStrategy(m),
 				oidc.NewStrategy(m),
+				saml.NewStrategy(m),
 				code.NewStrategy(m),
 				link.NewStrategy(m),
 				totp.NewStrategy(m),
@@ -463,6 +489,15 @@ func (m *RegistryDefault) AllLoginStrategies() login.Strategies {
 	return loginStrategies
 }
 
+func (m *RegistryDefault) LogoutStrategies(ctx context.Context) (logoutStrategies logout.Strategies) {
+	for _, strategy := range m.selfServiceStrategies() {
+		if s, ok := strategy.(logout.Strategy); ok && m.strategyLoginEnabled(ctx, s.ID().String()) {
+			logoutStrategies = append(logoutStrategies, s)
+		}
+	}
+	return
+}
+
 func (m *RegistryDefault) ActiveCredentialsCounterStrategies(_ context.Context) (activeCredentialsCounterStrategies []identity.ActiveCredentialsCounter) {
 	for _, strategy := range m.selfServiceStrategies() {
 		if s, ok := strategy.(identity.ActiveCredentialsCounter); ok {
@@ -802,6 +837,14 @@ func (m *RegistryDefault) Courier(ctx context.Context) (courier.Courier, error)
 	return courier.NewCourier(ctx, m)
 }
 
+func (m *RegistryDefault) CourierTemplateIdentity(ctx context.Context, id uuid.UUID) (json.RawMessage, error) {
+	i, err := m.IdentityPool().GetIdentity(ctx, id, identity.ExpandDefault)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(i)
+}
+
 func (m *RegistryDefault) ContinuityManager() *continuity.Manager {
 	return m.continuityManager
 }
@@ -954,6 +997,10 @@ func (m *RegistryDefault) initCheapMembers() {
 	m.sessionManager = session.NewManagerHTTP(m)
 	m.errorManager = errorx.NewManager(m)
 	m.continuityManager = continuity.NewManager(m)
+	m.lockoutManager = lockout.NewManager(m)
+	m.dormancyManager = dormancy.NewManager(m)
+	m.auditSink = audit.NewSink(m)
+	m.outboxDispatcher = outbox.NewDispatcher(m)
 }
 
 type initOnce[T any] struct {
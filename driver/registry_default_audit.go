@@ -0,0 +1,21 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/audit"
+
+func (m *RegistryDefault) AuditPersister() audit.Persister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) AuditSink() *audit.Sink {
+	return m.auditSink
+}
+
+func (m *RegistryDefault) AuditHandler() *audit.Handler {
+	if m.auditHandler == nil {
+		m.auditHandler = audit.NewHandler(m)
+	}
+	return m.auditHandler
+}
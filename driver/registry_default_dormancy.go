@@ -0,0 +1,14 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/identity/dormancy"
+
+func (m *RegistryDefault) DormancyPersister() dormancy.Persister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) DormancyManager() *dormancy.Manager {
+	return m.dormancyManager
+}
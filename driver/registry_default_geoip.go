@@ -0,0 +1,37 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"context"
+
+	"github.com/ory/kratos/geoip"
+)
+
+func (m *RegistryDefault) GeoIPResolver(ctx context.Context) geoip.Resolver {
+	return m.geoIPResolver.Get(func() geoip.Resolver {
+		// Clients can set the location headers themselves, so the MaxMind
+		// databases only fall back to them if explicitly configured. An
+		// empty chain resolves nothing.
+		c := m.Config().SessionGeoIP(ctx)
+		switch c.Provider {
+		case "headers":
+			return geoip.NewHeaders()
+		case "mmdb":
+			var fallback []geoip.Resolver
+			if c.HeaderFallback {
+				fallback = append(fallback, geoip.NewHeaders())
+			}
+
+			db, err := geoip.NewMMDB(c.CityDatabase, c.ASNDatabase)
+			if err != nil {
+				m.Logger().WithError(err).Error("Unable to open the GeoIP databases. Locations are only resolved from the location headers, if enabled.")
+				return geoip.NewChain(fallback...)
+			}
+			return geoip.NewChain(append([]geoip.Resolver{db}, fallback...)...)
+		default:
+			return geoip.NewChain()
+		}
+	})
+}
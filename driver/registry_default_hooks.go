@@ -60,6 +60,10 @@ func (m *RegistryDefault) HookNotifyPreviousAddresses(c *hook.NotifyPreviousAddr
 	return hook.NewNotifyPreviousAddresses(m, c)
 }
 
+func (m *RegistryDefault) HookLoginNewDevice(c *hook.LoginNewDeviceConfig) *hook.LoginNewDevice {
+	return hook.NewLoginNewDevice(m, c)
+}
+
 func (m *RegistryDefault) WithHooks(hooks map[string]NewHookFn) {
 	m.injectedSelfserviceHooks = hooks
 }
@@ -117,6 +121,17 @@ allHooksLoop:
 			if h, ok := any(m.HookNotifyPreviousAddresses(cfg)).(T); ok {
 				hooks = append(hooks, h)
 			}
+		case hook.KeyLoginNewDevice:
+			cfg := &hook.LoginNewDeviceConfig{}
+			if len(hookConfig.Config) > 0 {
+				if err := json.Unmarshal(hookConfig.Config, cfg); err != nil {
+					m.l.WithError(err).WithField("raw_config", string(hookConfig.Config)).Error("failed to unmarshal hook configuration, ignoring hook")
+					return nil, errors.WithStack(fmt.Errorf("failed to unmarshal login_new_device configuration for %s: %w", credentialsType, err))
+				}
+			}
+			if h, ok := any(m.HookLoginNewDevice(cfg)).(T); ok {
+				hooks = append(hooks, h)
+			}
 		default:
 			for name, newHook := range m.injectedSelfserviceHooks {
 				if name == hookConfig.Name {
@@ -36,6 +36,20 @@ func (m *RegistryDefault) PostLoginHooks(ctx context.Context, credentialsType id
 	return getHooks[login.PostHookExecutor](m, config.HookGlobal, m.Config().SelfServiceFlowLoginAfterHooks(ctx, config.HookGlobal))
 }
 
+func (m *RegistryDefault) PostLoginPostPersistHooks(ctx context.Context, credentialsType identity.CredentialsType) ([]login.PostHookPostPersistExecutor, error) {
+	hooks, err := getHooks[login.PostHookPostPersistExecutor](m, string(credentialsType), m.Config().SelfServiceFlowLoginAfterHooks(ctx, string(credentialsType)))
+	if err != nil {
+		return nil, err
+	}
+	if len(hooks) > 0 {
+		return hooks, nil
+	}
+
+	// since we don't want merging hooks defined in a specific strategy and global hooks
+	// global hooks are added only if no strategy specific hooks are defined
+	return getHooks[login.PostHookPostPersistExecutor](m, config.HookGlobal, m.Config().SelfServiceFlowLoginAfterHooks(ctx, config.HookGlobal))
+}
+
 func (m *RegistryDefault) LoginHandler() *login.Handler {
 	if m.selfserviceLoginHandler == nil {
 		m.selfserviceLoginHandler = login.NewHandler(m)
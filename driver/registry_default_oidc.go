@@ -0,0 +1,16 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/selfservice/strategy/oidc"
+
+func (m *RegistryDefault) OIDCProviderPersister() oidc.Persister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) OIDCDiscoveryCache() *oidc.DiscoveryCache {
+	return m.oidcDiscoveryCache.Get(func() *oidc.DiscoveryCache {
+		return oidc.NewDiscoveryCache()
+	})
+}
@@ -0,0 +1,14 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/outbox"
+
+func (m *RegistryDefault) OutboxPersister() outbox.Persister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) OutboxDispatcher() *outbox.Dispatcher {
+	return m.outboxDispatcher
+}
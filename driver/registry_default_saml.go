@@ -0,0 +1,10 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import "github.com/ory/kratos/selfservice/strategy/saml"
+
+func (m *RegistryDefault) SAMLPersister() saml.Persister {
+	return m.Persister()
+}
@@ -0,0 +1,61 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package geoip
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+)
+
+// Location is the geographical location and network of a client IP address.
+// Fields which could not be resolved are empty.
+type Location struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country.
+	Country string `json:"country,omitempty"`
+
+	// Region is the name of the region, e.g. the state or province.
+	Region string `json:"region,omitempty"`
+
+	// City is the name of the city.
+	City string `json:"city,omitempty"`
+
+	// ASN is the number of the autonomous system the IP address belongs to.
+	ASN string `json:"asn,omitempty"`
+}
+
+// Resolver resolves the location of the client which sent a request.
+type Resolver interface {
+	// Resolve returns the location of the client which sent the request. It
+	// returns an empty location, and not an error, if the location is unknown.
+	Resolve(ctx context.Context, r *http.Request) (*Location, error)
+}
+
+type Provider interface {
+	GeoIPResolver(ctx context.Context) Resolver
+}
+
+// Chain resolves each field of the location with the first resolver which
+// knows it. It is used to fall back to the request headers for fields which
+// are not part of the configured databases.
+type Chain []Resolver
+
+func NewChain(resolvers ...Resolver) Chain {
+	return resolvers
+}
+
+func (c Chain) Resolve(ctx context.Context, r *http.Request) (*Location, error) {
+	var loc Location
+	for _, resolver := range c {
+		next, err := resolver.Resolve(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		loc.Country = cmp.Or(loc.Country, next.Country)
+		loc.Region = cmp.Or(loc.Region, next.Region)
+		loc.City = cmp.Or(loc.City, next.City)
+		loc.ASN = cmp.Or(loc.ASN, next.ASN)
+	}
+	return &loc, nil
+}
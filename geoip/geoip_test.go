@@ -0,0 +1,57 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package geoip_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/geoip"
+)
+
+type staticResolver geoip.Location
+
+func (s staticResolver) Resolve(context.Context, *http.Request) (*geoip.Location, error) {
+	loc := geoip.Location(s)
+	return &loc, nil
+}
+
+func TestHeaders(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Cf-Ipcountry", "DE")
+	r.Header.Set("Cf-Region", "Bavaria")
+	r.Header.Set("Cf-Ipcity", "Munich")
+
+	loc, err := geoip.NewHeaders().Resolve(t.Context(), r)
+	require.NoError(t, err)
+	assert.Equal(t, &geoip.Location{Country: "DE", Region: "Bavaria", City: "Munich"}, loc)
+
+	loc, err = geoip.NewHeaders().Resolve(t.Context(), httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, &geoip.Location{}, loc)
+}
+
+func TestChain(t *testing.T) {
+	loc, err := geoip.NewChain(
+		staticResolver{City: "Munich", ASN: "3320"},
+		staticResolver{Country: "DE", City: "Berlin"},
+	).Resolve(t.Context(), httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, &geoip.Location{Country: "DE", City: "Munich", ASN: "3320"}, loc,
+		"each field is resolved by the first resolver which knows it")
+}
+
+func TestNewMMDB(t *testing.T) {
+	_, err := geoip.NewMMDB("", "")
+	assert.Error(t, err)
+
+	_, err = geoip.NewMMDB(filepath.Join(t.TempDir(), "GeoLite2-City.mmdb"), "")
+	assert.ErrorContains(t, err, "GeoLite2-City.mmdb")
+}
@@ -0,0 +1,30 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package geoip
+
+import (
+	"context"
+	"net/http"
+)
+
+var _ Resolver = new(Headers)
+
+// Headers resolves the location from the visitor location headers which
+// Cloudflare adds to proxied requests. The headers do not contain the ASN.
+//
+// Clients can send these headers themselves. Headers must therefore only be
+// used if all requests are proxied by Cloudflare, which overwrites them.
+type Headers struct{}
+
+func NewHeaders() *Headers {
+	return &Headers{}
+}
+
+func (*Headers) Resolve(_ context.Context, r *http.Request) (*Location, error) {
+	return &Location{
+		Country: r.Header.Get("Cf-Ipcountry"),
+		Region:  r.Header.Get("Cf-Region"),
+		City:    r.Header.Get("Cf-Ipcity"),
+	}, nil
+}
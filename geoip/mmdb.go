@@ -0,0 +1,115 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package geoip
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/httpx"
+)
+
+var _ Resolver = new(MMDB)
+
+type (
+	// MMDB resolves the location of the client IP address using local
+	// MaxMind databases in the MMDB format, for example GeoLite2-City and
+	// GeoLite2-ASN.
+	MMDB struct {
+		city *maxminddb.Reader
+		asn  *maxminddb.Reader
+	}
+
+	cityRecord struct {
+		Country struct {
+			ISOCode string `maxminddb:"iso_code"`
+		} `maxminddb:"country"`
+		Subdivisions []struct {
+			Names map[string]string `maxminddb:"names"`
+		} `maxminddb:"subdivisions"`
+		City struct {
+			Names map[string]string `maxminddb:"names"`
+		} `maxminddb:"city"`
+	}
+
+	asnRecord struct {
+		AutonomousSystemNumber uint `maxminddb:"autonomous_system_number"`
+	}
+)
+
+// NewMMDB opens the given databases. The city database may also be a
+// country database. Either path may be empty, but not both.
+func NewMMDB(cityDatabase, asnDatabase string) (*MMDB, error) {
+	if cityDatabase == "" && asnDatabase == "" {
+		return nil, errors.New("at least one of the GeoIP city and ASN databases must be set")
+	}
+
+	m := new(MMDB)
+	if cityDatabase != "" {
+		db, err := maxminddb.Open(cityDatabase)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to open the GeoIP city database %q", cityDatabase)
+		}
+		m.city = db
+	}
+	if asnDatabase != "" {
+		db, err := maxminddb.Open(asnDatabase)
+		if err != nil {
+			_ = m.Close()
+			return nil, errors.Wrapf(err, "unable to open the GeoIP ASN database %q", asnDatabase)
+		}
+		m.asn = db
+	}
+	return m, nil
+}
+
+func (m *MMDB) Resolve(_ context.Context, r *http.Request) (*Location, error) {
+	var loc Location
+
+	ip := net.ParseIP(httpx.ClientIP(r))
+	if ip == nil {
+		return &loc, nil
+	}
+
+	if m.city != nil {
+		var record cityRecord
+		if err := m.city.Lookup(ip, &record); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		loc.Country = record.Country.ISOCode
+		loc.City = record.City.Names["en"]
+		if len(record.Subdivisions) > 0 {
+			loc.Region = record.Subdivisions[0].Names["en"]
+		}
+	}
+
+	if m.asn != nil {
+		var record asnRecord
+		if err := m.asn.Lookup(ip, &record); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if record.AutonomousSystemNumber > 0 {
+			loc.ASN = strconv.FormatUint(uint64(record.AutonomousSystemNumber), 10)
+		}
+	}
+
+	return &loc, nil
+}
+
+// Close closes the databases.
+func (m *MMDB) Close() error {
+	var errs []error
+	for _, db := range []*maxminddb.Reader{m.city, m.asn} {
+		if db != nil {
+			errs = append(errs, db.Close())
+		}
+	}
+	return errors.WithStack(stderrors.Join(errs...))
+}
@@ -147,6 +147,10 @@ const (
 	// It is not used within the credentials object itself.
 	CredentialsTypeRecoveryLink CredentialsType = "link_recovery"
 	CredentialsTypeRecoveryCode CredentialsType = "code_recovery"
+
+	// CredentialsTypeImpersonation is a special credential type used for sessions which an administrator
+	// issued through the admin API to act as the identity. It is not used within the credentials object itself.
+	CredentialsTypeImpersonation CredentialsType = "impersonation"
 )
 
 // ParseCredentialsType parses a string into a CredentialsType or returns false as the second argument.
@@ -195,7 +199,13 @@ type Credentials struct {
 
 	// UpdatedAt is a helper struct field for gobuffalo.pop.
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
-	NID       uuid.UUID `json:"-"  faker:"-" db:"nid"`
+
+	// LastUsedAt contains the last time this credential was used to complete a login flow.
+	//
+	// It is not set if the credential was never used to sign in.
+	LastUsedAt *sqlxx.NullTime `json:"last_used_at,omitempty" faker:"-" db:"last_used_at"`
+
+	NID uuid.UUID `json:"-"  faker:"-" db:"nid"`
 }
 
 func (c Credentials) TableName(context.Context) string {
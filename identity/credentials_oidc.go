@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -31,13 +32,18 @@ type CredentialsOIDCProvider struct {
 	InitialRefreshToken string `json:"initial_refresh_token"`
 	Organization        string `json:"organization,omitempty"`
 	UseAutoLink         bool   `json:"use_auto_link,omitzero"`
+
+	// AccessTokenExpiresAt is the time at which the stored access token
+	// expires. It is zero if the provider did not report an expiry.
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at,omitzero"`
 }
 
 // swagger:ignore
 type CredentialsOIDCEncryptedTokens struct {
-	RefreshToken string `json:"refresh_token,omitempty"`
-	IDToken      string `json:"id_token,omitempty"`
-	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	IDToken      string    `json:"id_token,omitempty"`
+	AccessToken  string    `json:"access_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitzero"`
 }
 
 func (c *CredentialsOIDCEncryptedTokens) GetRefreshToken() string {
@@ -61,6 +67,13 @@ func (c *CredentialsOIDCEncryptedTokens) GetIDToken() string {
 	return c.IDToken
 }
 
+func (c *CredentialsOIDCEncryptedTokens) GetExpiresAt() time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	return c.ExpiresAt
+}
+
 // NewCredentialsOIDC creates a new OIDC credential.
 func NewCredentialsOIDC(tokens *CredentialsOIDCEncryptedTokens, provider, subject, organization string) (*Credentials, error) {
 	return NewOIDCLikeCredentials(tokens, CredentialsTypeOIDC, provider, subject, organization)
@@ -80,12 +93,13 @@ func NewOIDCLikeCredentials(tokens *CredentialsOIDCEncryptedTokens, t Credential
 	if err := json.NewEncoder(&b).Encode(CredentialsOIDC{
 		Providers: []CredentialsOIDCProvider{
 			{
-				Subject:             subject,
-				Provider:            provider,
-				InitialIDToken:      tokens.GetIDToken(),
-				InitialAccessToken:  tokens.GetAccessToken(),
-				InitialRefreshToken: tokens.GetRefreshToken(),
-				Organization:        organization,
+				Subject:              subject,
+				Provider:             provider,
+				InitialIDToken:       tokens.GetIDToken(),
+				InitialAccessToken:   tokens.GetAccessToken(),
+				InitialRefreshToken:  tokens.GetRefreshToken(),
+				Organization:         organization,
+				AccessTokenExpiresAt: tokens.GetExpiresAt(),
 			},
 		},
 	}); err != nil {
@@ -105,6 +119,21 @@ func (c *CredentialsOIDCProvider) GetTokens() *CredentialsOIDCEncryptedTokens {
 		RefreshToken: c.InitialRefreshToken,
 		IDToken:      c.InitialIDToken,
 		AccessToken:  c.InitialAccessToken,
+		ExpiresAt:    c.AccessTokenExpiresAt,
+	}
+}
+
+// UpdateTokens replaces the stored tokens with the ones issued by a later
+// login or token refresh. Providers may omit the refresh token when
+// refreshing, in which case the stored one is kept.
+func (c *CredentialsOIDCProvider) UpdateTokens(tokens *CredentialsOIDCEncryptedTokens) {
+	c.InitialAccessToken = tokens.GetAccessToken()
+	c.AccessTokenExpiresAt = tokens.GetExpiresAt()
+	if idToken := tokens.GetIDToken(); idToken != "" {
+		c.InitialIDToken = idToken
+	}
+	if refreshToken := tokens.GetRefreshToken(); refreshToken != "" {
+		c.InitialRefreshToken = refreshToken
 	}
 }
 
@@ -5,7 +5,9 @@ package identity
 
 import (
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,3 +19,26 @@ func TestNewCredentialsOIDC(t *testing.T) {
 	_, err = NewCredentialsOIDC(new(CredentialsOIDCEncryptedTokens), "not-empty", "not-empty", "")
 	require.NoError(t, err)
 }
+
+func TestCredentialsOIDCProviderUpdateTokens(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).UTC()
+	c := CredentialsOIDCProvider{
+		InitialIDToken:       "id-token",
+		InitialAccessToken:   "access-token",
+		InitialRefreshToken:  "refresh-token",
+		AccessTokenExpiresAt: time.Now().UTC(),
+	}
+
+	c.UpdateTokens(&CredentialsOIDCEncryptedTokens{AccessToken: "new-access-token", ExpiresAt: expiresAt})
+	assert.Equal(t, "id-token", c.InitialIDToken)
+	assert.Equal(t, "new-access-token", c.InitialAccessToken)
+	assert.Equal(t, "refresh-token", c.InitialRefreshToken, "the refresh token must be kept if none was issued")
+	assert.Equal(t, expiresAt, c.AccessTokenExpiresAt)
+
+	c.UpdateTokens(&CredentialsOIDCEncryptedTokens{IDToken: "new-id-token", AccessToken: "newer-access-token", RefreshToken: "new-refresh-token"})
+	assert.Equal(t, "new-id-token", c.InitialIDToken)
+	assert.Equal(t, "newer-access-token", c.InitialAccessToken)
+	assert.Equal(t, "new-refresh-token", c.InitialRefreshToken)
+	assert.True(t, c.AccessTokenExpiresAt.IsZero())
+	assert.Equal(t, c.GetTokens(), &CredentialsOIDCEncryptedTokens{IDToken: "new-id-token", AccessToken: "newer-access-token", RefreshToken: "new-refresh-token"})
+}
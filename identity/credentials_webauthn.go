@@ -166,6 +166,18 @@ func (c CredentialsWebAuthn) UpdateFromLogin(updated *webauthn.Credential) (chan
 	return false
 }
 
+// MarkUsed records that the credential with the given ID was used to sign in
+// at the given time. It returns false if no such credential exists.
+func (c CredentialsWebAuthn) MarkUsed(id []byte, at time.Time) bool {
+	for k := range c {
+		if bytes.Equal(c[k].ID, id) {
+			c[k].LastUsedAt = new(at.UTC())
+			return true
+		}
+	}
+	return false
+}
+
 func (c *CredentialWebAuthn) ToWebAuthn() *webauthn.Credential {
 	wc := &webauthn.Credential{
 		ID:              c.ID,
@@ -218,6 +230,7 @@ type CredentialWebAuthn struct {
 	Flags           *CredentialWebAuthnFlags          `json:"flags,omitempty"`
 	Transport       []protocol.AuthenticatorTransport `json:"transport,omitempty"`
 	Attestation     *CredentialWebAuthnAttestation    `json:"attestation,omitempty"`
+	LastUsedAt      *time.Time                        `json:"last_used_at,omitempty"`
 }
 
 // CredentialWebAuthnFlags contains information about the flags of a webauthn credential.
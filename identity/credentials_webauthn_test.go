@@ -5,6 +5,7 @@ package identity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -180,3 +181,20 @@ func TestPasswordlessOnly(t *testing.T) {
 	require.Len(t, actual, 2)
 	assert.Equal(t, []webauthn.Credential{*c.ToWebAuthn(), *e.ToWebAuthn()}, actual)
 }
+
+func TestMarkUsed(t *testing.T) {
+	c := CredentialsWebAuthn{
+		*CredentialFromWebAuthn(&webauthn.Credential{ID: []byte("a")}, false),
+		*CredentialFromWebAuthn(&webauthn.Credential{ID: []byte("b")}, false),
+	}
+	now := time.Now()
+
+	assert.False(t, c.MarkUsed([]byte("unknown"), now))
+	assert.Nil(t, c[0].LastUsedAt)
+	assert.Nil(t, c[1].LastUsedAt)
+
+	assert.True(t, c.MarkUsed([]byte("b"), now))
+	assert.Nil(t, c[0].LastUsedAt)
+	require.NotNil(t, c[1].LastUsedAt)
+	assert.True(t, now.Equal(*c[1].LastUsedAt))
+}
@@ -0,0 +1,63 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package dormancy
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type (
+	// Persister finds and transitions dormant identities.
+	//
+	// An identity's last activity is the time it last signed in, or its
+	// creation time if it never signed in. Identities which were (re)activated
+	// after the cut-off are never considered dormant.
+	Persister interface {
+		// ListIdentitiesDueForDormancyWarning returns active identities whose
+		// last activity lies before lastActiveBefore and which were not warned
+		// yet. Identities whose warning failed before are returned last, so
+		// that they do not take up the whole batch in every run.
+		ListIdentitiesDueForDormancyWarning(ctx context.Context, lastActiveBefore time.Time, limit int) ([]uuid.UUID, error)
+
+		// MarkDormancyWarningSent records that the identity was warned at the
+		// given time.
+		MarkDormancyWarningSent(ctx context.Context, id uuid.UUID, at time.Time) error
+
+		// MarkDormancyWarningFailed records that the warning could not be sent
+		// to the identity. Only the first failed attempt is kept.
+		MarkDormancyWarningFailed(ctx context.Context, id uuid.UUID, at time.Time) error
+
+		// ListIdentitiesDueForDeactivation returns active identities whose last
+		// activity lies before lastActiveBefore. If warnedBefore is not zero,
+		// only identities which were warned, or whose warning first failed,
+		// before that time are returned.
+		ListIdentitiesDueForDeactivation(ctx context.Context, lastActiveBefore, warnedBefore time.Time, limit int) ([]uuid.UUID, error)
+
+		// MarkDormantIdentityDeactivated records that the policy deactivated
+		// the identity at the given time, which must be the identity's state
+		// change time, and resets its warning.
+		MarkDormantIdentityDeactivated(ctx context.Context, id uuid.UUID, at time.Time) error
+
+		// ListIdentitiesDueForDeletion returns identities which were
+		// deactivated by the policy before inactiveBefore, and whose state did
+		// not change since. Identities deactivated otherwise are never
+		// returned.
+		ListIdentitiesDueForDeletion(ctx context.Context, inactiveBefore time.Time, limit int) ([]uuid.UUID, error)
+	}
+	PersistenceProvider interface {
+		DormancyPersister() Persister
+	}
+
+	// Report lists the identities which were, or in dry-run mode would have
+	// been, warned, deactivated, and deleted in a policy run.
+	Report struct {
+		DryRun      bool        `json:"dry_run"`
+		Warned      []uuid.UUID `json:"warned"`
+		Deactivated []uuid.UUID `json:"deactivated"`
+		Deleted     []uuid.UUID `json:"deleted"`
+	}
+)
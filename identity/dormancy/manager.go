@@ -0,0 +1,250 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package dormancy
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+type (
+	managerDependencies interface {
+		config.Provider
+		logrusx.Provider
+		otelx.Provider
+		identity.PrivilegedPoolProvider
+		identity.ManagementProvider
+		session.PersistenceProvider
+		PersistenceProvider
+	}
+	ManagementProvider interface {
+		DormancyManager() *Manager
+	}
+
+	// Manager applies the dormant identity policy.
+	//
+	// Each run warns identities which are about to become dormant, deactivates
+	// dormant identities, and deletes identities which it deactivated and which
+	// have been inactive for longer than the retention period. A run handles at most one batch per
+	// step, so it should be repeated periodically.
+	Manager struct {
+		r managerDependencies
+	}
+)
+
+func NewManager(r managerDependencies) *Manager {
+	return &Manager{r: r}
+}
+
+// Run applies the dormant identity policy once. In dry-run mode, the report
+// lists the affected identities but nothing is changed and no emails are
+// sent.
+func (m *Manager) Run(ctx context.Context, dryRun bool) (_ *Report, err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "identity.dormancy.Manager.Run")
+	defer otelx.End(span, &err)
+
+	report := &Report{DryRun: dryRun, Warned: []uuid.UUID{}, Deactivated: []uuid.UUID{}, Deleted: []uuid.UUID{}}
+
+	c := m.r.Config().IdentityDormancy(ctx)
+	if !c.Enabled {
+		return report, nil
+	}
+
+	now := time.Now().UTC()
+
+	if c.WarnBefore > 0 {
+		ids, err := m.r.DormancyPersister().ListIdentitiesDueForDormancyWarning(ctx, now.Add(-(c.DeactivateAfter - c.WarnBefore)), c.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			if !dryRun {
+				// The identity is not marked as warned, so that the warning
+				// is sent again in a later run. Recording the failure moves
+				// the identity to the end of the queue, and deactivates it
+				// anyway if the warning keeps failing for the whole warning
+				// period.
+				if err := m.warn(ctx, id, now, now.Add(c.WarnBefore)); err != nil {
+					m.r.Logger().WithError(err).WithField("identity_id", id).Warn("Unable to send the dormant account warning.")
+					if err := m.r.DormancyPersister().MarkDormancyWarningFailed(ctx, id, now); err != nil {
+						return nil, err
+					}
+					continue
+				}
+			}
+			report.Warned = append(report.Warned, id)
+		}
+	}
+
+	var warnedBefore time.Time
+	if c.WarnBefore > 0 {
+		warnedBefore = now.Add(-c.WarnBefore)
+	}
+
+	lastActiveBefore := now.Add(-c.DeactivateAfter)
+	ids, err := m.r.DormancyPersister().ListIdentitiesDueForDeactivation(ctx, lastActiveBefore, warnedBefore, c.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if !dryRun {
+			if ok, err := m.deactivate(ctx, id, lastActiveBefore, now); err != nil {
+				return nil, err
+			} else if !ok {
+				continue
+			}
+			m.r.Logger().WithField("identity_id", id).Info("Deactivated dormant identity.")
+		}
+		report.Deactivated = append(report.Deactivated, id)
+	}
+
+	if c.DeleteAfter > 0 {
+		ids, err := m.r.DormancyPersister().ListIdentitiesDueForDeletion(ctx, now.Add(-c.DeleteAfter), c.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			if !dryRun {
+				if err := m.r.PrivilegedIdentityPool().DeleteIdentity(ctx, id); err != nil {
+					return nil, err
+				}
+				m.r.Logger().WithField("identity_id", id).Info("Deleted inactive identity after the retention period.")
+			}
+			report.Deleted = append(report.Deleted, id)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Bool("dormancy.dry_run", dryRun),
+		attribute.Int("dormancy.warned", len(report.Warned)),
+		attribute.Int("dormancy.deactivated", len(report.Deactivated)),
+		attribute.Int("dormancy.deleted", len(report.Deleted)),
+	)
+
+	return report, nil
+}
+
+// warn sends the dormant account warning to all email addresses of the
+// identity. Identities without an email address are marked as warned anyway,
+// so that they are deactivated after the warning period as well.
+func (m *Manager) warn(ctx context.Context, id uuid.UUID, now, deactivatesAt time.Time) error {
+	i, err := m.r.PrivilegedIdentityPool().GetIdentity(ctx, id, identity.ExpandDefault)
+	if err != nil {
+		return err
+	}
+
+	if err := m.r.IdentityManager().SendDormantAccountWarningNotifications(ctx, emailTargets(i), i, deactivatesAt); err != nil {
+		return err
+	}
+
+	return m.r.DormancyPersister().MarkDormancyWarningSent(ctx, id, now)
+}
+
+// deactivate sets the state of the identity to inactive and revokes its
+// sessions. It returns false if the identity is no longer dormant, because it
+// signed in or its state changed since it was listed.
+func (m *Manager) deactivate(ctx context.Context, id uuid.UUID, lastActiveBefore, now time.Time) (bool, error) {
+	i, err := m.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if !isDormant(i, lastActiveBefore) {
+		return false, nil
+	}
+
+	stateChangedAt := sqlxx.NullTime(now)
+	i.State = identity.StateInactive
+	i.StateChangedAt = &stateChangedAt
+	if err := m.r.IdentityManager().Update(ctx, i, identity.ManagerAllowWriteProtectedTraits); err != nil {
+		return false, err
+	}
+
+	if err := m.r.DormancyPersister().MarkDormantIdentityDeactivated(ctx, id, now); err != nil {
+		return false, err
+	}
+
+	if _, err := m.r.SessionPersister().RevokeSessionsIdentityExcept(ctx, id, uuid.Nil); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// isDormant reports whether the identity is active and neither signed in nor
+// changed its state after lastActiveBefore.
+func isDormant(i *identity.Identity, lastActiveBefore time.Time) bool {
+	if i.State != identity.StateActive {
+		return false
+	}
+
+	lastActive := i.CreatedAt
+	if i.LastAuthenticatedAt != nil {
+		lastActive = time.Time(*i.LastAuthenticatedAt)
+	}
+	if !lastActive.Before(lastActiveBefore) {
+		return false
+	}
+
+	return i.StateChangedAt == nil || time.Time(*i.StateChangedAt).Before(lastActiveBefore)
+}
+
+// emailTargets returns the verifiable email addresses of the identity, or its
+// recovery email addresses if it has none.
+func emailTargets(i *identity.Identity) (targets []identity.AddressRef) {
+	seen := map[string]bool{}
+	add := func(via, value string) {
+		if via != identity.AddressTypeEmail || seen[value] {
+			return
+		}
+		seen[value] = true
+		targets = append(targets, identity.AddressRef{Value: value, Via: via})
+	}
+
+	for _, a := range i.VerifiableAddresses {
+		add(a.Via, a.Value)
+	}
+	if len(targets) == 0 {
+		for _, a := range i.RecoveryAddresses {
+			add(a.Via, a.Value)
+		}
+	}
+	return targets
+}
+
+// Watch applies the policy every worker interval until the context is
+// canceled. Errors are logged and the policy is applied again in the next
+// interval.
+func (m *Manager) Watch(ctx context.Context) error {
+	m.r.Logger().Println("Dormant identity worker started.")
+	for {
+		if _, err := m.Run(ctx, false); err != nil {
+			m.r.Logger().WithError(err).Error("Unable to apply the dormant identity policy.")
+		}
+
+		select {
+		case <-ctx.Done():
+			m.r.Logger().Println("Dormant identity worker was shutdown gracefully.")
+			return nil
+		case <-time.After(m.r.Config().IdentityDormancy(ctx).WorkerInterval):
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package dormancy_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/session"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/sqlcon"
+)
+
+func TestManager(t *testing.T) {
+	t.Parallel()
+
+	const day = 24 * time.Hour
+
+	newRegistry := func(t *testing.T, enabled bool) *driver.RegistryDefault {
+		_, reg := pkg.NewFastRegistryWithMocks(t,
+			configx.WithValues(map[string]any{
+				config.ViperKeyCourierSMTPURL:                  "smtp://foo@bar@dev.null/",
+				config.ViperKeyDefaultIdentitySchemaID:         "default",
+				config.ViperKeyIdentityDormancyEnabled:         enabled,
+				config.ViperKeyIdentityDormancyDeactivateAfter: "720h",
+				config.ViperKeyIdentityDormancyWarnBefore:      "168h",
+				config.ViperKeyIdentityDormancyDeleteAfter:     "2160h",
+			}),
+			configx.WithValues(testhelpers.IdentitySchemasConfig(map[string]string{
+				"default": "file://../stub/manager.schema.json",
+			})),
+		)
+		return reg
+	}
+
+	createIdentity := func(t *testing.T, ctx context.Context, reg *driver.RegistryDefault) *identity.Identity {
+		i := identity.NewIdentity("default")
+		i.Traits = identity.Traits(fmt.Sprintf(`{"email_verify":"%s@ory.sh"}`, uuid.Must(uuid.NewV4())))
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		return i
+	}
+
+	// backdate moves the identity's creation, state change, deactivation, and
+	// warning times into the past. A zero warnedAgo leaves the warning
+	// untouched.
+	backdate := func(t *testing.T, ctx context.Context, reg *driver.RegistryDefault, id uuid.UUID, createdAgo, stateChangedAgo, warnedAgo time.Duration) {
+		now := time.Now().UTC()
+		require.NoError(t, reg.Persister().GetConnection(ctx).RawQuery(
+			"UPDATE identities SET created_at = ?, state_changed_at = ?, dormancy_deactivated_at = CASE WHEN dormancy_deactivated_at IS NULL THEN NULL ELSE ? END WHERE id = ?",
+			now.Add(-createdAgo), now.Add(-stateChangedAgo), now.Add(-stateChangedAgo), id,
+		).Exec())
+		if warnedAgo > 0 {
+			require.NoError(t, reg.DormancyPersister().MarkDormancyWarningSent(ctx, id, now.Add(-warnedAgo)))
+		}
+	}
+
+	t.Run("case=warns, deactivates, and deletes dormant identities", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		reg := newRegistry(t, true)
+		m := reg.DormancyManager()
+
+		dormant := createIdentity(t, ctx, reg)
+		active := createIdentity(t, ctx, reg)
+		backdate(t, ctx, reg, dormant.ID, 25*day, 25*day, 0)
+
+		report, err := m.Run(ctx, true)
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, []uuid.UUID{dormant.ID}, report.Warned)
+		assert.Empty(t, report.Deactivated)
+		assert.Empty(t, report.Deleted)
+
+		_, err = reg.CourierPersister().NextMessages(ctx, 10)
+		require.ErrorIs(t, err, courier.ErrQueueEmpty, "dry runs must not send emails")
+
+		report, err = m.Run(ctx, false)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{dormant.ID}, report.Warned)
+		assert.Empty(t, report.Deactivated, "identities must not be deactivated before the warning period ended")
+
+		messages, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, template.TypeDormantAccountWarning, messages[0].TemplateType)
+		assert.Equal(t, dormant.VerifiableAddresses[0].Value, messages[0].Recipient)
+
+		report, err = m.Run(ctx, false)
+		require.NoError(t, err)
+		assert.Empty(t, report.Warned, "identities must only be warned once")
+
+		backdate(t, ctx, reg, dormant.ID, 31*day, 31*day, 8*day)
+
+		sess, err := testhelpers.NewActiveSession(
+			httptest.NewRequest(http.MethodGet, "/", nil),
+			reg, dormant, time.Now().UTC(),
+			identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1,
+		)
+		require.NoError(t, err)
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, sess))
+
+		report, err = m.Run(ctx, true)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{dormant.ID}, report.Deactivated)
+
+		actual, err := reg.IdentityPool().GetIdentity(ctx, dormant.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, identity.StateActive, actual.State, "dry runs must not deactivate identities")
+
+		report, err = m.Run(ctx, false)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{dormant.ID}, report.Deactivated)
+		assert.Empty(t, report.Deleted)
+
+		actual, err = reg.IdentityPool().GetIdentity(ctx, dormant.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, identity.StateInactive, actual.State)
+
+		actualSession, err := reg.SessionPersister().GetSession(ctx, sess.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.False(t, actualSession.Active, "the sessions of deactivated identities must be revoked")
+
+		backdate(t, ctx, reg, dormant.ID, 121*day, 91*day, 0)
+
+		report, err = m.Run(ctx, false)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{dormant.ID}, report.Deleted)
+
+		_, err = reg.IdentityPool().GetIdentity(ctx, dormant.ID, identity.ExpandNothing)
+		require.ErrorIs(t, err, sqlcon.ErrNoRows())
+
+		actual, err = reg.IdentityPool().GetIdentity(ctx, active.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, identity.StateActive, actual.State, "recently created identities must not be affected")
+	})
+
+	t.Run("case=does not delete identities deactivated by an administrator", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		reg := newRegistry(t, true)
+
+		deactivated := createIdentity(t, ctx, reg)
+		deactivated.State = identity.StateInactive
+		require.NoError(t, reg.IdentityManager().Update(ctx, deactivated, identity.ManagerAllowWriteProtectedTraits))
+		backdate(t, ctx, reg, deactivated.ID, 121*day, 91*day, 0)
+
+		report, err := reg.DormancyManager().Run(ctx, false)
+		require.NoError(t, err)
+		assert.Empty(t, report.Deleted)
+
+		actual, err := reg.IdentityPool().GetIdentity(ctx, deactivated.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, identity.StateInactive, actual.State)
+	})
+
+	t.Run("case=signing in ends the warning period", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		reg := newRegistry(t, true)
+
+		i := createIdentity(t, ctx, reg)
+		backdate(t, ctx, reg, i.ID, 31*day, 31*day, 8*day)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateLastAuthenticatedAt(ctx, i.ID, identity.CredentialsTypePassword, time.Now()))
+
+		report, err := reg.DormancyManager().Run(ctx, false)
+		require.NoError(t, err)
+		assert.Empty(t, report.Warned)
+		assert.Empty(t, report.Deactivated)
+
+		actual, err := reg.IdentityPool().GetIdentity(ctx, i.ID, identity.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, identity.StateActive, actual.State)
+	})
+
+	t.Run("case=does nothing if disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		reg := newRegistry(t, false)
+
+		i := createIdentity(t, ctx, reg)
+		backdate(t, ctx, reg, i.ID, 365*day, 365*day, 0)
+
+		report, err := reg.DormancyManager().Run(ctx, false)
+		require.NoError(t, err)
+		assert.Empty(t, report.Warned)
+		assert.Empty(t, report.Deactivated)
+		assert.Empty(t, report.Deleted)
+	})
+}
@@ -0,0 +1,168 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/pkg/testhelpers"
+)
+
+func TestPersister(ctx context.Context, p interface {
+	persistence.Persister
+},
+) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, p := testhelpers.NewNetworkUnlessExisting(t, ctx, p)
+
+		now := time.Now().UTC().Truncate(time.Second)
+
+		// newIdentity creates an identity which was created and last changed
+		// its state at the given time.
+		newIdentity := func(t *testing.T, state identity.State, at time.Time) *identity.Identity {
+			var i identity.Identity
+			require.NoError(t, faker.FakeData(&i))
+			i.State = state
+			require.NoError(t, p.CreateIdentity(ctx, &i))
+			require.NoError(t, p.GetConnection(ctx).RawQuery(
+				"UPDATE identities SET created_at = ?, state_changed_at = ? WHERE id = ?", at, at, i.ID,
+			).Exec())
+			return &i
+		}
+
+		// deactivated creates an identity which was deactivated by the policy at
+		// the given time.
+		deactivated := func(t *testing.T, at time.Time) *identity.Identity {
+			i := newIdentity(t, identity.StateInactive, at)
+			require.NoError(t, p.MarkDormantIdentityDeactivated(ctx, i.ID, at))
+			return i
+		}
+
+		t.Run("case=lists identities due for a warning", func(t *testing.T) {
+			dormant := newIdentity(t, identity.StateActive, now.Add(-48*time.Hour))
+			_ = newIdentity(t, identity.StateActive, now)
+			_ = newIdentity(t, identity.StateInactive, now.Add(-48*time.Hour))
+
+			ids, err := p.ListIdentitiesDueForDormancyWarning(ctx, now.Add(-time.Hour), 100)
+			require.NoError(t, err)
+			assert.Contains(t, ids, dormant.ID)
+
+			require.NoError(t, p.MarkDormancyWarningSent(ctx, dormant.ID, now))
+
+			ids, err = p.ListIdentitiesDueForDormancyWarning(ctx, now.Add(-time.Hour), 100)
+			require.NoError(t, err)
+			assert.NotContains(t, ids, dormant.ID, "warned identities must not be listed again")
+		})
+
+		t.Run("case=lists identities whose warning failed last", func(t *testing.T) {
+			// Use a cut-off no other case matches, so that only these
+			// identities are listed.
+			at := now.Add(-24 * 365 * time.Hour)
+			failed := newIdentity(t, identity.StateActive, at.Add(-time.Hour))
+			pending := newIdentity(t, identity.StateActive, at.Add(-time.Hour))
+			if failed.ID.String() > pending.ID.String() {
+				failed, pending = pending, failed
+			}
+
+			ids, err := p.ListIdentitiesDueForDormancyWarning(ctx, at, 100)
+			require.NoError(t, err)
+			require.Len(t, ids, 2)
+			assert.Equal(t, failed.ID, ids[0])
+
+			require.NoError(t, p.MarkDormancyWarningFailed(ctx, failed.ID, now.Add(-2*time.Hour)))
+			require.NoError(t, p.MarkDormancyWarningFailed(ctx, failed.ID, now), "only the first failure must be kept")
+
+			ids, err = p.ListIdentitiesDueForDormancyWarning(ctx, at, 1)
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{pending.ID}, ids, "identities whose warning failed must not block the batch")
+
+			ids, err = p.ListIdentitiesDueForDeactivation(ctx, at, now.Add(-time.Hour), 100)
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{failed.ID}, ids, "identities whose warning kept failing for the warning period must be deactivated")
+
+			require.NoError(t, p.MarkDormancyWarningSent(ctx, pending.ID, now))
+			ids, err = p.ListIdentitiesDueForDeactivation(ctx, at, now.Add(-3*time.Hour), 100)
+			require.NoError(t, err)
+			assert.Empty(t, ids)
+		})
+
+		t.Run("case=lists and deactivates identities due for deactivation", func(t *testing.T) {
+			dormant := newIdentity(t, identity.StateActive, now.Add(-48*time.Hour))
+			warned := newIdentity(t, identity.StateActive, now.Add(-48*time.Hour))
+			recent := newIdentity(t, identity.StateActive, now)
+			signedIn := newIdentity(t, identity.StateActive, now.Add(-48*time.Hour))
+
+			require.NoError(t, p.MarkDormancyWarningSent(ctx, warned.ID, now.Add(-2*time.Hour)))
+			require.NoError(t, p.UpdateLastAuthenticatedAt(ctx, signedIn.ID, identity.CredentialsTypePassword, now))
+
+			ids, err := p.ListIdentitiesDueForDeactivation(ctx, now.Add(-time.Hour), time.Time{}, 100)
+			require.NoError(t, err)
+			assert.Contains(t, ids, dormant.ID)
+			assert.Contains(t, ids, warned.ID)
+			assert.NotContains(t, ids, recent.ID)
+			assert.NotContains(t, ids, signedIn.ID)
+
+			ids, err = p.ListIdentitiesDueForDeactivation(ctx, now.Add(-time.Hour), now.Add(-time.Hour), 100)
+			require.NoError(t, err)
+			assert.NotContains(t, ids, dormant.ID, "identities must be warned first")
+			assert.Contains(t, ids, warned.ID)
+		})
+
+		t.Run("case=lists identities due for deletion", func(t *testing.T) {
+			expired := deactivated(t, now.Add(-48*time.Hour))
+			recent := deactivated(t, now)
+			active := newIdentity(t, identity.StateActive, now.Add(-48*time.Hour))
+			manual := newIdentity(t, identity.StateInactive, now.Add(-48*time.Hour))
+
+			// An administrator reactivated the identity, and deactivated it
+			// again later on.
+			reactivated := deactivated(t, now.Add(-72*time.Hour))
+			require.NoError(t, p.GetConnection(ctx).RawQuery(
+				"UPDATE identities SET state_changed_at = ? WHERE id = ?", now.Add(-48*time.Hour), reactivated.ID,
+			).Exec())
+
+			ids, err := p.ListIdentitiesDueForDeletion(ctx, now.Add(-time.Hour), 100)
+			require.NoError(t, err)
+			assert.Contains(t, ids, expired.ID)
+			assert.NotContains(t, ids, recent.ID)
+			assert.NotContains(t, ids, active.ID)
+			assert.NotContains(t, ids, manual.ID, "identities deactivated by an administrator must not be deleted")
+			assert.NotContains(t, ids, reactivated.ID, "identities whose state changed since must not be deleted")
+		})
+
+		t.Run("case=respects the limit", func(t *testing.T) {
+			for range 3 {
+				_ = deactivated(t, now.Add(-48*time.Hour))
+			}
+
+			ids, err := p.ListIdentitiesDueForDeletion(ctx, now.Add(-time.Hour), 2)
+			require.NoError(t, err)
+			assert.Len(t, ids, 2)
+		})
+
+		t.Run("case=network isolation", func(t *testing.T) {
+			dormant := newIdentity(t, identity.StateActive, now.Add(-48*time.Hour))
+
+			_, other := testhelpers.NewNetwork(t, ctx, p)
+			ids, err := other.ListIdentitiesDueForDeactivation(ctx, now.Add(-time.Hour), time.Time{}, 100)
+			require.NoError(t, err)
+			assert.NotContains(t, ids, dormant.ID)
+
+			require.NoError(t, other.MarkDormancyWarningSent(ctx, dormant.ID, now))
+
+			ids, err = p.ListIdentitiesDueForDormancyWarning(ctx, now.Add(-time.Hour), 100)
+			require.NoError(t, err)
+			assert.Contains(t, ids, dormant.ID)
+		})
+	}
+}
@@ -181,6 +181,34 @@ type _ struct {
 	// in: query
 	OrganizationID string `json:"organization_id"`
 
+	// List identities that did not sign in since the given RFC 3339 timestamp. Identities that never signed in are
+	// included if they were created before the given timestamp. Can be combined with other filters.
+	//
+	// required: false
+	// in: query
+	LastAuthenticatedBefore string `json:"last_authenticated_before"`
+
+	// List identities that signed in after the given RFC 3339 timestamp. Can be combined with other filters.
+	//
+	// required: false
+	// in: query
+	LastAuthenticatedAfter string `json:"last_authenticated_after"`
+
+	// Sort identities by the given field. Identities are sorted by ID if not set. Identities that never signed in
+	// are sorted as the oldest when sorting by `last_authenticated_at`.
+	//
+	// required: false
+	// in: query
+	// enum: last_authenticated_at
+	OrderBy string `json:"order_by"`
+
+	// Sort direction used together with `order_by`. Defaults to `asc`.
+	//
+	// required: false
+	// in: query
+	// enum: asc,desc
+	Order string `json:"order"`
+
 	crdbx.ConsistencyRequestParameters
 }
 
@@ -237,6 +265,37 @@ func parseListIdentitiesParameters(r *http.Request) (params ListIdentityParamete
 		return params, errors.WithStack(herodot.ErrBadRequest().WithReason("You cannot combine multiple filters in this API"))
 	}
 
+	for key, target := range map[string]*time.Time{
+		"last_authenticated_before": &params.LastAuthenticatedBefore,
+		"last_authenticated_after":  &params.LastAuthenticatedAfter,
+	} {
+		if v := query.Get(key); v != "" {
+			*target, err = time.Parse(time.RFC3339, v)
+			if err != nil {
+				return params, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Invalid RFC 3339 timestamp `%s` for parameter `%s`.", v, key))
+			}
+		}
+	}
+
+	switch orderBy := ListIdentitiesOrderBy(query.Get("order_by")); orderBy {
+	case "":
+	case ListIdentitiesOrderByLastAuthenticatedAt:
+		if params.CredentialsIdentifier != "" || params.CredentialsIdentifierSimilar != "" {
+			return params, errors.WithStack(herodot.ErrBadRequest().WithReason("Sorting cannot be combined with a credentials identifier filter."))
+		}
+		params.OrderBy = orderBy
+	default:
+		return params, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Invalid value `%s` for parameter `order_by`.", orderBy))
+	}
+
+	switch order := strings.ToUpper(query.Get("order")); order {
+	case "":
+	case string(keysetpagination.OrderAscending), string(keysetpagination.OrderDescending):
+		params.Order = keysetpagination.Order(order)
+	default:
+		return params, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Invalid value `%s` for parameter `order`.", query.Get("order")))
+	}
+
 	params.KeySetPagination, params.PagePagination, err = x.ParseKeysetOrPagePagination(r)
 	if err != nil {
 		return params, err
@@ -35,6 +35,7 @@ import (
 	"github.com/ory/kratos/pkg/testhelpers"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/strategy/deviceauthn"
+	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/configx"
 	"github.com/ory/x/ioutilx"
@@ -3517,3 +3518,76 @@ func TestHandler_Region(t *testing.T) {
 	})
 
 }
+
+func TestHandler_DeleteIdentityLockout(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.MethodEnableConfig(identity.CredentialsTypePassword, true)),
+		configx.WithValues(testhelpers.IdentitySchemasConfig(map[string]string{
+			"default": "file://./stub/identity.schema.json",
+		})),
+		configx.WithValues(map[string]any{
+			config.ViperKeySecurityBruteForceProtectionEnabled:            true,
+			config.ViperKeySecurityBruteForceProtectionIdentifierAttempts: 1,
+			config.ViperKeySecurityBruteForceProtectionIPAttempts:         0,
+		}),
+	)
+	publicTS, adminTS := testhelpers.NewKratosServer(t, reg)
+
+	email := x.NewUUID().String() + "@ory.sh"
+	password := x.NewUUID().String()
+	hashed, err := reg.Hasher(t.Context()).Generate(t.Context(), []byte(password))
+	require.NoError(t, err)
+	i := &identity.Identity{
+		Traits: identity.Traits(fmt.Sprintf(`{"email":%q}`, email)),
+		Credentials: map[identity.CredentialsType]identity.Credentials{
+			identity.CredentialsTypePassword: {
+				Type:        identity.CredentialsTypePassword,
+				Identifiers: []string{email},
+				Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(hashed) + `"}`),
+			},
+		},
+	}
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(t.Context(), i))
+
+	login := func(t *testing.T, password string) (string, *http.Response) {
+		client := testhelpers.NewDebugClient(t)
+		f := testhelpers.InitializeLoginFlowViaAPI(t, client, publicTS, false)
+		return testhelpers.LoginMakeRequest(t, true, false, f, client,
+			fmt.Sprintf(`{"method":"password","identifier":%q,"password":%q}`, email, password))
+	}
+
+	unlock := func(t *testing.T, id string) *http.Response {
+		req, err := http.NewRequest("DELETE", adminTS.URL+"/admin/identities/"+id+"/lockout", nil)
+		require.NoError(t, err)
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res
+	}
+
+	t.Run("case=unlocks a locked identity", func(t *testing.T) {
+		_, res := login(t, "not-"+password)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+		body, res := login(t, password)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+		assert.EqualValues(t, text.ErrorValidationLoginLockedOut, gjson.Get(body, "ui.messages.0.id").Int(), body)
+
+		assert.Equal(t, http.StatusNoContent, unlock(t, i.ID.String()).StatusCode)
+
+		body, res = login(t, password)
+		require.Equal(t, http.StatusOK, res.StatusCode, body)
+		assert.NotEmpty(t, gjson.Get(body, "session_token").String(), body)
+		assert.Equal(t, i.ID.String(), gjson.Get(body, "session.identity.id").String(), body)
+	})
+
+	t.Run("case=returns not found for an unknown identity", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, unlock(t, x.NewUUID().String()).StatusCode)
+	})
+
+	t.Run("case=returns bad request for an invalid identity id", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, unlock(t, "not-a-uuid").StatusCode)
+	})
+}
@@ -103,6 +103,11 @@ type Identity struct {
 	// StateChangedAt contains the last time when the identity's state changed.
 	StateChangedAt *sqlxx.NullTime `json:"state_changed_at,omitempty" faker:"-" db:"state_changed_at"`
 
+	// LastAuthenticatedAt contains the last time the identity completed a login flow.
+	//
+	// It is not set if the identity never signed in.
+	LastAuthenticatedAt *sqlxx.NullTime `json:"last_authenticated_at,omitempty" faker:"-" db:"last_authenticated_at"`
+
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits
 	// in a self-service manner. The input will always be validated against the JSON Schema defined
 	// in `schema_url`.
@@ -537,6 +542,13 @@ func (i *Identity) WithDeclassifiedCredentials(ctx context.Context, c cipher.Pro
 					}
 				}
 
+				if expiresAt := v.Get("access_token_expires_at").String(); ct == CredentialsTypeOIDC && expiresAt != "" {
+					toPublish.Config, err = sjson.SetBytes(toPublish.Config, fmt.Sprintf("providers.%d.access_token_expires_at", i), expiresAt)
+					if err != nil {
+						return false
+					}
+				}
+
 				i++
 				return true
 			})
@@ -58,6 +58,13 @@ type VerifiableAddress struct {
 	// required: true
 	Status VerifiableAddressStatus `json:"status" db:"status"`
 
+	// Indicates that messages to the address bounced or that the recipient
+	// complained about them. No account recovery messages are sent to
+	// undeliverable addresses until they are verified again.
+	//
+	// required: false
+	Undeliverable bool `json:"undeliverable,omitempty" faker:"-" db:"undeliverable"`
+
 	// When the address was verified
 	//
 	// example: 2014-01-01T23:28:56.782Z
@@ -103,7 +110,7 @@ func (a VerifiableAddress) GetID() uuid.UUID {
 
 // Signature returns a unique string representation for the recovery address.
 func (a VerifiableAddress) Signature() string {
-	return fmt.Sprintf("%v|%v|%v|%v|%v|%v|%v", a.Value, a.Verified, a.Via, a.Status, a.VerifiedAt, a.IdentityID, a.NID)
+	return fmt.Sprintf("%v|%v|%v|%v|%v|%v|%v|%v", a.Value, a.Verified, a.Via, a.Status, a.Undeliverable, a.VerifiedAt, a.IdentityID, a.NID)
 }
 
 func VerifiableAddressesEqual(original, updated []VerifiableAddress) bool {
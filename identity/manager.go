@@ -718,7 +718,9 @@ type AddressRef struct {
 // the appropriate courier channel, sharing the courier, identity-model, and
 // error-collection plumbing across the concrete notification types. buildEmail
 // and buildSMS construct the channel-specific template for a single recipient
-// from the identity model and a shared RFC3339 timestamp. Errors from individual
+// from the identity model, a shared RFC3339 timestamp, and the locale of the
+// identity. buildSMS may be nil
+// for notifications which are only sent by email. Errors from individual
 // targets are collected and returned as a joined error but do not short-circuit
 // the batch — a failure to notify one recipient must not prevent others from
 // being notified.
@@ -727,8 +729,8 @@ func (m *Manager) sendIdentityNotifications(
 	spanName string,
 	targets []AddressRef,
 	i *Identity,
-	buildEmail func(to string, identity map[string]any, at string) courier.EmailTemplate,
-	buildSMS func(to string, identity map[string]any, at string) courier.SMSTemplate,
+	buildEmail func(to string, identity map[string]any, at, locale string) courier.EmailTemplate,
+	buildSMS func(to string, identity map[string]any, at, locale string) courier.SMSTemplate,
 ) (err error) {
 	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, spanName)
 	defer otelx.End(span, &err)
@@ -747,19 +749,28 @@ func (m *Manager) sendIdentityNotifications(
 		return errors.WithStack(err)
 	}
 	at := time.Now().UTC().Format(time.RFC3339)
+	// Notifications are not sent in response to a request of the recipient,
+	// so the locale is only resolved from the identity.
+	locale := template.ResolveLocale(ctx, m.r.Config(), i.Traits, nil)
 
 	var errs []error
 	for _, t := range targets {
 		switch t.Via {
 		case AddressTypeEmail:
-			if _, qerr := c.QueueEmail(ctx, buildEmail(t.Value, model, at)); qerr != nil {
+			if _, qerr := c.QueueEmail(ctx, buildEmail(t.Value, model, at, locale)); qerr != nil {
 				m.r.Logger().WithError(qerr).
 					WithField("via", t.Via).
 					Warn("Failed to queue identity notification email.")
 				errs = append(errs, qerr)
 			}
 		case AddressTypeSMS:
-			if _, qerr := c.QueueSMS(ctx, buildSMS(t.Value, model, at)); qerr != nil {
+			if buildSMS == nil {
+				m.r.Logger().
+					WithField("via", t.Via).
+					Debug("Skipping identity notification target because the notification has no SMS template.")
+				continue
+			}
+			if _, qerr := c.QueueSMS(ctx, buildSMS(t.Value, model, at, locale)); qerr != nil {
 				m.r.Logger().WithError(qerr).
 					WithField("via", t.Via).
 					Warn("Failed to queue identity notification SMS.")
@@ -782,11 +793,11 @@ func (m *Manager) sendIdentityNotifications(
 // prevent others from receiving their notification.
 func (m *Manager) SendVerifiableAddressChangedNotifications(ctx context.Context, targets []AddressRef, i *Identity) error {
 	return m.sendIdentityNotifications(ctx, "identity.Manager.SendVerifiableAddressChangedNotifications", targets, i,
-		func(to string, identity map[string]any, at string) courier.EmailTemplate {
-			return email.NewVerifiableAddressChanged(m.r, &email.VerifiableAddressChangedModel{To: to, Identity: identity, ChangedAt: at})
+		func(to string, identity map[string]any, at, locale string) courier.EmailTemplate {
+			return email.NewVerifiableAddressChanged(m.r, &email.VerifiableAddressChangedModel{To: to, Identity: identity, ChangedAt: at, Locale: locale})
 		},
-		func(to string, identity map[string]any, at string) courier.SMSTemplate {
-			return sms.NewVerifiableAddressChanged(m.r, &sms.VerifiableAddressChangedModel{To: to, Identity: identity, ChangedAt: at})
+		func(to string, identity map[string]any, at, locale string) courier.SMSTemplate {
+			return sms.NewVerifiableAddressChanged(m.r, &sms.VerifiableAddressChangedModel{To: to, Identity: identity, ChangedAt: at, Locale: locale})
 		},
 	)
 }
@@ -799,11 +810,139 @@ func (m *Manager) SendVerifiableAddressChangedNotifications(ctx context.Context,
 // error; they should log and continue.
 func (m *Manager) SendAuthenticatorKeyAddedNotifications(ctx context.Context, targets []AddressRef, i *Identity) error {
 	return m.sendIdentityNotifications(ctx, "identity.Manager.SendAuthenticatorKeyAddedNotifications", targets, i,
-		func(to string, identity map[string]any, at string) courier.EmailTemplate {
-			return email.NewAuthenticatorKeyAdded(m.r, &email.AuthenticatorKeyAddedModel{To: to, Identity: identity, AddedAt: at})
+		func(to string, identity map[string]any, at, locale string) courier.EmailTemplate {
+			return email.NewAuthenticatorKeyAdded(m.r, &email.AuthenticatorKeyAddedModel{To: to, Identity: identity, AddedAt: at, Locale: locale})
+		},
+		func(to string, identity map[string]any, at, locale string) courier.SMSTemplate {
+			return sms.NewAuthenticatorKeyAdded(m.r, &sms.AuthenticatorKeyAddedModel{To: to, Identity: identity, AddedAt: at, Locale: locale})
+		},
+	)
+}
+
+// NewDeviceLogin describes a sign-in from a device, IP range, or location
+// which the identity did not use before.
+type NewDeviceLogin struct {
+	IPAddress string
+	UserAgent string
+	Location  string
+
+	// NotMeURL revokes the session and starts account recovery.
+	NotMeURL string
+}
+
+// SendLoginNewDeviceNotifications queues a security notification to each
+// target via the appropriate courier channel after the identity signed in
+// from a new device or location. Errors from individual targets are collected
+// and returned as a joined error but do not short-circuit the batch — callers
+// must never fail the login on a courier error.
+func (m *Manager) SendLoginNewDeviceNotifications(ctx context.Context, targets []AddressRef, i *Identity, login NewDeviceLogin) error {
+	return m.sendIdentityNotifications(ctx, "identity.Manager.SendLoginNewDeviceNotifications", targets, i,
+		func(to string, identity map[string]any, at, locale string) courier.EmailTemplate {
+			return email.NewLoginNewDevice(m.r, &email.LoginNewDeviceModel{
+				To: to, Identity: identity, LoggedInAt: at,
+				IPAddress: login.IPAddress, UserAgent: login.UserAgent, Location: login.Location, NotMeURL: login.NotMeURL,
+				Locale: locale,
+			})
 		},
-		func(to string, identity map[string]any, at string) courier.SMSTemplate {
-			return sms.NewAuthenticatorKeyAdded(m.r, &sms.AuthenticatorKeyAddedModel{To: to, Identity: identity, AddedAt: at})
+		func(to string, identity map[string]any, at, locale string) courier.SMSTemplate {
+			return sms.NewLoginNewDevice(m.r, &sms.LoginNewDeviceModel{
+				To: to, Identity: identity, LoggedInAt: at,
+				IPAddress: login.IPAddress, UserAgent: login.UserAgent, Location: login.Location, NotMeURL: login.NotMeURL,
+				Locale: locale,
+			})
+		},
+	)
+}
+
+// SecurityNotification describes a change to the credentials or sessions of an
+// identity which the identity is notified about.
+type SecurityNotification struct {
+	// TemplateType is one of the password_changed, totp_removed,
+	// lookup_secrets_regenerated, oidc_provider_unlinked, or sessions_revoked
+	// template types.
+	TemplateType template.TemplateType
+
+	// Provider is the ID of the unlinked OpenID Connect provider.
+	Provider string
+}
+
+// SendSecurityNotification queues the security notification to all verified
+// email addresses and phone numbers of the identity, if security notifications
+// are enabled. Callers must never fail the flow which changed the credentials
+// on a courier error; they should log and continue.
+func (m *Manager) SendSecurityNotification(ctx context.Context, i *Identity, n SecurityNotification) error {
+	if !m.r.Config().CourierSecurityNotificationsEnabled(ctx) {
+		return nil
+	}
+
+	var targets []AddressRef
+	for _, a := range i.VerifiableAddresses {
+		if a.Verified && (a.Via == AddressTypeEmail || a.Via == AddressTypeSMS) {
+			targets = append(targets, AddressRef{Value: a.Value, Via: a.Via})
+		}
+	}
+
+	spanName := "identity.Manager.SendSecurityNotification"
+	switch n.TemplateType {
+	case template.TypePasswordChanged:
+		return m.sendIdentityNotifications(ctx, spanName, targets, i,
+			func(to string, identity map[string]any, at, locale string) courier.EmailTemplate {
+				return email.NewPasswordChanged(m.r, &email.PasswordChangedModel{To: to, Identity: identity, ChangedAt: at, Locale: locale})
+			},
+			func(to string, identity map[string]any, at, locale string) courier.SMSTemplate {
+				return sms.NewPasswordChanged(m.r, &sms.PasswordChangedModel{To: to, Identity: identity, ChangedAt: at, Locale: locale})
+			},
+		)
+	case template.TypeTOTPRemoved:
+		return m.sendIdentityNotifications(ctx, spanName, targets, i,
+			func(to string, identity map[string]any, at, locale string) courier.EmailTemplate {
+				return email.NewTOTPRemoved(m.r, &email.TOTPRemovedModel{To: to, Identity: identity, RemovedAt: at, Locale: locale})
+			},
+			func(to string, identity map[string]any, at, locale string) courier.SMSTemplate {
+				return sms.NewTOTPRemoved(m.r, &sms.TOTPRemovedModel{To: to, Identity: identity, RemovedAt: at, Locale: locale})
+			},
+		)
+	case template.TypeLookupSecretsRegenerated:
+		return m.sendIdentityNotifications(ctx, spanName, targets, i,
+			func(to string, identity map[string]any, at, locale string) courier.EmailTemplate {
+				return email.NewLookupSecretsRegenerated(m.r, &email.LookupSecretsRegeneratedModel{To: to, Identity: identity, RegeneratedAt: at, Locale: locale})
+			},
+			func(to string, identity map[string]any, at, locale string) courier.SMSTemplate {
+				return sms.NewLookupSecretsRegenerated(m.r, &sms.LookupSecretsRegeneratedModel{To: to, Identity: identity, RegeneratedAt: at, Locale: locale})
+			},
+		)
+	case template.TypeOIDCProviderUnlinked:
+		return m.sendIdentityNotifications(ctx, spanName, targets, i,
+			func(to string, identity map[string]any, at, locale string) courier.EmailTemplate {
+				return email.NewOIDCProviderUnlinked(m.r, &email.OIDCProviderUnlinkedModel{To: to, Identity: identity, Provider: n.Provider, UnlinkedAt: at, Locale: locale})
+			},
+			func(to string, identity map[string]any, at, locale string) courier.SMSTemplate {
+				return sms.NewOIDCProviderUnlinked(m.r, &sms.OIDCProviderUnlinkedModel{To: to, Identity: identity, Provider: n.Provider, UnlinkedAt: at, Locale: locale})
+			},
+		)
+	case template.TypeSessionsRevoked:
+		return m.sendIdentityNotifications(ctx, spanName, targets, i,
+			func(to string, identity map[string]any, at, locale string) courier.EmailTemplate {
+				return email.NewSessionsRevoked(m.r, &email.SessionsRevokedModel{To: to, Identity: identity, RevokedAt: at, Locale: locale})
+			},
+			func(to string, identity map[string]any, at, locale string) courier.SMSTemplate {
+				return sms.NewSessionsRevoked(m.r, &sms.SessionsRevokedModel{To: to, Identity: identity, RevokedAt: at, Locale: locale})
+			},
+		)
+	default:
+		return errors.Errorf("unexpected security notification template type: %s", n.TemplateType)
+	}
+}
+
+// SendDormantAccountWarningNotifications queues a warning to each email target
+// that the identity will be deactivated at the given time because it did not
+// sign in for a while. There is no SMS variant of this notification, so SMS
+// targets are skipped.
+func (m *Manager) SendDormantAccountWarningNotifications(ctx context.Context, targets []AddressRef, i *Identity, deactivatesAt time.Time) error {
+	return m.sendIdentityNotifications(ctx, "identity.Manager.SendDormantAccountWarningNotifications", targets, i,
+		func(to string, identity map[string]any, _, locale string) courier.EmailTemplate {
+			return email.NewDormantAccountWarning(m.r, &email.DormantAccountWarningModel{To: to, Identity: identity, DeactivatesAt: deactivatesAt.UTC().Format(time.RFC3339), Locale: locale})
 		},
+		nil,
 	)
 }
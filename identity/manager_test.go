@@ -1180,3 +1180,104 @@ func TestManager_SendAuthenticatorKeyAddedNotifications(t *testing.T) {
 		require.NoError(t, reg.IdentityManager().SendAuthenticatorKeyAddedNotifications(ctx, nil, i))
 	})
 }
+
+func TestManager_SendSecurityNotification(t *testing.T) {
+	newIdentity := func() (*identity.Identity, string, string) {
+		verified := x.NewUUID().String() + "@example.com"
+		unverified := x.NewUUID().String() + "@example.com"
+		i := identity.NewIdentity("default")
+		i.VerifiableAddresses = []identity.VerifiableAddress{
+			{Value: verified, Via: identity.AddressTypeEmail, Verified: true},
+			{Value: unverified, Via: identity.AddressTypeEmail},
+		}
+		return i, verified, unverified
+	}
+
+	t.Run("case=enabled", func(t *testing.T) {
+		_, reg := pkg.NewFastRegistryWithMocks(t,
+			configx.WithValues(map[string]interface{}{
+				config.ViperKeyCourierSMTPURL:                      "smtp://foo@bar@dev.null/",
+				config.ViperKeyDefaultIdentitySchemaID:             "default",
+				config.ViperKeyCourierSecurityNotificationsEnabled: true,
+			}),
+			configx.WithValues(testhelpers.IdentitySchemasConfig(map[string]string{
+				"default": "file://./stub/manager.schema.json",
+			})),
+		)
+
+		for _, n := range []identity.SecurityNotification{
+			{TemplateType: template.TypePasswordChanged},
+			{TemplateType: template.TypeTOTPRemoved},
+			{TemplateType: template.TypeLookupSecretsRegenerated},
+			{TemplateType: template.TypeOIDCProviderUnlinked, Provider: "github"},
+			{TemplateType: template.TypeSessionsRevoked},
+		} {
+			t.Run("template_type="+string(n.TemplateType), func(t *testing.T) {
+				ctx := t.Context()
+				i, verified, unverified := newIdentity()
+				require.NoError(t, reg.IdentityManager().SendSecurityNotification(ctx, i, n))
+
+				count, err := reg.CourierPersister().CountMessages(ctx, courier.MessagesFilter{Recipient: verified, TemplateType: n.TemplateType})
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+
+				count, err = reg.CourierPersister().CountMessages(ctx, courier.MessagesFilter{Recipient: unverified})
+				require.NoError(t, err)
+				assert.Zero(t, count, "unverified addresses are not notified")
+			})
+		}
+
+		t.Run("case=unknown template type", func(t *testing.T) {
+			i, _, _ := newIdentity()
+			require.Error(t, reg.IdentityManager().SendSecurityNotification(t.Context(), i, identity.SecurityNotification{TemplateType: template.TypeTestStub}))
+		})
+	})
+
+	t.Run("case=disabled", func(t *testing.T) {
+		ctx := t.Context()
+		_, reg := pkg.NewFastRegistryWithMocks(t,
+			configx.WithValues(map[string]interface{}{
+				config.ViperKeyCourierSMTPURL:          "smtp://foo@bar@dev.null/",
+				config.ViperKeyDefaultIdentitySchemaID: "default",
+			}),
+		)
+
+		i, verified, _ := newIdentity()
+		require.NoError(t, reg.IdentityManager().SendSecurityNotification(ctx, i, identity.SecurityNotification{TemplateType: template.TypePasswordChanged}))
+
+		count, err := reg.CourierPersister().CountMessages(ctx, courier.MessagesFilter{Recipient: verified})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func TestManager_SendDormantAccountWarningNotifications(t *testing.T) {
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(map[string]interface{}{
+			config.ViperKeyCourierSMTPURL:          "smtp://foo@bar@dev.null/",
+			config.ViperKeyDefaultIdentitySchemaID: "default",
+		}),
+		configx.WithValues(testhelpers.IdentitySchemasConfig(map[string]string{
+			"default": "file://./stub/manager.schema.json",
+		})),
+	)
+
+	ctx := t.Context()
+	i := identity.NewIdentity("default")
+	i.Traits = identity.Traits(`{"email":"dormant@example.com"}`)
+	require.NoError(t, reg.IdentityManager().Create(ctx, i))
+
+	deactivatesAt := time.Date(2026, 4, 21, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, reg.IdentityManager().SendDormantAccountWarningNotifications(ctx, []identity.AddressRef{
+		{Value: "dormant@example.com", Via: identity.AddressTypeEmail},
+		{Value: "+15557654321", Via: identity.AddressTypeSMS},
+	}, i, deactivatesAt))
+
+	messages, err := reg.CourierPersister().NextMessages(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 1, "SMS targets must be skipped")
+	assert.Equal(t, template.TypeDormantAccountWarning, messages[0].TemplateType)
+	assert.Equal(t, courier.MessageTypeEmail, messages[0].Type)
+	assert.Equal(t, "dormant@example.com", messages[0].Recipient)
+	assert.Contains(t, messages[0].Body, "2026-04-21T12:00:00Z")
+}
@@ -5,6 +5,7 @@ package identity
 
 import (
 	"context"
+	"time"
 
 	"github.com/ory/kratos/x"
 	"github.com/ory/pop/v6"
@@ -101,6 +102,13 @@ func (o UpdateIdentityOptions) ExtraColumns() []ExtraColumn {
 	return o.extraColumns
 }
 
+// ListIdentitiesOrderBy is a column identities can be sorted by.
+type ListIdentitiesOrderBy string
+
+// ListIdentitiesOrderByLastAuthenticatedAt sorts identities by the time they
+// last signed in. Identities that never signed in sort as the oldest.
+const ListIdentitiesOrderByLastAuthenticatedAt ListIdentitiesOrderBy = "last_authenticated_at"
+
 type (
 	ListIdentityParameters struct {
 		Expand                       Expandables
@@ -113,6 +121,21 @@ type (
 		ConsistencyLevel             crdbx.ConsistencyLevel
 		StatementTransformer         func(string) string
 
+		// LastAuthenticatedBefore limits the result to identities that did not
+		// sign in since the given time. Identities that never signed in are
+		// included if they were created before the given time.
+		LastAuthenticatedBefore time.Time
+
+		// LastAuthenticatedAfter limits the result to identities that signed in
+		// after the given time.
+		LastAuthenticatedAfter time.Time
+
+		// OrderBy sorts the result. Identities are sorted by ID if empty.
+		OrderBy ListIdentitiesOrderBy
+
+		// Order is the sort direction used together with OrderBy.
+		Order keysetpagination.Order
+
 		// ColumnsTransformer rewrites the SELECT column list to add extra
 		// columns the persister scans. Must be set together with RowScanner;
 		// the persister rejects the call if only one is provided.
@@ -214,6 +237,11 @@ type (
 		// UpdateIdentityColumns updates targeted columns of an identity.
 		UpdateIdentityColumns(ctx context.Context, i *Identity, columns ...string) error
 
+		// UpdateLastAuthenticatedAt records that the identity completed a login flow
+		// using the given credentials type at the given time. It sets the identity's
+		// last_authenticated_at and the credential's last_used_at.
+		UpdateLastAuthenticatedAt(ctx context.Context, identityID uuid.UUID, ct CredentialsType, at time.Time) error
+
 		// UpdateCredentialsConfig atomically read-modify-writes a single
 		// identity_credentials row's config under an exclusive row lock:
 		// concurrent updates serialize and mutate always observes the latest
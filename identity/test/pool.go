@@ -4218,6 +4218,132 @@ func TestPool(ctx context.Context, p persistence.Persister, m *identity.Manager,
 				assert.Equal(t, before, identifierRows(t, p, c.ID))
 			})
 		})
+
+		t.Run("suite=last-authenticated-at", func(t *testing.T) {
+			_, p := testhelpers.NewNetwork(t, ctx, p)
+
+			create := func(t *testing.T) *identity.Identity {
+				i := passwordIdentity("", x.NewUUID().String())
+				require.NoError(t, p.CreateIdentity(ctx, i))
+				return i
+			}
+
+			never := create(t)
+			earlier := create(t)
+			later := create(t)
+
+			now := time.Now().UTC().Truncate(time.Second)
+			require.NoError(t, p.UpdateLastAuthenticatedAt(ctx, earlier.ID, identity.CredentialsTypePassword, now.Add(-48*time.Hour)))
+			require.NoError(t, p.UpdateLastAuthenticatedAt(ctx, later.ID, identity.CredentialsTypePassword, now))
+
+			t.Run("case=records identity and credential timestamps", func(t *testing.T) {
+				actual, err := p.GetIdentityConfidential(ctx, later.ID)
+				require.NoError(t, err)
+				require.NotNil(t, actual.LastAuthenticatedAt)
+				assert.WithinDuration(t, now, time.Time(*actual.LastAuthenticatedAt), time.Second)
+
+				c, ok := actual.GetCredentials(identity.CredentialsTypePassword)
+				require.True(t, ok)
+				require.NotNil(t, c.LastUsedAt)
+				assert.WithinDuration(t, now, time.Time(*c.LastUsedAt), time.Second)
+
+				actual, err = p.GetIdentityConfidential(ctx, never.ID)
+				require.NoError(t, err)
+				assert.Nil(t, actual.LastAuthenticatedAt)
+			})
+
+			t.Run("case=is not reset by identity updates", func(t *testing.T) {
+				i, err := p.GetIdentityConfidential(ctx, later.ID)
+				require.NoError(t, err)
+				i.LastAuthenticatedAt = nil
+				require.NoError(t, p.UpdateIdentity(ctx, i))
+
+				actual, err := p.GetIdentity(ctx, later.ID, identity.ExpandNothing)
+				require.NoError(t, err)
+				require.NotNil(t, actual.LastAuthenticatedAt)
+			})
+
+			t.Run("case=filters by last authentication", func(t *testing.T) {
+				is, _, err := p.ListIdentities(ctx, identity.ListIdentityParameters{
+					Expand:                 identity.ExpandNothing,
+					LastAuthenticatedAfter: now.Add(-time.Hour),
+				})
+				require.NoError(t, err)
+				require.Len(t, is, 1)
+				assert.Equal(t, later.ID, is[0].ID)
+
+				is, _, err = p.ListIdentities(ctx, identity.ListIdentityParameters{
+					Expand:                  identity.ExpandNothing,
+					LastAuthenticatedBefore: now.Add(-time.Hour),
+				})
+				require.NoError(t, err)
+				require.Len(t, is, 1, "identities which never signed in are compared by their creation date")
+				assert.Equal(t, earlier.ID, is[0].ID)
+
+				is, _, err = p.ListIdentities(ctx, identity.ListIdentityParameters{
+					Expand:                  identity.ExpandNothing,
+					LastAuthenticatedBefore: now.Add(time.Hour),
+				})
+				require.NoError(t, err)
+				assert.Len(t, is, 3)
+			})
+
+			t.Run("case=sorts by last authentication", func(t *testing.T) {
+				for _, tc := range []struct {
+					order    keysetpagination.Order
+					expected []uuid.UUID
+				}{
+					{order: keysetpagination.OrderAscending, expected: []uuid.UUID{never.ID, earlier.ID, later.ID}},
+					{order: keysetpagination.OrderDescending, expected: []uuid.UUID{later.ID, earlier.ID, never.ID}},
+				} {
+					t.Run("order="+string(tc.order), func(t *testing.T) {
+						var actual []uuid.UUID
+						opts := []keysetpagination.Option{keysetpagination.WithSize(1)}
+						for range len(tc.expected) + 1 {
+							is, next, err := p.ListIdentities(ctx, identity.ListIdentityParameters{
+								Expand:           identity.ExpandNothing,
+								KeySetPagination: opts,
+								OrderBy:          identity.ListIdentitiesOrderByLastAuthenticatedAt,
+								Order:            tc.order,
+							})
+							require.NoError(t, err)
+							for _, i := range is {
+								actual = append(actual, i.ID)
+							}
+							if next.IsLast() {
+								break
+							}
+							opts = next.ToOptions()
+						}
+						assert.Equal(t, tc.expected, actual)
+					})
+				}
+			})
+
+			t.Run("case=credential timestamps are not rolled back by stale updates", func(t *testing.T) {
+				i := create(t)
+				require.NoError(t, p.UpdateLastAuthenticatedAt(ctx, i.ID, identity.CredentialsTypePassword, now.Add(-time.Hour)))
+				stale, err := p.GetIdentityConfidential(ctx, i.ID)
+				require.NoError(t, err)
+
+				// A login completes while the stale copy is being changed.
+				require.NoError(t, p.UpdateLastAuthenticatedAt(ctx, i.ID, identity.CredentialsTypePassword, now))
+
+				c, ok := stale.GetCredentials(identity.CredentialsTypePassword)
+				require.True(t, ok)
+				c.Config = sqlxx.JSONRawMessage(`{"foo":"baz"}`)
+				stale.SetCredentials(identity.CredentialsTypePassword, *c)
+				require.NoError(t, p.UpdateIdentity(ctx, stale))
+
+				actual, err := p.GetIdentityConfidential(ctx, i.ID)
+				require.NoError(t, err)
+				c, ok = actual.GetCredentials(identity.CredentialsTypePassword)
+				require.True(t, ok)
+				assert.JSONEq(t, `{"foo":"baz"}`, string(c.Config))
+				require.NotNil(t, c.LastUsedAt)
+				assert.WithinDuration(t, now, time.Time(*c.LastUsedAt), time.Second)
+			})
+		})
 	}
 }
 
@@ -0,0 +1,235 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/request"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/jsonnetsecure"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+// CloudEventsSpecVersion is the CloudEvents specification version of the
+// delivered events.
+const CloudEventsSpecVersion = "1.0"
+
+type (
+	dispatcherDependencies interface {
+		PersistenceProvider
+		config.Provider
+		otelx.Provider
+		logrusx.Provider
+		httpx.ClientProvider
+		jsonnetsecure.VMProvider
+	}
+	DispatcherProvider interface {
+		OutboxDispatcher() *Dispatcher
+	}
+
+	// Dispatcher delivers the outbox messages to the configured HTTP
+	// endpoint using the CloudEvents HTTP binary content mode: the event
+	// attributes are sent as `ce-` headers and the event data is the request
+	// body.
+	//
+	// Failed deliveries are retried with an exponential backoff. Messages
+	// which could not be delivered after the maximum number of attempts are
+	// dead-lettered.
+	Dispatcher struct {
+		r       dispatcherDependencies
+		backoff backoff.BackOff
+	}
+)
+
+func NewDispatcher(r dispatcherDependencies) *Dispatcher {
+	return &Dispatcher{
+		r:       r,
+		backoff: backoff.NewExponentialBackOff(),
+	}
+}
+
+func (d *Dispatcher) UseBackoff(b backoff.BackOff) {
+	d.backoff = b
+}
+
+// Work delivers messages until the context is canceled.
+func (d *Dispatcher) Work(ctx context.Context) error {
+	errChan := make(chan error)
+	defer close(errChan)
+
+	go d.watchMessages(ctx, errChan)
+
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil
+		}
+		return ctx.Err()
+	case err := <-errChan:
+		return errors.WithStack(err)
+	}
+}
+
+func (d *Dispatcher) watchMessages(ctx context.Context, errChan chan error) {
+	d.backoff.Reset()
+	for {
+		if err := backoff.Retry(func() error {
+			return d.DispatchQueue(ctx)
+		}, d.backoff); err != nil {
+			errChan <- errors.WithStack(err)
+			return
+		}
+
+		c, err := d.r.Config().Outbox(ctx)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		time.Sleep(c.PullWait)
+	}
+}
+
+// DispatchQueue delivers the messages which are due. Delivery failures are
+// recorded on the message and are not returned.
+func (d *Dispatcher) DispatchQueue(ctx context.Context) (err error) {
+	ctx, span := d.r.Tracer(ctx).Tracer().Start(ctx, "outbox.Dispatcher.DispatchQueue")
+	defer otelx.End(span, &err)
+
+	c, err := d.r.Config().Outbox(ctx)
+	if err != nil {
+		return err
+	}
+	if !c.Enabled {
+		return nil
+	}
+
+	messages, err := d.r.OutboxPersister().NextOutboxMessages(ctx, c.PullCount, c.LeaseDuration)
+	if err != nil {
+		if errors.Is(err, ErrQueueEmpty) {
+			return nil
+		}
+		return err
+	}
+	span.SetAttributes(attribute.Int("messages_count", len(messages)))
+
+	for _, m := range messages {
+		logger := d.r.Logger().
+			WithField("message_id", m.ID).
+			WithField("message_nid", m.NID).
+			WithField("message_event_type", m.EventType).
+			WithField("message_attempts", m.Attempts+1)
+
+		// The lease expired during the delivery, and another dispatcher
+		// claimed the message again. Its attempt is recorded instead.
+		leaseLost := func(err error) bool {
+			if !errors.Is(err, ErrMessageLeaseLost) {
+				return false
+			}
+			logger.WithError(err).Warn("Skipped recording the outbox delivery attempt because the lease expired.")
+			return true
+		}
+
+		deliveryErr := d.deliver(ctx, c, m)
+		if deliveryErr == nil {
+			if err := d.r.OutboxPersister().SetOutboxMessageDelivered(ctx, &m); leaseLost(err) {
+				continue
+			} else if err != nil {
+				logger.WithError(err).Error(`Unable to set the outbox message's status to "delivered".`)
+				return err
+			}
+			logger.Debug("Delivered outbox message.")
+			continue
+		}
+
+		if m.Attempts+1 >= c.MaxAttempts {
+			if err := d.r.OutboxPersister().DeadLetterOutboxMessage(ctx, &m, deliveryErr.Error()); leaseLost(err) {
+				continue
+			} else if err != nil {
+				logger.WithError(err).Error(`Unable to set the outbox message's status to "dead_lettered".`)
+				return err
+			}
+			logger.WithError(deliveryErr).Warn("Outbox message was dead-lettered because it could not be delivered.")
+			continue
+		}
+
+		next := time.Now().UTC().Add(retryInterval(c, m.Attempts+1))
+		if err := d.r.OutboxPersister().RetryOutboxMessage(ctx, &m, next, deliveryErr.Error()); leaseLost(err) {
+			continue
+		} else if err != nil {
+			logger.WithError(err).Error(`Unable to reset the outbox message's status to "queued".`)
+			return err
+		}
+		logger.WithError(deliveryErr).WithField("next_attempt_at", next).Warn("Unable to deliver outbox message.")
+	}
+
+	return nil
+}
+
+// retryInterval returns the time to wait after the given number of failed
+// attempts. The interval doubles with every attempt.
+func retryInterval(c *config.Outbox, attempts int) time.Duration {
+	interval := c.InitialInterval
+	for i := 1; i < attempts && interval < c.MaxInterval; i++ {
+		interval *= 2
+	}
+	return min(interval, c.MaxInterval)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, c *config.Outbox, m Message) (err error) {
+	ctx, span := d.r.Tracer(ctx).Tracer().Start(ctx, "outbox.Dispatcher.deliver", trace.WithAttributes(
+		attribute.Stringer("message.id", m.ID),
+		attribute.Stringer("message.nid", m.NID),
+		attribute.String("message.event_type", m.EventType),
+		attribute.Int("message.attempts", m.Attempts),
+	))
+	defer otelx.End(span, &err)
+
+	// The builder keeps the headers on the configuration, so each request
+	// gets its own copy.
+	rc := c.RequestConfig
+	builder, err := request.NewBuilder(&rc, d.r)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req, err := builder.BuildRawRequest(ctx, json.RawMessage(m.Data))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req.Header.Set("ce-specversion", CloudEventsSpecVersion)
+	req.Header.Set("ce-id", m.ID.String())
+	req.Header.Set("ce-source", c.Source)
+	req.Header.Set("ce-type", m.EventType)
+	req.Header.Set("ce-subject", m.Subject.String())
+	req.Header.Set("ce-time", m.CreatedAt.UTC().Format(time.RFC3339Nano))
+
+	res, err := d.r.HTTPClient(ctx,
+		// fail fast and let the dispatcher retry instead of blocking the queue
+		httpx.ResilientClientWithMaxRetry(0),
+		httpx.ResilientClientWithConnectionTimeout(10*time.Second),
+	).Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func(body io.ReadCloser) { _ = body.Close() }(res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return errors.Errorf("event receiver replied with status code %d: %s", res.StatusCode, body)
+	}
+
+	return nil
+}
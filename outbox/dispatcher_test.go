@@ -0,0 +1,190 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/configx"
+)
+
+type receivedEvent struct {
+	header http.Header
+	body   []byte
+}
+
+type receiver struct {
+	sync.Mutex
+	status int
+	events []receivedEvent
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.Lock()
+	defer r.Unlock()
+	r.events = append(r.events, receivedEvent{header: req.Header.Clone(), body: body})
+	w.WriteHeader(r.status)
+}
+
+func (r *receiver) received() []receivedEvent {
+	r.Lock()
+	defer r.Unlock()
+	return append([]receivedEvent{}, r.events...)
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newRegistry := func(t *testing.T, status int, values map[string]any) (*driver.RegistryDefault, *receiver) {
+		rcv := &receiver{status: status}
+		srv := httptest.NewServer(rcv)
+		t.Cleanup(srv.Close)
+
+		v := map[string]any{
+			config.ViperKeyOutboxEnabled:                    true,
+			config.ViperKeyOutboxSource:                     "https://auth.example.com/",
+			config.ViperKeyOutboxHTTPRequestConfig + ".url": srv.URL,
+		}
+		for k, val := range values {
+			v[k] = val
+		}
+		_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(v))
+		testhelpers.SetDefaultIdentitySchema(reg.Config(), "file://../identity/stub/identity.schema.json")
+		return reg, rcv
+	}
+
+	createIdentity := func(t *testing.T, reg *driver.RegistryDefault) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"` + uuid.Must(uuid.NewV4()).String() + `@ory.sh"}`)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	t.Run("case=delivers identity changes as CloudEvents", func(t *testing.T) {
+		t.Parallel()
+
+		reg, rcv := newRegistry(t, http.StatusNoContent, nil)
+
+		i := createIdentity(t, reg)
+		i.Traits = identity.Traits(`{"email":"updated-` + i.ID.String() + `@ory.sh"}`)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, i))
+		require.NoError(t, reg.PrivilegedIdentityPool().DeleteIdentity(ctx, i.ID))
+
+		require.NoError(t, reg.OutboxDispatcher().DispatchQueue(ctx))
+
+		received := rcv.received()
+		require.Len(t, received, 3)
+
+		types := make([]string, len(received))
+		for k, e := range received {
+			types[k] = e.header.Get("ce-type")
+			assert.Equal(t, "1.0", e.header.Get("ce-specversion"))
+			assert.Equal(t, "https://auth.example.com/", e.header.Get("ce-source"))
+			assert.Equal(t, i.ID.String(), e.header.Get("ce-subject"))
+			assert.NotEmpty(t, e.header.Get("ce-id"))
+			assert.NotEmpty(t, e.header.Get("ce-time"))
+			assert.Equal(t, "application/json", e.header.Get("Content-Type"))
+		}
+		assert.ElementsMatch(t, []string{
+			outbox.EventTypeIdentityCreated,
+			outbox.EventTypeIdentityUpdated,
+			outbox.EventTypeIdentityDeleted,
+		}, types)
+
+		for _, e := range received {
+			switch e.header.Get("ce-type") {
+			case outbox.EventTypeIdentityUpdated:
+				assert.Equal(t, "updated-"+i.ID.String()+"@ory.sh", gjson.GetBytes(e.body, "traits.email").String(), "%s", e.body)
+				assert.False(t, gjson.GetBytes(e.body, "credentials").Exists(), "credentials must not be delivered: %s", e.body)
+			case outbox.EventTypeIdentityDeleted:
+				assert.Equal(t, i.ID.String(), gjson.GetBytes(e.body, "identity_id").String(), "%s", e.body)
+			}
+		}
+
+		_, err := reg.OutboxPersister().NextOutboxMessages(ctx, 10, time.Hour)
+		assert.ErrorIs(t, err, outbox.ErrQueueEmpty, "delivered messages must not be delivered again")
+	})
+
+	t.Run("case=delivers session revocations", func(t *testing.T) {
+		t.Parallel()
+
+		reg, rcv := newRegistry(t, http.StatusOK, nil)
+		sess := testhelpers.CreateSession(t, reg)
+		require.NoError(t, reg.OutboxDispatcher().DispatchQueue(ctx))
+
+		require.NoError(t, reg.SessionPersister().RevokeSession(ctx, sess.IdentityID, sess.ID))
+		// Revoking an inactive session does not write another message.
+		require.NoError(t, reg.SessionPersister().RevokeSession(ctx, sess.IdentityID, sess.ID))
+		require.NoError(t, reg.OutboxDispatcher().DispatchQueue(ctx))
+
+		received := rcv.received()
+		require.Len(t, received, 2)
+		assert.Equal(t, outbox.EventTypeIdentityCreated, received[0].header.Get("ce-type"))
+		assert.Equal(t, outbox.EventTypeSessionRevoked, received[1].header.Get("ce-type"))
+		assert.Equal(t, sess.ID.String(), received[1].header.Get("ce-subject"))
+		assert.Equal(t, sess.IdentityID.String(), gjson.GetBytes(received[1].body, "identity_id").String())
+	})
+
+	t.Run("case=retries and dead-letters failed deliveries", func(t *testing.T) {
+		t.Parallel()
+
+		reg, rcv := newRegistry(t, http.StatusInternalServerError, map[string]any{
+			config.ViperKeyOutboxRetryMaxAttempts:     2,
+			config.ViperKeyOutboxRetryInitialInterval: "1ms",
+			config.ViperKeyOutboxRetryMaxInterval:     "1ms",
+		})
+		i := createIdentity(t, reg)
+
+		require.NoError(t, reg.OutboxDispatcher().DispatchQueue(ctx))
+		require.Len(t, rcv.received(), 1)
+
+		time.Sleep(time.Second)
+		require.NoError(t, reg.OutboxDispatcher().DispatchQueue(ctx))
+		require.Len(t, rcv.received(), 2)
+
+		time.Sleep(time.Second)
+		require.NoError(t, reg.OutboxDispatcher().DispatchQueue(ctx))
+		assert.Len(t, rcv.received(), 2, "dead-lettered messages must not be retried")
+
+		id, err := uuid.FromString(rcv.received()[0].header.Get("ce-id"))
+		require.NoError(t, err)
+		m, err := reg.OutboxPersister().FetchOutboxMessage(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, i.ID, m.Subject)
+		assert.Equal(t, outbox.MessageStatusDeadLettered, m.Status)
+		assert.Equal(t, 2, m.Attempts)
+		assert.Contains(t, m.LastError, "500")
+	})
+
+	t.Run("case=writes nothing if disabled", func(t *testing.T) {
+		t.Parallel()
+
+		reg, _ := newRegistry(t, http.StatusOK, map[string]any{config.ViperKeyOutboxEnabled: false})
+		i := createIdentity(t, reg)
+		require.NoError(t, reg.PrivilegedIdentityPool().DeleteIdentity(ctx, i.ID))
+
+		_, err := reg.OutboxPersister().NextOutboxMessages(ctx, 10, time.Hour)
+		assert.ErrorIs(t, err, outbox.ErrQueueEmpty)
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/x/sqlxx"
+)
+
+// MessageStatus is the delivery status of an outbox message.
+type MessageStatus string
+
+const (
+	MessageStatusQueued       MessageStatus = "queued"
+	MessageStatusProcessing   MessageStatus = "processing"
+	MessageStatusDelivered    MessageStatus = "delivered"
+	MessageStatusDeadLettered MessageStatus = "dead_lettered"
+)
+
+// The CloudEvents types of the outbox messages.
+const (
+	EventTypeIdentityCreated = "sh.ory.kratos.identity.created.v1"
+	EventTypeIdentityUpdated = "sh.ory.kratos.identity.updated.v1"
+	EventTypeIdentityDeleted = "sh.ory.kratos.identity.deleted.v1"
+	EventTypeSessionRevoked  = "sh.ory.kratos.session.revoked.v1"
+)
+
+// Message is a change which is written to the outbox in the same transaction
+// as the change itself, and delivered to the configured endpoint by the
+// dispatcher.
+type Message struct {
+	ID  uuid.UUID `json:"id" db:"id"`
+	NID uuid.UUID `json:"-" db:"nid"`
+
+	// EventType is the CloudEvents type, for example
+	// `sh.ory.kratos.identity.created.v1`.
+	EventType string `json:"event_type" db:"event_type"`
+
+	// Subject is the ID of the identity or session the event is about.
+	Subject uuid.UUID `json:"subject" db:"subject"`
+
+	// Data is the JSON payload of the event.
+	Data sqlxx.JSONRawMessage `json:"data" db:"data"`
+
+	Status        MessageStatus `json:"status" db:"status"`
+	Attempts      int           `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time     `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError     string        `json:"last_error" db:"last_error"`
+
+	// LockedUntil is the time at which the lease of the dispatcher which
+	// claimed the message expires. Processing messages whose lease expired
+	// are claimed again.
+	LockedUntil *sqlxx.NullTime `json:"-" faker:"-" db:"locked_until"`
+
+	// LeaseID identifies the claim of the dispatcher which holds the lease.
+	// Only that dispatcher may record the delivery attempt.
+	LeaseID uuid.NullUUID `json:"-" faker:"-" db:"lease_id"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// NewMessage returns a queued message which can be delivered right away.
+func NewMessage(eventType string, subject uuid.UUID, data any) (*Message, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ID:            uuid.Must(uuid.NewV4()),
+		EventType:     eventType,
+		Subject:       subject,
+		Data:          sqlxx.JSONRawMessage(raw),
+		Status:        MessageStatusQueued,
+		NextAttemptAt: time.Now().UTC().Truncate(time.Second),
+	}, nil
+}
+
+// NewIdentityMessage returns a message about a created or updated identity.
+// The payload is the identity without its credentials.
+func NewIdentityMessage(eventType string, i *identity.Identity) (*Message, error) {
+	return NewMessage(eventType, i.ID, i.CopyWithoutCredentials())
+}
+
+// NewIdentityDeletedMessage returns a message about a deleted identity.
+func NewIdentityDeletedMessage(identityID uuid.UUID) (*Message, error) {
+	return NewMessage(EventTypeIdentityDeleted, identityID, map[string]any{
+		"identity_id": identityID,
+	})
+}
+
+// NewSessionRevokedMessage returns a message about a revoked session.
+func NewSessionRevokedMessage(sessionID, identityID uuid.UUID) (*Message, error) {
+	return NewMessage(EventTypeSessionRevoked, sessionID, map[string]any{
+		"session_id":  sessionID,
+		"identity_id": identityID,
+	})
+}
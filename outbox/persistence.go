@@ -0,0 +1,60 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+)
+
+var ErrQueueEmpty = errors.New("outbox is empty")
+
+// ErrMessageLeaseLost is returned if a dispatcher records a delivery attempt
+// for a message whose lease it no longer holds.
+var ErrMessageLeaseLost = herodot.ErrConflict().WithReason("The outbox message is no longer leased by this dispatcher.")
+
+type (
+	Persister interface {
+		// EnqueueOutboxMessages writes the messages to the outbox. If the
+		// context carries a transaction, the messages are written as part of
+		// it.
+		EnqueueOutboxMessages(ctx context.Context, messages ...*Message) error
+
+		// NextOutboxMessages claims up to limit queued messages which are due
+		// for delivery by setting their status to processing, and leases them
+		// for the given duration. Processing messages whose lease expired are
+		// claimed again. Returns ErrQueueEmpty if no message is due.
+		NextOutboxMessages(ctx context.Context, limit int, lease time.Duration) ([]Message, error)
+
+		// The following methods record a delivery attempt for a message
+		// returned by NextOutboxMessages and end its lease. They return
+		// ErrMessageLeaseLost if the message was claimed again since.
+
+		// SetOutboxMessageDelivered marks the message as delivered.
+		SetOutboxMessageDelivered(ctx context.Context, m *Message) error
+
+		// RetryOutboxMessage puts the message back into the queue after a
+		// failed delivery attempt.
+		RetryOutboxMessage(ctx context.Context, m *Message, nextAttemptAt time.Time, lastError string) error
+
+		// DeadLetterOutboxMessage marks the message as undeliverable after a
+		// failed delivery attempt. It is not retried anymore.
+		DeadLetterOutboxMessage(ctx context.Context, m *Message, lastError string) error
+
+		// FetchOutboxMessage returns the message with the given ID.
+		FetchOutboxMessage(ctx context.Context, id uuid.UUID) (*Message, error)
+
+		// DeleteExpiredOutboxMessages deletes delivered messages which were
+		// last updated before the given time.
+		DeleteExpiredOutboxMessages(ctx context.Context, before time.Time, limit int) error
+	}
+	PersistenceProvider interface {
+		OutboxPersister() Persister
+	}
+)
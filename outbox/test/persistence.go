@@ -0,0 +1,173 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/outbox"
+	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/sqlcon"
+)
+
+func TestPersister(ctx context.Context, p interface {
+	persistence.Persister
+},
+) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, p := testhelpers.NewNetworkUnlessExisting(t, ctx, p)
+
+		enqueue := func(t *testing.T, p outbox.Persister) *outbox.Message {
+			m, err := outbox.NewSessionRevokedMessage(uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()))
+			require.NoError(t, err)
+			require.NoError(t, p.EnqueueOutboxMessages(ctx, m))
+			return m
+		}
+
+		// drain claims all due messages, so that the following cases start
+		// with an empty queue.
+		drain := func(t *testing.T, p outbox.Persister) []outbox.Message {
+			messages, err := p.NextOutboxMessages(ctx, 1000, time.Hour)
+			if errors.Is(err, outbox.ErrQueueEmpty) {
+				return nil
+			}
+			require.NoError(t, err)
+			return messages
+		}
+
+		t.Run("case=stores the message", func(t *testing.T) {
+			m := enqueue(t, p)
+
+			actual, err := p.FetchOutboxMessage(ctx, m.ID)
+			require.NoError(t, err)
+			assert.Equal(t, outbox.EventTypeSessionRevoked, actual.EventType)
+			assert.Equal(t, m.Subject, actual.Subject)
+			assert.JSONEq(t, string(m.Data), string(actual.Data))
+			assert.Equal(t, outbox.MessageStatusQueued, actual.Status)
+			assert.Zero(t, actual.Attempts)
+		})
+
+		t.Run("case=claims due messages once", func(t *testing.T) {
+			drain(t, p)
+			m := enqueue(t, p)
+
+			messages := drain(t, p)
+			require.Len(t, messages, 1)
+			assert.Equal(t, m.ID, messages[0].ID)
+			assert.Equal(t, outbox.MessageStatusProcessing, messages[0].Status)
+
+			_, err := p.NextOutboxMessages(ctx, 10, time.Hour)
+			assert.ErrorIs(t, err, outbox.ErrQueueEmpty)
+		})
+
+		t.Run("case=claims messages again after the lease expired", func(t *testing.T) {
+			drain(t, p)
+			m := enqueue(t, p)
+
+			messages, err := p.NextOutboxMessages(ctx, 10, -time.Second)
+			require.NoError(t, err)
+			require.Len(t, messages, 1)
+			require.NotNil(t, messages[0].LockedUntil)
+
+			// The dispatcher crashed before recording the delivery attempt.
+			expired := messages[0]
+			messages = drain(t, p)
+			require.Len(t, messages, 1)
+			assert.Equal(t, m.ID, messages[0].ID)
+			assert.Empty(t, drain(t, p), "the message must not be claimed while it is leased")
+
+			assert.ErrorIs(t, p.SetOutboxMessageDelivered(ctx, &expired), outbox.ErrMessageLeaseLost,
+				"the dispatcher whose lease expired must not record the attempt")
+			assert.ErrorIs(t, p.DeadLetterOutboxMessage(ctx, &expired, "status code 500"), outbox.ErrMessageLeaseLost)
+
+			require.NoError(t, p.SetOutboxMessageDelivered(ctx, &messages[0]))
+			actual, err := p.FetchOutboxMessage(ctx, m.ID)
+			require.NoError(t, err)
+			assert.Nil(t, actual.LockedUntil, "recording the attempt ends the lease")
+			assert.Equal(t, outbox.MessageStatusDelivered, actual.Status)
+			assert.Equal(t, 1, actual.Attempts)
+
+			assert.ErrorIs(t, p.RetryOutboxMessage(ctx, &messages[0], time.Now(), "connection refused"), outbox.ErrMessageLeaseLost,
+				"the attempt must only be recorded once")
+		})
+
+		t.Run("case=retries messages when due", func(t *testing.T) {
+			drain(t, p)
+			m := enqueue(t, p)
+			messages := drain(t, p)
+			require.Len(t, messages, 1)
+
+			require.NoError(t, p.RetryOutboxMessage(ctx, &messages[0], time.Now().Add(time.Hour), "connection refused"))
+			assert.Empty(t, drain(t, p), "the message must not be claimed before it is due")
+
+			actual, err := p.FetchOutboxMessage(ctx, m.ID)
+			require.NoError(t, err)
+			assert.Equal(t, outbox.MessageStatusQueued, actual.Status)
+			assert.Equal(t, 1, actual.Attempts)
+			assert.Equal(t, "connection refused", actual.LastError)
+
+			require.NoError(t, p.RetryOutboxMessage(ctx, &messages[0], time.Now().Add(-time.Second), "connection refused"))
+			messages = drain(t, p)
+			require.Len(t, messages, 1)
+			assert.Equal(t, 2, messages[0].Attempts)
+		})
+
+		t.Run("case=dead-letters messages", func(t *testing.T) {
+			drain(t, p)
+			m := enqueue(t, p)
+			messages := drain(t, p)
+			require.Len(t, messages, 1)
+
+			require.NoError(t, p.DeadLetterOutboxMessage(ctx, &messages[0], "status code 500"))
+
+			actual, err := p.FetchOutboxMessage(ctx, m.ID)
+			require.NoError(t, err)
+			assert.Equal(t, outbox.MessageStatusDeadLettered, actual.Status)
+			assert.Equal(t, "status code 500", actual.LastError)
+			assert.Empty(t, drain(t, p))
+		})
+
+		t.Run("case=deletes expired delivered messages", func(t *testing.T) {
+			drain(t, p)
+			delivered, deadLettered := enqueue(t, p), enqueue(t, p)
+			messages := drain(t, p)
+			require.Len(t, messages, 2)
+			for _, m := range messages {
+				if m.ID == delivered.ID {
+					require.NoError(t, p.SetOutboxMessageDelivered(ctx, &m))
+				} else {
+					require.NoError(t, p.DeadLetterOutboxMessage(ctx, &m, "status code 500"))
+				}
+			}
+
+			require.NoError(t, p.DeleteExpiredOutboxMessages(ctx, time.Now().Add(time.Hour), 100))
+
+			_, err := p.FetchOutboxMessage(ctx, delivered.ID)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows())
+			_, err = p.FetchOutboxMessage(ctx, deadLettered.ID)
+			assert.NoError(t, err, "dead-lettered messages must be kept")
+		})
+
+		t.Run("case=network isolation", func(t *testing.T) {
+			drain(t, p)
+			m := enqueue(t, p)
+
+			_, other := testhelpers.NewNetwork(t, ctx, p)
+			assert.Empty(t, drain(t, other))
+			_, err := other.FetchOutboxMessage(ctx, m.ID)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows())
+			messages := drain(t, p)
+			require.Len(t, messages, 1)
+			assert.ErrorIs(t, other.SetOutboxMessageDelivered(ctx, &messages[0]), outbox.ErrMessageLeaseLost)
+		})
+	}
+}
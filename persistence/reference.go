@@ -9,7 +9,10 @@ import (
 
 	"github.com/ory/kratos/x"
 
+	"github.com/ory/kratos/selfservice/lockout"
 	"github.com/ory/kratos/selfservice/sessiontokenexchange"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/selfservice/strategy/saml"
 	"github.com/ory/x/networkx"
 
 	"github.com/gofrs/uuid"
@@ -18,9 +21,12 @@ import (
 
 	"github.com/ory/x/popx"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/identity/dormancy"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
@@ -41,12 +47,18 @@ type Persister interface {
 	continuity.Persister
 	identity.PrivilegedPool
 	identity.PendingTraitsChangePersister
+	dormancy.Persister
 	registration.FlowPersister
 	login.FlowPersister
 	settings.FlowPersister
 	courier.Persister
 	session.Persister
 	sessiontokenexchange.Persister
+	lockout.Persister
+	oidc.Persister
+	saml.Persister
+	audit.Persister
+	outbox.Persister
 	errorx.Persister
 	verification.FlowPersister
 	recovery.FlowPersister
@@ -43,3 +43,15 @@ func (p *DevicePersister) CreateDevice(ctx context.Context, d *session.Device) e
 	d.NID = p.NetworkID(ctx)
 	return sqlcon.HandleError(popx.GetConnection(ctx, p.c.WithContext(ctx)).Create(d))
 }
+
+func (p *DevicePersister) ListDevicesByIdentity(ctx context.Context, identityID uuid.UUID, limit int) ([]session.Device, error) {
+	var devices []session.Device
+	if err := popx.GetConnection(ctx, p.c.WithContext(ctx)).
+		Where("identity_id = ? AND nid = ?", identityID, p.NetworkID(ctx)).
+		Order("created_at DESC").
+		Limit(limit).
+		All(&devices); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return devices, nil
+}
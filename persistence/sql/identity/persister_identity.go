@@ -28,6 +28,7 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/otp"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/persistence/sql/batch"
 	"github.com/ory/kratos/persistence/sql/update"
 	"github.com/ory/kratos/schema"
@@ -605,6 +606,20 @@ func (p *IdentityPersister) updateCredentialsAssociation(ctx context.Context, ex
 	credsToKeep, newCreds, credsToDeleteIDs := diffAssociations(fromDatabase, updateTo)
 
 	if len(credsToDeleteIDs) > 0 {
+		// Changed credentials are deleted and created again below. They keep
+		// the last_used_at of the database, unless the update is newer, so
+		// that an update based on a stale copy of the identity does not roll
+		// back the timestamp written by a concurrent login.
+		lastUsedAt, err := p.lockCredentialsLastUsedAt(ctx, credsToDeleteIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range newCreds {
+			if at, ok := lastUsedAt[c.Type]; ok && (c.LastUsedAt == nil || time.Time(*c.LastUsedAt).Before(at)) {
+				c.LastUsedAt = new(sqlxx.NullTime(at))
+			}
+		}
+
 		// Delete the credential and its identifiers.
 		conn := p.GetConnection(ctx)
 		q := "DELETE FROM identity_credentials WHERE nid = ? AND id IN (?)"
@@ -640,6 +655,39 @@ func (p *IdentityPersister) updateCredentialsAssociation(ctx context.Context, ex
 	return result, nil
 }
 
+// lockCredentialsLastUsedAt returns the last_used_at of the credentials by
+// type, and locks the credentials until the transaction ends, so that a
+// concurrent login cannot update them in between.
+func (p *IdentityPersister) lockCredentialsLastUsedAt(ctx context.Context, ids []uuid.UUID) (map[identity.CredentialsType]time.Time, error) {
+	conn := p.GetConnection(ctx)
+	query := `
+	SELECT ict.name, ic.last_used_at
+	FROM identity_credentials ic
+	INNER JOIN identity_credential_types ict ON ict.id = ic.identity_credential_type_id
+	WHERE ic.nid = ? AND ic.id IN (?) AND ic.last_used_at IS NOT NULL`
+	if conn.Dialect.Name() != "sqlite3" {
+		// SQLite has no FOR UPDATE, but serializes write transactions. Only
+		// the credentials are locked, not the shared type rows.
+		query += " FOR UPDATE OF ic"
+	}
+
+	var rows []struct {
+		Type       identity.CredentialsType `db:"name"`
+		LastUsedAt time.Time                `db:"last_used_at"`
+	}
+	if err := conn.RawQuery(query, p.NetworkID(ctx), ids).All(&rows); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	lastUsedAt := make(map[identity.CredentialsType]time.Time, len(rows))
+	for _, row := range rows {
+		if row.LastUsedAt.After(lastUsedAt[row.Type]) {
+			lastUsedAt[row.Type] = row.LastUsedAt
+		}
+	}
+	return lastUsedAt, nil
+}
+
 func diffAssociations[T differ](fromDatabase, updateTo []T) (unchanged, toCreate []*T, toRemoveIDs []uuid.UUID) {
 	newAssocs := make(map[string]*T, len(updateTo))
 	oldAssocs := make(map[string]*T, len(fromDatabase))
@@ -925,8 +973,6 @@ func (p *IdentityPersister) CreateIdentities(ctx context.Context, identities []*
 			if err := p.DeleteIdentities(ctx, idsToBeRemoved); err != nil {
 				return sqlcon.HandleError(err)
 			}
-
-			return nil
 		} else {
 			// No failures: report all identities as created.
 			for _, ident := range identities {
@@ -934,14 +980,14 @@ func (p *IdentityPersister) CreateIdentities(ctx context.Context, identities []*
 			}
 		}
 
-		return nil
+		return p.enqueueIdentityMessages(ctx, tx, outbox.EventTypeIdentityCreated, succeededIDs, identities...)
 	}); err != nil {
 		return err
 	}
 
 	// Report succeeded identities as created.
 	for _, identID := range succeededIDs {
-		span.AddEvent(events.NewIdentityCreated(ctx, identID))
+		events.Audit(ctx, span).AddEvent(events.NewIdentityCreated(ctx, identID))
 	}
 
 	return partialErr.ErrOrNil()
@@ -1024,6 +1070,7 @@ func QueryForCredentials(con *pop.Connection, where ...Where) (credentialsPerIde
 		"identity_credentials.version",
 		"identity_credentials.created_at",
 		"identity_credentials.updated_at",
+		"identity_credentials.last_used_at",
 	).LeftJoin(identifiersTableNameWithIndexHint(con),
 		"identity_credential_identifiers.identity_credential_id = identity_credentials.id AND identity_credential_identifiers.nid = identity_credentials.nid",
 	)
@@ -1136,7 +1183,14 @@ func (p *IdentityPersister) ListIdentities(ctx context.Context, params identity.
 		attribute.Stringer("network.id", p.NetworkID(ctx)))...))
 	defer otelx.End(span, &err)
 
-	if _, err := uuid.FromString(paginator.Token().Parse("id")["id"]); err != nil {
+	sortByLastAuthenticatedAt := params.OrderBy == identity.ListIdentitiesOrderByLastAuthenticatedAt
+	order := cmp.Or(params.Order, keysetpagination.OrderAscending)
+	var cursor *lastAuthenticatedAtCursor
+	if sortByLastAuthenticatedAt {
+		if cursor, err = parseLastAuthenticatedAtCursor(paginator.Token()); err != nil {
+			return nil, nil, err
+		}
+	} else if _, err := uuid.FromString(paginator.Token().Parse("id")["id"]); err != nil {
 		return nil, nil, errors.WithStack(x.PageTokenInvalid)
 	}
 
@@ -1168,6 +1222,20 @@ func (p *IdentityPersister) ListIdentities(ctx context.Context, params identity.
 		joins := ""
 		wheres := "identities.nid = ? AND identities.id > ?"
 		args := []any{nid, paginator.Token().Encode()}
+		orderBy := "identities.id ASC"
+		if sortByLastAuthenticatedAt {
+			wheres = "identities.nid = ?"
+			args = []any{nid}
+			if cursor != nil {
+				sign := ">"
+				if order == keysetpagination.OrderDescending {
+					sign = "<"
+				}
+				wheres += fmt.Sprintf(" AND (%[1]s %[2]s ? OR (%[1]s = ? AND identities.id > ?))", lastAuthenticatedAtSortKey, sign)
+				args = append(args, neverAuthenticated, cursor.at, neverAuthenticated, cursor.at, cursor.id)
+			}
+			orderBy = fmt.Sprintf("%s %s, identities.id ASC", lastAuthenticatedAtSortKey, order)
+		}
 		limit := fmt.Sprintf("LIMIT %d", paginator.Size()+1)
 		if params.PagePagination != nil {
 			wheres = "identities.nid = ?"
@@ -1229,6 +1297,19 @@ func (p *IdentityPersister) ListIdentities(ctx context.Context, params identity.
 			args = append(args, params.OrganizationID.String())
 		}
 
+		if !params.LastAuthenticatedBefore.IsZero() {
+			wheres += `
+				AND (identities.last_authenticated_at < ? OR (identities.last_authenticated_at IS NULL AND identities.created_at < ?))
+			`
+			args = append(args, params.LastAuthenticatedBefore.UTC(), params.LastAuthenticatedBefore.UTC())
+		}
+		if !params.LastAuthenticatedAfter.IsZero() {
+			wheres += `
+				AND identities.last_authenticated_at > ?
+			`
+			args = append(args, params.LastAuthenticatedAfter.UTC())
+		}
+
 		columns := popx.DBColumns[identity.Identity](&popx.AliasQuoter{Alias: "identities", Quoter: con.Dialect})
 		if params.ColumnsTransformer != nil {
 			columns = params.ColumnsTransformer(columns)
@@ -1250,10 +1331,13 @@ func (p *IdentityPersister) ListIdentities(ctx context.Context, params identity.
 		%s
 		WHERE
 		%s
-		ORDER BY identities.id ASC
+		ORDER BY %s
 		%s`,
 			distinct, columns,
-			joins, wheres, limit)
+			joins, wheres, orderBy, limit)
+		if sortByLastAuthenticatedAt {
+			args = append(args, neverAuthenticated)
+		}
 
 		if params.RowScanner != nil {
 			is, err = params.RowScanner(con, query, args)
@@ -1266,6 +1350,10 @@ func (p *IdentityPersister) ListIdentities(ctx context.Context, params identity.
 
 		if params.PagePagination == nil {
 			is, nextPage = keysetpagination.Result(is, paginator)
+			if sortByLastAuthenticatedAt && !nextPage.IsLast() {
+				nextPage = keysetpagination.GetPaginator(append(nextPage.ToOptions(),
+					keysetpagination.WithToken(lastAuthenticatedAtPageToken(&is[len(is)-1])))...)
+			}
 		}
 
 		if len(is) == 0 {
@@ -1342,6 +1430,55 @@ func (p *IdentityPersister) ListIdentities(ctx context.Context, params identity.
 	return is, nextPage, nil
 }
 
+// lastAuthenticatedAtSortKey sorts identities which never signed in as if
+// they signed in at neverAuthenticated.
+const lastAuthenticatedAtSortKey = "COALESCE(identities.last_authenticated_at, ?)"
+
+var neverAuthenticated = time.Unix(0, 0).UTC()
+
+// lastAuthenticatedAtCursor is the position of the last identity of a page
+// when listing identities sorted by last_authenticated_at.
+type lastAuthenticatedAtCursor struct {
+	at time.Time
+	id uuid.UUID
+}
+
+func lastAuthenticatedAtPageToken(i *identity.Identity) keysetpagination.PageToken {
+	at := neverAuthenticated
+	if i.LastAuthenticatedAt != nil {
+		at = time.Time(*i.LastAuthenticatedAt).UTC()
+	}
+	return keysetpagination.MapPageToken{
+		"id":                    i.ID.String(),
+		"last_authenticated_at": at.Format(time.RFC3339Nano),
+	}
+}
+
+// parseLastAuthenticatedAtCursor returns nil if the token points to the first
+// page.
+func parseLastAuthenticatedAtCursor(token keysetpagination.PageToken) (*lastAuthenticatedAtCursor, error) {
+	if token.Encode() == identity.DefaultPageToken().Encode() {
+		return nil, nil
+	}
+
+	parsed, err := keysetpagination.NewMapPageToken(token.Encode())
+	if err != nil {
+		return nil, errors.WithStack(x.PageTokenInvalid)
+	}
+	values := parsed.Parse("id")
+
+	id, err := uuid.FromString(values["id"])
+	if err != nil {
+		return nil, errors.WithStack(x.PageTokenInvalid)
+	}
+	at, err := time.Parse(time.RFC3339Nano, values["last_authenticated_at"])
+	if err != nil {
+		return nil, errors.WithStack(x.PageTokenInvalid)
+	}
+
+	return &lastAuthenticatedAtCursor{at: at, id: id}, nil
+}
+
 func (p *IdentityPersister) UpdateIdentityColumns(ctx context.Context, i *identity.Identity, columns ...string) (err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateIdentityColumns",
 		trace.WithAttributes(
@@ -1350,16 +1487,48 @@ func (p *IdentityPersister) UpdateIdentityColumns(ctx context.Context, i *identi
 	defer otelx.End(span, &err)
 
 	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
-		_, err := tx.Where("id = ? AND nid = ?", i.ID, p.NetworkID(ctx)).UpdateQuery(i, columns...)
-		return sqlcon.HandleError(err)
+		if _, err := tx.Where("id = ? AND nid = ?", i.ID, p.NetworkID(ctx)).UpdateQuery(i, columns...); err != nil {
+			return sqlcon.HandleError(err)
+		}
+		return p.enqueueIdentityMessages(ctx, tx, outbox.EventTypeIdentityUpdated, []uuid.UUID{i.ID}, i)
 	}); err != nil {
 		return err
 	}
 
-	span.AddEvent(events.NewIdentityUpdated(ctx, i.ID))
+	events.Audit(ctx, span).AddEvent(events.NewIdentityUpdated(ctx, i.ID))
 	return nil
 }
 
+func (p *IdentityPersister) UpdateLastAuthenticatedAt(ctx context.Context, identityID uuid.UUID, ct identity.CredentialsType, at time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateLastAuthenticatedAt",
+		trace.WithAttributes(
+			attribute.Stringer("identity.id", identityID),
+			attribute.String("credentials.type", string(ct)),
+			attribute.Stringer("network.id", p.NetworkID(ctx))))
+	defer otelx.End(span, &err)
+
+	nid := p.NetworkID(ctx)
+	at = at.UTC().Truncate(time.Microsecond)
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		// Signing in ends the dormancy warning period, so that a new warning is
+		// sent should the identity become dormant again.
+		if err := tx.RawQuery(
+			"UPDATE identities SET last_authenticated_at = ?, dormancy_warned_at = NULL WHERE id = ? AND nid = ?",
+			at, identityID, nid,
+		).Exec(); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		// The sub-select matches no rows for login methods which are not backed
+		// by a credentials type, in which case only the identity is updated.
+		return sqlcon.HandleError(tx.RawQuery(
+			"UPDATE identity_credentials SET last_used_at = ? WHERE identity_id = ? AND nid = ? AND identity_credential_type_id = (SELECT id FROM identity_credential_types WHERE name = ?)",
+			at, identityID, nid, ct,
+		).Exec())
+	})
+}
+
 // credentialsConfigLockTimeout bounds how long UpdateCredentialsConfig waits
 // for the credential-row lock, so a flood of requests against one row cannot
 // park waiters on pooled connections until the pool is exhausted. Enforced
@@ -1558,7 +1727,7 @@ func (p *IdentityPersister) UpdateCredentialsConfig(ctx context.Context, identit
 
 	// Only a real write is an identity update; the no-op path changed nothing.
 	if wrote {
-		span.AddEvent(events.NewIdentityUpdated(ctx, identityID))
+		events.Audit(ctx, span).AddEvent(events.NewIdentityUpdated(ctx, identityID))
 	}
 	return nil
 }
@@ -1689,10 +1858,13 @@ func (p *IdentityPersister) UpdateIdentity(ctx context.Context, i *identity.Iden
 	externalIDUnchanged := o.FromDatabase() != nil && o.FromDatabase().ExternalID == i.ExternalID
 	if err := sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
 		// This returns "ErrNoRows" if the identity does not exist
+		// last_authenticated_at is only ever written by UpdateLastAuthenticatedAt,
+		// so that an update based on a stale copy of the identity does not roll
+		// it back.
 		if externalIDUnchanged {
-			err = update.GenericExcept(WithTransaction(ctx, tx), tx, p.r.Tracer(ctx).Tracer(), i, "external_id")
+			err = update.GenericExcept(WithTransaction(ctx, tx), tx, p.r.Tracer(ctx).Tracer(), i, "external_id", "last_authenticated_at")
 		} else {
-			err = update.Generic(WithTransaction(ctx, tx), tx, p.r.Tracer(ctx).Tracer(), i)
+			err = update.GenericExcept(WithTransaction(ctx, tx), tx, p.r.Tracer(ctx).Tracer(), i, "last_authenticated_at")
 		}
 		if err != nil {
 			return err
@@ -1784,12 +1956,12 @@ func (p *IdentityPersister) UpdateIdentity(ctx context.Context, i *identity.Iden
 		// state on the returned identity instead of the in-memory copy.
 		maps.Copy(updatedCreds, excludedCreds)
 		i.Credentials = updatedCreds
-		return nil
+		return p.enqueueIdentityMessages(ctx, tx, outbox.EventTypeIdentityUpdated, []uuid.UUID{i.ID}, i)
 	})); err != nil {
 		return err
 	}
 
-	span.AddEvent(events.NewIdentityUpdated(ctx, i.ID))
+	events.Audit(ctx, span).AddEvent(events.NewIdentityUpdated(ctx, i.ID))
 	return nil
 }
 
@@ -1805,17 +1977,27 @@ func (p *IdentityPersister) DeleteIdentity(ctx context.Context, id uuid.UUID) (e
 		tableName += "@primary"
 	}
 	nid := p.NetworkID(ctx)
-	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE id = ? AND nid = ?", tableName),
-		id,
-		nid,
-	).ExecWithCount()
-	if err != nil {
-		return sqlcon.HandleError(err)
-	}
-	if count == 0 {
-		return errors.WithStack(sqlcon.ErrNoRows())
+	if err := TransactionWithOutbox(ctx, p.r.Config().OutboxEnabled(ctx), p.c, nid, func(ctx context.Context) ([]*outbox.Message, error) {
+		count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE id = ? AND nid = ?", tableName),
+			id,
+			nid,
+		).ExecWithCount()
+		if err != nil {
+			return nil, sqlcon.HandleError(err)
+		}
+		if count == 0 {
+			return nil, errors.WithStack(sqlcon.ErrNoRows())
+		}
+
+		m, err := outbox.NewIdentityDeletedMessage(id)
+		if err != nil {
+			return nil, err
+		}
+		return []*outbox.Message{m}, nil
+	}); err != nil {
+		return err
 	}
-	span.AddEvent(events.NewIdentityDeleted(ctx, id))
+	events.Audit(ctx, span).AddEvent(events.NewIdentityDeleted(ctx, id))
 	return nil
 }
 
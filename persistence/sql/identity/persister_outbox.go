@@ -0,0 +1,90 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/dbal"
+	"github.com/ory/x/popx"
+	"github.com/ory/x/sqlcon"
+)
+
+// EnqueueOutboxMessages writes the messages to the outbox of the network
+// using the given connection.
+func EnqueueOutboxMessages(conn *pop.Connection, nid uuid.UUID, messages ...*outbox.Message) error {
+	for _, m := range messages {
+		m.NID = nid
+		if err := conn.Create(m); err != nil {
+			return sqlcon.HandleError(err)
+		}
+	}
+	return nil
+}
+
+// TransactionWithOutbox runs fn and writes the outbox messages it returns to
+// the outbox of the network in the same transaction. On CockroachDB, the
+// transaction uses READ COMMITTED, so that the changes of fn do not run into
+// serialization retries under contention. If the outbox is disabled, fn runs
+// without an additional transaction and its messages are discarded.
+func TransactionWithOutbox(ctx context.Context, enabled bool, c *pop.Connection, nid uuid.UUID, fn func(ctx context.Context) ([]*outbox.Message, error)) error {
+	if !enabled {
+		_, err := fn(ctx)
+		return err
+	}
+
+	var opts *sql.TxOptions
+	if c.Dialect.Name() == dbal.DriverCockroachDB {
+		opts = &sql.TxOptions{Isolation: sql.LevelReadCommitted}
+	}
+	return popx.TransactionWithOptions(ctx, c.WithContext(ctx), opts, func(ctx context.Context, tx *pop.Connection) error {
+		messages, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+		return EnqueueOutboxMessages(tx, nid, messages...)
+	})
+}
+
+// enqueueOutboxMessages writes the messages to the outbox using the given
+// connection, which is the transaction of the change the messages are about.
+// Nothing is written if the outbox is disabled.
+func (p *IdentityPersister) enqueueOutboxMessages(ctx context.Context, conn *pop.Connection, messages ...*outbox.Message) error {
+	if len(messages) == 0 || !p.r.Config().OutboxEnabled(ctx) {
+		return nil
+	}
+	return EnqueueOutboxMessages(conn, p.NetworkID(ctx), messages...)
+}
+
+// enqueueIdentityMessages writes an outbox message of the given type for each
+// of the identities whose ID is in ids.
+func (p *IdentityPersister) enqueueIdentityMessages(ctx context.Context, conn *pop.Connection, eventType string, ids []uuid.UUID, identities ...*identity.Identity) error {
+	if !p.r.Config().OutboxEnabled(ctx) {
+		return nil
+	}
+
+	include := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		include[id] = struct{}{}
+	}
+
+	messages := make([]*outbox.Message, 0, len(ids))
+	for _, i := range identities {
+		if _, ok := include[i.ID]; !ok {
+			continue
+		}
+		m, err := outbox.NewIdentityMessage(eventType, i)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, m)
+	}
+	return p.enqueueOutboxMessages(ctx, conn, messages...)
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS selfservice_login_attempts;
@@ -0,0 +1,19 @@
+CREATE TABLE selfservice_login_attempts (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    subject VARCHAR(64) NOT NULL,
+    identity_id CHAR(36) NULL,
+    failed_attempts INT NOT NULL DEFAULT 0,
+    lockout_count INT NOT NULL DEFAULT 0,
+    locked_until timestamp NULL,
+    last_failed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT selfservice_login_attempts_identities_id_fk FOREIGN KEY (identity_id) REFERENCES identities (id) ON DELETE CASCADE,
+    CONSTRAINT selfservice_login_attempts_networks_id_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE UNIQUE INDEX selfservice_login_attempts_nid_kind_subject_uq_idx ON selfservice_login_attempts (nid, kind, subject);
+CREATE INDEX selfservice_login_attempts_nid_identity_id_idx ON selfservice_login_attempts (nid, identity_id);
+CREATE INDEX selfservice_login_attempts_nid_last_failed_at_idx ON selfservice_login_attempts (nid, last_failed_at);
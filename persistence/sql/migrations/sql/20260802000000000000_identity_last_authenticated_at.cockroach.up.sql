@@ -0,0 +1,2 @@
+ALTER TABLE "identities" ADD COLUMN IF NOT EXISTS "last_authenticated_at" timestamp NULL;
+ALTER TABLE "identity_credentials" ADD COLUMN IF NOT EXISTS "last_used_at" timestamp NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE `identities` ADD COLUMN `last_authenticated_at` timestamp NULL;
+ALTER TABLE `identity_credentials` ADD COLUMN `last_used_at` timestamp NULL;
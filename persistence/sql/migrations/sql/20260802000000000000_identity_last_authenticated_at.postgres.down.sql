@@ -0,0 +1,2 @@
+ALTER TABLE "identities" DROP COLUMN IF EXISTS "last_authenticated_at";
+ALTER TABLE "identity_credentials" DROP COLUMN IF EXISTS "last_used_at";
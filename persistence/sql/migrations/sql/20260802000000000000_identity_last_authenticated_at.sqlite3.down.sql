@@ -0,0 +1,2 @@
+ALTER TABLE "identities" DROP COLUMN "last_authenticated_at";
+ALTER TABLE "identity_credentials" DROP COLUMN "last_used_at";
@@ -0,0 +1 @@
+DROP INDEX identities_nid_last_authenticated_at_idx ON identities;
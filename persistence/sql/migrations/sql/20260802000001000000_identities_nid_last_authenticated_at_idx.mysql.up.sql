@@ -0,0 +1 @@
+CREATE INDEX identities_nid_last_authenticated_at_idx ON identities (nid ASC, last_authenticated_at ASC);
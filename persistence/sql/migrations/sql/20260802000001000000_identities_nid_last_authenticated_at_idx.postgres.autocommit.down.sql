@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS identities_nid_last_authenticated_at_idx;
@@ -0,0 +1 @@
+ALTER TABLE "identities" ADD COLUMN IF NOT EXISTS "dormancy_warned_at" timestamp NULL;
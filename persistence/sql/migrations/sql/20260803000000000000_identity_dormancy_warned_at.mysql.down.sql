@@ -0,0 +1 @@
+ALTER TABLE `identities` DROP COLUMN `dormancy_warned_at`;
@@ -0,0 +1 @@
+ALTER TABLE "identities" ADD COLUMN "dormancy_warned_at" DATETIME NULL;
@@ -0,0 +1,18 @@
+CREATE TABLE audit_events (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "event_type" VARCHAR(64) NOT NULL,
+    "actor" VARCHAR(16) NOT NULL,
+    "identity_id" UUID NULL,
+    "session_id" UUID NULL,
+    "flow_id" UUID NULL,
+    "ip_address" VARCHAR(50) NOT NULL DEFAULT '',
+    "user_agent" VARCHAR(512) NOT NULL DEFAULT '',
+    "attributes" jsonb NOT NULL,
+    "created_at" timestamp NOT NULL,
+    CONSTRAINT audit_events_networks_id_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX audit_events_nid_created_at_id_idx ON audit_events (nid, created_at DESC, id);
+CREATE INDEX audit_events_nid_identity_id_created_at_idx ON audit_events (nid, identity_id, created_at DESC);
+CREATE INDEX audit_events_nid_event_type_created_at_idx ON audit_events (nid, event_type, created_at DESC);
@@ -0,0 +1,17 @@
+CREATE TABLE outbox_messages (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    nid CHAR(36) NOT NULL,
+    event_type VARCHAR(128) NOT NULL,
+    subject CHAR(36) NOT NULL,
+    data JSON NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT outbox_messages_networks_id_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE INDEX outbox_messages_nid_status_next_attempt_at_idx ON outbox_messages (nid, status, next_attempt_at);
+CREATE INDEX outbox_messages_nid_status_updated_at_idx ON outbox_messages (nid, status, updated_at);
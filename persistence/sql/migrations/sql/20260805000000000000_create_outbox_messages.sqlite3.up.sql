@@ -0,0 +1,17 @@
+CREATE TABLE outbox_messages (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "event_type" VARCHAR(128) NOT NULL,
+    "subject" char(36) NOT NULL,
+    "data" TEXT NOT NULL,
+    "status" VARCHAR(16) NOT NULL,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "next_attempt_at" DATETIME NOT NULL,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT outbox_messages_networks_id_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX outbox_messages_nid_status_next_attempt_at_idx ON outbox_messages (nid, status, next_attempt_at);
+CREATE INDEX outbox_messages_nid_status_updated_at_idx ON outbox_messages (nid, status, updated_at);
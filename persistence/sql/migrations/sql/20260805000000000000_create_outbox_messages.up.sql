@@ -0,0 +1,17 @@
+CREATE TABLE outbox_messages (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "event_type" VARCHAR(128) NOT NULL,
+    "subject" UUID NOT NULL,
+    "data" jsonb NOT NULL,
+    "status" VARCHAR(16) NOT NULL,
+    "attempts" INT NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamp NOT NULL,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT outbox_messages_networks_id_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE INDEX outbox_messages_nid_status_next_attempt_at_idx ON outbox_messages (nid, status, next_attempt_at);
+CREATE INDEX outbox_messages_nid_status_updated_at_idx ON outbox_messages (nid, status, updated_at);
@@ -0,0 +1,4 @@
+ALTER TABLE `session_devices` ADD COLUMN `country` VARCHAR(64) NULL;
+ALTER TABLE `session_devices` ADD COLUMN `region` VARCHAR(255) NULL;
+ALTER TABLE `session_devices` ADD COLUMN `city` VARCHAR(255) NULL;
+ALTER TABLE `session_devices` ADD COLUMN `asn` VARCHAR(32) NULL;
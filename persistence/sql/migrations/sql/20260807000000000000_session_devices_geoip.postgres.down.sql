@@ -0,0 +1,4 @@
+ALTER TABLE "session_devices" DROP COLUMN IF EXISTS "country";
+ALTER TABLE "session_devices" DROP COLUMN IF EXISTS "region";
+ALTER TABLE "session_devices" DROP COLUMN IF EXISTS "city";
+ALTER TABLE "session_devices" DROP COLUMN IF EXISTS "asn";
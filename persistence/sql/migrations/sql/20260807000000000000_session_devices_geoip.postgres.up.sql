@@ -0,0 +1,4 @@
+ALTER TABLE "session_devices" ADD COLUMN IF NOT EXISTS "country" VARCHAR(64) NULL;
+ALTER TABLE "session_devices" ADD COLUMN IF NOT EXISTS "region" VARCHAR(255) NULL;
+ALTER TABLE "session_devices" ADD COLUMN IF NOT EXISTS "city" VARCHAR(255) NULL;
+ALTER TABLE "session_devices" ADD COLUMN IF NOT EXISTS "asn" VARCHAR(32) NULL;
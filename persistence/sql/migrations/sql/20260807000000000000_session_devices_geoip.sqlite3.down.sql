@@ -0,0 +1,4 @@
+ALTER TABLE "session_devices" DROP COLUMN "country";
+ALTER TABLE "session_devices" DROP COLUMN "region";
+ALTER TABLE "session_devices" DROP COLUMN "city";
+ALTER TABLE "session_devices" DROP COLUMN "asn";
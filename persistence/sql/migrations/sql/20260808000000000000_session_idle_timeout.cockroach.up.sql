@@ -0,0 +1,2 @@
+ALTER TABLE "sessions" ADD COLUMN IF NOT EXISTS "last_seen_at" timestamp NULL;
+ALTER TABLE "sessions" ADD COLUMN IF NOT EXISTS "idle_expires_at" timestamp NULL;
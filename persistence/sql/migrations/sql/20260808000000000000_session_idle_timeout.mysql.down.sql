@@ -0,0 +1,2 @@
+ALTER TABLE `sessions` DROP COLUMN `idle_expires_at`;
+ALTER TABLE `sessions` DROP COLUMN `last_seen_at`;
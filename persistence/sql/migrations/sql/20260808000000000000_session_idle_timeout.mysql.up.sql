@@ -0,0 +1,2 @@
+ALTER TABLE `sessions` ADD COLUMN `last_seen_at` timestamp NULL;
+ALTER TABLE `sessions` ADD COLUMN `idle_expires_at` timestamp NULL;
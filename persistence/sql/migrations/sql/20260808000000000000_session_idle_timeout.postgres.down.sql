@@ -0,0 +1,2 @@
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "idle_expires_at";
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "last_seen_at";
@@ -0,0 +1,2 @@
+ALTER TABLE "sessions" ADD COLUMN "last_seen_at" DATETIME NULL;
+ALTER TABLE "sessions" ADD COLUMN "idle_expires_at" DATETIME NULL;
@@ -0,0 +1,2 @@
+DELETE FROM courier_message_dispatches WHERE status IN ('requeued', 'cancelled');
+ALTER TABLE courier_message_dispatches ALTER COLUMN status TYPE VARCHAR(7);
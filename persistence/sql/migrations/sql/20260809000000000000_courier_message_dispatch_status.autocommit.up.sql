@@ -0,0 +1 @@
+ALTER TABLE courier_message_dispatches ALTER COLUMN status TYPE VARCHAR(16);
@@ -0,0 +1 @@
+ALTER TABLE courier_message_dispatches MODIFY status VARCHAR(16) NOT NULL;
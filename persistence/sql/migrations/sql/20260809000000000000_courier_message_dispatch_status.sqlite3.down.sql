@@ -0,0 +1 @@
+DELETE FROM courier_message_dispatches WHERE status IN ('requeued', 'cancelled');
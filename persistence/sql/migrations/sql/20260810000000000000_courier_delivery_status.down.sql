@@ -0,0 +1,2 @@
+DELETE FROM courier_message_dispatches WHERE status IN ('delivered', 'bounced', 'complained');
+ALTER TABLE identity_verifiable_addresses DROP COLUMN undeliverable;
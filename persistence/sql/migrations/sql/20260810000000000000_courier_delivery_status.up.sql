@@ -0,0 +1 @@
+ALTER TABLE identity_verifiable_addresses ADD COLUMN undeliverable BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+ALTER TABLE
+  courier_message_dispatches DROP column channel;
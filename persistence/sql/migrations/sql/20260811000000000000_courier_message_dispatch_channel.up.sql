@@ -0,0 +1,4 @@
+ALTER TABLE
+  courier_message_dispatches
+ADD
+  column channel VARCHAR(32) NULL;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS courier_messages_nid_recipient_created_at_idx ON courier_messages (nid ASC, recipient ASC, created_at DESC);
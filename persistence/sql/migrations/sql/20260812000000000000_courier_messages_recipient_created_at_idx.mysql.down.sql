@@ -0,0 +1 @@
+DROP INDEX courier_messages_nid_recipient_created_at_idx ON courier_messages;
@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS courier_messages_nid_recipient_created_at_idx;
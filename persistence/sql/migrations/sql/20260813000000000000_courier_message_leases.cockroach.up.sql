@@ -0,0 +1,2 @@
+ALTER TABLE "courier_messages" ADD COLUMN IF NOT EXISTS "worker_id" VARCHAR(255) NULL;
+ALTER TABLE "courier_messages" ADD COLUMN IF NOT EXISTS "locked_until" timestamp NULL;
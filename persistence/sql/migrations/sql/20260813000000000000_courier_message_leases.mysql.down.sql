@@ -0,0 +1,2 @@
+ALTER TABLE `courier_messages` DROP COLUMN `locked_until`;
+ALTER TABLE `courier_messages` DROP COLUMN `worker_id`;
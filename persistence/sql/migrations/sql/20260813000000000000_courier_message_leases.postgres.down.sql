@@ -0,0 +1,2 @@
+ALTER TABLE "courier_messages" DROP COLUMN IF EXISTS "locked_until";
+ALTER TABLE "courier_messages" DROP COLUMN IF EXISTS "worker_id";
@@ -0,0 +1,2 @@
+ALTER TABLE "courier_messages" ADD COLUMN "worker_id" VARCHAR(255) NULL;
+ALTER TABLE "courier_messages" ADD COLUMN "locked_until" DATETIME NULL;
@@ -0,0 +1,3 @@
+-- Messages which were processing before leases existed are reclaimed by the
+-- next worker.
+UPDATE courier_messages SET locked_until = CURRENT_TIMESTAMP WHERE status = 3;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS selfservice_saml_consumed_assertions;
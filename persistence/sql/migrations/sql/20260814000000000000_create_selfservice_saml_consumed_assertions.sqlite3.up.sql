@@ -0,0 +1,13 @@
+CREATE TABLE selfservice_saml_consumed_assertions (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "nid" char(36) NOT NULL,
+    "provider_id" VARCHAR(255) NOT NULL,
+    "assertion_id" VARCHAR(255) NOT NULL,
+    "expires_at" DATETIME NOT NULL,
+    "created_at" DATETIME NOT NULL,
+    "updated_at" DATETIME NOT NULL,
+    CONSTRAINT selfservice_saml_consumed_assertions_networks_id_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX selfservice_saml_consumed_assertions_nid_assertion_uq_idx ON selfservice_saml_consumed_assertions (nid, provider_id, assertion_id);
+CREATE INDEX selfservice_saml_consumed_assertions_nid_expires_at_idx ON selfservice_saml_consumed_assertions (nid, expires_at);
@@ -0,0 +1 @@
+ALTER TABLE "identities" ADD COLUMN IF NOT EXISTS "dormancy_deactivated_at" timestamp NULL;
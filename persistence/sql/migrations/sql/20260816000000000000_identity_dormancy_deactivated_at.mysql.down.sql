@@ -0,0 +1 @@
+ALTER TABLE `identities` DROP COLUMN `dormancy_deactivated_at`;
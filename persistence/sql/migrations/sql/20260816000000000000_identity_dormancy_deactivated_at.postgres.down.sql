@@ -0,0 +1 @@
+ALTER TABLE "identities" DROP COLUMN IF EXISTS "dormancy_deactivated_at";
@@ -0,0 +1 @@
+ALTER TABLE "identities" ADD COLUMN "dormancy_deactivated_at" DATETIME NULL;
@@ -0,0 +1 @@
+ALTER TABLE "outbox_messages" ADD COLUMN IF NOT EXISTS "locked_until" timestamp NULL;
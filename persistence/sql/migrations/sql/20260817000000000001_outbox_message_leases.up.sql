@@ -0,0 +1,3 @@
+-- Messages which were processing before leases existed are reclaimed by the
+-- next dispatcher.
+UPDATE outbox_messages SET locked_until = CURRENT_TIMESTAMP WHERE status = 'processing';
@@ -0,0 +1,7 @@
+CREATE TABLE courier_recipient_locks (
+    nid CHAR(36) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    locked_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (nid, recipient),
+    CONSTRAINT courier_recipient_locks_networks_id_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
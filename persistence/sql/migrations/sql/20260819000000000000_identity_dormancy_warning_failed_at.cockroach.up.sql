@@ -0,0 +1 @@
+ALTER TABLE "identities" ADD COLUMN IF NOT EXISTS "dormancy_warning_failed_at" timestamp NULL;
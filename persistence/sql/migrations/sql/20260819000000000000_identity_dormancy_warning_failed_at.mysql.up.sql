@@ -0,0 +1 @@
+ALTER TABLE `identities` ADD COLUMN `dormancy_warning_failed_at` timestamp NULL;
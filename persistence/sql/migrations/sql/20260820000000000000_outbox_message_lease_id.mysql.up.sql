@@ -0,0 +1 @@
+ALTER TABLE `outbox_messages` ADD COLUMN `lease_id` CHAR(36) NULL;
@@ -0,0 +1 @@
+ALTER TABLE "outbox_messages" ADD COLUMN "lease_id" char(36) NULL;
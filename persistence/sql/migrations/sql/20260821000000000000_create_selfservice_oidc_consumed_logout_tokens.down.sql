@@ -0,0 +1 @@
+DROP TABLE IF EXISTS selfservice_oidc_consumed_logout_tokens;
@@ -0,0 +1,13 @@
+CREATE TABLE selfservice_oidc_consumed_logout_tokens (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "nid" UUID NOT NULL,
+    "provider_id" VARCHAR(255) NOT NULL,
+    "jti" VARCHAR(255) NOT NULL,
+    "expires_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    CONSTRAINT selfservice_oidc_consumed_logout_tokens_networks_id_fk FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX selfservice_oidc_consumed_logout_tokens_nid_jti_uq_idx ON selfservice_oidc_consumed_logout_tokens (nid, provider_id, jti);
+CREATE INDEX selfservice_oidc_consumed_logout_tokens_nid_expires_at_idx ON selfservice_oidc_consumed_logout_tokens (nid, expires_at);
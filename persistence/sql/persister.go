@@ -231,6 +231,47 @@ func (p *Persister) CleanupDatabase(ctx context.Context, wait time.Duration, old
 	}
 	time.Sleep(wait)
 
+	p.r.Logger().Println("Cleaning up expired login attempts")
+	if err := p.DeleteExpiredLoginAttempts(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	time.Sleep(wait)
+
+	p.r.Logger().Println("Cleaning up expired SAML assertions")
+	if err := p.DeleteExpiredSAMLAssertions(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	time.Sleep(wait)
+
+	p.r.Logger().Println("Cleaning up consumed OIDC logout tokens")
+	if err := p.DeleteExpiredLogoutTokens(ctx, currentTime, batchSize); err != nil {
+		return err
+	}
+	time.Sleep(wait)
+
+	// Audit events are deleted after the configured retention period,
+	// regardless of keep-last, as the retention period may be a compliance
+	// limit.
+	if retention := p.r.Config().SecurityAuditLog(ctx).Retention; retention > 0 {
+		p.r.Logger().Println("Cleaning up expired audit events")
+		if err := p.DeleteExpiredAuditEvents(ctx, time.Now().Add(-retention), batchSize); err != nil {
+			return err
+		}
+		time.Sleep(wait)
+	}
+
+	outboxConfig, err := p.r.Config().Outbox(ctx)
+	if err != nil {
+		return err
+	}
+	if retention := outboxConfig.Retention; retention > 0 {
+		p.r.Logger().Println("Cleaning up delivered outbox messages")
+		if err := p.DeleteExpiredOutboxMessages(ctx, time.Now().Add(-retention), batchSize); err != nil {
+			return err
+		}
+		time.Sleep(wait)
+	}
+
 	p.r.Logger().Println("Successfully cleaned up the latest batch of the SQL database! " +
 		"This should be re-run periodically, to be sure that all expired data is purged.")
 	return nil
@@ -0,0 +1,107 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/x/otelx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ audit.Persister = new(Persister)
+
+func (p *Persister) CreateAuditEvent(ctx context.Context, e *audit.Event) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateAuditEvent")
+	defer otelx.End(span, &err)
+
+	e.ID = uuid.Must(uuid.NewV4())
+	e.NID = p.NetworkID(ctx)
+	e.CreatedAt = time.Now().UTC()
+
+	c := p.GetConnection(ctx)
+	if c.TX == nil {
+		return sqlcon.HandleError(c.Create(e))
+	}
+
+	// A failed statement aborts the whole transaction on PostgreSQL and
+	// CockroachDB. The savepoint confines a failed insert to the event, so
+	// that the operation which caused the event is not rolled back.
+	if err := c.RawQuery("SAVEPOINT audit_event").Exec(); err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if err := c.Create(e); err != nil {
+		if err := c.RawQuery("ROLLBACK TO SAVEPOINT audit_event").Exec(); err != nil {
+			return sqlcon.HandleError(err)
+		}
+		return sqlcon.HandleError(err)
+	}
+	return sqlcon.HandleError(c.RawQuery("RELEASE SAVEPOINT audit_event").Exec())
+}
+
+func (p *Persister) ListAuditEvents(ctx context.Context, filter audit.ListAuditEventsParameters, opts []keysetpagination.Option) (_ []audit.Event, _ *keysetpagination.Paginator, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListAuditEvents")
+	defer otelx.End(span, &err)
+
+	q := p.GetConnection(ctx).Where("nid = ?", p.NetworkID(ctx))
+
+	if filter.IdentityID != nil {
+		q = q.Where("identity_id = ?", *filter.IdentityID)
+	}
+	if filter.SessionID != nil {
+		q = q.Where("session_id = ?", *filter.SessionID)
+	}
+	if filter.FlowID != nil {
+		q = q.Where("flow_id = ?", *filter.FlowID)
+	}
+	if filter.EventType != "" {
+		q = q.Where("event_type = ?", filter.EventType)
+	}
+	if filter.Actor != nil {
+		q = q.Where("actor = ?", *filter.Actor)
+	}
+	if filter.CreatedAfter != nil {
+		q = q.Where("created_at >= ?", filter.CreatedAfter.UTC())
+	}
+	if filter.CreatedBefore != nil {
+		q = q.Where("created_at < ?", filter.CreatedBefore.UTC())
+	}
+
+	opts = append(opts, keysetpagination.WithDefaultToken(audit.Event{}.DefaultPageToken()))
+	opts = append(opts, keysetpagination.WithDefaultSize(100))
+	paginator, err := keysetpagination.NewPaginator(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	auditEvents := make([]audit.Event, paginator.Size())
+	if err := q.Scope(keysetpagination.Paginate[audit.Event](paginator)).
+		All(&auditEvents); err != nil {
+		return nil, nil, sqlcon.HandleError(err)
+	}
+
+	auditEvents, nextPage := keysetpagination.Result(auditEvents, paginator)
+	return auditEvents, nextPage, nil
+}
+
+func (p *Persister) DeleteExpiredAuditEvents(ctx context.Context, before time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredAuditEvents")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %[1]s WHERE id in (SELECT id FROM (SELECT id FROM %[1]s c WHERE created_at < ? AND nid = ? ORDER BY created_at ASC LIMIT ?) AS s)",
+		audit.Event{}.TableName(),
+	),
+		before.UTC(),
+		p.NetworkID(ctx),
+		limit,
+	).Exec())
+}
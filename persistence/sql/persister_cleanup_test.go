@@ -174,3 +174,93 @@ func TestPersister_SessionTokenExchange_Cleanup(t *testing.T) {
 		assert.Error(t, p.DeleteExpiredExchangers(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
 	})
 }
+
+func TestPersister_LoginAttempts_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+	p := reg.Persister()
+	currentTime := time.Now()
+	ctx := context.Background()
+
+	t.Run("case=should not throw error on cleanup login attempts", func(t *testing.T) {
+		assert.Nil(t, p.DeleteExpiredLoginAttempts(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+
+	t.Run("case=should throw error on cleanup login attempts if DB is closed", func(t *testing.T) {
+		require.NoError(t, p.GetConnection(ctx).Close())
+		assert.Error(t, p.DeleteExpiredLoginAttempts(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+}
+
+func TestPersister_SAMLAssertions_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+	p := reg.Persister()
+	currentTime := time.Now()
+	ctx := context.Background()
+
+	t.Run("case=should not throw error on cleanup SAML assertions", func(t *testing.T) {
+		assert.Nil(t, p.DeleteExpiredSAMLAssertions(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+
+	t.Run("case=should throw error on cleanup SAML assertions if DB is closed", func(t *testing.T) {
+		require.NoError(t, p.GetConnection(ctx).Close())
+		assert.Error(t, p.DeleteExpiredSAMLAssertions(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+}
+
+func TestPersister_OIDCLogoutTokens_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+	p := reg.Persister()
+	currentTime := time.Now()
+	ctx := context.Background()
+
+	t.Run("case=should not throw error on cleanup OIDC logout tokens", func(t *testing.T) {
+		assert.Nil(t, p.DeleteExpiredLogoutTokens(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+
+	t.Run("case=should throw error on cleanup OIDC logout tokens if DB is closed", func(t *testing.T) {
+		require.NoError(t, p.GetConnection(ctx).Close())
+		assert.Error(t, p.DeleteExpiredLogoutTokens(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+}
+
+func TestPersister_AuditEvents_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+	p := reg.Persister()
+	currentTime := time.Now()
+	ctx := context.Background()
+
+	t.Run("case=should not throw error on cleanup audit events", func(t *testing.T) {
+		assert.Nil(t, p.DeleteExpiredAuditEvents(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+
+	t.Run("case=should throw error on cleanup audit events if DB is closed", func(t *testing.T) {
+		require.NoError(t, p.GetConnection(ctx).Close())
+		assert.Error(t, p.DeleteExpiredAuditEvents(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+}
+
+func TestPersister_OutboxMessages_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+	p := reg.Persister()
+	currentTime := time.Now()
+	ctx := context.Background()
+
+	t.Run("case=should not throw error on cleanup outbox messages", func(t *testing.T) {
+		assert.Nil(t, p.DeleteExpiredOutboxMessages(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+
+	t.Run("case=should throw error on cleanup outbox messages if DB is closed", func(t *testing.T) {
+		require.NoError(t, p.GetConnection(ctx).Close())
+		assert.Error(t, p.DeleteExpiredOutboxMessages(ctx, currentTime, reg.Config().DatabaseCleanupBatchSize(ctx)))
+	})
+}
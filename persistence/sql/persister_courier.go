@@ -14,12 +14,14 @@ import (
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/courier"
-	"github.com/ory/kratos/persistence/sql/update"
+	"github.com/ory/kratos/persistence/sql/batch"
+	"github.com/ory/kratos/x"
 	"github.com/ory/pop/v6"
 	"github.com/ory/x/dbal"
 	"github.com/ory/x/otelx"
 	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
 	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/uuidx"
 )
 
@@ -70,11 +72,21 @@ func (p *Persister) NextMessages(ctx context.Context, limit uint8) (messages []c
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.NextMessages")
 	defer otelx.End(span, &err)
 
+	return p.LeaseMessages(ctx, limit, "", p.r.Config().CourierWorkerLeaseDuration(ctx))
+}
+
+func (p *Persister) LeaseMessages(ctx context.Context, limit uint8, workerID string, lease time.Duration) (messages []courier.Message, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.LeaseMessages")
+	defer otelx.End(span, &err)
+
+	nid := p.NetworkID(ctx)
 	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
-		q := tx.Where("nid = ? AND status = ?",
-			p.NetworkID(ctx),
-			courier.MessageStatusQueued,
-		)
+		now := time.Now().UTC()
+
+		// Processing messages whose lease expired belong to a worker which
+		// crashed or was stopped, so they are reclaimed.
+		query := "SELECT id FROM courier_messages WHERE nid = ? AND (status = ? OR (status = ? AND locked_until < ?))"
+		args := []any{nid, courier.MessageStatusQueued, courier.MessageStatusProcessing, now}
 		if tx.Dialect.Name() == dbal.DriverCockroachDB {
 			// On CockroachDB, exclude messages enqueued within the last second.
 			// Concurrent INSERTs land at created_at ~ now; keeping them out of
@@ -84,27 +96,51 @@ func (p *Persister) NextMessages(ctx context.Context, limit uint8) (messages []c
 			// up by a subsequent poll. The other databases serve this query
 			// from an MVCC snapshot that does not conflict with concurrent
 			// inserts, so they keep the exact current behavior.
-			q = q.Where("created_at < ?", time.Now().UTC().Add(-time.Second))
+			query += " AND created_at < ?"
+			args = append(args, now.Add(-time.Second))
+		}
+		query += " ORDER BY created_at ASC LIMIT ?"
+		args = append(args, int(limit))
+
+		switch tx.Dialect.Name() {
+		case dbal.DriverPostgreSQL, dbal.DriverMySQL:
+			// Concurrent workers skip the messages which another worker is
+			// leasing right now instead of waiting for its transaction and
+			// leasing them a second time. CockroachDB serializes the
+			// transactions instead, and SQLite has a single writer.
+			query += " FOR UPDATE SKIP LOCKED"
 		}
 
-		var m []courier.Message
-		if err := q.
-			Order("created_at ASC").
-			Limit(int(limit)).
-			All(&m); err != nil {
+		var rows []struct {
+			ID uuid.UUID `db:"id"`
+		}
+		if err := tx.RawQuery(query, args...).All(&rows); err != nil {
 			return err
 		}
-
-		if len(m) == 0 {
+		if len(rows) == 0 {
 			return sql.ErrNoRows
 		}
 
-		for i := range m {
-			message := &m[i]
-			message.Status = courier.MessageStatusProcessing
-			if err := update.Generic(ctx, tx, p.r.Tracer(ctx).Tracer(), message, "status"); err != nil {
-				return err
-			}
+		ids := make([]uuid.UUID, len(rows))
+		for i := range rows {
+			ids[i] = rows[i].ID
+		}
+
+		if err := tx.RawQuery(
+			"UPDATE courier_messages SET status = ?, worker_id = ?, locked_until = ?, updated_at = ? WHERE nid = ? AND id IN (?)",
+			courier.MessageStatusProcessing,
+			sqlxx.NullString(workerID),
+			now.Add(lease),
+			now,
+			nid,
+			ids,
+		).Exec(); err != nil {
+			return err
+		}
+
+		var m []courier.Message
+		if err := tx.Where("nid = ? AND id IN (?)", nid, ids).Order("created_at ASC").All(&m); err != nil {
+			return err
 		}
 
 		messages = m
@@ -148,8 +184,9 @@ func (p *Persister) SetMessageStatus(ctx context.Context, id uuid.UUID, ms couri
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.SetMessageStatus")
 	defer otelx.End(span, &err)
 
+	// Changing the status ends the lease of the worker.
 	count, err := p.GetConnection(ctx).RawQuery(
-		"UPDATE courier_messages SET status = ? WHERE id = ? AND nid = ?",
+		"UPDATE courier_messages SET status = ?, worker_id = NULL, locked_until = NULL WHERE id = ? AND nid = ?",
 		ms,
 		id,
 		p.NetworkID(ctx),
@@ -165,6 +202,32 @@ func (p *Persister) SetMessageStatus(ctx context.Context, id uuid.UUID, ms couri
 	return nil
 }
 
+func (p *Persister) SetLeasedMessageStatus(ctx context.Context, id uuid.UUID, workerID string, ms courier.MessageStatus) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.SetLeasedMessageStatus")
+	defer otelx.End(span, &err)
+
+	// The message is only changed while it is leased by the worker. Otherwise,
+	// another worker reclaimed it after the lease expired, or it was changed
+	// through the admin API.
+	count, err := p.GetConnection(ctx).RawQuery(
+		"UPDATE courier_messages SET status = ?, worker_id = NULL, locked_until = NULL WHERE id = ? AND nid = ? AND status = ? AND worker_id = ?",
+		ms,
+		id,
+		p.NetworkID(ctx),
+		courier.MessageStatusProcessing,
+		workerID,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(courier.ErrMessageLeaseLost)
+	}
+
+	return nil
+}
+
 func (p *Persister) IncrementMessageSendCount(ctx context.Context, id uuid.UUID) (err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.IncrementMessageSendCount")
 	defer otelx.End(span, &err)
@@ -200,7 +263,7 @@ func (p *Persister) FetchMessage(ctx context.Context, msgID uuid.UUID) (_ *couri
 	return &message, nil
 }
 
-func (p *Persister) RecordDispatch(ctx context.Context, msgID uuid.UUID, status courier.CourierMessageDispatchStatus, err error) error {
+func (p *Persister) RecordDispatch(ctx context.Context, msgID uuid.UUID, channel string, status courier.CourierMessageDispatchStatus, err error) error {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RecordDispatch")
 	defer otelx.End(span, &err)
 
@@ -208,6 +271,7 @@ func (p *Persister) RecordDispatch(ctx context.Context, msgID uuid.UUID, status
 		ID:        uuidx.NewV4(),
 		MessageID: msgID,
 		Status:    status,
+		Channel:   sqlxx.NullString(channel),
 		NID:       p.NetworkID(ctx),
 	}
 
@@ -227,3 +291,318 @@ func (p *Persister) RecordDispatch(ctx context.Context, msgID uuid.UUID, status
 
 	return nil
 }
+
+// courierMessagesBatchSize is the number of messages which are changed or
+// deleted per transaction by the bulk operations.
+const courierMessagesBatchSize = 500
+
+func (p *Persister) RequeueMessages(ctx context.Context, filter courier.MessagesFilter) (_ int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RequeueMessages")
+	defer otelx.End(span, &err)
+
+	return p.transitionMessages(ctx, filter, courier.MessageStatusAbandoned, courier.MessageStatusQueued, courier.CourierMessageDispatchStatusRequeued)
+}
+
+func (p *Persister) CancelMessages(ctx context.Context, filter courier.MessagesFilter) (_ int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CancelMessages")
+	defer otelx.End(span, &err)
+
+	return p.transitionMessages(ctx, filter, courier.MessageStatusQueued, courier.MessageStatusCancelled, courier.CourierMessageDispatchStatusCancelled)
+}
+
+// transitionMessages moves the messages matching the filter from one status to
+// another and records a dispatch with the given status for each of them.
+func (p *Persister) transitionMessages(ctx context.Context, filter courier.MessagesFilter, from, to courier.MessageStatus, dispatchStatus courier.CourierMessageDispatchStatus) (int, error) {
+	if filter.IsEmpty() && !filter.All {
+		return 0, errors.WithStack(courier.ErrMessagesFilterEmpty)
+	}
+
+	nid := p.NetworkID(ctx)
+
+	var total int
+	for {
+		var selected, changed int
+		if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+			query := "SELECT id FROM courier_messages WHERE nid = ? AND status = ?"
+			args := []any{nid, from}
+			if filter.ID != uuid.Nil {
+				query += " AND id = ?"
+				args = append(args, filter.ID)
+			}
+			if filter.Recipient != "" {
+				query += " AND recipient = ?"
+				args = append(args, filter.Recipient)
+			}
+			if filter.TemplateType != "" {
+				query += " AND template_type = ?"
+				args = append(args, filter.TemplateType)
+			}
+			if filter.CreatedAfter != nil {
+				query += " AND created_at > ?"
+				args = append(args, filter.CreatedAfter.UTC())
+			}
+			if filter.CreatedBefore != nil {
+				query += " AND created_at < ?"
+				args = append(args, filter.CreatedBefore.UTC())
+			}
+			query += " ORDER BY created_at ASC LIMIT ?"
+			args = append(args, courierMessagesBatchSize)
+
+			switch tx.Dialect.Name() {
+			case dbal.DriverPostgreSQL, dbal.DriverMySQL:
+				// Skip the messages which a worker is leasing right now, see
+				// LeaseMessages. The locked rows keep their status until this
+				// transaction ends, so the update below changes all of them.
+				query += " FOR UPDATE SKIP LOCKED"
+			}
+
+			var rows []struct {
+				ID uuid.UUID `db:"id"`
+			}
+			if err := tx.RawQuery(query, args...).All(&rows); err != nil {
+				return err
+			}
+			selected = len(rows)
+			if len(rows) == 0 {
+				return nil
+			}
+
+			ids := make([]uuid.UUID, len(rows))
+			for i := range rows {
+				ids[i] = rows[i].ID
+			}
+
+			stmt := "UPDATE courier_messages SET status = ?, updated_at = ? WHERE nid = ? AND status = ? AND id IN (?)"
+			if to == courier.MessageStatusQueued {
+				stmt = "UPDATE courier_messages SET status = ?, send_count = 0, updated_at = ? WHERE nid = ? AND status = ? AND id IN (?)"
+			}
+			count, err := tx.RawQuery(stmt, to, time.Now().UTC(), nid, from, ids).ExecWithCount()
+			if err != nil {
+				return err
+			}
+			if count != len(ids) {
+				// Some messages changed their status since they were selected,
+				// so only the ones which were transitioned get a dispatch.
+				rows = nil
+				if err := tx.RawQuery("SELECT id FROM courier_messages WHERE nid = ? AND status = ? AND id IN (?)", nid, to, ids).All(&rows); err != nil {
+					return err
+				}
+				ids = ids[:0]
+				for i := range rows {
+					ids = append(ids, rows[i].ID)
+				}
+			}
+			changed = len(ids)
+			if len(ids) == 0 {
+				return nil
+			}
+
+			dispatches := make([]*courier.MessageDispatch, len(ids))
+			for i, id := range ids {
+				dispatches[i] = &courier.MessageDispatch{
+					ID:        uuidx.NewV4(),
+					MessageID: id,
+					Status:    dispatchStatus,
+					NID:       nid,
+				}
+			}
+			return batch.Create(ctx, &batch.TracerConnection{Tracer: p.r.Tracer(ctx), Connection: tx}, dispatches)
+		}); err != nil {
+			return total, sqlcon.HandleError(err)
+		}
+
+		total += changed
+		if selected < courierMessagesBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (p *Persister) PurgeMessages(ctx context.Context, createdBefore time.Time, statuses []courier.MessageStatus) (_ int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.PurgeMessages")
+	defer otelx.End(span, &err)
+
+	if len(statuses) == 0 {
+		return 0, nil
+	}
+
+	nid := p.NetworkID(ctx)
+
+	var total int
+	for {
+		var ids []uuid.UUID
+		if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+			var messages []courier.Message
+			if err := tx.
+				Where("nid = ? AND created_at < ?", nid, createdBefore.UTC()).
+				Where("status IN (?)", statuses).
+				Select("id").
+				Limit(courierMessagesBatchSize).
+				All(&messages); err != nil {
+				return err
+			}
+			if len(messages) == 0 {
+				return nil
+			}
+
+			ids = make([]uuid.UUID, len(messages))
+			for i := range messages {
+				ids[i] = messages[i].ID
+			}
+
+			if err := tx.RawQuery("DELETE FROM courier_message_dispatches WHERE nid = ? AND message_id IN (?)", nid, ids).Exec(); err != nil {
+				return err
+			}
+			return tx.RawQuery("DELETE FROM courier_messages WHERE nid = ? AND id IN (?)", nid, ids).Exec()
+		}); err != nil {
+			return total, sqlcon.HandleError(err)
+		}
+
+		total += len(ids)
+		if len(ids) < courierMessagesBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (p *Persister) MarkAddressUndeliverable(ctx context.Context, via, address string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.MarkAddressUndeliverable")
+	defer otelx.End(span, &err)
+
+	if err := p.GetConnection(ctx).RawQuery(
+		"UPDATE identity_verifiable_addresses SET undeliverable = ?, updated_at = ? WHERE nid = ? AND via = ? AND value IN (?, ?)",
+		true,
+		time.Now().UTC(),
+		p.NetworkID(ctx),
+		via,
+		address,
+		x.GracefulNormalization(address),
+	).Exec(); err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	return nil
+}
+
+func (p *Persister) CountMessages(ctx context.Context, filter courier.MessagesFilter) (_ int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CountMessages")
+	defer otelx.End(span, &err)
+
+	q := p.GetConnection(ctx).Where("nid = ?", p.NetworkID(ctx))
+	if filter.Recipient != "" {
+		q = q.Where("recipient = ?", filter.Recipient)
+	}
+	if filter.TemplateType != "" {
+		q = q.Where("template_type = ?", filter.TemplateType)
+	}
+	if filter.CreatedAfter != nil {
+		q = q.Where("created_at > ?", filter.CreatedAfter.UTC())
+	}
+	if filter.CreatedBefore != nil {
+		q = q.Where("created_at < ?", filter.CreatedBefore.UTC())
+	}
+
+	count, err := q.Count(new(courier.Message))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+	return count, nil
+}
+
+func (p *Persister) FindQueuedDuplicate(ctx context.Context, m *courier.Message) (_ *courier.Message, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FindQueuedDuplicate")
+	defer otelx.End(span, &err)
+
+	// The content is not compared: messages of the same template differ in
+	// their one-time codes and links.
+	var duplicate courier.Message
+	if err := p.GetConnection(ctx).
+		Where("nid = ? AND status = ? AND recipient = ? AND type = ? AND template_type = ?",
+			p.NetworkID(ctx),
+			courier.MessageStatusQueued,
+			m.Recipient,
+			m.Type,
+			m.TemplateType,
+		).
+		Order("created_at DESC").
+		First(&duplicate); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &duplicate, nil
+}
+
+func (p *Persister) UpdateQueuedMessage(ctx context.Context, id uuid.UUID, m *courier.Message) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateQueuedMessage")
+	defer otelx.End(span, &err)
+
+	count, err := p.GetConnection(ctx).RawQuery(
+		"UPDATE courier_messages SET subject = ?, body = ?, template_data = ?, request_headers = ?, updated_at = ? WHERE id = ? AND nid = ? AND status = ?",
+		m.Subject,
+		m.Body,
+		m.TemplateData,
+		m.RequestHeaders,
+		time.Now().UTC(),
+		id,
+		p.NetworkID(ctx),
+		courier.MessageStatusQueued,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows())
+	}
+	return nil
+}
+
+func (p *Persister) QueueMessage(ctx context.Context, m *courier.Message, admit func(context.Context) (uuid.UUID, error)) (_ uuid.UUID, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.QueueMessage")
+	defer otelx.End(span, &err)
+
+	var id uuid.UUID
+	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		id = uuid.Nil
+		if err := p.lockRecipient(ctx, tx, m.Recipient); err != nil {
+			return err
+		}
+
+		existing, err := admit(ctx)
+		if err != nil {
+			return err
+		} else if existing != uuid.Nil {
+			id = existing
+		} else {
+			if err := p.AddMessage(ctx, m); err != nil {
+				return err
+			}
+			id = m.ID
+		}
+
+		return p.unlockRecipient(ctx, tx, m.Recipient)
+	}); err != nil {
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+// lockRecipient locks the recipient until the transaction ends, so that
+// concurrent transactions queueing messages for the recipient run one after
+// the other. The lock row is written instead of selected FOR UPDATE, because
+// the row may not exist yet and SQLite has no FOR UPDATE. Writing it locks the
+// row on every database, and takes the write lock up front on SQLite.
+func (p *Persister) lockRecipient(ctx context.Context, tx *pop.Connection, recipient string) error {
+	query := "INSERT INTO courier_recipient_locks (nid, recipient, locked_at) VALUES (?, ?, ?) ON CONFLICT (nid, recipient) DO UPDATE SET locked_at = excluded.locked_at"
+	if tx.Dialect.Name() == dbal.DriverMySQL {
+		query = "INSERT INTO courier_recipient_locks (nid, recipient, locked_at) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE locked_at = VALUES(locked_at)"
+	}
+	return sqlcon.HandleError(tx.RawQuery(query, p.NetworkID(ctx), recipient, time.Now().UTC()).Exec())
+}
+
+// unlockRecipient deletes the lock row written by lockRecipient, so that the
+// table does not keep a row for every recipient. The row stays locked until
+// the transaction ends, so concurrent transactions still wait for it.
+func (p *Persister) unlockRecipient(ctx context.Context, tx *pop.Connection, recipient string) error {
+	return sqlcon.HandleError(tx.RawQuery("DELETE FROM courier_recipient_locks WHERE nid = ? AND recipient = ?", p.NetworkID(ctx), recipient).Exec())
+}
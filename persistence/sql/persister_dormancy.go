@@ -0,0 +1,116 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/identity/dormancy"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ dormancy.Persister = new(Persister)
+
+// dormantIdentityCondition matches active identities whose last activity and
+// last state change lie before the bound time.
+const dormantIdentityCondition = "nid = ? AND state = ? AND COALESCE(last_authenticated_at, created_at) < ? AND (state_changed_at IS NULL OR state_changed_at < ?)"
+
+func (p *Persister) listIdentityIDs(ctx context.Context, query string, args ...any) ([]uuid.UUID, error) {
+	var rows []struct {
+		ID uuid.UUID `db:"id"`
+	}
+	if err := p.GetConnection(ctx).RawQuery(query, args...).All(&rows); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for k, r := range rows {
+		ids[k] = r.ID
+	}
+	return ids, nil
+}
+
+func (p *Persister) ListIdentitiesDueForDormancyWarning(ctx context.Context, lastActiveBefore time.Time, limit int) (_ []uuid.UUID, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListIdentitiesDueForDormancyWarning")
+	defer otelx.End(span, &err)
+
+	lastActiveBefore = lastActiveBefore.UTC()
+	return p.listIdentityIDs(ctx,
+		"SELECT id FROM identities WHERE "+dormantIdentityCondition+" AND dormancy_warned_at IS NULL ORDER BY CASE WHEN dormancy_warning_failed_at IS NULL THEN 0 ELSE 1 END ASC, dormancy_warning_failed_at ASC, id ASC LIMIT ?",
+		p.NetworkID(ctx), identity.StateActive, lastActiveBefore, lastActiveBefore, limit,
+	)
+}
+
+func (p *Persister) MarkDormancyWarningSent(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.MarkDormancyWarningSent")
+	defer otelx.End(span, &err)
+
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(
+		"UPDATE identities SET dormancy_warned_at = ?, dormancy_warning_failed_at = NULL WHERE id = ? AND nid = ?",
+		at.UTC(), id, p.NetworkID(ctx),
+	).Exec())
+}
+
+func (p *Persister) MarkDormancyWarningFailed(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.MarkDormancyWarningFailed")
+	defer otelx.End(span, &err)
+
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(
+		"UPDATE identities SET dormancy_warning_failed_at = COALESCE(dormancy_warning_failed_at, ?) WHERE id = ? AND nid = ?",
+		at.UTC(), id, p.NetworkID(ctx),
+	).Exec())
+}
+
+func (p *Persister) ListIdentitiesDueForDeactivation(ctx context.Context, lastActiveBefore, warnedBefore time.Time, limit int) (_ []uuid.UUID, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListIdentitiesDueForDeactivation")
+	defer otelx.End(span, &err)
+
+	lastActiveBefore = lastActiveBefore.UTC()
+	if warnedBefore.IsZero() {
+		return p.listIdentityIDs(ctx,
+			"SELECT id FROM identities WHERE "+dormantIdentityCondition+" ORDER BY id ASC LIMIT ?",
+			p.NetworkID(ctx), identity.StateActive, lastActiveBefore, lastActiveBefore, limit,
+		)
+	}
+
+	// Identities which could not be warned during the whole warning period
+	// are deactivated anyway.
+	warnedBefore = warnedBefore.UTC()
+	return p.listIdentityIDs(ctx,
+		"SELECT id FROM identities WHERE "+dormantIdentityCondition+" AND (dormancy_warned_at <= ? OR dormancy_warning_failed_at <= ?) ORDER BY id ASC LIMIT ?",
+		p.NetworkID(ctx), identity.StateActive, lastActiveBefore, lastActiveBefore, warnedBefore, warnedBefore, limit,
+	)
+}
+
+func (p *Persister) MarkDormantIdentityDeactivated(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.MarkDormantIdentityDeactivated")
+	defer otelx.End(span, &err)
+
+	// Clearing the warning starts a new warning period should the identity be
+	// reactivated later on. dormancy_deactivated_at tells identities
+	// deactivated by the policy apart from identities deactivated by an
+	// administrator, which are never deleted.
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(
+		"UPDATE identities SET dormancy_warned_at = NULL, dormancy_warning_failed_at = NULL, dormancy_deactivated_at = ? WHERE id = ? AND nid = ?",
+		at.UTC(), id, p.NetworkID(ctx),
+	).Exec())
+}
+
+func (p *Persister) ListIdentitiesDueForDeletion(ctx context.Context, inactiveBefore time.Time, limit int) (_ []uuid.UUID, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListIdentitiesDueForDeletion")
+	defer otelx.End(span, &err)
+
+	// The state must not have changed since the policy deactivated the
+	// identity. Otherwise, an administrator reactivated the identity, and
+	// might have deactivated it again on purpose.
+	return p.listIdentityIDs(ctx,
+		"SELECT id FROM identities WHERE nid = ? AND state = ? AND dormancy_deactivated_at < ? AND state_changed_at <= dormancy_deactivated_at ORDER BY id ASC LIMIT ?",
+		p.NetworkID(ctx), identity.StateInactive, inactiveBefore.UTC(), limit,
+	)
+}
@@ -0,0 +1,140 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/selfservice/lockout"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+var _ lockout.Persister = new(Persister)
+
+func (p *Persister) GetLoginAttempt(ctx context.Context, kind lockout.SubjectKind, subject string) (_ *lockout.Attempt, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetLoginAttempt")
+	defer otelx.End(span, &err)
+
+	var a lockout.Attempt
+	if err := p.GetConnection(ctx).
+		Where("nid = ? AND kind = ? AND subject = ?", p.NetworkID(ctx), kind, p.hmacValue(ctx, subject)).
+		First(&a); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &a, nil
+}
+
+func (p *Persister) IncrementFailedLoginAttempts(ctx context.Context, kind lockout.SubjectKind, subject string, identityID uuid.NullUUID, windowStart time.Time) (_ *lockout.Attempt, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.IncrementFailedLoginAttempts")
+	defer otelx.End(span, &err)
+
+	nid := p.NetworkID(ctx)
+	hashed := p.hmacValue(ctx, subject)
+
+	// The first attempt creates the row. Concurrent first attempts race on the
+	// unique index, in which case the loser simply increments the winner's row.
+	for range 2 {
+		now := time.Now().UTC()
+
+		// Counters whose last failure is older than the attempt window start
+		// over. The lockout count is only reset if the subject is not locked
+		// anymore, so that the backoff keeps growing for persistent attackers.
+		//
+		// The assignment to last_failed_at must come last, as MySQL evaluates
+		// the SET clause from left to right.
+		//
+		//#nosec G201 -- TableName is static
+		count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(`UPDATE %s SET
+failed_attempts = CASE WHEN last_failed_at < ? THEN 1 ELSE failed_attempts + 1 END,
+lockout_count = CASE WHEN last_failed_at < ? AND (locked_until IS NULL OR locked_until < ?) THEN 0 ELSE lockout_count END,
+identity_id = COALESCE(?, identity_id),
+updated_at = ?,
+last_failed_at = ?
+WHERE nid = ? AND kind = ? AND subject = ?`, lockout.Attempt{}.TableName()),
+			windowStart, windowStart, now, identityID, now, now, nid, kind, hashed,
+		).ExecWithCount()
+		if err != nil {
+			return nil, sqlcon.HandleError(err)
+		}
+
+		if count == 0 {
+			a := &lockout.Attempt{
+				ID:             uuid.Must(uuid.NewV4()),
+				NID:            nid,
+				Kind:           kind,
+				Subject:        hashed,
+				IdentityID:     identityID,
+				FailedAttempts: 1,
+				LastFailedAt:   now,
+			}
+			if err := sqlcon.HandleError(p.GetConnection(ctx).Create(a)); errors.Is(err, sqlcon.ErrUniqueViolation()) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			return a, nil
+		}
+
+		return p.GetLoginAttempt(ctx, kind, subject)
+	}
+
+	return nil, errors.WithStack(sqlcon.ErrConcurrentUpdate())
+}
+
+func (p *Persister) LockLoginAttempt(ctx context.Context, id uuid.UUID, until time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.LockLoginAttempt")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(
+		fmt.Sprintf("UPDATE %s SET failed_attempts = 0, lockout_count = lockout_count + 1, locked_until = ?, updated_at = ? WHERE id = ? AND nid = ?", lockout.Attempt{}.TableName()),
+		sqlxx.NullTime(until.UTC()), time.Now().UTC(), id, p.NetworkID(ctx),
+	).Exec())
+}
+
+func (p *Persister) DeleteLoginAttempt(ctx context.Context, kind lockout.SubjectKind, subject string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteLoginAttempt")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(
+		fmt.Sprintf("DELETE FROM %s WHERE nid = ? AND kind = ? AND subject = ?", lockout.Attempt{}.TableName()),
+		p.NetworkID(ctx), kind, p.hmacValue(ctx, subject),
+	).Exec())
+}
+
+func (p *Persister) DeleteLoginAttemptsForIdentity(ctx context.Context, identityID uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteLoginAttemptsForIdentity")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(
+		fmt.Sprintf("DELETE FROM %s WHERE nid = ? AND identity_id = ?", lockout.Attempt{}.TableName()),
+		p.NetworkID(ctx), identityID,
+	).Exec())
+}
+
+func (p *Persister) DeleteExpiredLoginAttempts(ctx context.Context, before time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredLoginAttempts")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %[1]s WHERE id in (SELECT id FROM (SELECT id FROM %[1]s c WHERE last_failed_at <= ? AND (locked_until IS NULL OR locked_until <= ?) AND nid = ? ORDER BY last_failed_at ASC LIMIT ?) AS s)",
+		lockout.Attempt{}.TableName(),
+	),
+		before,
+		before,
+		p.NetworkID(ctx),
+		limit,
+	).Exec())
+}
@@ -0,0 +1,125 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/x/otelx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ oidc.Persister = new(Persister)
+
+func (p *Persister) CreateOIDCProvider(ctx context.Context, provider *oidc.DynamicProvider) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateOIDCProvider")
+	defer otelx.End(span, &err)
+
+	provider.ID = uuid.Must(uuid.NewV4())
+	provider.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(provider))
+}
+
+func (p *Persister) GetOIDCProvider(ctx context.Context, providerID string) (_ *oidc.DynamicProvider, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetOIDCProvider")
+	defer otelx.End(span, &err)
+
+	var provider oidc.DynamicProvider
+	if err := p.GetConnection(ctx).Where("provider_id = ? AND nid = ?", providerID, p.NetworkID(ctx)).First(&provider); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &provider, nil
+}
+
+func (p *Persister) ListOIDCProviders(ctx context.Context, opts []keysetpagination.Option) (_ []oidc.DynamicProvider, _ *keysetpagination.Paginator, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListOIDCProviders")
+	defer otelx.End(span, &err)
+
+	opts = append(opts, keysetpagination.WithDefaultToken(oidc.DynamicProvider{}.DefaultPageToken()))
+	opts = append(opts, keysetpagination.WithDefaultSize(100))
+	paginator, err := keysetpagination.NewPaginator(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	providers := make([]oidc.DynamicProvider, paginator.Size())
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Scope(keysetpagination.Paginate[oidc.DynamicProvider](paginator)).
+		All(&providers); err != nil {
+		return nil, nil, sqlcon.HandleError(err)
+	}
+
+	providers, nextPage := keysetpagination.Result(providers, paginator)
+	return providers, nextPage, nil
+}
+
+func (p *Persister) UpdateOIDCProvider(ctx context.Context, provider *oidc.DynamicProvider) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateOIDCProvider")
+	defer otelx.End(span, &err)
+
+	provider.UpdatedAt = time.Now().UTC()
+	if count, err := p.GetConnection(ctx).RawQuery(
+		//#nosec G201 -- TableName is static
+		fmt.Sprintf("UPDATE %s SET organization_id = ?, config = ?, updated_at = ? WHERE provider_id = ? AND nid = ?",
+			oidc.DynamicProvider{}.TableName()),
+		provider.OrganizationID, provider.Config, provider.UpdatedAt, provider.ProviderID, p.NetworkID(ctx)).ExecWithCount(); err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows())
+	}
+	return nil
+}
+
+func (p *Persister) DeleteOIDCProvider(ctx context.Context, providerID string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteOIDCProvider")
+	defer otelx.End(span, &err)
+
+	if count, err := p.GetConnection(ctx).RawQuery(
+		//#nosec G201 -- TableName is static
+		fmt.Sprintf("DELETE FROM %s WHERE provider_id = ? AND nid = ?",
+			oidc.DynamicProvider{}.TableName()), providerID, p.NetworkID(ctx)).ExecWithCount(); err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows())
+	}
+	return nil
+}
+
+func (p *Persister) ConsumeLogoutToken(ctx context.Context, providerID, jti string, expiresAt time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ConsumeLogoutToken")
+	defer otelx.End(span, &err)
+
+	// The unique index on the network, provider, and jti rejects the second
+	// use of a logout token, also when both arrive concurrently.
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(&oidc.ConsumedLogoutToken{
+		ID:         uuid.Must(uuid.NewV4()),
+		NID:        p.NetworkID(ctx),
+		ProviderID: providerID,
+		JTI:        jti,
+		ExpiresAt:  expiresAt.UTC(),
+	}))
+}
+
+func (p *Persister) DeleteExpiredLogoutTokens(ctx context.Context, before time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredLogoutTokens")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %[1]s WHERE id in (SELECT id FROM (SELECT id FROM %[1]s c WHERE expires_at <= ? AND nid = ? ORDER BY expires_at ASC LIMIT ?) AS s)",
+		oidc.ConsumedLogoutToken{}.TableName(),
+	),
+		before,
+		p.NetworkID(ctx),
+		limit,
+	).Exec())
+}
@@ -0,0 +1,211 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/outbox"
+	idpersistence "github.com/ory/kratos/persistence/sql/identity"
+	"github.com/ory/pop/v6"
+	"github.com/ory/x/dbal"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/stringsx"
+)
+
+var _ outbox.Persister = new(Persister)
+
+// outboxLastErrorMaxLength bounds the stored delivery error, which may contain
+// parts of the receiver's response.
+const outboxLastErrorMaxLength = 1024
+
+func (p *Persister) EnqueueOutboxMessages(ctx context.Context, messages ...*outbox.Message) (err error) {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.EnqueueOutboxMessages")
+	defer otelx.End(span, &err)
+
+	return idpersistence.EnqueueOutboxMessages(p.GetConnection(ctx), p.NetworkID(ctx), messages...)
+}
+
+func (p *Persister) NextOutboxMessages(ctx context.Context, limit int, lease time.Duration) (messages []outbox.Message, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.NextOutboxMessages")
+	defer otelx.End(span, &err)
+
+	nid := p.NetworkID(ctx)
+	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		now := time.Now().UTC()
+
+		// Processing messages whose lease expired belong to a dispatcher
+		// which crashed or was stopped, so they are claimed again.
+		//#nosec G201 -- TableName is static
+		query := fmt.Sprintf(
+			"SELECT id FROM %s WHERE nid = ? AND ((status = ? AND next_attempt_at <= ?) OR (status = ? AND locked_until < ?)) ORDER BY next_attempt_at ASC LIMIT ?",
+			outbox.Message{}.TableName(),
+		)
+		switch tx.Dialect.Name() {
+		case dbal.DriverPostgreSQL, dbal.DriverMySQL:
+			// Concurrent dispatchers skip the messages which another
+			// dispatcher is claiming right now instead of waiting for its
+			// transaction and claiming them a second time. CockroachDB
+			// serializes the transactions instead, and SQLite has a single
+			// writer.
+			query += " FOR UPDATE SKIP LOCKED"
+		}
+
+		var rows []struct {
+			ID uuid.UUID `db:"id"`
+		}
+		if err := tx.RawQuery(query,
+			nid,
+			outbox.MessageStatusQueued,
+			now,
+			outbox.MessageStatusProcessing,
+			now,
+			limit,
+		).All(&rows); err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return sql.ErrNoRows
+		}
+
+		ids := make([]uuid.UUID, len(rows))
+		for i := range rows {
+			ids[i] = rows[i].ID
+		}
+
+		// Every claim gets its own lease ID, so that a dispatcher whose
+		// lease expired can not record an attempt for a message which was
+		// claimed again.
+		//#nosec G201 -- TableName is static
+		if err := tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET status = ?, locked_until = ?, lease_id = ?, updated_at = ? WHERE id IN (?) AND nid = ?",
+			outbox.Message{}.TableName(),
+		),
+			outbox.MessageStatusProcessing,
+			now.Add(lease),
+			uuid.Must(uuid.NewV4()),
+			now,
+			ids,
+			nid,
+		).Exec(); err != nil {
+			return err
+		}
+
+		var m []outbox.Message
+		if err := tx.Where("id IN (?) AND nid = ?", ids, nid).Order("next_attempt_at ASC").All(&m); err != nil {
+			return err
+		}
+
+		messages = m
+		return nil
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(outbox.ErrQueueEmpty)
+		}
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return messages, nil
+}
+
+func (p *Persister) SetOutboxMessageDelivered(ctx context.Context, m *outbox.Message) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.SetOutboxMessageDelivered")
+	defer otelx.End(span, &err)
+
+	return p.updateOutboxMessage(ctx, m, outbox.MessageStatusDelivered, time.Time{}, "")
+}
+
+func (p *Persister) RetryOutboxMessage(ctx context.Context, m *outbox.Message, nextAttemptAt time.Time, lastError string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RetryOutboxMessage")
+	defer otelx.End(span, &err)
+
+	return p.updateOutboxMessage(ctx, m, outbox.MessageStatusQueued, nextAttemptAt, lastError)
+}
+
+func (p *Persister) DeadLetterOutboxMessage(ctx context.Context, m *outbox.Message, lastError string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeadLetterOutboxMessage")
+	defer otelx.End(span, &err)
+
+	return p.updateOutboxMessage(ctx, m, outbox.MessageStatusDeadLettered, time.Time{}, lastError)
+}
+
+// updateOutboxMessage records a delivery attempt and ends the lease of the
+// dispatcher. The next attempt time is only changed if nextAttemptAt is set.
+// Returns ErrMessageLeaseLost if the message was claimed again since.
+func (p *Persister) updateOutboxMessage(ctx context.Context, m *outbox.Message, status outbox.MessageStatus, nextAttemptAt time.Time, lastError string) error {
+	if !m.LeaseID.Valid {
+		return errors.WithStack(outbox.ErrMessageLeaseLost)
+	}
+
+	now := time.Now().UTC()
+	if nextAttemptAt.IsZero() {
+		nextAttemptAt = now
+	}
+
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET status = ?, attempts = attempts + 1, next_attempt_at = ?, last_error = ?, locked_until = NULL, lease_id = NULL, updated_at = ? WHERE id = ? AND nid = ? AND status = ? AND lease_id = ?",
+		outbox.Message{}.TableName(),
+	),
+		status,
+		nextAttemptAt.UTC(),
+		stringsx.TruncateByteLen(lastError, outboxLastErrorMaxLength),
+		now,
+		m.ID,
+		p.NetworkID(ctx),
+		outbox.MessageStatusProcessing,
+		m.LeaseID,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(outbox.ErrMessageLeaseLost)
+	}
+	return nil
+}
+
+func (p *Persister) FetchOutboxMessage(ctx context.Context, id uuid.UUID) (_ *outbox.Message, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FetchOutboxMessage")
+	defer otelx.End(span, &err)
+
+	var m outbox.Message
+	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, p.NetworkID(ctx)).First(&m); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &m, nil
+}
+
+func (p *Persister) DeleteExpiredOutboxMessages(ctx context.Context, before time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredOutboxMessages")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %[1]s WHERE id in (SELECT id FROM (SELECT id FROM %[1]s c WHERE status = ? AND updated_at < ? AND nid = ? ORDER BY updated_at ASC LIMIT ?) AS s)",
+		outbox.Message{}.TableName(),
+	),
+		outbox.MessageStatusDelivered,
+		before.UTC(),
+		p.NetworkID(ctx),
+		limit,
+	).Exec())
+}
+
+// transactionWithOutbox runs fn and writes the outbox messages it returns in
+// the same transaction. See idpersistence.TransactionWithOutbox.
+func (p *Persister) transactionWithOutbox(ctx context.Context, fn func(ctx context.Context) ([]*outbox.Message, error)) error {
+	return idpersistence.TransactionWithOutbox(ctx, p.r.Config().OutboxEnabled(ctx), p.c, p.NetworkID(ctx), fn)
+}
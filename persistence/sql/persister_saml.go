@@ -0,0 +1,48 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/selfservice/strategy/saml"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ saml.Persister = new(Persister)
+
+func (p *Persister) ConsumeSAMLAssertion(ctx context.Context, providerID, assertionID string, expiresAt time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ConsumeSAMLAssertion")
+	defer otelx.End(span, &err)
+
+	// The unique index on the network, provider, and assertion ID rejects
+	// the second use of an assertion, also when both arrive concurrently.
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(&saml.ConsumedAssertion{
+		ID:          uuid.Must(uuid.NewV4()),
+		NID:         p.NetworkID(ctx),
+		ProviderID:  providerID,
+		AssertionID: assertionID,
+		ExpiresAt:   expiresAt.UTC(),
+	}))
+}
+
+func (p *Persister) DeleteExpiredSAMLAssertions(ctx context.Context, before time.Time, limit int) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredSAMLAssertions")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %[1]s WHERE id in (SELECT id FROM (SELECT id FROM %[1]s c WHERE expires_at <= ? AND nid = ? ORDER BY expires_at ASC LIMIT ?) AS s)",
+		saml.ConsumedAssertion{}.TableName(),
+	),
+		before,
+		p.NetworkID(ctx),
+		limit,
+	).Exec())
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/outbox"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/events"
@@ -33,6 +34,10 @@ var _ session.Persister = new(Persister)
 const (
 	SessionDeviceUserAgentMaxLength = 512
 	SessionDeviceLocationMaxLength  = 512
+	SessionDeviceCountryMaxLength   = 64
+	SessionDeviceRegionMaxLength    = 255
+	SessionDeviceCityMaxLength      = 255
+	SessionDeviceASNMaxLength       = 32
 	paginationMaxItemsSize          = 1000
 	paginationDefaultItemsSize      = 250
 )
@@ -91,10 +96,11 @@ func (p *Persister) ListSessions(ctx context.Context, active *bool, paginatorOpt
 
 		q := c.Where("nid = ?", nid)
 		if active != nil {
+			now := time.Now().UTC()
 			if *active {
-				q.Where("active = ? AND expires_at >= ?", *active, time.Now().UTC())
+				q.Where("active = ? AND expires_at >= ? AND (idle_expires_at IS NULL OR idle_expires_at > ?)", *active, now, now)
 			} else {
-				q.Where("(active = ? OR expires_at < ?)", *active, time.Now().UTC())
+				q.Where("(active = ? OR expires_at < ? OR idle_expires_at <= ?)", *active, now, now)
 			}
 		}
 
@@ -148,10 +154,11 @@ func (p *Persister) ListSessionsByIdentity(
 			q = q.Where("id != ?", except)
 		}
 		if active != nil {
+			now := time.Now().UTC()
 			if *active {
-				q.Where("active = ? AND expires_at >= ?", *active, time.Now().UTC())
+				q.Where("active = ? AND expires_at >= ? AND (idle_expires_at IS NULL OR idle_expires_at > ?)", *active, now, now)
 			} else {
-				q.Where("(active = ? OR expires_at < ?)", *active, time.Now().UTC())
+				q.Where("(active = ? OR expires_at < ? OR idle_expires_at <= ?)", *active, now, now)
 			}
 		}
 
@@ -178,7 +185,7 @@ func (p *Persister) ListSessionsByIdentity(
 	return s, t, nil
 }
 
-// ExtendSession updates the expiry of a session.
+// ExtendSession updates the expiry and the idle expiry of a session.
 func (p *Persister) ExtendSession(ctx context.Context, sessionID uuid.UUID) (err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ExtendSession")
 	defer otelx.End(span, &err)
@@ -209,15 +216,24 @@ func (p *Persister) ExtendSession(ctx context.Context, sessionID uuid.UUID) (err
 			return sqlcon.HandleError(err)
 		}
 
-		if !s.CanBeRefreshed(ctx, p.r.Config()) {
+		var columns []string
+		if s.CanBeRefreshed(ctx, p.r.Config()) {
+			didRefresh = true
+			s = s.Refresh(ctx, p.r.Config())
+			columns = append(columns, "expires_at")
+		}
+		// Extending a session also counts as using it, so that it does not
+		// run into its idle timeout right after it was extended.
+		if now := time.Now(); s.NeedsLastSeenUpdate(ctx, p.r.Config(), now) {
+			s = s.SetLastSeen(ctx, p.r.Config(), now)
+			columns = append(columns, "last_seen_at", "idle_expires_at")
+		}
+		if len(columns) == 0 {
 			// This prevents excessive writes to the database.
 			return nil
 		}
 
-		didRefresh = true
-		s = s.Refresh(ctx, p.r.Config())
-
-		if _, err := tx.Where("id = ? AND nid = ?", sessionID, nid).UpdateQuery(s, "expires_at"); err != nil {
+		if _, err := tx.Where("id = ? AND nid = ?", sessionID, nid).UpdateQuery(s, columns...); err != nil {
 			return sqlcon.HandleError(err)
 		}
 
@@ -227,12 +243,23 @@ func (p *Persister) ExtendSession(ctx context.Context, sessionID uuid.UUID) (err
 	}
 
 	if didRefresh {
-		trace.SpanFromContext(ctx).AddEvent(events.NewSessionLifespanExtended(ctx, s.ID, s.IdentityID, s.ExpiresAt))
+		events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionLifespanExtended(ctx, s.ID, s.IdentityID, s.ExpiresAt))
 	}
 
 	return nil
 }
 
+// UpdateSessionLastSeen writes the last seen timestamp and the idle expiry of a session.
+func (p *Persister) UpdateSessionLastSeen(ctx context.Context, s *session.Session) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateSessionLastSeen")
+	defer otelx.End(span, &err)
+
+	if _, err := p.GetConnection(ctx).Where("id = ? AND nid = ?", s.ID, p.NetworkID(ctx)).UpdateQuery(s, "last_seen_at", "idle_expires_at"); err != nil {
+		return sqlcon.HandleError(err)
+	}
+	return nil
+}
+
 // UpsertSession creates a session if not found else updates.
 // This operation also inserts Session device records when a session is being created.
 // The update operation skips updating Session device records since only one record would need to be updated in this case.
@@ -253,9 +280,9 @@ func (p *Persister) UpsertSession(ctx context.Context, s *session.Session) (err
 			return
 		}
 		if updated {
-			trace.SpanFromContext(ctx).AddEvent(events.NewSessionChanged(ctx, string(s.AuthenticatorAssuranceLevel), s.ID, s.IdentityID))
+			events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionChanged(ctx, string(s.AuthenticatorAssuranceLevel), s.ID, s.IdentityID))
 		} else {
-			trace.SpanFromContext(ctx).AddEvent(events.NewSessionIssued(ctx, string(s.AuthenticatorAssuranceLevel), s.ID, s.IdentityID))
+			events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionIssued(ctx, string(s.AuthenticatorAssuranceLevel), s.ID, s.IdentityID))
 		}
 	}()
 
@@ -296,6 +323,18 @@ func (p *Persister) UpsertSession(ctx context.Context, s *session.Session) (err
 			if device.UserAgent != nil {
 				device.UserAgent = new(stringsx.TruncateByteLen(*device.UserAgent, SessionDeviceUserAgentMaxLength))
 			}
+			if device.Country != nil {
+				device.Country = new(stringsx.TruncateByteLen(*device.Country, SessionDeviceCountryMaxLength))
+			}
+			if device.Region != nil {
+				device.Region = new(stringsx.TruncateByteLen(*device.Region, SessionDeviceRegionMaxLength))
+			}
+			if device.City != nil {
+				device.City = new(stringsx.TruncateByteLen(*device.City, SessionDeviceCityMaxLength))
+			}
+			if device.ASN != nil {
+				device.ASN = new(stringsx.TruncateByteLen(*device.ASN, SessionDeviceASNMaxLength))
+			}
 
 			if err := p.CreateDevice(ctx, device); err != nil {
 				return err
@@ -419,35 +458,43 @@ func (p *Persister) RevokeSessionByToken(ctx context.Context, token string) (rev
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeSessionByToken")
 	defer otelx.End(span, &err)
 
-	con := p.GetConnection(ctx)
 	nid := p.NetworkID(ctx)
 	var dst struct {
 		ID         uuid.UUID `db:"id"`
 		IdentityID uuid.UUID `db:"identity_id"`
 	}
 
-	switch con.Dialect.Name() {
-	case dbal.DriverCockroachDB, dbal.DriverPostgreSQL:
-		// CTE: identify the row by (token, nid), conditionally flip active=false
-		// only when currently true, and return the matched row's identifiers in
-		// one round trip. See revokeMatchingSessions for the contention rationale.
-		const query = `WITH found AS (SELECT id, identity_id FROM sessions WHERE token = ? AND nid = ?),
+	err = p.transactionWithOutbox(ctx, func(ctx context.Context) (messages []*outbox.Message, err error) {
+		messages, err = p.revokedSessionMessages(ctx, "token = ? AND nid = ?", token, nid)
+		if err != nil {
+			return nil, err
+		}
+
+		con := p.GetConnection(ctx)
+		switch con.Dialect.Name() {
+		case dbal.DriverCockroachDB, dbal.DriverPostgreSQL:
+			// CTE: identify the row by (token, nid), conditionally flip active=false
+			// only when currently true, and return the matched row's identifiers in
+			// one round trip. See revokeMatchingSessions for the contention rationale.
+			const query = `WITH found AS (SELECT id, identity_id FROM sessions WHERE token = ? AND nid = ?),
      upd AS (UPDATE sessions SET active = false FROM found WHERE sessions.id = found.id AND sessions.active = true RETURNING 1)
 SELECT id, identity_id FROM found`
 
-		err = p.runInReadCommittedOnCRDB(ctx, func(c *pop.Connection) error {
-			return c.RawQuery(query, token, nid).First(&dst)
-		})
-	default:
-		// SQLite and MySQL: data-modifying CTEs are not portable here, so issue
-		// a separate SELECT followed by the legacy UPDATE. Same two-statement
-		// shape as today's caller (GetSessionByToken + RevokeSessionByToken),
-		// so no regression on these dialects.
-		err = con.RawQuery("SELECT id, identity_id FROM sessions WHERE token = ? AND nid = ?", token, nid).First(&dst)
-		if err == nil {
-			err = con.RawQuery("UPDATE sessions SET active = false WHERE token = ? AND nid = ?", token, nid).Exec()
+			err = p.runInReadCommittedOnCRDB(ctx, func(c *pop.Connection) error {
+				return c.RawQuery(query, token, nid).First(&dst)
+			})
+		default:
+			// SQLite and MySQL: data-modifying CTEs are not portable here, so issue
+			// a separate SELECT followed by the legacy UPDATE. Same two-statement
+			// shape as today's caller (GetSessionByToken + RevokeSessionByToken),
+			// so no regression on these dialects.
+			err = con.RawQuery("SELECT id, identity_id FROM sessions WHERE token = ? AND nid = ?", token, nid).First(&dst)
+			if err == nil {
+				err = con.RawQuery("UPDATE sessions SET active = false WHERE token = ? AND nid = ?", token, nid).Exec()
+			}
 		}
-	}
+		return messages, err
+	})
 	if err != nil {
 		return session.RevokedSession{}, sqlcon.HandleError(err)
 	}
@@ -460,8 +507,15 @@ func (p *Persister) RevokeSessionById(ctx context.Context, sID uuid.UUID) (err e
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeSessionById")
 	defer otelx.End(span, &err)
 
-	count, err := p.revokeMatchingSessions(ctx, "id = ? AND nid = ?", sID, p.NetworkID(ctx))
-	if err != nil {
+	var count int
+	if err := p.transactionWithOutbox(ctx, func(ctx context.Context) (messages []*outbox.Message, err error) {
+		messages, err = p.revokedSessionMessages(ctx, "id = ? AND nid = ?", sID, p.NetworkID(ctx))
+		if err != nil {
+			return nil, err
+		}
+		count, err = p.revokeMatchingSessions(ctx, "id = ? AND nid = ?", sID, p.NetworkID(ctx))
+		return messages, err
+	}); err != nil {
 		return err
 	}
 	if count == 0 {
@@ -476,11 +530,17 @@ func (p *Persister) RevokeSession(ctx context.Context, iID, sID uuid.UUID) (err
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeSession")
 	defer otelx.End(span, &err)
 
-	return p.runInReadCommittedOnCRDB(ctx, func(c *pop.Connection) error {
-		return c.RawQuery(
-			"UPDATE sessions SET active = false WHERE id = ? AND identity_id = ? AND nid = ? AND active = true",
-			sID, iID, p.NetworkID(ctx),
-		).Exec()
+	return p.transactionWithOutbox(ctx, func(ctx context.Context) (messages []*outbox.Message, err error) {
+		messages, err = p.revokedSessionMessages(ctx, "id = ? AND identity_id = ? AND nid = ?", sID, iID, p.NetworkID(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return messages, p.runInReadCommittedOnCRDB(ctx, func(c *pop.Connection) error {
+			return c.RawQuery(
+				"UPDATE sessions SET active = false WHERE id = ? AND identity_id = ? AND nid = ? AND active = true",
+				sID, iID, p.NetworkID(ctx),
+			).Exec()
+		})
 	})
 }
 
@@ -489,7 +549,15 @@ func (p *Persister) RevokeSessionsIdentityExcept(ctx context.Context, iID, sID u
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeSessionsIdentityExcept")
 	defer otelx.End(span, &err)
 
-	return p.revokeMatchingSessions(ctx, "identity_id = ? AND id != ? AND nid = ?", iID, sID, p.NetworkID(ctx))
+	err = p.transactionWithOutbox(ctx, func(ctx context.Context) (messages []*outbox.Message, err error) {
+		messages, err = p.revokedSessionMessages(ctx, "identity_id = ? AND id != ? AND nid = ?", iID, sID, p.NetworkID(ctx))
+		if err != nil {
+			return nil, err
+		}
+		res, err = p.revokeMatchingSessions(ctx, "identity_id = ? AND id != ? AND nid = ?", iID, sID, p.NetworkID(ctx))
+		return messages, err
+	})
+	return res, err
 }
 
 // RevokeSessionsByIdentities marks all currently active sessions inactive for the given identity IDs.
@@ -501,13 +569,19 @@ func (p *Persister) RevokeSessionsByIdentities(ctx context.Context, identityIDs
 		return 0, nil
 	}
 
-	err = p.runInReadCommittedOnCRDB(ctx, func(c *pop.Connection) error {
-		var inner error
-		count, inner = c.RawQuery(
-			"UPDATE sessions SET active = false WHERE identity_id IN (?) AND active = true AND nid = ?",
-			identityIDs, p.NetworkID(ctx),
-		).ExecWithCount()
-		return inner
+	err = p.transactionWithOutbox(ctx, func(ctx context.Context) (messages []*outbox.Message, err error) {
+		messages, err = p.revokedSessionMessages(ctx, "identity_id IN (?) AND nid = ?", identityIDs, p.NetworkID(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return messages, p.runInReadCommittedOnCRDB(ctx, func(c *pop.Connection) error {
+			var inner error
+			count, inner = c.RawQuery(
+				"UPDATE sessions SET active = false WHERE identity_id IN (?) AND active = true AND nid = ?",
+				identityIDs, p.NetworkID(ctx),
+			).ExecWithCount()
+			return inner
+		})
 	})
 	if err != nil {
 		return 0, sqlcon.HandleError(err)
@@ -524,13 +598,19 @@ func (p *Persister) RevokeSessionsByIDs(ctx context.Context, sessionIDs []uuid.U
 		return 0, nil
 	}
 
-	err = p.runInReadCommittedOnCRDB(ctx, func(c *pop.Connection) error {
-		var inner error
-		count, inner = c.RawQuery(
-			"UPDATE sessions SET active = false WHERE id IN (?) AND active = true AND nid = ?",
-			sessionIDs, p.NetworkID(ctx),
-		).ExecWithCount()
-		return inner
+	err = p.transactionWithOutbox(ctx, func(ctx context.Context) (messages []*outbox.Message, err error) {
+		messages, err = p.revokedSessionMessages(ctx, "id IN (?) AND nid = ?", sessionIDs, p.NetworkID(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return messages, p.runInReadCommittedOnCRDB(ctx, func(c *pop.Connection) error {
+			var inner error
+			count, inner = c.RawQuery(
+				"UPDATE sessions SET active = false WHERE id IN (?) AND active = true AND nid = ?",
+				sessionIDs, p.NetworkID(ctx),
+			).ExecWithCount()
+			return inner
+		})
 	})
 	if err != nil {
 		return 0, sqlcon.HandleError(err)
@@ -625,6 +705,38 @@ func (p *Persister) runInReadCommittedOnCRDB(ctx context.Context, fn func(*pop.C
 	)
 }
 
+// revokedSessionMessages returns a session revoked outbox message for every
+// active session matching the predicate, or nothing if the outbox is disabled.
+// The revoke entry points call it right before flipping active=false, inside
+// the same transaction.
+func (p *Persister) revokedSessionMessages(ctx context.Context, predicate string, args ...any) ([]*outbox.Message, error) {
+	if !p.r.Config().OutboxEnabled(ctx) {
+		return nil, nil
+	}
+
+	var revoked []struct {
+		ID         uuid.UUID `db:"id"`
+		IdentityID uuid.UUID `db:"identity_id"`
+	}
+	//#nosec G201 -- predicate is a static persister-internal constant, not user input
+	if err := p.GetConnection(ctx).RawQuery(
+		fmt.Sprintf("SELECT id, identity_id FROM sessions WHERE active = true AND %s", predicate),
+		args...,
+	).All(&revoked); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	messages := make([]*outbox.Message, 0, len(revoked))
+	for _, s := range revoked {
+		m, err := outbox.NewSessionRevokedMessage(s.ID, s.IdentityID)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
 // listWithinReadCommittedReadOnlyTx invokes fn, which must only read, inside a READ
 // COMMITTED read-only transaction on CockroachDB and PostgreSQL, and inside a
 // regular transaction on every other dialect. The paginated session list
@@ -19,12 +19,15 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 
+	audit "github.com/ory/kratos/audit/test"
 	continuity "github.com/ory/kratos/continuity/test"
 	"github.com/ory/kratos/corpx"
 	courier "github.com/ory/kratos/courier/test"
 	"github.com/ory/kratos/driver/config"
 	ri "github.com/ory/kratos/identity"
+	dormancy "github.com/ory/kratos/identity/dormancy/test"
 	identity "github.com/ory/kratos/identity/test"
+	outbox "github.com/ory/kratos/outbox/test"
 	"github.com/ory/kratos/persistence/sql"
 	"github.com/ory/kratos/persistence/sql/batch"
 	sqltesthelpers "github.com/ory/kratos/persistence/sql/testhelpers"
@@ -38,9 +41,11 @@ import (
 	registration "github.com/ory/kratos/selfservice/flow/registration/test"
 	settings "github.com/ory/kratos/selfservice/flow/settings/test"
 	verification "github.com/ory/kratos/selfservice/flow/verification/test"
+	lockout "github.com/ory/kratos/selfservice/lockout/test"
 	sessiontokenexchange "github.com/ory/kratos/selfservice/sessiontokenexchange/test"
 	code "github.com/ory/kratos/selfservice/strategy/code/test"
 	link "github.com/ory/kratos/selfservice/strategy/link/test"
+	saml "github.com/ory/kratos/selfservice/strategy/saml/test"
 	session "github.com/ory/kratos/session/test"
 	"github.com/ory/kratos/x"
 	"github.com/ory/pop/v6"
@@ -288,6 +293,66 @@ func TestPersister(t *testing.T) {
 				_, p := testhelpers.NewNetwork(t, ctx, reg.Persister())
 				sessiontokenexchange.TestPersister(ctx, p)(t)
 			})
+			t.Run("contract=lockout.TestPersister", func(t *testing.T) {
+				t.Parallel()
+				dsn := dsn
+				// Just have a separate DB for sqlite to speed it up.
+				if name == "sqlite" {
+					dsn = dbal.NewSQLiteTestDatabase(t)
+				}
+
+				_, reg := pkg.NewRegistryDefaultWithDSN(t, dsn)
+				_, p := testhelpers.NewNetwork(t, ctx, reg.Persister())
+				lockout.TestPersister(ctx, p)(t)
+			})
+			t.Run("contract=saml.TestPersister", func(t *testing.T) {
+				t.Parallel()
+				dsn := dsn
+				// Just have a separate DB for sqlite to speed it up.
+				if name == "sqlite" {
+					dsn = dbal.NewSQLiteTestDatabase(t)
+				}
+
+				_, reg := pkg.NewRegistryDefaultWithDSN(t, dsn)
+				_, p := testhelpers.NewNetwork(t, ctx, reg.Persister())
+				saml.TestPersister(ctx, p)(t)
+			})
+			t.Run("contract=audit.TestPersister", func(t *testing.T) {
+				t.Parallel()
+				dsn := dsn
+				// Just have a separate DB for sqlite to speed it up.
+				if name == "sqlite" {
+					dsn = dbal.NewSQLiteTestDatabase(t)
+				}
+
+				_, reg := pkg.NewRegistryDefaultWithDSN(t, dsn)
+				_, p := testhelpers.NewNetwork(t, ctx, reg.Persister())
+				audit.TestPersister(ctx, p)(t)
+			})
+			t.Run("contract=outbox.TestPersister", func(t *testing.T) {
+				t.Parallel()
+				dsn := dsn
+				// Just have a separate DB for sqlite to speed it up.
+				if name == "sqlite" {
+					dsn = dbal.NewSQLiteTestDatabase(t)
+				}
+
+				_, reg := pkg.NewRegistryDefaultWithDSN(t, dsn)
+				_, p := testhelpers.NewNetwork(t, ctx, reg.Persister())
+				outbox.TestPersister(ctx, p)(t)
+			})
+			t.Run("contract=dormancy.TestPersister", func(t *testing.T) {
+				t.Parallel()
+				dsn := dsn
+				// Just have a separate DB for sqlite to speed it up.
+				if name == "sqlite" {
+					dsn = dbal.NewSQLiteTestDatabase(t)
+				}
+
+				_, reg := pkg.NewRegistryDefaultWithDSN(t, dsn)
+				_, p := testhelpers.NewNetwork(t, ctx, reg.Persister())
+				dormancy.TestPersister(ctx, p)(t)
+			})
 			t.Run("contract=courier.TestPersister", func(t *testing.T) {
 				t.Parallel()
 				dsn := dsn
@@ -5,10 +5,12 @@ package schema
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/ory/jsonschema/v3"
+	"github.com/ory/x/clock"
 
 	"github.com/ory/kratos/text"
 )
@@ -422,3 +424,33 @@ func NewUnknownAddressError() error {
 	},
 	)
 }
+
+func NewLoginLockedOutError(c clock.Clock, lockedUntil time.Time) error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     fmt.Sprintf(`too many failed login attempts, the account is locked until %s`, lockedUntil.Format(time.RFC3339)),
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationLoginLockedOut(c, lockedUntil)),
+	})
+}
+
+func NewLoginTooManyAttemptsError(c clock.Clock, lockedUntil time.Time) error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     fmt.Sprintf(`too many failed login attempts from this client, try again after %s`, lockedUntil.Format(time.RFC3339)),
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationLoginTooManyAttempts(c, lockedUntil)),
+	})
+}
+
+func NewTooManyMessagesError() error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `too many messages have been sent to this recipient`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationTooManyMessages()),
+	})
+}
@@ -311,7 +311,7 @@ preLoginHook:
 				continue
 			} else if errors.Is(err, flow.ErrCompletedByStrategy) {
 				span := trace.SpanFromContext(r.Context())
-				span.AddEvent(events.NewLoginInitiated(r.Context(), f.ID, ft.String(), f.Refresh, f.OrganizationID, string(f.RequestedAAL)))
+				events.Audit(r.Context(), span).AddEvent(events.NewLoginInitiated(r.Context(), f.ID, ft.String(), f.Refresh, f.OrganizationID, string(f.RequestedAAL)))
 				return nil, nil, err
 			} else if err != nil {
 				return nil, nil, err
@@ -321,7 +321,7 @@ preLoginHook:
 	}
 
 	span := trace.SpanFromContext(r.Context())
-	span.AddEvent(events.NewLoginInitiated(r.Context(), f.ID, ft.String(), f.Refresh, f.OrganizationID, string(f.RequestedAAL)))
+	events.Audit(r.Context(), span).AddEvent(events.NewLoginInitiated(r.Context(), f.ID, ft.String(), f.Refresh, f.OrganizationID, string(f.RequestedAAL)))
 	return f, nil, nil
 }
 
@@ -41,9 +41,16 @@ type (
 		ExecuteLoginPostHook(w http.ResponseWriter, r *http.Request, g node.UiNodeGroup, a *Flow, s *session.Session) error
 	}
 
+	// PostHookPostPersistExecutor runs after the session was persisted. The
+	// login already succeeded at that point, so its errors are only logged.
+	PostHookPostPersistExecutor interface {
+		ExecuteLoginPostPersistHook(w http.ResponseWriter, r *http.Request, g node.UiNodeGroup, a *Flow, s *session.Session) error
+	}
+
 	HooksProvider interface {
 		PreLoginHooks(ctx context.Context) ([]PreHookExecutor, error)
 		PostLoginHooks(ctx context.Context, credentialsType identity.CredentialsType) ([]PostHookExecutor, error)
+		PostLoginPostPersistHooks(ctx context.Context, credentialsType identity.CredentialsType) ([]PostHookPostPersistExecutor, error)
 	}
 )
 
@@ -125,6 +132,46 @@ func (e *HookExecutor) handleLoginError(_ http.ResponseWriter, r *http.Request,
 	return flowError
 }
 
+// recordAuthentication updates the identity's last_authenticated_at and the
+// used credential's last_used_at. The login already succeeded at this point,
+// so errors are only logged.
+func (e *HookExecutor) recordAuthentication(ctx context.Context, i *identity.Identity, f *Flow) {
+	if err := e.d.PrivilegedIdentityPool().UpdateLastAuthenticatedAt(ctx, i.ID, f.Active, time.Now().UTC()); err != nil {
+		e.d.Logger().WithError(err).WithField("identity_id", i.ID).Warn("Unable to record the time of the last authentication.")
+	}
+}
+
+// runPostPersistHooks runs the hooks which need the persisted session. The
+// login already succeeded at this point, so errors are only logged.
+func (e *HookExecutor) runPostPersistHooks(w http.ResponseWriter, r *http.Request, g node.UiNodeGroup, f *Flow, s *session.Session) {
+	hooks, err := e.d.PostLoginPostPersistHooks(r.Context(), f.Active)
+	if err != nil {
+		e.d.Logger().WithRequest(r).WithError(err).Error("Unable to load the ExecuteLoginPostPersistHook hooks.")
+		return
+	}
+	for k, executor := range hooks {
+		if err := executor.ExecuteLoginPostPersistHook(w, r, g, f, s); err != nil {
+			e.d.Logger().
+				WithRequest(r).
+				WithError(err).
+				WithField("executor", fmt.Sprintf("%T", executor)).
+				WithField("executor_position", k).
+				WithField("identity_id", s.IdentityID).
+				WithField("flow_method", f.Active).
+				Error("ExecuteLoginPostPersistHook hook failed with an error.")
+			continue
+		}
+
+		e.d.Logger().
+			WithRequest(r).
+			WithField("executor", fmt.Sprintf("%T", executor)).
+			WithField("executor_position", k).
+			WithField("identity_id", s.IdentityID).
+			WithField("flow_method", f.Active).
+			Debug("ExecuteLoginPostPersistHook completed successfully.")
+	}
+}
+
 func (e *HookExecutor) PostLoginHook(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -225,8 +272,10 @@ func (e *HookExecutor) PostLoginHook(
 			WithField("session_id", s.ID).
 			WithField("identity_id", i.ID).
 			Info("Identity authenticated successfully and was issued an Ory Kratos Session Token.")
+		e.recordAuthentication(ctx, i, f)
+		e.runPostPersistHooks(w, r, g, f, s)
 
-		span.AddEvent(events.NewLoginSucceeded(ctx, &events.LoginSucceededOpts{
+		events.Audit(ctx, span).AddEvent(events.NewLoginSucceeded(ctx, &events.LoginSucceededOpts{
 			SessionID:    s.ID,
 			IdentityID:   i.ID,
 			FlowID:       f.ID,
@@ -268,8 +317,10 @@ func (e *HookExecutor) PostLoginHook(
 		WithField("identity_id", i.ID).
 		WithField("session_id", s.ID).
 		Info("Identity authenticated successfully and was issued an Ory Kratos Session Cookie.")
+	e.recordAuthentication(ctx, i, f)
+	e.runPostPersistHooks(w, r, g, f, s)
 
-	span.AddEvent(events.NewLoginSucceeded(ctx, &events.LoginSucceededOpts{
+	events.Audit(ctx, span).AddEvent(events.NewLoginSucceeded(ctx, &events.LoginSucceededOpts{
 		SessionID:  s.ID,
 		FlowID:     f.ID,
 		IdentityID: i.ID, FlowType: string(f.Type), RequestedAAL: string(f.RequestedAAL), IsRefresh: f.Refresh, Method: f.Active.String(),
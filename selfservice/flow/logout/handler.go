@@ -12,6 +12,7 @@ import (
 	"github.com/ory/kratos/x/redir"
 	"github.com/ory/x/httprouterx"
 	"github.com/ory/x/httpx"
+	"github.com/ory/x/logrusx"
 
 	"go.opentelemetry.io/otel/trace"
 
@@ -44,6 +45,8 @@ type (
 		session.PersistenceProvider
 		errorx.ManagementProvider
 		config.Provider
+		logrusx.Provider
+		StrategyProvider
 	}
 	HandlerProvider interface {
 		LogoutHandler() *Handler
@@ -249,7 +252,7 @@ func (h *Handler) performNativeLogout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	trace.SpanFromContext(r.Context()).AddEvent(events.NewSessionRevoked(r.Context(), revoked.ID, revoked.IdentityID))
+	events.Audit(r.Context(), trace.SpanFromContext(r.Context())).AddEvent(events.NewSessionRevoked(r.Context(), revoked.ID, revoked.IdentityID))
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -292,6 +295,9 @@ type updateLogoutFlow struct {
 //
 // If the `Accept` HTTP header is not set to `application/json`, the browser will be redirected (HTTP 303 See Other)
 // to the `return_to` parameter of the initial request or fall back to `urls.default_return_to`.
+// If the session was authenticated by an OpenID Connect provider with RP-initiated logout enabled,
+// the browser is redirected to the end session endpoint of that provider instead, which then
+// redirects to the `return_to` URL.
 //
 // If the `Accept` HTTP header is set to `application/json`, a 204 No Content response
 // will be sent on successful logout instead.
@@ -340,12 +346,12 @@ func (h *Handler) updateLogoutFlow(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	trace.SpanFromContext(r.Context()).AddEvent(events.NewSessionRevoked(r.Context(), sess.ID, sess.IdentityID))
+	events.Audit(r.Context(), trace.SpanFromContext(r.Context())).AddEvent(events.NewSessionRevoked(r.Context(), sess.ID, sess.IdentityID))
 
-	h.completeLogout(w, r)
+	h.completeLogout(w, r, sess)
 }
 
-func (h *Handler) completeLogout(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) completeLogout(w http.ResponseWriter, r *http.Request, sess *session.Session) {
 	_ = h.d.CSRFHandler().RegenerateToken(w, r)
 
 	ret, err := redir.SecureRedirectTo(r, h.d.Config().SelfServiceFlowLogoutRedirectURL(r.Context()),
@@ -371,5 +377,23 @@ func (h *Handler) completeLogout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Redirect(w, r, ret.String(), http.StatusSeeOther)
+	http.Redirect(w, r, h.upstreamLogoutURL(r, sess, ret).String(), http.StatusSeeOther)
+}
+
+// upstreamLogoutURL returns the URL which also ends the session at the
+// upstream identity provider, if the session was authenticated by a provider
+// which supports it. The local session is revoked already, so failing to end
+// the upstream session does not fail the logout.
+func (h *Handler) upstreamLogoutURL(r *http.Request, sess *session.Session, returnTo *url.URL) *url.URL {
+	for _, s := range h.d.LogoutStrategies(r.Context()) {
+		u, err := s.UpstreamLogoutURL(r.Context(), sess, returnTo)
+		if err != nil {
+			h.d.Logger().WithRequest(r).WithError(err).WithField("strategy", s.ID()).Warn("Unable to end the session at the upstream identity provider.")
+			continue
+		}
+		if u != nil {
+			return u
+		}
+	}
+	return returnTo
 }
@@ -0,0 +1,29 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package logout
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+)
+
+// Strategy is implemented by self-service strategies which can end the session
+// at the upstream identity provider which authenticated it.
+type Strategy interface {
+	ID() identity.CredentialsType
+
+	// UpstreamLogoutURL returns the URL which ends the session at the upstream
+	// identity provider and then redirects to returnTo. It returns nil if the
+	// session was not authenticated by such a provider.
+	UpstreamLogoutURL(ctx context.Context, sess *session.Session, returnTo *url.URL) (*url.URL, error)
+}
+
+type Strategies []Strategy
+
+type StrategyProvider interface {
+	LogoutStrategies(ctx context.Context) Strategies
+}
@@ -271,6 +271,16 @@ func (e *HookExecutor) PostSettingsHook(ctx context.Context, w http.ResponseWrit
 		WithField("identity_id", i.ID).
 		Debug("An identity's settings have been updated.")
 
+	for _, n := range ctxUpdate.securityNotifications {
+		if err := e.d.IdentityManager().SendSecurityNotification(ctx, i, n); err != nil {
+			// The settings were updated successfully — never fail the flow on courier errors.
+			e.d.Logger().WithRequest(r).WithError(err).
+				WithField("identity_id", i.ID).
+				WithField("template_type", n.TemplateType).
+				Warn("Failed to queue one or more security notifications.")
+		}
+	}
+
 	ctxUpdate.UpdateIdentity(i)
 	ctxUpdate.Flow.State = flow.StateSuccess
 	if hookOptions.cb != nil {
@@ -333,7 +343,7 @@ func (e *HookExecutor) PostSettingsHook(ctx context.Context, w http.ResponseWrit
 		WithField("flow_method", settingsType).
 		Debug("Completed all PostSettingsPrePersistHooks and PostSettingsPostPersistHooks.")
 
-	trace.SpanFromContext(ctx).AddEvent(events.NewSettingsSucceeded(
+	events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSettingsSucceeded(
 		ctx, ctxUpdate.Flow.ID, i.ID, string(ctxUpdate.Flow.Type), settingsType))
 
 	if ctxUpdate.Flow.Type == flow.TypeAPI {
@@ -43,6 +43,10 @@ type UpdateContext struct {
 	// those credentials themselves (e.g. through a locked credential-config
 	// update) set this via ExcludeCredentialTypesFromUpdate.
 	excludeCredentialTypesFromUpdate []identity.CredentialsType
+
+	// securityNotifications are sent to the identity once the update was
+	// persisted.
+	securityNotifications []identity.SecurityNotification
 }
 
 func (c *UpdateContext) UpdateIdentity(i *identity.Identity) {
@@ -56,6 +60,12 @@ func (c *UpdateContext) ExcludeCredentialTypesFromUpdate(cts ...identity.Credent
 	c.excludeCredentialTypesFromUpdate = append(c.excludeCredentialTypesFromUpdate, cts...)
 }
 
+// AddSecurityNotification queues the security notification to the identity
+// once the update was persisted.
+func (c *UpdateContext) AddSecurityNotification(n identity.SecurityNotification) {
+	c.securityNotifications = append(c.securityNotifications, n)
+}
+
 func (c *UpdateContext) GetIdentityToUpdate() (*identity.Identity, error) {
 	if c.toUpdate == nil {
 		return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Could not find a identity to update."))
@@ -12,4 +12,5 @@ const (
 	KeyVerifier                = "verification"
 	KeyVerifyNewAddress        = "verify_new_address"
 	KeyNotifyPreviousAddresses = "notify_previous_addresses"
+	KeyLoginNewDevice          = "login_new_device"
 )
@@ -0,0 +1,132 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/pointerx"
+)
+
+const (
+	// knownDevicesLimit caps how many of the identity's most recent devices
+	// the new device is compared against.
+	knownDevicesLimit = 100
+
+	// notMeLinkLifespan is how long the "this wasn't me" link of the
+	// notification can be used.
+	notMeLinkLifespan = 7 * 24 * time.Hour
+)
+
+type (
+	LoginNewDeviceConfig struct {
+		Recipients string `json:"recipients"`
+	}
+
+	loginNewDeviceDependencies interface {
+		identity.ManagementProvider
+		session.PersistenceProvider
+		config.Provider
+		logrusx.Provider
+		otelx.Provider
+	}
+
+	// LoginNewDevice notifies the identity if a session is issued to a
+	// device, IP range, or location which the identity did not use before.
+	LoginNewDevice struct {
+		r loginNewDeviceDependencies
+		c *LoginNewDeviceConfig
+	}
+)
+
+var _ login.PostHookPostPersistExecutor = new(LoginNewDevice)
+
+func NewLoginNewDevice(r loginNewDeviceDependencies, c *LoginNewDeviceConfig) *LoginNewDevice {
+	if c == nil {
+		c = &LoginNewDeviceConfig{}
+	}
+	if c.Recipients == "" {
+		c.Recipients = RecipientsAllVerified
+	}
+	return &LoginNewDevice{r: r, c: c}
+}
+
+func (e *LoginNewDevice) ExecuteLoginPostPersistHook(_ http.ResponseWriter, r *http.Request, _ node.UiNodeGroup, _ *login.Flow, s *session.Session) error {
+	return otelx.WithSpan(r.Context(), "selfservice.hook.LoginNewDevice.ExecuteLoginPostPersistHook", func(ctx context.Context) error {
+		if s.Identity == nil || len(s.Devices) == 0 {
+			return nil
+		}
+		device := s.Devices[len(s.Devices)-1]
+
+		known, err := e.r.SessionPersister().ListDevicesByIdentity(ctx, s.Identity.ID, knownDevicesLimit)
+		if err != nil {
+			e.r.Logger().WithError(err).
+				WithField("identity_id", s.Identity.ID).
+				Warn("Unable to load the known devices of the identity, skipping the new device notification.")
+			return nil
+		}
+		// The device was persisted together with the session already.
+		known = slices.DeleteFunc(known, func(d session.Device) bool { return d.ID == device.ID })
+		// Every device is new to identities which never signed in before, for
+		// example because they were imported.
+		if len(known) == 0 {
+			return nil
+		}
+
+		unrecognized := device.Unrecognized(known)
+		if len(unrecognized) == 0 {
+			return nil
+		}
+
+		events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewLoginFromNewDevice(ctx, s.ID, s.Identity.ID, unrecognized))
+
+		targets := loginNewDeviceTargets(e.c.Recipients, s.Identity)
+		if len(targets) == 0 {
+			return nil
+		}
+
+		if err := e.r.IdentityManager().SendLoginNewDeviceNotifications(ctx, targets, s.Identity, identity.NewDeviceLogin{
+			IPAddress: pointerx.Deref(device.IPAddress),
+			UserAgent: pointerx.Deref(device.UserAgent),
+			Location:  device.DisplayLocation(),
+			NotMeURL:  session.NewRevocationURL(ctx, e.r.Config(), s.ID, time.Now().Add(notMeLinkLifespan)).String(),
+		}); err != nil {
+			e.r.Logger().WithError(err).
+				WithField("count", len(targets)).
+				Warn("Failed to queue one or more new device notifications.")
+			// The identity signed in successfully — never fail the login on courier errors.
+		}
+		return nil
+	})
+}
+
+// loginNewDeviceTargets returns the email and SMS addresses of the identity
+// which receive the notification. Unverified addresses are only included if
+// the recipients mode is "all".
+func loginNewDeviceTargets(mode string, i *identity.Identity) []identity.AddressRef {
+	out := make([]identity.AddressRef, 0, len(i.VerifiableAddresses))
+	for _, a := range i.VerifiableAddresses {
+		if a.Via != identity.AddressTypeEmail && a.Via != identity.AddressTypeSMS {
+			continue
+		}
+		if mode != RecipientsAll && !a.Verified {
+			continue
+		}
+		out = append(out, identity.AddressRef{Value: a.Value, Via: a.Via})
+	}
+	return out
+}
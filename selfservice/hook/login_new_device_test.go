@@ -0,0 +1,133 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+)
+
+func TestLoginNewDevice(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chromeWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+		firefoxLinux  = "Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0"
+	)
+
+	ctx := context.Background()
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/verify_single_email.schema.json")
+	conf.MustSet(ctx, config.ViperKeyCourierSMTPURL, "smtp://foo@bar@dev.null/")
+
+	newIdentity := func(t *testing.T, addresses ...identity.VerifiableAddress) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{}`)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		i.VerifiableAddresses = addresses
+		return i
+	}
+
+	signIn := func(t *testing.T, i *identity.Identity, device session.Device) *session.Session {
+		s, err := testhelpers.NewActiveSession(httptest.NewRequest(http.MethodPost, "/", nil), reg, i, time.Now().UTC(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		s.Devices = []session.Device{device}
+		return s
+	}
+
+	// execute persists the session like the login hook executor does before
+	// it runs the post persist hooks.
+	execute := func(t *testing.T, c *hook.LoginNewDeviceConfig, s *session.Session) {
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+		r := httptest.NewRequest(http.MethodPost, "/", nil).WithContext(ctx)
+		require.NoError(t, hook.NewLoginNewDevice(reg, c).ExecuteLoginPostPersistHook(httptest.NewRecorder(), r, node.PasswordGroup, &login.Flow{}, s))
+	}
+
+	known := session.Device{IPAddress: new("54.155.246.232"), UserAgent: new(chromeWindows), Location: new("Munich, DE")}
+	verified := identity.VerifiableAddress{Value: "verified@example.com", Via: identity.AddressTypeEmail, Verified: true, Status: identity.VerifiableAddressStatusCompleted}
+	unverified := identity.VerifiableAddress{Value: "unverified@example.com", Via: identity.AddressTypeEmail, Status: identity.VerifiableAddressStatusPending}
+
+	t.Run("case=does not notify on the first sign in", func(t *testing.T) {
+		s := signIn(t, newIdentity(t, verified), known)
+		execute(t, nil, s)
+
+		_, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.ErrorIs(t, err, courier.ErrQueueEmpty)
+	})
+
+	t.Run("case=does not notify for known devices", func(t *testing.T) {
+		i := newIdentity(t, verified)
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, signIn(t, i, known)))
+
+		s := signIn(t, i, session.Device{IPAddress: new("54.155.246.7"), UserAgent: new(chromeWindows), Location: new("Munich, DE")})
+		execute(t, nil, s)
+
+		_, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.ErrorIs(t, err, courier.ErrQueueEmpty)
+	})
+
+	for _, tc := range []struct {
+		name           string
+		config         *hook.LoginNewDeviceConfig
+		wantRecipients []string
+	}{
+		{
+			name:           "notifies verified addresses by default",
+			wantRecipients: []string{"verified@example.com"},
+		},
+		{
+			name:           "notifies all addresses",
+			config:         &hook.LoginNewDeviceConfig{Recipients: hook.RecipientsAll},
+			wantRecipients: []string{"verified@example.com", "unverified@example.com"},
+		},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			i := newIdentity(t, verified, unverified)
+			require.NoError(t, reg.SessionPersister().UpsertSession(ctx, signIn(t, i, known)))
+
+			s := signIn(t, i, session.Device{IPAddress: new("203.0.113.9"), UserAgent: new(firefoxLinux), Location: new("Paris, FR")})
+			execute(t, tc.config, s)
+
+			messages, err := reg.CourierPersister().NextMessages(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, messages, len(tc.wantRecipients))
+
+			notMeURL := session.NewRevocationURL(ctx, conf, s.ID, time.Now())
+			gotRecipients := make([]string, len(messages))
+			for k, m := range messages {
+				gotRecipients[k] = m.Recipient
+				assert.Equal(t, courier.MessageTypeEmail, m.Type)
+				assert.Equal(t, template.TypeLoginNewDevice, m.TemplateType)
+				assert.Contains(t, m.Body, "Paris, FR")
+				assert.Contains(t, m.Body, notMeURL.Path+"?token=")
+			}
+			assert.ElementsMatch(t, tc.wantRecipients, gotRecipients)
+
+			// The notification links to the persisted session.
+			token := regexp.MustCompile(`\?token=([\w-]+)`).FindStringSubmatch(messages[0].Body)
+			require.Len(t, token, 2, "%s", messages[0].Body)
+			sessionID, err := session.ParseRevocationToken(conf.SecretsSession(ctx), token[1])
+			require.NoError(t, err)
+			assert.Equal(t, s.ID, sessionID)
+		})
+	}
+}
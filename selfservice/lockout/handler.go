@@ -0,0 +1,107 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x/nosurfx"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/sqlxx"
+)
+
+type (
+	handlerDependencies interface {
+		ManagementProvider
+		identity.PoolProvider
+		httpx.WriterProvider
+		nosurfx.CSRFProvider
+		config.Provider
+	}
+	HandlerProvider interface {
+		LockoutHandler() *Handler
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+)
+
+const (
+	AdminRouteIdentity        = "/identities"
+	AdminRouteIdentityLockout = AdminRouteIdentity + "/{id}/lockout"
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.DELETE(AdminRouteIdentityLockout, h.deleteIdentityLockout)
+}
+
+func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
+	h.r.CSRFHandler().IgnoreGlob(AdminRouteIdentity + "/*/lockout")
+	public.DELETE(AdminRouteIdentityLockout, redir.RedirectToAdminRoute(h.r))
+}
+
+// Delete Identity Lockout Parameters
+//
+// swagger:parameters deleteIdentityLockout
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type deleteIdentityLockout struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /admin/identities/{id}/lockout identity deleteIdentityLockout
+//
+// # Unlock an Identity
+//
+// Calling this endpoint resets all failed sign in attempt counters of the given identity and lifts an active
+// brute-force protection lockout. Counters kept for client IP addresses are not affected.
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) deleteIdentityLockout(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.FromString(r.PathValue("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest().WithError(err.Error()).WithDebug("could not parse UUID"))
+		return
+	}
+
+	if _, err := h.r.IdentityPool().GetIdentity(r.Context(), id, sqlxx.Expandables{}); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.LockoutManager().Unlock(r.Context(), id); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
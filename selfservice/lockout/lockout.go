@@ -0,0 +1,101 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/sqlxx"
+)
+
+// SubjectKind is the kind of subject failed login attempts are counted for.
+type SubjectKind string
+
+const (
+	// SubjectKindIdentifier counts failed attempts per credentials identifier
+	// (e.g. an email address or username).
+	SubjectKindIdentifier SubjectKind = "identifier"
+
+	// SubjectKindIP counts failed attempts per client IP address.
+	SubjectKindIP SubjectKind = "ip"
+)
+
+// Attempt tracks the failed login attempts of a single subject.
+//
+// The subject itself is never stored in plain text. Persisters store a keyed
+// hash of it instead.
+//
+// swagger:ignore
+type Attempt struct {
+	ID  uuid.UUID `json:"id" db:"id"`
+	NID uuid.UUID `json:"-" db:"nid"`
+
+	// Kind is the kind of subject this attempt counter belongs to.
+	Kind SubjectKind `json:"kind" db:"kind"`
+
+	// Subject is the hashed identifier or IP address.
+	Subject string `json:"-" db:"subject"`
+
+	// IdentityID is set when the subject could be resolved to an identity.
+	IdentityID uuid.NullUUID `json:"identity_id" db:"identity_id"`
+
+	// FailedAttempts is the number of failed attempts since the last lockout
+	// or since the attempt window was last exceeded.
+	FailedAttempts int `json:"failed_attempts" db:"failed_attempts"`
+
+	// LockoutCount is the number of lockouts within the attempt window. It
+	// drives the exponential backoff of the lockout duration.
+	LockoutCount int `json:"lockout_count" db:"lockout_count"`
+
+	// LockedUntil is set while the subject is locked.
+	LockedUntil sqlxx.NullTime `json:"locked_until" db:"locked_until"`
+
+	// LastFailedAt is the time of the last failed attempt.
+	LastFailedAt time.Time `json:"last_failed_at" db:"last_failed_at"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (Attempt) TableName() string { return "selfservice_login_attempts" }
+
+// IsLocked returns true if the subject is locked at the given time.
+func (a *Attempt) IsLocked(now time.Time) bool {
+	return a != nil && time.Time(a.LockedUntil).After(now)
+}
+
+type (
+	Persister interface {
+		// GetLoginAttempt returns the attempt counter for the given subject or
+		// sqlcon.ErrNoRows if none exists.
+		GetLoginAttempt(ctx context.Context, kind SubjectKind, subject string) (*Attempt, error)
+
+		// IncrementFailedLoginAttempts atomically increments the failed attempt
+		// counter of the given subject, creating it if necessary. Counters whose
+		// last failure happened before windowStart are reset first.
+		IncrementFailedLoginAttempts(ctx context.Context, kind SubjectKind, subject string, identityID uuid.NullUUID, windowStart time.Time) (*Attempt, error)
+
+		// LockLoginAttempt locks the attempt until the given time, increments its
+		// lockout count, and resets its failed attempt counter.
+		LockLoginAttempt(ctx context.Context, id uuid.UUID, until time.Time) error
+
+		// DeleteLoginAttempt removes the attempt counter of the given subject.
+		DeleteLoginAttempt(ctx context.Context, kind SubjectKind, subject string) error
+
+		// DeleteLoginAttemptsForIdentity removes all attempt counters linked to
+		// the given identity, which unlocks it.
+		DeleteLoginAttemptsForIdentity(ctx context.Context, identityID uuid.UUID) error
+
+		// DeleteExpiredLoginAttempts removes counters that are neither locked nor
+		// had a failure since the given time.
+		DeleteExpiredLoginAttempts(ctx context.Context, before time.Time, limit int) error
+	}
+
+	PersistenceProvider interface {
+		LockoutPersister() Persister
+	}
+)
@@ -0,0 +1,184 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/clock"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+)
+
+type (
+	managerDependencies interface {
+		config.Provider
+		clock.Provider
+		otelx.Provider
+		PersistenceProvider
+	}
+	ManagementProvider interface {
+		LockoutManager() *Manager
+	}
+
+	// Manager implements brute-force protection for login strategies.
+	//
+	// Strategies call Check before verifying credentials, RecordFailure when
+	// the credentials were wrong, and RecordSuccess once they were correct.
+	Manager struct {
+		r managerDependencies
+	}
+)
+
+func NewManager(r managerDependencies) *Manager {
+	return &Manager{r: r}
+}
+
+// NormalizeIdentifier returns the identifier as it is used for counting
+// failed attempts, so that "Foo@example.com" and "foo@example.com " share a
+// counter.
+func NormalizeIdentifier(identifier string) string {
+	return strings.ToLower(strings.TrimSpace(identifier))
+}
+
+// Duration returns how long a subject is locked for after it was already
+// locked lockoutCount times within the attempt window. The duration doubles
+// with every lockout and is capped at the configured maximum.
+func Duration(c *config.BruteForceProtection, lockoutCount int) time.Duration {
+	d := c.LockoutDuration
+	for range lockoutCount {
+		if d >= c.MaxLockoutDuration/2 {
+			return c.MaxLockoutDuration
+		}
+		d *= 2
+	}
+	return min(d, c.MaxLockoutDuration)
+}
+
+// Check returns an error if either the identifier or the client IP address
+// of the request is currently locked.
+func (m *Manager) Check(ctx context.Context, r *http.Request, identifier string) (err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "selfservice.lockout.Manager.Check")
+	defer otelx.End(span, &err)
+
+	c := m.r.Config().SecurityBruteForceProtection(ctx)
+	if !c.Enabled {
+		return nil
+	}
+
+	now := m.r.Clock().Now()
+	if c.MaxFailedAttemptsPerIP > 0 {
+		a, err := m.getAttempt(ctx, SubjectKindIP, httpx.ClientIP(r))
+		if err != nil {
+			return err
+		}
+		if a.IsLocked(now) {
+			return errors.WithStack(schema.NewLoginTooManyAttemptsError(m.r.Clock(), time.Time(a.LockedUntil)))
+		}
+	}
+
+	if c.MaxFailedAttemptsPerIdentifier > 0 && identifier != "" {
+		a, err := m.getAttempt(ctx, SubjectKindIdentifier, NormalizeIdentifier(identifier))
+		if err != nil {
+			return err
+		}
+		if a.IsLocked(now) {
+			return errors.WithStack(schema.NewLoginLockedOutError(m.r.Clock(), time.Time(a.LockedUntil)))
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) getAttempt(ctx context.Context, kind SubjectKind, subject string) (*Attempt, error) {
+	a, err := m.r.LockoutPersister().GetLoginAttempt(ctx, kind, subject)
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// RecordFailure counts a failed login attempt for the identifier and the
+// client IP address of the request and locks them once the configured
+// thresholds are reached. The identity ID is optional and only used to allow
+// administrators to unlock the identity later on.
+func (m *Manager) RecordFailure(ctx context.Context, r *http.Request, identifier string, identityID uuid.NullUUID) (err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "selfservice.lockout.Manager.RecordFailure")
+	defer otelx.End(span, &err)
+
+	c := m.r.Config().SecurityBruteForceProtection(ctx)
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.MaxFailedAttemptsPerIP > 0 {
+		if err := m.recordFailure(ctx, c, SubjectKindIP, httpx.ClientIP(r), uuid.NullUUID{}, c.MaxFailedAttemptsPerIP); err != nil {
+			return err
+		}
+	}
+
+	if c.MaxFailedAttemptsPerIdentifier > 0 && identifier != "" {
+		if err := m.recordFailure(ctx, c, SubjectKindIdentifier, NormalizeIdentifier(identifier), identityID, c.MaxFailedAttemptsPerIdentifier); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) recordFailure(ctx context.Context, c *config.BruteForceProtection, kind SubjectKind, subject string, identityID uuid.NullUUID, threshold int) error {
+	now := m.r.Clock().Now().UTC()
+	a, err := m.r.LockoutPersister().IncrementFailedLoginAttempts(ctx, kind, subject, identityID, now.Add(-c.AttemptWindow))
+	if err != nil {
+		return err
+	}
+
+	if a.FailedAttempts < threshold {
+		return nil
+	}
+
+	until := now.Add(Duration(c, a.LockoutCount))
+	if err := m.r.LockoutPersister().LockLoginAttempt(ctx, a.ID, until); err != nil {
+		return err
+	}
+
+	events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewLoginLockedOut(ctx, a.IdentityID, string(kind), until))
+	return nil
+}
+
+// RecordSuccess resets the failed attempt counter of the identifier. The
+// counter of the client IP address is kept, as a successful login for one
+// account does not vouch for the other attempts made from that address.
+func (m *Manager) RecordSuccess(ctx context.Context, identifier string) (err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "selfservice.lockout.Manager.RecordSuccess")
+	defer otelx.End(span, &err)
+
+	c := m.r.Config().SecurityBruteForceProtection(ctx)
+	if !c.Enabled || c.MaxFailedAttemptsPerIdentifier == 0 || identifier == "" {
+		return nil
+	}
+
+	return m.r.LockoutPersister().DeleteLoginAttempt(ctx, SubjectKindIdentifier, NormalizeIdentifier(identifier))
+}
+
+// Unlock removes all failed attempt counters and lockouts of the identity.
+func (m *Manager) Unlock(ctx context.Context, identityID uuid.UUID) (err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "selfservice.lockout.Manager.Unlock")
+	defer otelx.End(span, &err)
+
+	return m.r.LockoutPersister().DeleteLoginAttemptsForIdentity(ctx, identityID)
+}
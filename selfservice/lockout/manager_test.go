@@ -0,0 +1,109 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/lockout"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/configx"
+)
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	c := &config.BruteForceProtection{
+		LockoutDuration:    time.Minute,
+		MaxLockoutDuration: 10 * time.Minute,
+	}
+
+	for count, expected := range []time.Duration{
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		8 * time.Minute,
+		10 * time.Minute,
+		10 * time.Minute,
+	} {
+		assert.Equalf(t, expected, lockout.Duration(c, count), "lockout count %d", count)
+	}
+
+	assert.Equal(t, 10*time.Minute, lockout.Duration(c, 1<<20), "must not overflow")
+}
+
+func TestManager(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+		config.ViperKeySecurityBruteForceProtectionEnabled:            true,
+		config.ViperKeySecurityBruteForceProtectionIdentifierAttempts: 3,
+		config.ViperKeySecurityBruteForceProtectionIPAttempts:         5,
+	}))
+	m := reg.LockoutManager()
+
+	t.Run("case=locks identifier after too many failures", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/self-service/login", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+
+		for range 3 {
+			require.NoError(t, m.Check(ctx, r, "Foo@ory.sh"))
+			require.NoError(t, m.RecordFailure(ctx, r, "Foo@ory.sh", uuid.NullUUID{}))
+		}
+
+		err := m.Check(ctx, r, " foo@ory.sh")
+		var ve *schema.ValidationError
+		require.ErrorAs(t, err, &ve)
+		assert.EqualValues(t, text.ErrorValidationLoginLockedOut, ve.Messages[0].ID)
+
+		require.NoError(t, m.Check(ctx, r, "bar@ory.sh"), "other identifiers are not affected")
+	})
+
+	t.Run("case=success resets identifier counter", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/self-service/login", nil)
+		r.RemoteAddr = "192.0.2.2:1234"
+
+		for range 2 {
+			require.NoError(t, m.RecordFailure(ctx, r, "baz@ory.sh", uuid.NullUUID{}))
+		}
+		require.NoError(t, m.RecordSuccess(ctx, "baz@ory.sh"))
+		require.NoError(t, m.RecordFailure(ctx, r, "baz@ory.sh", uuid.NullUUID{}))
+		require.NoError(t, m.Check(ctx, r, "baz@ory.sh"))
+	})
+
+	t.Run("case=locks ip address after too many failures", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/self-service/login", nil)
+		r.RemoteAddr = "192.0.2.3:1234"
+
+		for i := range 5 {
+			require.NoError(t, m.RecordFailure(ctx, r, uuid.Must(uuid.NewV4()).String()+"@ory.sh", uuid.NullUUID{}), "%d", i)
+		}
+
+		err := m.Check(ctx, r, "someone-else@ory.sh")
+		var ve *schema.ValidationError
+		require.ErrorAs(t, err, &ve)
+		assert.EqualValues(t, text.ErrorValidationLoginTooManyAttempts, ve.Messages[0].ID)
+	})
+
+	t.Run("case=disabled", func(t *testing.T) {
+		_, reg := pkg.NewFastRegistryWithMocks(t)
+		r := httptest.NewRequest("POST", "/self-service/login", nil)
+
+		for range 10 {
+			require.NoError(t, reg.LockoutManager().RecordFailure(ctx, r, "foo@ory.sh", uuid.NullUUID{}))
+		}
+		require.NoError(t, reg.LockoutManager().Check(ctx, r, "foo@ory.sh"))
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/lockout"
+	"github.com/ory/x/contextx"
+	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlcon"
+)
+
+func TestPersister(ctx context.Context, p interface {
+	persistence.Persister
+},
+) func(t *testing.T) {
+	return func(t *testing.T) {
+		nid, p := testhelpers.NewNetworkUnlessExisting(t, ctx, p)
+
+		ctx := contextx.WithConfigValue(ctx, config.ViperKeySecretsDefault, []string{"secret-a", "secret-b"})
+
+		newIdentity := func(t *testing.T) *identity.Identity {
+			var i identity.Identity
+			require.NoError(t, faker.FakeData(&i))
+			require.NoError(t, p.CreateIdentity(ctx, &i))
+			return &i
+		}
+		newSubject := func() string {
+			return randx.MustString(16, randx.AlphaLowerNum) + "@ory.sh"
+		}
+		windowStart := func() time.Time {
+			return time.Now().Add(-time.Hour)
+		}
+
+		t.Run("case=returns not found for unknown subject", func(t *testing.T) {
+			_, err := p.GetLoginAttempt(ctx, lockout.SubjectKindIdentifier, newSubject())
+			require.ErrorIs(t, err, sqlcon.ErrNoRows())
+		})
+
+		t.Run("case=increments failed attempts", func(t *testing.T) {
+			subject := newSubject()
+			i := newIdentity(t)
+
+			a, err := p.IncrementFailedLoginAttempts(ctx, lockout.SubjectKindIdentifier, subject, uuid.NullUUID{}, windowStart())
+			require.NoError(t, err)
+			assert.Equal(t, 1, a.FailedAttempts)
+			assert.Equal(t, nid, a.NID)
+			assert.NotEqual(t, subject, a.Subject, "the subject must not be stored in plain text")
+			assert.False(t, a.IdentityID.Valid)
+
+			a, err = p.IncrementFailedLoginAttempts(ctx, lockout.SubjectKindIdentifier, subject, uuid.NullUUID{UUID: i.ID, Valid: true}, windowStart())
+			require.NoError(t, err)
+			assert.Equal(t, 2, a.FailedAttempts)
+			assert.Equal(t, i.ID, a.IdentityID.UUID)
+
+			actual, err := p.GetLoginAttempt(ctx, lockout.SubjectKindIdentifier, subject)
+			require.NoError(t, err)
+			assert.Equal(t, a.ID, actual.ID)
+			assert.Equal(t, 2, actual.FailedAttempts)
+
+			_, err = p.GetLoginAttempt(ctx, lockout.SubjectKindIP, subject)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows(), "counters are scoped to the subject kind")
+		})
+
+		t.Run("case=resets counter outside of attempt window", func(t *testing.T) {
+			subject := newSubject()
+
+			_, err := p.IncrementFailedLoginAttempts(ctx, lockout.SubjectKindIP, subject, uuid.NullUUID{}, windowStart())
+			require.NoError(t, err)
+			_, err = p.IncrementFailedLoginAttempts(ctx, lockout.SubjectKindIP, subject, uuid.NullUUID{}, windowStart())
+			require.NoError(t, err)
+
+			a, err := p.IncrementFailedLoginAttempts(ctx, lockout.SubjectKindIP, subject, uuid.NullUUID{}, time.Now().Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, 1, a.FailedAttempts)
+		})
+
+		t.Run("case=locks and unlocks", func(t *testing.T) {
+			subject := newSubject()
+			i := newIdentity(t)
+
+			a, err := p.IncrementFailedLoginAttempts(ctx, lockout.SubjectKindIdentifier, subject, uuid.NullUUID{UUID: i.ID, Valid: true}, windowStart())
+			require.NoError(t, err)
+
+			until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+			require.NoError(t, p.LockLoginAttempt(ctx, a.ID, until))
+
+			a, err = p.GetLoginAttempt(ctx, lockout.SubjectKindIdentifier, subject)
+			require.NoError(t, err)
+			assert.True(t, a.IsLocked(time.Now()))
+			assert.False(t, a.IsLocked(until.Add(time.Second)))
+			assert.Equal(t, 0, a.FailedAttempts)
+			assert.Equal(t, 1, a.LockoutCount)
+
+			require.NoError(t, p.DeleteLoginAttemptsForIdentity(ctx, i.ID))
+			_, err = p.GetLoginAttempt(ctx, lockout.SubjectKindIdentifier, subject)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows())
+		})
+
+		t.Run("case=deletes attempt", func(t *testing.T) {
+			subject := newSubject()
+
+			_, err := p.IncrementFailedLoginAttempts(ctx, lockout.SubjectKindIdentifier, subject, uuid.NullUUID{}, windowStart())
+			require.NoError(t, err)
+
+			require.NoError(t, p.DeleteLoginAttempt(ctx, lockout.SubjectKindIdentifier, subject))
+			_, err = p.GetLoginAttempt(ctx, lockout.SubjectKindIdentifier, subject)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows())
+		})
+
+		t.Run("case=deletes expired attempts", func(t *testing.T) {
+			expired, locked := newSubject(), newSubject()
+
+			_, err := p.IncrementFailedLoginAttempts(ctx, lockout.SubjectKindIdentifier, expired, uuid.NullUUID{}, windowStart())
+			require.NoError(t, err)
+			a, err := p.IncrementFailedLoginAttempts(ctx, lockout.SubjectKindIdentifier, locked, uuid.NullUUID{}, windowStart())
+			require.NoError(t, err)
+			require.NoError(t, p.LockLoginAttempt(ctx, a.ID, time.Now().Add(time.Hour)))
+
+			require.NoError(t, p.DeleteExpiredLoginAttempts(ctx, time.Now().Add(time.Minute), 1000))
+
+			_, err = p.GetLoginAttempt(ctx, lockout.SubjectKindIdentifier, expired)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows())
+			_, err = p.GetLoginAttempt(ctx, lockout.SubjectKindIdentifier, locked)
+			require.NoError(t, err, "locked subjects must be kept")
+		})
+
+		t.Run("case=network isolation", func(t *testing.T) {
+			subject := newSubject()
+
+			_, err := p.IncrementFailedLoginAttempts(ctx, lockout.SubjectKindIdentifier, subject, uuid.NullUUID{}, windowStart())
+			require.NoError(t, err)
+
+			_, other := testhelpers.NewNetwork(t, ctx, p)
+			_, err = other.GetLoginAttempt(ctx, lockout.SubjectKindIdentifier, subject)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows())
+		})
+	}
+}
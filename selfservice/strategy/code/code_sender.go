@@ -115,6 +115,7 @@ func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identit
 			if err != nil {
 				return err
 			}
+			locale := template.ResolveLocale(ctx, s.deps.Config(), id.Traits, header)
 
 			s.deps.Logger().
 				WithField("registration_flow_id", code.FlowID).
@@ -134,6 +135,7 @@ func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identit
 					ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
 					OAuth2LoginRequest: oauth2LoginRequest,
 					UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+					Locale:             locale,
 				})
 			case identity.ChannelTypeSMS:
 				t = sms.NewRegistrationCodeValid(s.deps, &sms.RegistrationCodeValidModel{
@@ -146,6 +148,7 @@ func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identit
 					ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
 					OAuth2LoginRequest: oauth2LoginRequest,
 					UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+					Locale:             locale,
 				})
 			}
 
@@ -173,6 +176,7 @@ func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identit
 			if err != nil {
 				return err
 			}
+			locale := template.ResolveLocale(ctx, s.deps.Config(), id.Traits, header)
 			s.deps.Logger().
 				WithField("login_flow_id", code.FlowID).
 				WithField("login_code_id", code.ID).
@@ -191,6 +195,7 @@ func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identit
 					ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
 					OAuth2LoginRequest: oauth2LoginRequest,
 					UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+					Locale:             locale,
 				})
 			case identity.ChannelTypeSMS:
 				t = sms.NewLoginCodeValid(s.deps, &sms.LoginCodeValidModel{
@@ -203,6 +208,7 @@ func (s *Sender) SendCode(ctx context.Context, f flow.Flow, id *identity.Identit
 					ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
 					OAuth2LoginRequest: oauth2LoginRequest,
 					UserRequestHeaders: hook.RemoveDisallowedHeaders(header, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+					Locale:             locale,
 				})
 			}
 
@@ -254,6 +260,7 @@ func (s *Sender) SendRecoveryCode(ctx context.Context, f *recovery.Flow, via, to
 			To:               to,
 			RequestURL:       f.RequestURL,
 			TransientPayload: transientPayload,
+			Locale:           template.ResolveLocale(ctx, s.deps.Config(), nil, requestHeader),
 		})); err != nil {
 			return err
 		}
@@ -263,6 +270,19 @@ func (s *Sender) SendRecoveryCode(ctx context.Context, f *recovery.Flow, via, to
 		return err
 	}
 
+	// Addresses which bounced or complained are treated like unknown addresses,
+	// so that recovery is not attempted against dead addresses.
+	if va, err := s.deps.IdentityPool().FindVerifiableAddressByValue(ctx, via, to); err == nil && va.Undeliverable {
+		s.deps.Logger().
+			WithField("via", via).
+			WithSensitiveField("address", to).
+			WithField("strategy", "code").
+			Info("Account recovery was requested for an address which is marked as undeliverable.")
+		return errors.WithStack(ErrUnknownAddress())
+	} else if err != nil && !errors.Is(err, sqlcon.ErrNoRows()) {
+		return err
+	}
+
 	// Get the identity associated with the recovery address
 	i, err := s.deps.IdentityPool().GetIdentity(ctx, address.IdentityID, identity.ExpandDefault)
 	if err != nil {
@@ -301,6 +321,7 @@ func (s *Sender) SendRecoveryCodeTo(ctx context.Context, i *identity.Identity, c
 	if err != nil {
 		return err
 	}
+	locale := template.ResolveLocale(ctx, s.deps.Config(), i.Traits, requestHeader)
 
 	transientPayload, err := x.ParseRawMessageOrEmpty(f.GetTransientPayload())
 	if err != nil {
@@ -319,6 +340,7 @@ func (s *Sender) SendRecoveryCodeTo(ctx context.Context, i *identity.Identity, c
 			TransientPayload:   transientPayload,
 			ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
 			UserRequestHeaders: hook.RemoveDisallowedHeaders(requestHeader, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+			Locale:             locale,
 		})
 	case identity.AddressTypeSMS:
 		u, err := url.Parse(f.GetRequestURL())
@@ -335,6 +357,7 @@ func (s *Sender) SendRecoveryCodeTo(ctx context.Context, i *identity.Identity, c
 			TransientPayload:   transientPayload,
 			ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
 			UserRequestHeaders: hook.RemoveDisallowedHeaders(requestHeader, s.deps.Config().WebhookHeaderAllowlist(ctx)),
+			Locale:             locale,
 		})
 	default:
 		return errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected email or sms but got %s", code.RecoveryAddress.Via))
@@ -348,7 +371,7 @@ func (s *Sender) SendRecoveryCodeTo(ctx context.Context, i *identity.Identity, c
 // If the address does not exist in the store and dispatching invalid emails is enabled (CourierEnableInvalidDispatch is
 // true), an email is still being sent to prevent account enumeration attacks. In that case, this function returns the
 // ErrUnknownAddress error.
-func (s *Sender) SendVerificationCode(ctx context.Context, f *verification.Flow, via string, to string) error {
+func (s *Sender) SendVerificationCode(ctx context.Context, f *verification.Flow, via string, to string, requestHeader http.Header) error {
 	s.deps.Logger().
 		WithField("via", via).
 		WithSensitiveField("address", to).
@@ -375,6 +398,7 @@ func (s *Sender) SendVerificationCode(ctx context.Context, f *verification.Flow,
 			To:               to,
 			RequestURL:       f.GetRequestURL(),
 			TransientPayload: transientPayload,
+			Locale:           template.ResolveLocale(ctx, s.deps.Config(), nil, requestHeader),
 		})); err != nil {
 			return err
 		}
@@ -400,7 +424,7 @@ func (s *Sender) SendVerificationCode(ctx context.Context, f *verification.Flow,
 		return err
 	}
 
-	if err := s.SendVerificationCodeTo(ctx, f, i, rawCode, address); err != nil {
+	if err := s.sendVerificationCodeTo(ctx, f, i, rawCode, address, requestHeader); err != nil {
 		return err
 	}
 
@@ -417,7 +441,14 @@ func (s *Sender) constructVerificationLink(ctx context.Context, f *verification.
 		}).String()
 }
 
+// SendVerificationCodeTo sends the verification code to the address. As there
+// is no request from the recipient, the message locale is only resolved from
+// the identity.
 func (s *Sender) SendVerificationCodeTo(ctx context.Context, f *verification.Flow, i *identity.Identity, codeString string, address identity.VerifiableAddressLike) error {
+	return s.sendVerificationCodeTo(ctx, f, i, codeString, address, nil)
+}
+
+func (s *Sender) sendVerificationCodeTo(ctx context.Context, f *verification.Flow, i *identity.Identity, codeString string, address identity.VerifiableAddressLike, requestHeader http.Header) error {
 	to, via := address.Address(), address.DeliveryVia()
 	s.deps.Logger().
 		WithField("via", via).
@@ -430,6 +461,7 @@ func (s *Sender) SendVerificationCodeTo(ctx context.Context, f *verification.Flo
 	if err != nil {
 		return err
 	}
+	locale := template.ResolveLocale(ctx, s.deps.Config(), i.Traits, requestHeader)
 
 	transientPayload, err := x.ParseRawMessageOrEmpty(f.GetTransientPayload())
 	if err != nil {
@@ -462,6 +494,7 @@ func (s *Sender) SendVerificationCodeTo(ctx context.Context, f *verification.Flo
 			TransientPayload:   transientPayload,
 			ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
 			OAuth2LoginRequest: oauth2LoginRequest,
+			Locale:             locale,
 		})
 	case identity.ChannelTypeSMS:
 		t = sms.NewVerificationCodeValid(s.deps, &sms.VerificationCodeValidModel{
@@ -473,6 +506,7 @@ func (s *Sender) SendVerificationCodeTo(ctx context.Context, f *verification.Flo
 			TransientPayload:   transientPayload,
 			ExpiresInMinutes:   int(s.deps.Config().SelfServiceCodeMethodLifespan(ctx).Minutes()),
 			OAuth2LoginRequest: oauth2LoginRequest,
+			Locale:             locale,
 		})
 	default:
 		return errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Expected email or sms but got %s", via))
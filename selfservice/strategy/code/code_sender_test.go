@@ -202,8 +202,8 @@ func TestSender(t *testing.T) {
 
 			require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
 
-			require.NoError(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "tracked@ory.sh"))
-			require.ErrorIs(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "not-tracked@ory.sh"), code.ErrUnknownAddress())
+			require.NoError(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "tracked@ory.sh", nil))
+			require.ErrorIs(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "not-tracked@ory.sh", nil), code.ErrUnknownAddress())
 		}
 
 		t.Run("case=with default templates", func(t *testing.T) {
@@ -249,6 +249,19 @@ func TestSender(t *testing.T) {
 		})
 	})
 
+	t.Run("case=does not send recovery codes to undeliverable addresses", func(t *testing.T) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email": "undeliverable@ory.sh"}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		require.NoError(t, reg.CourierPersister().MarkAddressUndeliverable(ctx, identity.AddressTypeEmail, "undeliverable@ory.sh"))
+
+		f, err := recovery.NewFlow(reg, time.Hour, "", u, recovery.Strategies{code.NewStrategy(reg)}, flow.TypeBrowser)
+		require.NoError(t, err)
+		require.NoError(t, reg.RecoveryFlowPersister().CreateRecoveryFlow(ctx, f))
+
+		require.ErrorIs(t, reg.CodeSender().SendRecoveryCode(ctx, f, "email", "undeliverable@ory.sh", nil), code.ErrUnknownAddress())
+	})
+
 	t.Run("case=should be able to disable invalid email dispatch", func(t *testing.T) {
 		for _, tc := range []struct {
 			flow      string
@@ -281,7 +294,7 @@ func TestSender(t *testing.T) {
 
 					require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
 
-					err = reg.CodeSender().SendVerificationCode(ctx, f, "email", "not-tracked@ory.sh")
+					err = reg.CodeSender().SendVerificationCode(ctx, f, "email", "not-tracked@ory.sh", nil)
 					require.ErrorIs(t, err, code.ErrUnknownAddress())
 				},
 			},
@@ -397,7 +410,7 @@ func TestSender(t *testing.T) {
 			f.OAuth2LoginChallenge = sqlxx.NullString(hydra.FakeValidLoginChallenge)
 			require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
 
-			require.NoError(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "tracked@ory.sh"))
+			require.NoError(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "tracked@ory.sh", nil))
 
 			messages, err := reg.CourierPersister().NextMessages(ctx, 12)
 			require.NoError(t, err)
@@ -414,7 +427,7 @@ func TestSender(t *testing.T) {
 			require.NoError(t, err)
 			require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
 
-			require.NoError(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "tracked@ory.sh"))
+			require.NoError(t, reg.CodeSender().SendVerificationCode(ctx, f, "email", "tracked@ory.sh", nil))
 
 			messages, err := reg.CourierPersister().NextMessages(ctx, 12)
 			require.NoError(t, err)
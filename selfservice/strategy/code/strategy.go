@@ -32,6 +32,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
+	"github.com/ory/kratos/selfservice/lockout"
 	"github.com/ory/kratos/selfservice/sessiontokenexchange"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/text"
@@ -119,6 +120,8 @@ type (
 		continuity.ManagementProvider
 
 		hydra.Provider
+
+		lockout.ManagementProvider
 	}
 
 	Strategy struct{ deps dependencies }
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
 	"go.opentelemetry.io/otel/attribute"
@@ -252,7 +253,7 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		}
 		return nil, nil
 	case flow.StateEmailSent:
-		i, err := s.loginVerifyCode(ctx, f, &p, sess)
+		i, err := s.loginVerifyCode(ctx, r, f, &p, sess)
 		if err != nil {
 			return nil, s.HandleLoginError(r, f, &p, err, true)
 		}
@@ -579,7 +580,7 @@ func maybeNormalizeEmail(input string) string {
 	return input
 }
 
-func (s *Strategy) loginVerifyCode(ctx context.Context, f *login.Flow, p *updateLoginFlowWithCodeMethod, sess *session.Session) (_ *identity.Identity, err error) {
+func (s *Strategy) loginVerifyCode(ctx context.Context, r *http.Request, f *login.Flow, p *updateLoginFlowWithCodeMethod, sess *session.Session) (_ *identity.Identity, err error) {
 	ctx, span := s.deps.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.code.Strategy.loginVerifyCode")
 	defer otelx.End(span, &err)
 
@@ -596,6 +597,10 @@ func (s *Strategy) loginVerifyCode(ctx context.Context, f *login.Flow, p *update
 		),
 	)
 
+	if err := s.deps.LockoutManager().Check(ctx, r, p.Identifier); err != nil {
+		return nil, err
+	}
+
 	var i *identity.Identity
 	if f.RequestedAAL == identity.AuthenticatorAssuranceLevel2 {
 		i = sess.Identity
@@ -614,11 +619,18 @@ func (s *Strategy) loginVerifyCode(ctx context.Context, f *login.Flow, p *update
 	loginCode, err := s.deps.LoginCodePersister().UseLoginCode(ctx, f.ID, i.ID, p.Code)
 	if err != nil {
 		if errors.Is(err, ErrCodeNotFound()) {
+			if err := s.deps.LockoutManager().RecordFailure(ctx, r, p.Identifier, uuid.NullUUID{UUID: i.ID, Valid: true}); err != nil {
+				s.deps.Logger().WithError(err).Warn("Unable to record failed login attempt.")
+			}
 			return nil, schema.NewLoginCodeInvalid()
 		}
 		return nil, errors.WithStack(err)
 	}
 
+	if err := s.deps.LockoutManager().RecordSuccess(ctx, p.Identifier); err != nil {
+		s.deps.Logger().WithError(err).Warn("Unable to reset failed login attempts.")
+	}
+
 	i, err = s.deps.PrivilegedIdentityPool().GetIdentity(ctx, loginCode.IdentityID, identity.ExpandDefault)
 	if err != nil {
 		return nil, errors.WithStack(err)
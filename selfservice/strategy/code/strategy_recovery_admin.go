@@ -227,7 +227,7 @@ func (s *Strategy) createRecoveryCodeForIdentity(w http.ResponseWriter, r *http.
 		return
 	}
 
-	trace.SpanFromContext(r.Context()).AddEvent(
+	events.Audit(r.Context(), trace.SpanFromContext(r.Context())).AddEvent(
 		events.NewRecoveryInitiatedByAdmin(ctx, recoveryFlow.ID, id.ID, flowType.String(), "code"),
 	)
 
@@ -226,7 +226,7 @@ func (s *Strategy) verificationHandleFormSubmission(ctx context.Context, w http.
 		if !errors.Is(ptcErr, sqlcon.ErrNoRows()) {
 			return s.handleVerificationError(r, f, body, ptcErr)
 		}
-		if err := s.deps.CodeSender().SendVerificationCode(ctx, f, via, body.Email); err != nil {
+		if err := s.deps.CodeSender().SendVerificationCode(ctx, f, via, body.Email, r.Header); err != nil {
 			if !errors.Is(err, ErrUnknownAddress()) {
 				return s.handleVerificationError(r, f, body, err)
 			}
@@ -363,7 +363,9 @@ func (s *Strategy) verificationUseCode(ctx context.Context, w http.ResponseWrite
 		verifiedAt := sqlxx.NullTime(time.Now().UTC())
 		address.VerifiedAt = &verifiedAt
 		address.Status = identity.VerifiableAddressStatusCompleted
-		if err := s.deps.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "verified", "verified_at", "status"); err != nil {
+		// A successful verification proves that the address receives messages.
+		address.Undeliverable = false
+		if err := s.deps.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "verified", "verified_at", "status", "undeliverable"); err != nil {
 			return s.retryVerificationFlowWithError(ctx, w, r, f.Type, err)
 		}
 
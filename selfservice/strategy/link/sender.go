@@ -5,6 +5,7 @@ package link
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 
 	"github.com/pkg/errors"
@@ -61,7 +62,7 @@ func NewSender(r senderDependencies) *Sender {
 // If the address does not exist in the store and dispatching invalid emails is enabled (CourierEnableInvalidDispatch is
 // true), an email is still being sent to prevent account enumeration attacks. In that case, this function returns the
 // ErrUnknownAddress error.
-func (s *Sender) SendRecoveryLink(ctx context.Context, f *recovery.Flow, via, to string) error {
+func (s *Sender) SendRecoveryLink(ctx context.Context, f *recovery.Flow, via, to string, requestHeader http.Header) error {
 	s.r.Logger().
 		WithField("via", via).
 		WithSensitiveField("address", to).
@@ -87,6 +88,7 @@ func (s *Sender) SendRecoveryLink(ctx context.Context, f *recovery.Flow, via, to
 			To:               to,
 			RequestURL:       f.GetRequestURL(),
 			TransientPayload: transientPayload,
+			Locale:           template.ResolveLocale(ctx, s.r.Config(), nil, requestHeader),
 		})); err != nil {
 			return err
 		}
@@ -96,6 +98,19 @@ func (s *Sender) SendRecoveryLink(ctx context.Context, f *recovery.Flow, via, to
 		return err
 	}
 
+	// Addresses which bounced or complained are treated like unknown addresses,
+	// so that recovery is not attempted against dead addresses.
+	if va, err := s.r.IdentityPool().FindVerifiableAddressByValue(ctx, via, to); err == nil && va.Undeliverable {
+		s.r.Logger().
+			WithField("via", via).
+			WithSensitiveField("address", to).
+			WithField("strategy", "link").
+			Info("Account recovery was requested for an address which is marked as undeliverable.")
+		return errors.WithStack(ErrUnknownAddress)
+	} else if err != nil && !errors.Is(err, sqlcon.ErrNoRows()) {
+		return err
+	}
+
 	// Get the identity associated with the recovery address
 	i, err := s.r.IdentityPool().GetIdentity(ctx, address.IdentityID, identity.ExpandDefault)
 	if err != nil {
@@ -107,7 +122,7 @@ func (s *Sender) SendRecoveryLink(ctx context.Context, f *recovery.Flow, via, to
 		return err
 	}
 
-	if err := s.SendRecoveryTokenTo(ctx, f, i, address, token); err != nil {
+	if err := s.SendRecoveryTokenTo(ctx, f, i, address, token, requestHeader); err != nil {
 		return err
 	}
 
@@ -119,7 +134,7 @@ func (s *Sender) SendRecoveryLink(ctx context.Context, f *recovery.Flow, via, to
 // If the address does not exist in the store and dispatching invalid emails is enabled (CourierEnableInvalidDispatch is
 // true), an email is still being sent to prevent account enumeration attacks. In that case, this function returns the
 // ErrUnknownAddress error.
-func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow, via, to string) error {
+func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow, via, to string, requestHeader http.Header) error {
 	s.r.Logger().
 		WithField("via", via).
 		WithSensitiveField("address", to).
@@ -145,6 +160,7 @@ func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow,
 			To:               to,
 			RequestURL:       f.GetRequestURL(),
 			TransientPayload: transientPayload,
+			Locale:           template.ResolveLocale(ctx, s.r.Config(), nil, requestHeader),
 		})); err != nil {
 			return err
 		}
@@ -165,7 +181,7 @@ func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow,
 		return err
 	}
 
-	if err := s.SendVerificationTokenTo(ctx, f, i, address, token); err != nil {
+	if err := s.sendVerificationTokenTo(ctx, f, i, address, token, requestHeader); err != nil {
 		return err
 	}
 
@@ -173,7 +189,7 @@ func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow,
 	return s.r.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "status")
 }
 
-func (s *Sender) SendRecoveryTokenTo(ctx context.Context, f *recovery.Flow, i *identity.Identity, address *identity.RecoveryAddress, token *RecoveryToken) error {
+func (s *Sender) SendRecoveryTokenTo(ctx context.Context, f *recovery.Flow, i *identity.Identity, address *identity.RecoveryAddress, token *RecoveryToken, requestHeader http.Header) error {
 	s.r.Logger().
 		WithField("via", address.Via).
 		WithField("identity_id", address.IdentityID).
@@ -208,10 +224,18 @@ func (s *Sender) SendRecoveryTokenTo(ctx context.Context, f *recovery.Flow, i *i
 			RequestURL:       f.GetRequestURL(),
 			TransientPayload: transientPayload,
 			ExpiresInMinutes: int(s.r.Config().SelfServiceLinkMethodLifespan(ctx).Minutes()),
+			Locale:           template.ResolveLocale(ctx, s.r.Config(), i.Traits, requestHeader),
 		}))
 }
 
+// SendVerificationTokenTo sends the verification link to the address. As there
+// is no request from the recipient, the message locale is only resolved from
+// the identity.
 func (s *Sender) SendVerificationTokenTo(ctx context.Context, f *verification.Flow, i *identity.Identity, sendable identity.VerifiableAddressLike, token *VerificationToken) error {
+	return s.sendVerificationTokenTo(ctx, f, i, sendable, token, nil)
+}
+
+func (s *Sender) sendVerificationTokenTo(ctx context.Context, f *verification.Flow, i *identity.Identity, sendable identity.VerifiableAddressLike, token *VerificationToken, requestHeader http.Header) error {
 	address, via := sendable.Address(), sendable.DeliveryVia()
 	s.r.Logger().
 		WithField("via", via).
@@ -258,6 +282,7 @@ func (s *Sender) SendVerificationTokenTo(ctx context.Context, f *verification.Fl
 			TransientPayload:   transientPayload,
 			ExpiresInMinutes:   int(s.r.Config().SelfServiceLinkMethodLifespan(ctx).Minutes()),
 			OAuth2LoginRequest: template.NewOAuth2LoginRequest(f.HydraLoginRequest),
+			Locale:             template.ResolveLocale(ctx, s.r.Config(), i.Traits, requestHeader),
 		})); err != nil {
 		return err
 	}
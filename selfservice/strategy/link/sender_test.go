@@ -80,8 +80,8 @@ func TestManager(t *testing.T) {
 
 				require.NoError(t, reg.RecoveryFlowPersister().CreateRecoveryFlow(ctx, f))
 
-				require.NoError(t, reg.LinkSender().SendRecoveryLink(ctx, f, "email", "tracked@ory.sh"))
-				require.EqualError(t, reg.LinkSender().SendRecoveryLink(ctx, f, "email", "not-tracked@ory.sh"), link.ErrUnknownAddress.Error())
+				require.NoError(t, reg.LinkSender().SendRecoveryLink(ctx, f, "email", "tracked@ory.sh", nil))
+				require.EqualError(t, reg.LinkSender().SendRecoveryLink(ctx, f, "email", "not-tracked@ory.sh", nil), link.ErrUnknownAddress.Error())
 
 				messages, err := reg.CourierPersister().NextMessages(ctx, 12)
 				require.NoError(t, err)
@@ -128,8 +128,8 @@ func TestManager(t *testing.T) {
 
 				require.NoError(t, reg.RecoveryFlowPersister().CreateRecoveryFlow(ctx, f))
 
-				require.NoError(t, reg.LinkSender().SendRecoveryLink(ctx, f, "email", "tracked@ory.sh"))
-				require.EqualError(t, reg.LinkSender().SendRecoveryLink(ctx, f, "email", "not-tracked@ory.sh"), link.ErrUnknownAddress.Error())
+				require.NoError(t, reg.LinkSender().SendRecoveryLink(ctx, f, "email", "tracked@ory.sh", nil))
+				require.EqualError(t, reg.LinkSender().SendRecoveryLink(ctx, f, "email", "not-tracked@ory.sh", nil), link.ErrUnknownAddress.Error())
 
 				cour, err := reg.Courier(ctx)
 				require.NoError(t, err)
@@ -159,8 +159,8 @@ func TestManager(t *testing.T) {
 
 				require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
 
-				require.NoError(t, reg.LinkSender().SendVerificationLink(ctx, f, "email", "tracked@ory.sh"))
-				require.EqualError(t, reg.LinkSender().SendVerificationLink(ctx, f, "email", "not-tracked@ory.sh"), link.ErrUnknownAddress.Error())
+				require.NoError(t, reg.LinkSender().SendVerificationLink(ctx, f, "email", "tracked@ory.sh", nil))
+				require.EqualError(t, reg.LinkSender().SendVerificationLink(ctx, f, "email", "not-tracked@ory.sh", nil), link.ErrUnknownAddress.Error())
 				messages, err := reg.CourierPersister().NextMessages(ctx, 12)
 				require.NoError(t, err)
 				require.Len(t, messages, 2)
@@ -191,7 +191,7 @@ func TestManager(t *testing.T) {
 		f.OAuth2LoginChallenge = sqlxx.NullString(hydra.FakeValidLoginChallenge)
 		require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
 
-		require.NoError(t, reg.LinkSender().SendVerificationLink(ctx, f, "email", "tracked@ory.sh"))
+		require.NoError(t, reg.LinkSender().SendVerificationLink(ctx, f, "email", "tracked@ory.sh", nil))
 
 		messages, err := reg.CourierPersister().NextMessages(ctx, 12)
 		require.NoError(t, err)
@@ -218,7 +218,7 @@ func TestManager(t *testing.T) {
 
 					require.NoError(t, reg.RecoveryFlowPersister().CreateRecoveryFlow(ctx, f))
 
-					err = reg.LinkSender().SendRecoveryLink(ctx, f, "email", "not-tracked@ory.sh")
+					err = reg.LinkSender().SendRecoveryLink(ctx, f, "email", "not-tracked@ory.sh", nil)
 					require.ErrorIs(t, err, link.ErrUnknownAddress)
 				},
 			},
@@ -233,7 +233,7 @@ func TestManager(t *testing.T) {
 
 					require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(ctx, f))
 
-					err = reg.LinkSender().SendVerificationLink(ctx, f, "email", "not-tracked@ory.sh")
+					err = reg.LinkSender().SendVerificationLink(ctx, f, "email", "not-tracked@ory.sh", nil)
 					require.ErrorIs(t, err, link.ErrUnknownAddress)
 				},
 			},
@@ -199,7 +199,7 @@ func (s *Strategy) createRecoveryLinkForIdentity(w http.ResponseWriter, r *http.
 		return
 	}
 
-	trace.SpanFromContext(ctx).AddEvent(
+	events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(
 		events.NewRecoveryInitiatedByAdmin(ctx, req.ID, id.ID, req.Type.String(), "link"),
 	)
 
@@ -474,7 +474,7 @@ func (s *Strategy) recoveryHandleFormSubmission(r *http.Request, f *recovery.Flo
 		return s.HandleRecoveryError(r, f, body, err)
 	}
 
-	if err := s.d.LinkSender().SendRecoveryLink(r.Context(), f, identity.AddressTypeEmail, body.Email); err != nil {
+	if err := s.d.LinkSender().SendRecoveryLink(r.Context(), f, identity.AddressTypeEmail, body.Email, r.Header); err != nil {
 		if !errors.Is(err, ErrUnknownAddress) {
 			return s.HandleRecoveryError(r, f, body, err)
 		}
@@ -175,7 +175,7 @@ func (s *Strategy) verificationHandleFormSubmission(ctx context.Context, r *http
 		return s.handleVerificationError(r, f, body, err)
 	}
 
-	if err := s.d.LinkSender().SendVerificationLink(ctx, f, identity.AddressTypeEmail, body.Email); err != nil {
+	if err := s.d.LinkSender().SendVerificationLink(ctx, f, identity.AddressTypeEmail, body.Email, r.Header); err != nil {
 		if !errors.Is(err, ErrUnknownAddress) {
 			return s.handleVerificationError(r, f, body, err)
 		}
@@ -254,7 +254,9 @@ func (s *Strategy) verificationUseToken(ctx context.Context, w http.ResponseWrit
 		verifiedAt := sqlxx.NullTime(time.Now().UTC())
 		address.VerifiedAt = &verifiedAt
 		address.Status = identity.VerifiableAddressStatusCompleted
-		if err := s.d.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "verified", "verified_at", "status"); err != nil {
+		// A successful verification proves that the address receives messages.
+		address.Undeliverable = false
+		if err := s.d.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address, "verified", "verified_at", "status", "undeliverable"); err != nil {
 			return s.retryVerificationFlowWithError(ctx, w, r, flow.TypeBrowser, err)
 		}
 
@@ -26,6 +26,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/settings"
@@ -323,6 +324,7 @@ func (s *Strategy) continueSettingsFlowConfirm(ctx context.Context, ctxUpdate *s
 		return err
 	}
 
+	ctxUpdate.AddSecurityNotification(identity.SecurityNotification{TemplateType: template.TypeLookupSecretsRegenerated})
 	ctxUpdate.UpdateIdentity(i)
 	return nil
 }
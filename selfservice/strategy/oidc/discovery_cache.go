@@ -0,0 +1,451 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	jose "github.com/go-jose/go-jose/v4"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/logrusx"
+)
+
+const (
+	documentDiscovery = "discovery"
+	documentJWKS      = "jwks"
+
+	// maxDocumentSize limits the size of discovery documents and JSON Web Key
+	// Sets.
+	maxDocumentSize = 1 << 20
+
+	// maxCachedDocuments limits the number of cached discovery documents and
+	// JSON Web Key Sets each. The least recently used ones are dropped first.
+	maxCachedDocuments = 1024
+
+	fetchTimeout = 30 * time.Second
+)
+
+// discoveryFetchFailures is registered on the Prometheus default registerer,
+// which is exposed on /metrics/prometheus.
+var discoveryFetchFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "kratos_oidc_discovery_fetch_failures_total",
+	Help: "Number of failed fetches of OpenID Connect discovery documents and JSON Web Key Sets, by document and host.",
+}, []string{"document", "host"})
+
+// signatureAlgorithms are the algorithms accepted when parsing tokens. The
+// verifier checks the algorithm against the ones of the provider before.
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.EdDSA,
+}
+
+type (
+	discoveryDependencies interface {
+		config.Provider
+		logrusx.Provider
+		httpx.ClientProvider
+	}
+
+	DiscoveryCacheProvider interface {
+		OIDCDiscoveryCache() *DiscoveryCache
+	}
+
+	// DiscoveryCache caches the discovery documents and JSON Web Key Sets of
+	// OpenID Connect providers, so that signing in does not depend on the
+	// provider serving them for every request.
+	//
+	// Fresh documents are served from the cache. Stale documents are served
+	// as well, while they are refreshed in the background. If refreshing
+	// fails, the stale document is served until it expires. Only expired
+	// documents are fetched while the request waits. If a token is signed
+	// with an unknown key, the key set is fetched again, because the
+	// provider may have rotated its keys.
+	DiscoveryCache struct {
+		// freshFor and expiresAfter override the configured durations if
+		// they are set.
+		freshFor, expiresAfter, retryInterval time.Duration
+
+		documents *lru.Cache[string, *cachedDocument[*discoveryDocument]]
+		keySets   *lru.Cache[string, *cachedDocument[*jose.JSONWebKeySet]]
+
+		fetches singleflight.Group
+	}
+
+	DiscoveryCacheOption func(*DiscoveryCache)
+
+	cachedDocument[T any] struct {
+		mu          sync.Mutex
+		value       T
+		fetchedAt   time.Time
+		attemptedAt time.Time
+		refreshing  bool
+	}
+
+	discoveryDocument struct {
+		raw []byte
+
+		Issuer        string   `json:"issuer"`
+		AuthURL       string   `json:"authorization_endpoint"`
+		TokenURL      string   `json:"token_endpoint"`
+		DeviceAuthURL string   `json:"device_authorization_endpoint"`
+		JWKSURL       string   `json:"jwks_uri"`
+		UserInfoURL   string   `json:"userinfo_endpoint"`
+		Algorithms    []string `json:"id_token_signing_alg_values_supported"`
+	}
+
+	// DiscoveredProvider is an OpenID Connect provider configured by its
+	// cached discovery document.
+	DiscoveredProvider struct {
+		provider   *gooidc.Provider
+		document   *discoveryDocument
+		algorithms []string
+		keySet     gooidc.KeySet
+	}
+
+	// cachedKeySet verifies signatures with a cached JSON Web Key Set.
+	cachedKeySet struct {
+		c   *DiscoveryCache
+		d   discoveryDependencies
+		url string
+	}
+)
+
+// WithDiscoveryCacheTTL sets how long documents are fresh, and after how long
+// stale documents are no longer served, instead of the configured durations.
+func WithDiscoveryCacheTTL(freshFor, expiresAfter time.Duration) DiscoveryCacheOption {
+	return func(c *DiscoveryCache) {
+		c.freshFor, c.expiresAfter = freshFor, expiresAfter
+	}
+}
+
+// WithDiscoveryCacheRetryInterval sets how long to wait before refreshing a
+// document again after a failed refresh, and how often a key set is fetched
+// at most because of unknown keys.
+func WithDiscoveryCacheRetryInterval(interval time.Duration) DiscoveryCacheOption {
+	return func(c *DiscoveryCache) {
+		c.retryInterval = interval
+	}
+}
+
+// WithDiscoveryCacheSize sets how many discovery documents and JSON Web Key
+// Sets are cached at most each.
+func WithDiscoveryCacheSize(size int) DiscoveryCacheOption {
+	return func(c *DiscoveryCache) {
+		c.documents, _ = lru.New[string, *cachedDocument[*discoveryDocument]](size)
+		c.keySets, _ = lru.New[string, *cachedDocument[*jose.JSONWebKeySet]](size)
+	}
+}
+
+func NewDiscoveryCache(opts ...DiscoveryCacheOption) *DiscoveryCache {
+	c := &DiscoveryCache{
+		retryInterval: 30 * time.Second,
+	}
+	WithDiscoveryCacheSize(maxCachedDocuments)(c)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ttl returns how long documents are fresh, and after how long stale
+// documents are no longer served.
+func (c *DiscoveryCache) ttl(ctx context.Context, d discoveryDependencies) (freshFor, expiresAfter time.Duration) {
+	freshFor, expiresAfter = c.freshFor, c.expiresAfter
+	if freshFor == 0 {
+		freshFor = d.Config().OIDCDiscoveryCacheFreshFor(ctx)
+	}
+	if expiresAfter == 0 {
+		expiresAfter = d.Config().OIDCDiscoveryCacheExpiresAfter(ctx)
+	}
+	return freshFor, expiresAfter
+}
+
+// Provider returns the provider of the issuer, configured by its discovery
+// document.
+func (c *DiscoveryCache) Provider(ctx context.Context, d discoveryDependencies, issuer string) (*DiscoveredProvider, error) {
+	doc, err := cached(ctx, c, d, c.documents, documentDiscovery, issuer, func(ctx context.Context) (*discoveryDocument, error) {
+		return fetchDiscoveryDocument(ctx, d, issuer)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	algorithms := make([]string, 0, len(doc.Algorithms))
+	for _, alg := range doc.Algorithms {
+		if slices.Contains(signatureAlgorithms, jose.SignatureAlgorithm(alg)) {
+			algorithms = append(algorithms, alg)
+		}
+	}
+
+	return &DiscoveredProvider{
+		provider: (&gooidc.ProviderConfig{
+			IssuerURL:     doc.Issuer,
+			AuthURL:       doc.AuthURL,
+			TokenURL:      doc.TokenURL,
+			DeviceAuthURL: doc.DeviceAuthURL,
+			UserInfoURL:   doc.UserInfoURL,
+			JWKSURL:       doc.JWKSURL,
+			Algorithms:    algorithms,
+		}).NewProvider(gooidc.ClientContext(ctx, d.HTTPClient(ctx).HTTPClient)),
+		document:   doc,
+		algorithms: algorithms,
+		keySet:     c.KeySet(d, doc.JWKSURL),
+	}, nil
+}
+
+// KeySet returns a key set which verifies signatures with the cached JSON Web
+// Key Set at the URL.
+func (c *DiscoveryCache) KeySet(d discoveryDependencies, jwksURL string) gooidc.KeySet {
+	return &cachedKeySet{c: c, d: d, url: jwksURL}
+}
+
+// cached returns the cached document, and fetches it if it is not cached or
+// expired. Stale documents are refreshed in the background.
+func cached[T any](ctx context.Context, c *DiscoveryCache, d discoveryDependencies, documents *lru.Cache[string, *cachedDocument[T]], kind, u string, fetch func(context.Context) (T, error)) (T, error) {
+	doc, ok := documents.Get(u)
+	if !ok {
+		return fetchCached(ctx, c, documents, kind, u, fetch)
+	}
+
+	freshFor, expiresAfter := c.ttl(ctx, d)
+	doc.mu.Lock()
+	now := time.Now()
+	age := now.Sub(doc.fetchedAt)
+	if age >= expiresAfter {
+		doc.mu.Unlock()
+		// Expired documents are never served again, so they are dropped
+		// even if fetching the document fails.
+		documents.Remove(u)
+		return fetchCached(ctx, c, documents, kind, u, fetch)
+	}
+
+	refresh := age >= freshFor && !doc.refreshing && now.Sub(doc.attemptedAt) >= c.retryInterval
+	if refresh {
+		doc.refreshing = true
+		doc.attemptedAt = now
+	}
+	value := doc.value
+	doc.mu.Unlock()
+
+	if refresh {
+		go func() {
+			_, err := fetchCached(context.WithoutCancel(ctx), c, documents, kind, u, fetch)
+
+			doc.mu.Lock()
+			doc.refreshing = false
+			doc.mu.Unlock()
+
+			if err != nil {
+				d.Logger().WithError(err).WithField("url", u).WithField("document", kind).
+					Warn("Unable to refresh the OpenID Connect document. The cached document is used until it expires.")
+			}
+		}()
+	}
+
+	return value, nil
+}
+
+// fetchCached fetches the document and caches it. Concurrent fetches of the
+// same document are deduplicated.
+func fetchCached[T any](ctx context.Context, c *DiscoveryCache, documents *lru.Cache[string, *cachedDocument[T]], kind, u string, fetch func(context.Context) (T, error)) (T, error) {
+	var zero T
+	result := c.fetches.DoChan(kind+" "+u, func() (any, error) {
+		// The fetch is shared by concurrent requests, so it must not be
+		// canceled together with the request which started it.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+
+		value, err := fetch(ctx)
+		if err != nil {
+			discoveryFetchFailures.WithLabelValues(kind, urlHost(u)).Inc()
+			return nil, err
+		}
+
+		// Documents are only added once they could be fetched, so that
+		// unknown issuers do not fill the cache.
+		documents.Add(u, &cachedDocument[T]{value: value, fetchedAt: time.Now()})
+		return value, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return zero, errors.WithStack(ctx.Err())
+	case res := <-result:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	}
+}
+
+func fetchDiscoveryDocument(ctx context.Context, d discoveryDependencies, issuer string) (*discoveryDocument, error) {
+	body, err := fetchDocument(ctx, d, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration")
+	if err != nil {
+		return nil, err
+	}
+
+	doc := discoveryDocument{raw: body}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, errors.Errorf("unable to decode the discovery document: %s", err)
+	}
+	if doc.Issuer != issuer {
+		return nil, errors.Errorf("the issuer %q of the discovery document does not match the issuer %q", doc.Issuer, issuer)
+	}
+	return &doc, nil
+}
+
+func fetchKeySet(ctx context.Context, d discoveryDependencies, jwksURL string) (*jose.JSONWebKeySet, error) {
+	body, err := fetchDocument(ctx, d, jwksURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return nil, errors.Errorf("unable to decode the JSON Web Key Set: %s", err)
+	}
+	return &keys, nil
+}
+
+func fetchDocument(ctx context.Context, d discoveryDependencies, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	res, err := d.HTTPClient(ctx).HTTPClient.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxDocumentSize))
+	if err != nil {
+		return nil, errors.Errorf("unable to read the response of %s: %s", u, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("%s responded with %s: %s", u, res.Status, body)
+	}
+	return body, nil
+}
+
+func (k *cachedKeySet) keys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	return cached(ctx, k.c, k.d, k.c.keySets, documentJWKS, k.url, k.fetch)
+}
+
+// rotatedKeys fetches the key set again, unless it was fetched recently.
+func (k *cachedKeySet) rotatedKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	if doc, ok := k.c.keySets.Get(k.url); ok {
+		doc.mu.Lock()
+		recent, keys := time.Since(doc.fetchedAt) < k.c.retryInterval, doc.value
+		doc.mu.Unlock()
+		if recent {
+			return keys, nil
+		}
+	}
+	return fetchCached(ctx, k.c, k.c.keySets, documentJWKS, k.url, k.fetch)
+}
+
+func (k *cachedKeySet) fetch(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	return fetchKeySet(ctx, k.d, k.url)
+}
+
+// VerifySignature implements gooidc.KeySet.
+func (k *cachedKeySet) VerifySignature(ctx context.Context, jwt string) ([]byte, error) {
+	jws, err := jose.ParseSigned(jwt, signatureAlgorithms)
+	if err != nil {
+		return nil, errors.Errorf("malformed JSON Web Token: %s", err)
+	}
+
+	keys, err := k.keys(ctx)
+	if err != nil {
+		return nil, errors.Errorf("unable to fetch the JSON Web Key Set: %s", err)
+	}
+	if payload, ok := verifyWithKeys(jws, keys); ok {
+		return payload, nil
+	}
+
+	// The provider may have rotated its keys, so that the token is signed
+	// with a key which is not cached yet.
+	keys, err = k.rotatedKeys(ctx)
+	if err != nil {
+		return nil, errors.Errorf("unable to fetch the JSON Web Key Set: %s", err)
+	}
+	if payload, ok := verifyWithKeys(jws, keys); ok {
+		return payload, nil
+	}
+	return nil, errors.New("none of the keys of the JSON Web Key Set can verify the signature")
+}
+
+func verifyWithKeys(jws *jose.JSONWebSignature, keys *jose.JSONWebKeySet) ([]byte, bool) {
+	var kid string
+	if len(jws.Signatures) > 0 {
+		kid = jws.Signatures[0].Header.KeyID
+	}
+	for _, key := range keys.Keys {
+		if kid != "" && key.KeyID != kid {
+			continue
+		}
+		if payload, err := jws.Verify(&key); err == nil {
+			return payload, true
+		}
+	}
+	return nil, false
+}
+
+func urlHost(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
+
+// Endpoint returns the OAuth 2.0 endpoints of the provider.
+func (p *DiscoveredProvider) Endpoint() oauth2.Endpoint {
+	return p.provider.Endpoint()
+}
+
+// UserInfo fetches the claims of the user from the userinfo endpoint.
+func (p *DiscoveredProvider) UserInfo(ctx context.Context, tokenSource oauth2.TokenSource) (*gooidc.UserInfo, error) {
+	return p.provider.UserInfo(ctx, tokenSource)
+}
+
+// Claims decodes the discovery document into v.
+func (p *DiscoveredProvider) Claims(v any) error {
+	if err := json.Unmarshal(p.document.raw, v); err != nil {
+		return errors.Wrap(err, "unable to decode the discovery document")
+	}
+	return nil
+}
+
+// Verifier returns a verifier of ID tokens which uses the cached key set of
+// the provider.
+func (p *DiscoveredProvider) Verifier(config *gooidc.Config) *gooidc.IDTokenVerifier {
+	if len(config.SupportedSigningAlgs) == 0 && len(p.algorithms) > 0 {
+		c := *config
+		c.SupportedSigningAlgs = p.algorithms
+		config = &c
+	}
+	return gooidc.NewVerifier(p.document.Issuer, p.keySet, config)
+}
@@ -0,0 +1,278 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/x/configx"
+)
+
+type testIssuer struct {
+	*httptest.Server
+
+	discoveryRequests, jwksRequests atomic.Int32
+	down                            atomic.Bool
+	tokenEndpoint                   atomic.Value
+	keys                            atomic.Pointer[jose.JSONWebKeySet]
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	issuer := new(testIssuer)
+	issuer.tokenEndpoint.Store("/token")
+	issuer.keys.Store(new(jose.JSONWebKeySet))
+	issuer.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if issuer.down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			issuer.discoveryRequests.Add(1)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"issuer":                                "http://" + r.Host,
+				"authorization_endpoint":                "http://" + r.Host + "/auth",
+				"token_endpoint":                        "http://" + r.Host + issuer.tokenEndpoint.Load().(string),
+				"jwks_uri":                              "http://" + r.Host + "/jwks",
+				"id_token_signing_alg_values_supported": []string{"RS256"},
+			})
+		case "/jwks":
+			issuer.jwksRequests.Add(1)
+			_ = json.NewEncoder(w).Encode(issuer.keys.Load())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+func (i *testIssuer) host(t *testing.T) string {
+	u, err := url.Parse(i.URL)
+	require.NoError(t, err)
+	return u.Host
+}
+
+type testSigningKey struct {
+	kid string
+	key *rsa.PrivateKey
+}
+
+func newTestSigningKey(t *testing.T, kid string) *testSigningKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return &testSigningKey{kid: kid, key: key}
+}
+
+func (k *testSigningKey) public() jose.JSONWebKey {
+	return jose.JSONWebKey{Key: &k.key.PublicKey, KeyID: k.kid, Algorithm: string(jose.RS256), Use: "sig"}
+}
+
+func (k *testSigningKey) sign(t *testing.T, issuer string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": issuer,
+		"aud": "client",
+		"sub": "subject",
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = k.kid
+	signed, err := token.SignedString(k.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestDiscoveryCache(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+
+	verify := func(t *testing.T, c *oidc.DiscoveryCache, issuer, token string) error {
+		p, err := c.Provider(t.Context(), reg, issuer)
+		require.NoError(t, err)
+		_, err = p.Verifier(&gooidc.Config{ClientID: "client"}).Verify(t.Context(), token)
+		return err
+	}
+
+	t.Run("case=fetches the documents once", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		key := newTestSigningKey(t, "a")
+		issuer.keys.Store(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{key.public()}})
+		c := oidc.NewDiscoveryCache()
+
+		for range 5 {
+			p, err := c.Provider(t.Context(), reg, issuer.URL)
+			require.NoError(t, err)
+			assert.Equal(t, issuer.URL+"/token", p.Endpoint().TokenURL)
+			require.NoError(t, verify(t, c, issuer.URL, key.sign(t, issuer.URL)))
+		}
+
+		assert.EqualValues(t, 1, issuer.discoveryRequests.Load())
+		assert.EqualValues(t, 1, issuer.jwksRequests.Load())
+	})
+
+	t.Run("case=deduplicates concurrent fetches", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		c := oidc.NewDiscoveryCache()
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				_, err := c.Provider(t.Context(), reg, issuer.URL)
+				assert.NoError(t, err)
+			})
+		}
+		wg.Wait()
+
+		assert.EqualValues(t, 1, issuer.discoveryRequests.Load())
+	})
+
+	t.Run("case=serves stale documents while the issuer is down", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		c := oidc.NewDiscoveryCache(oidc.WithDiscoveryCacheTTL(time.Millisecond, time.Hour), oidc.WithDiscoveryCacheRetryInterval(0))
+		failures := oidc.DiscoveryFetchFailures("discovery", issuer.host(t))
+
+		_, err := c.Provider(t.Context(), reg, issuer.URL)
+		require.NoError(t, err)
+
+		issuer.down.Store(true)
+		time.Sleep(5 * time.Millisecond)
+		require.EventuallyWithT(t, func(collect *assert.CollectT) {
+			p, err := c.Provider(t.Context(), reg, issuer.URL)
+			require.NoError(collect, err)
+			assert.Equal(collect, issuer.URL+"/token", p.Endpoint().TokenURL)
+			assert.Greater(collect, oidc.DiscoveryFetchFailures("discovery", issuer.host(t)), failures)
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// Once the issuer is back, the document is refreshed in the
+		// background.
+		issuer.tokenEndpoint.Store("/oauth2/token")
+		issuer.down.Store(false)
+		require.EventuallyWithT(t, func(collect *assert.CollectT) {
+			p, err := c.Provider(t.Context(), reg, issuer.URL)
+			require.NoError(collect, err)
+			assert.Equal(collect, issuer.URL+"/oauth2/token", p.Endpoint().TokenURL)
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("case=fails if the issuer is down and the document expired", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		c := oidc.NewDiscoveryCache(oidc.WithDiscoveryCacheTTL(time.Millisecond, time.Millisecond))
+		failures := oidc.DiscoveryFetchFailures("discovery", issuer.host(t))
+
+		_, err := c.Provider(t.Context(), reg, issuer.URL)
+		require.NoError(t, err)
+
+		issuer.down.Store(true)
+		time.Sleep(5 * time.Millisecond)
+		_, err = c.Provider(t.Context(), reg, issuer.URL)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "503")
+		assert.Equal(t, failures+1, oidc.DiscoveryFetchFailures("discovery", issuer.host(t)))
+	})
+
+	t.Run("case=drops expired documents", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		c := oidc.NewDiscoveryCache(oidc.WithDiscoveryCacheTTL(time.Millisecond, time.Millisecond))
+
+		_, err := c.Provider(t.Context(), reg, issuer.URL)
+		require.NoError(t, err)
+		assert.Equal(t, 1, c.CachedDocuments())
+
+		issuer.down.Store(true)
+		time.Sleep(5 * time.Millisecond)
+		_, err = c.Provider(t.Context(), reg, issuer.URL)
+		require.Error(t, err)
+		assert.Equal(t, 0, c.CachedDocuments())
+	})
+
+	t.Run("case=uses the configured durations", func(t *testing.T) {
+		_, reg := pkg.NewFastRegistryWithMocks(t, configx.WithValues(map[string]any{
+			config.ViperKeyOIDCDiscoveryCacheFreshFor:     "1ms",
+			config.ViperKeyOIDCDiscoveryCacheExpiresAfter: "1ms",
+		}))
+		issuer := newTestIssuer(t)
+		c := oidc.NewDiscoveryCache()
+
+		_, err := c.Provider(t.Context(), reg, issuer.URL)
+		require.NoError(t, err)
+
+		issuer.down.Store(true)
+		time.Sleep(5 * time.Millisecond)
+		_, err = c.Provider(t.Context(), reg, issuer.URL)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "503")
+	})
+
+	t.Run("case=drops the least recently used documents", func(t *testing.T) {
+		first, second := newTestIssuer(t), newTestIssuer(t)
+		c := oidc.NewDiscoveryCache(oidc.WithDiscoveryCacheSize(1))
+
+		for _, issuer := range []*testIssuer{first, second, first} {
+			_, err := c.Provider(t.Context(), reg, issuer.URL)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 1, c.CachedDocuments())
+		assert.EqualValues(t, 2, first.discoveryRequests.Load())
+		assert.EqualValues(t, 1, second.discoveryRequests.Load())
+	})
+
+	t.Run("case=rejects a discovery document of another issuer", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		c := oidc.NewDiscoveryCache()
+
+		_, err := c.Provider(t.Context(), reg, issuer.URL+"/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match")
+	})
+
+	t.Run("case=fetches the keys again after the issuer rotated them", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		previous, next := newTestSigningKey(t, "previous"), newTestSigningKey(t, "next")
+		issuer.keys.Store(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{previous.public()}})
+		c := oidc.NewDiscoveryCache(oidc.WithDiscoveryCacheRetryInterval(0))
+
+		require.NoError(t, verify(t, c, issuer.URL, previous.sign(t, issuer.URL)))
+
+		issuer.keys.Store(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{next.public()}})
+		require.NoError(t, verify(t, c, issuer.URL, next.sign(t, issuer.URL)))
+		assert.EqualValues(t, 2, issuer.jwksRequests.Load())
+
+		// Tokens signed with the previous key are rejected once the key is
+		// no longer published.
+		require.Error(t, verify(t, c, issuer.URL, previous.sign(t, issuer.URL)))
+	})
+
+	t.Run("case=does not fetch the keys for every unknown key", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		known, unknown := newTestSigningKey(t, "known"), newTestSigningKey(t, "unknown")
+		issuer.keys.Store(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{known.public()}})
+		c := oidc.NewDiscoveryCache(oidc.WithDiscoveryCacheRetryInterval(time.Hour))
+
+		require.NoError(t, verify(t, c, issuer.URL, known.sign(t, issuer.URL)))
+		for range 5 {
+			require.Error(t, verify(t, c, issuer.URL, unknown.sign(t, issuer.URL)))
+		}
+		assert.EqualValues(t, 1, issuer.jwksRequests.Load())
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/gofrs/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	oidcv1 "github.com/ory/kratos/gen/oidc/v1"
 	"github.com/ory/kratos/selfservice/flow"
@@ -40,3 +41,14 @@ func (s *Strategy) ProcessTestLoginForTest(ctx context.Context, w http.ResponseW
 func (s *Strategy) FinishTestLoginForTest(ctx context.Context, w http.ResponseWriter, r *http.Request, f *login.Flow, dp *login.DebugPayload) error {
 	return s.finishTestLogin(ctx, w, r, f, dp)
 }
+
+// DiscoveryFetchFailures returns the value of the counter of failed fetches
+// of OpenID Connect documents.
+func DiscoveryFetchFailures(document, host string) float64 {
+	return testutil.ToFloat64(discoveryFetchFailures.WithLabelValues(document, host))
+}
+
+// CachedDocuments returns the number of cached discovery documents.
+func (c *DiscoveryCache) CachedDocuments() int {
+	return c.documents.Len()
+}
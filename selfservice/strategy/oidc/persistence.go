@@ -0,0 +1,108 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlxx"
+)
+
+// DynamicProvider is a provider configuration which is managed through the
+// admin API instead of the configuration file. The secrets in the stored
+// configuration are encrypted.
+//
+// swagger:ignore
+type DynamicProvider struct {
+	ID  uuid.UUID `json:"-" db:"id"`
+	NID uuid.UUID `json:"-" db:"nid"`
+
+	// ProviderID is the ID of the provider, which is also the `id` of the
+	// configuration.
+	ProviderID string `json:"provider_id" db:"provider_id"`
+
+	// OrganizationID is the organization the provider belongs to, if any.
+	OrganizationID uuid.NullUUID `json:"organization_id" db:"organization_id"`
+
+	// Config is the provider's Configuration.
+	Config sqlxx.JSONRawMessage `json:"config" db:"config"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (DynamicProvider) TableName() string { return "selfservice_oidc_providers" }
+
+func (p DynamicProvider) PageToken() keysetpagination.PageToken {
+	return keysetpagination.NewPageToken(keysetpagination.Column{
+		Name:  "provider_id",
+		Value: p.ProviderID,
+	})
+}
+
+func (DynamicProvider) DefaultPageToken() keysetpagination.PageToken {
+	return DynamicProvider{}.PageToken()
+}
+
+// ConsumedLogoutToken records that a back-channel logout token of a provider
+// was used. It is kept until the token is too old to be accepted, so that a
+// replayed logout token is rejected.
+//
+// swagger:ignore
+type ConsumedLogoutToken struct {
+	ID  uuid.UUID `json:"id" db:"id"`
+	NID uuid.UUID `json:"-" db:"nid"`
+
+	// ProviderID is the ID of the provider which issued the logout token.
+	ProviderID string `json:"provider_id" db:"provider_id"`
+
+	// JTI is the `jti` claim of the logout token.
+	JTI string `json:"jti" db:"jti"`
+
+	// ExpiresAt is the time after which the logout token is rejected anyway.
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (ConsumedLogoutToken) TableName() string { return "selfservice_oidc_consumed_logout_tokens" }
+
+type (
+	Persister interface {
+		// CreateOIDCProvider stores a new provider. It returns
+		// sqlcon.ErrUniqueViolation if a provider with the same ID exists.
+		CreateOIDCProvider(context.Context, *DynamicProvider) error
+
+		// GetOIDCProvider returns the provider with the given ID.
+		GetOIDCProvider(ctx context.Context, providerID string) (*DynamicProvider, error)
+
+		// ListOIDCProviders lists the providers ordered by their ID.
+		ListOIDCProviders(context.Context, []keysetpagination.Option) ([]DynamicProvider, *keysetpagination.Paginator, error)
+
+		// UpdateOIDCProvider replaces the organization and configuration of
+		// the provider with the same ID.
+		UpdateOIDCProvider(context.Context, *DynamicProvider) error
+
+		// DeleteOIDCProvider deletes the provider with the given ID.
+		DeleteOIDCProvider(ctx context.Context, providerID string) error
+
+		// ConsumeLogoutToken records that the logout token was used. It
+		// returns sqlcon.ErrUniqueViolation if the logout token was used
+		// before.
+		ConsumeLogoutToken(ctx context.Context, providerID, jti string, expiresAt time.Time) error
+
+		// DeleteExpiredLogoutTokens removes consumed logout tokens which
+		// expired before the given time.
+		DeleteExpiredLogoutTokens(ctx context.Context, before time.Time, limit int) error
+	}
+
+	PersistenceProvider interface {
+		OIDCProviderPersister() Persister
+	}
+)
@@ -7,18 +7,20 @@ import (
 	"context"
 	"slices"
 
-	gooidc "github.com/coreos/go-oidc/v3/oidc"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 
+	"github.com/ory/kratos/driver/config"
 	oidcv1 "github.com/ory/kratos/gen/oidc/v1"
 	"github.com/ory/x/httpx"
 	"github.com/ory/x/logrusx"
 )
 
 type pkceDependencies interface {
+	config.Provider
 	logrusx.Provider
 	httpx.ClientProvider
+	DiscoveryCacheProvider
 }
 
 func PKCEChallenge(s *oidcv1.State) []oauth2.AuthCodeOption {
@@ -65,8 +67,7 @@ func discoverPKCE(ctx context.Context, d pkceDependencies, p OAuth2Provider) (pk
 		return false, errors.New("Issuer URL must be set to autodiscover PKCE support")
 	}
 
-	ctx = gooidc.ClientContext(ctx, d.HTTPClient(ctx).HTTPClient)
-	gp, err := gooidc.NewProvider(ctx, p.Config().IssuerURL)
+	gp, err := d.OIDCDiscoveryCache().Provider(ctx, d, p.Config().IssuerURL)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to initialize provider")
 	}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/dghubble/oauth1"
 	"github.com/pkg/errors"
@@ -36,8 +37,40 @@ type (
 		Claims(ctx context.Context, token *oauth1.Token) (*Claims, error)
 		ExchangeToken(ctx context.Context, req *http.Request) (*oauth1.Token, error)
 	}
+	// AssertionProvider is a provider which does not use OAuth. It redirects
+	// the user to the identity provider, and reads the claims from the query
+	// of the callback, for example after validating a SAML assertion.
+	AssertionProvider interface {
+		Provider
+		AuthURL(ctx context.Context, state string) (string, error)
+		Claims(ctx context.Context, query url.Values) (*Claims, error)
+	}
+	// LogoutProvider is a provider which supports OpenID Connect RP-Initiated
+	// Logout and Back-Channel Logout.
+	LogoutProvider interface {
+		Provider
+		// EndSessionURL returns the URL which ends the session at the
+		// provider, or nil if the provider has no end session endpoint.
+		EndSessionURL(ctx context.Context, idTokenHint string, postLogoutRedirectURI *url.URL) (*url.URL, error)
+		// VerifyLogoutToken verifies a back-channel logout token and returns
+		// its claims.
+		VerifyLogoutToken(ctx context.Context, rawLogoutToken string) (*LogoutTokenClaims, error)
+	}
 )
 
+// LogoutTokenClaims are the claims of a back-channel logout token. See OpenID
+// Connect Back-Channel Logout 1.0, Section 2.4.
+type LogoutTokenClaims struct {
+	Issuer    string                     `json:"iss"`
+	Subject   string                     `json:"sub,omitempty"`
+	SessionID string                     `json:"sid,omitempty"`
+	IssuedAt  int64                      `json:"iat"`
+	ExpiresAt int64                      `json:"exp,omitempty"`
+	JTI       string                     `json:"jti"`
+	Nonce     string                     `json:"nonce,omitempty"`
+	Events    map[string]json.RawMessage `json:"events"`
+}
+
 type OAuth2TokenExchanger interface {
 	Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error)
 }
@@ -88,6 +121,11 @@ type Claims struct {
 	// RFC 8176.
 	AMR []string `json:"amr,omitempty"`
 
+	// SessionID is the `sid` claim which identifies the session at the
+	// upstream OIDC provider. See OpenID Connect Front-Channel Logout 1.0,
+	// Section 3.
+	SessionID string `json:"sid,omitempty"`
+
 	RawClaims map[string]any `json:"raw_claims,omitempty"`
 }
 
@@ -125,6 +163,47 @@ func (c *Claims) Validate() error {
 	return nil
 }
 
+const (
+	backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+	// logoutTokenMaxAge limits how long a logout token can be replayed.
+	logoutTokenMaxAge = 5 * time.Minute
+	logoutTokenLeeway = time.Minute
+)
+
+// Validate checks the claims of a logout token whose signature, issuer, and
+// audience were verified already. See OpenID Connect Back-Channel Logout 1.0,
+// Section 2.6.
+func (c *LogoutTokenClaims) Validate(now time.Time) error {
+	if _, ok := c.Events[backChannelLogoutEvent]; !ok {
+		return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The logout token does not contain the %s event.", backChannelLogoutEvent))
+	}
+	if c.Nonce != "" {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The logout token must not contain a nonce."))
+	}
+	// Sessions are found by the subject, so logout tokens which only identify
+	// the upstream session can not be processed.
+	if c.Subject == "" {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The logout token does not contain a subject."))
+	}
+	// Used logout tokens are recorded by their jti to reject replays.
+	if c.JTI == "" {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The logout token does not contain a jti."))
+	}
+
+	issuedAt := time.Unix(c.IssuedAt, 0)
+	if issuedAt.After(now.Add(logoutTokenLeeway)) {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The logout token was issued in the future."))
+	}
+	if issuedAt.Before(now.Add(-logoutTokenMaxAge)) {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The logout token is too old."))
+	}
+	if c.ExpiresAt != 0 && now.After(time.Unix(c.ExpiresAt, 0).Add(logoutTokenLeeway)) {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The logout token has expired."))
+	}
+	return nil
+}
+
 // UpstreamParameters returns a list of oauth2.AuthCodeOption based on the upstream parameters.
 //
 // Only allowed parameters are returned and the rest is ignored.
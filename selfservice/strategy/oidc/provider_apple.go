@@ -12,7 +12,6 @@ import (
 	"net/url"
 	"time"
 
-	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/golang-jwt/jwt/v4"
 
 	"github.com/pkg/errors"
@@ -160,8 +159,7 @@ var _ IDTokenVerifier = new(ProviderApple)
 const issuerURLApple = "https://appleid.apple.com"
 
 func (a *ProviderApple) Verify(ctx context.Context, rawIDToken string) (*Claims, error) {
-	keySet := oidc.NewRemoteKeySet(ctx, a.JWKSUrl)
-	ctx = oidc.ClientContext(ctx, a.reg.HTTPClient(ctx).HTTPClient)
+	keySet := a.reg.OIDCDiscoveryCache().KeySet(a.reg, a.JWKSUrl)
 
 	return verifyToken(ctx, keySet, a.config, rawIDToken, issuerURLApple)
 }
@@ -185,6 +185,35 @@ type Configuration struct {
 	// the upstream `amr` array. When empty, `amr` does not influence the
 	// session AAL.
 	AAL2AMRValues []string `json:"aal2_amr_values,omitempty"`
+
+	// RPInitiatedLogout redirects the browser to the `end_session_endpoint`
+	// of the provider when the user logs out, so that the session at the
+	// provider ends too. Only supported by OpenID Connect providers which
+	// publish the endpoint in their discovery document.
+	RPInitiatedLogout bool `json:"rp_initiated_logout,omitempty"`
+
+	// BackChannelLogout accepts logout tokens of the provider at the
+	// back-channel logout endpoint, and revokes the sessions of the subject
+	// when the provider signals a logout.
+	BackChannelLogout bool `json:"backchannel_logout,omitempty"`
+
+	// SAMLIDPMetadataURL is the URL of the SAML identity provider metadata. It
+	// can be either a URL (file://, http(s)://, base64://).
+	// This is needed when `provider` is set to `saml`.
+	SAMLIDPMetadataURL string `json:"idp_metadata_url,omitempty"`
+
+	// SAMLSPCertificate is the PEM encoded certificate of the SAML service
+	// provider. If set together with `sp_private_key`, authentication
+	// requests are signed.
+	SAMLSPCertificate string `json:"sp_certificate,omitempty"`
+
+	// SAMLSPPrivateKey is the PEM encoded RSA or ECDSA private key of the
+	// SAML service provider.
+	SAMLSPPrivateKey string `json:"sp_private_key,omitempty"`
+
+	// SAMLNameIDFormat is the NameID format requested from the SAML identity
+	// provider. If empty, the identity provider chooses the format.
+	SAMLNameIDFormat string `json:"name_id_format,omitempty"`
 }
 
 // AALForClaims returns the session AuthenticatorAssuranceLevel that the
@@ -267,13 +296,17 @@ var supportedProviders = map[string]func(config *Configuration, reg Dependencies
 }
 
 func (c ConfigurationCollection) Provider(id string, reg Dependencies) (Provider, error) {
+	return c.provider(id, reg, supportedProviders)
+}
+
+func (c ConfigurationCollection) provider(id string, reg Dependencies, types map[string]func(config *Configuration, reg Dependencies) Provider) (Provider, error) {
 	for _, p := range c.Providers {
 		if p.ID == id {
-			if f, ok := supportedProviders[p.Provider]; ok {
+			if f, ok := types[p.Provider]; ok {
 				return f(&p, reg), nil
 			}
 
-			return nil, errors.Errorf("provider type %s is not supported, supported are: %v", p.Provider, maps.Keys(supportedProviders))
+			return nil, errors.Errorf("provider type %s is not supported, supported are: %v", p.Provider, maps.Keys(types))
 		}
 	}
 	return nil, errors.WithStack(herodot.ErrNotFound().WithReasonf(`OpenID Connect Provider "%s" is unknown or has not been configured`, id))
@@ -17,10 +17,12 @@ import (
 	"github.com/ory/x/reqlog"
 )
 
-var _ OAuth2Provider = (*ProviderGenericOIDC)(nil)
+var (
+	_ OAuth2Provider = (*ProviderGenericOIDC)(nil)
+	_ LogoutProvider = (*ProviderGenericOIDC)(nil)
+)
 
 type ProviderGenericOIDC struct {
-	p      *gooidc.Provider
 	config *Configuration
 	reg    Dependencies
 }
@@ -48,15 +50,12 @@ func (g *ProviderGenericOIDC) withHTTPClientContext(ctx context.Context) context
 	return gooidc.ClientContext(ctx, g.reg.HTTPClient(ctx).HTTPClient)
 }
 
-func (g *ProviderGenericOIDC) provider(ctx context.Context) (*gooidc.Provider, error) {
-	if g.p == nil {
-		p, err := gooidc.NewProvider(g.withHTTPClientContext(ctx), g.config.IssuerURL)
-		if err != nil {
-			return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to initialize OpenID Connect Provider: %s", err))
-		}
-		g.p = p
+func (g *ProviderGenericOIDC) provider(ctx context.Context) (*DiscoveredProvider, error) {
+	p, err := g.reg.OIDCDiscoveryCache().Provider(ctx, g.reg, g.config.IssuerURL)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to initialize OpenID Connect Provider: %s", err))
 	}
-	return g.p, nil
+	return p, nil
 }
 
 func (g *ProviderGenericOIDC) oauth2ConfigFromEndpoint(ctx context.Context, endpoint oauth2.Endpoint) *oauth2.Config {
@@ -97,8 +96,8 @@ func (g *ProviderGenericOIDC) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption
 	return options
 }
 
-func (g *ProviderGenericOIDC) verifyAndDecodeClaimsWithProvider(ctx context.Context, provider *gooidc.Provider, raw string) (*Claims, error) {
-	token, err := provider.VerifierContext(g.withHTTPClientContext(ctx), &gooidc.Config{ClientID: g.config.ClientID}).Verify(ctx, raw)
+func (g *ProviderGenericOIDC) verifyAndDecodeClaimsWithProvider(ctx context.Context, provider *DiscoveredProvider, raw string) (*Claims, error) {
+	token, err := provider.Verifier(&gooidc.Config{ClientID: g.config.ClientID}).Verify(ctx, raw)
 	if err != nil {
 		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("%s", err))
 	}
@@ -190,7 +189,7 @@ func (g *ProviderGenericOIDC) claimsFromIDToken(ctx context.Context, exchange *o
 	return g.verifyAndDecodeClaimsWithProvider(ctx, p, raw)
 }
 
-func (g *ProviderGenericOIDC) idTokenAndProvider(ctx context.Context, exchange *oauth2.Token) (*gooidc.Provider, string, error) {
+func (g *ProviderGenericOIDC) idTokenAndProvider(ctx context.Context, exchange *oauth2.Token) (*DiscoveredProvider, string, error) {
 	raw, ok := exchange.Extra("id_token").(string)
 	if !ok || len(raw) == 0 {
 		return nil, "", errors.WithStack(ErrIDTokenMissing())
@@ -210,10 +209,64 @@ func (g *ProviderGenericOIDC) verifiedIDToken(ctx context.Context, exchange *oau
 		return nil, err
 	}
 
-	token, err := p.VerifierContext(g.withHTTPClientContext(ctx), &gooidc.Config{ClientID: g.config.ClientID}).Verify(ctx, raw)
+	token, err := p.Verifier(&gooidc.Config{ClientID: g.config.ClientID}).Verify(ctx, raw)
 	if err != nil {
 		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("%s", err))
 	}
 
 	return token, nil
 }
+
+func (g *ProviderGenericOIDC) EndSessionURL(ctx context.Context, idTokenHint string, postLogoutRedirectURI *url.URL) (*url.URL, error) {
+	p, err := g.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+	if err := p.Claims(&metadata); err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("Unable to decode the OpenID Connect discovery document: %s", err))
+	}
+	if metadata.EndSessionEndpoint == "" {
+		return nil, nil
+	}
+
+	endpoint, err := url.Parse(metadata.EndSessionEndpoint)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("The end session endpoint of the OpenID Connect provider is invalid: %s", err))
+	}
+
+	// See OpenID Connect RP-Initiated Logout 1.0, Section 2.
+	query := endpoint.Query()
+	query.Set("client_id", g.config.ClientID)
+	if idTokenHint != "" {
+		query.Set("id_token_hint", idTokenHint)
+	}
+	if postLogoutRedirectURI != nil {
+		query.Set("post_logout_redirect_uri", postLogoutRedirectURI.String())
+	}
+	endpoint.RawQuery = query.Encode()
+	return endpoint, nil
+}
+
+func (g *ProviderGenericOIDC) VerifyLogoutToken(ctx context.Context, rawLogoutToken string) (*LogoutTokenClaims, error) {
+	p, err := g.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Logout tokens do not need to carry an expiry, so it is checked by
+	// LogoutTokenClaims.Validate instead.
+	token, err := p.Verifier(&gooidc.Config{ClientID: g.config.ClientID, SkipExpiryCheck: true}).Verify(ctx, rawLogoutToken)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("%s", err))
+	}
+
+	var claims LogoutTokenClaims
+	if err := token.Claims(&claims); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("%s", err))
+	}
+	return &claims, nil
+}
@@ -5,12 +5,18 @@ package oidc_test
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rakutentech/jwk-go/jwk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ory/herodot"
 	"github.com/ory/kratos/driver"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/pkg"
@@ -18,6 +24,7 @@ import (
 	"github.com/ory/kratos/selfservice/strategy/oidc"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/configx"
+	"github.com/ory/x/urlx"
 )
 
 func makeOIDCClaims() json.RawMessage {
@@ -92,3 +99,115 @@ func TestProviderGenericOIDC_AddAuthCodeURLOptions(t *testing.T) {
 		assert.Contains(t, makeAuthCodeURL(t, r, reg), "claims="+url.QueryEscape(string(makeOIDCClaims())))
 	})
 }
+
+func newLogoutTestIssuer(t *testing.T, endSessionEndpoint string) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/jwks" {
+			_, _ = w.Write(publicJWKS)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":               "http://" + r.Host,
+			"jwks_uri":             "http://" + r.Host + "/jwks",
+			"end_session_endpoint": endSessionEndpoint,
+		})
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func createLogoutToken(t *testing.T, claims jwt.MapClaims) string {
+	key := &jwk.KeySpec{}
+	require.NoError(t, json.Unmarshal(rawKey, key))
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = key.KeyID
+	token.Header["typ"] = "logout+jwt"
+	s, err := token.SignedString(key.Key)
+	require.NoError(t, err)
+	return s
+}
+
+func TestProviderGenericOIDC_EndSessionURL(t *testing.T) {
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+	returnTo := urlx.ParseOrPanic("https://www.ory.sh/logged-out")
+
+	t.Run("case=returns the end session endpoint", func(t *testing.T) {
+		ts := newLogoutTestIssuer(t, "https://idp.example.org/logout?tenant=acme")
+		p := oidc.NewProviderGenericOIDC(&oidc.Configuration{ID: "valid", ClientID: "client", IssuerURL: ts.URL}, reg).(oidc.LogoutProvider)
+
+		actual, err := p.EndSessionURL(t.Context(), "id-token", returnTo)
+		require.NoError(t, err)
+		assert.Equal(t, "idp.example.org", actual.Host)
+		assert.Equal(t, "/logout", actual.Path)
+		assert.Equal(t, url.Values{
+			"tenant":                   {"acme"},
+			"client_id":                {"client"},
+			"id_token_hint":            {"id-token"},
+			"post_logout_redirect_uri": {returnTo.String()},
+		}, actual.Query())
+	})
+
+	t.Run("case=omits an empty id token hint", func(t *testing.T) {
+		ts := newLogoutTestIssuer(t, "https://idp.example.org/logout")
+		p := oidc.NewProviderGenericOIDC(&oidc.Configuration{ID: "valid", ClientID: "client", IssuerURL: ts.URL}, reg).(oidc.LogoutProvider)
+
+		actual, err := p.EndSessionURL(t.Context(), "", returnTo)
+		require.NoError(t, err)
+		assert.False(t, actual.Query().Has("id_token_hint"))
+	})
+
+	t.Run("case=returns nil without end session endpoint", func(t *testing.T) {
+		ts := newLogoutTestIssuer(t, "")
+		p := oidc.NewProviderGenericOIDC(&oidc.Configuration{ID: "valid", ClientID: "client", IssuerURL: ts.URL}, reg).(oidc.LogoutProvider)
+
+		actual, err := p.EndSessionURL(t.Context(), "id-token", returnTo)
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestProviderGenericOIDC_VerifyLogoutToken(t *testing.T) {
+	_, reg := pkg.NewFastRegistryWithMocks(t)
+	ts := newLogoutTestIssuer(t, "")
+	p := oidc.NewProviderGenericOIDC(&oidc.Configuration{ID: "valid", ClientID: "client", IssuerURL: ts.URL}, reg).(oidc.LogoutProvider)
+
+	newClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":    ts.URL,
+			"aud":    "client",
+			"sub":    "subject",
+			"sid":    "upstream-session",
+			"iat":    time.Now().Unix(),
+			"jti":    "jti",
+			"events": map[string]any{"http://schemas.openid.net/event/backchannel-logout": map[string]any{}},
+		}
+	}
+
+	t.Run("case=accepts a valid logout token", func(t *testing.T) {
+		claims, err := p.VerifyLogoutToken(t.Context(), createLogoutToken(t, newClaims()))
+		require.NoError(t, err)
+		assert.Equal(t, "subject", claims.Subject)
+		assert.Equal(t, "upstream-session", claims.SessionID)
+		require.NoError(t, claims.Validate(time.Now()))
+	})
+
+	for _, tc := range []struct {
+		name   string
+		modify func(jwt.MapClaims)
+	}{
+		{name: "another audience", modify: func(c jwt.MapClaims) { c["aud"] = "other-client" }},
+		{name: "another issuer", modify: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.org" }},
+	} {
+		t.Run("case=rejects a logout token for "+tc.name, func(t *testing.T) {
+			claims := newClaims()
+			tc.modify(claims)
+			_, err := p.VerifyLogoutToken(t.Context(), createLogoutToken(t, claims))
+			require.ErrorIs(t, err, herodot.ErrBadRequest())
+		})
+	}
+
+	t.Run("case=rejects a malformed logout token", func(t *testing.T) {
+		_, err := p.VerifyLogoutToken(t.Context(), "not-a-jwt")
+		require.ErrorIs(t, err, herodot.ErrBadRequest())
+	})
+}
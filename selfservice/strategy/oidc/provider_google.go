@@ -75,8 +75,7 @@ var _ IDTokenVerifier = new(ProviderGoogle)
 const issuerURLGoogle = "https://accounts.google.com"
 
 func (g *ProviderGoogle) Verify(ctx context.Context, rawIDToken string) (*Claims, error) {
-	keySet := gooidc.NewRemoteKeySet(ctx, g.JWKSUrl)
-	ctx = gooidc.ClientContext(ctx, g.reg.HTTPClient(ctx).HTTPClient)
+	keySet := g.reg.OIDCDiscoveryCache().KeySet(g.reg, g.JWKSUrl)
 
 	return verifyToken(ctx, keySet, g.config, rawIDToken, issuerURLGoogle)
 }
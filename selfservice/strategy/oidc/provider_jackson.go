@@ -26,6 +26,7 @@ const jacksonTrustedIssuersEnv = "SAML_SP_TRUSTED_ISSUERS"
 
 type ProviderJackson struct {
 	*ProviderGenericOIDC
+	p *oidc.Provider
 }
 
 func NewProviderJackson(
@@ -40,9 +41,13 @@ func NewProviderJackson(
 	}
 }
 
+func (j *ProviderJackson) internalHost() string {
+	return strings.TrimSuffix(j.config.TokenURL, "/api/oauth/token")
+}
+
 func (j *ProviderJackson) setProvider(ctx context.Context) {
 	if j.p == nil {
-		internalHost := strings.TrimSuffix(j.config.TokenURL, "/api/oauth/token")
+		internalHost := j.internalHost()
 		config := oidc.ProviderConfig{
 			IssuerURL:     j.config.IssuerURL,
 			AuthURL:       j.config.AuthURL,
@@ -69,8 +74,6 @@ func (j *ProviderJackson) OAuth2(ctx context.Context) (*oauth2.Config, error) {
 }
 
 func (j *ProviderJackson) Claims(ctx context.Context, exchange *oauth2.Token, _ url.Values) (*Claims, error) {
-	j.setProvider(ctx)
-
 	raw, ok := exchange.Extra("id_token").(string)
 	if !ok || len(raw) == 0 {
 		return nil, errors.WithStack(ErrIDTokenMissing())
@@ -79,9 +82,10 @@ func (j *ProviderJackson) Claims(ctx context.Context, exchange *oauth2.Token, _
 	// go-oidc's SkipIssuerCheck defers issuer validation to the caller. The
 	// issuer is verified below against the configured issuer plus the
 	// JacksonTrustedIssuersEnv allowlist.
-	token, err := j.p.VerifierContext(
-		j.withHTTPClientContext(ctx),
-		&oidc.Config{ClientID: j.config.ClientID, SkipIssuerCheck: true},
+	token, err := oidc.NewVerifier(
+		j.config.IssuerURL,
+		j.reg.OIDCDiscoveryCache().KeySet(j.reg, j.internalHost()+"/oauth/jwks"),
+		&oidc.Config{ClientID: j.config.ClientID, SupportedSigningAlgs: []string{oidc.RS256}, SkipIssuerCheck: true},
 	).Verify(ctx, raw)
 	if err != nil {
 		return nil, errors.WithStack(herodot.ErrBadRequest().WithReasonf("%s", err))
@@ -145,10 +145,7 @@ func (n *ProviderNetID) Verify(ctx context.Context, rawIDToken string) (*Claims,
 		return nil, err
 	}
 
-	idToken, err := provider.VerifierContext(
-		n.withHTTPClientContext(ctx),
-		&oidc.Config{ClientID: n.config.ClientID},
-	).Verify(ctx, token.IDToken)
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: n.config.ClientID}).Verify(ctx, token.IDToken)
 	if err != nil {
 		return nil, err
 	}
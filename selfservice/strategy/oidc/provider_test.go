@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,6 +25,40 @@ func TestClaimsValidate(t *testing.T) {
 	require.NoError(t, (&Claims{Issuer: "not-empty", Subject: "not-empty"}).Validate())
 }
 
+func TestLogoutTokenClaimsValidate(t *testing.T) {
+	now := time.Now()
+	valid := func() *LogoutTokenClaims {
+		return &LogoutTokenClaims{
+			Issuer:   "https://idp.example.org",
+			Subject:  "subject",
+			IssuedAt: now.Unix(),
+			JTI:      "jti",
+			Events:   map[string]json.RawMessage{backChannelLogoutEvent: json.RawMessage("{}")},
+		}
+	}
+
+	require.NoError(t, valid().Validate(now))
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*LogoutTokenClaims)
+	}{
+		{name: "without the logout event", modify: func(c *LogoutTokenClaims) { c.Events = map[string]json.RawMessage{} }},
+		{name: "with a nonce", modify: func(c *LogoutTokenClaims) { c.Nonce = "nonce" }},
+		{name: "without a subject", modify: func(c *LogoutTokenClaims) { c.Subject, c.SessionID = "", "sid" }},
+		{name: "without a jti", modify: func(c *LogoutTokenClaims) { c.JTI = "" }},
+		{name: "issued in the future", modify: func(c *LogoutTokenClaims) { c.IssuedAt = now.Add(time.Hour).Unix() }},
+		{name: "issued too long ago", modify: func(c *LogoutTokenClaims) { c.IssuedAt = now.Add(-time.Hour).Unix() }},
+		{name: "expired", modify: func(c *LogoutTokenClaims) { c.ExpiresAt = now.Add(-2 * time.Minute).Unix() }},
+	} {
+		t.Run("case=rejects a logout token "+tc.name, func(t *testing.T) {
+			c := valid()
+			tc.modify(c)
+			require.Error(t, c.Validate(now))
+		})
+	}
+}
+
 type TestProvider struct {
 	*ProviderGenericOIDC
 }
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/cipher"
@@ -65,6 +66,9 @@ const (
 	RouteCallback             = RouteBase + "/callback/{provider}"
 	RouteCallbackGeneric      = RouteBase + "/callback"
 	RouteOrganizationCallback = RouteBase + "/organization/{organization}/callback/{provider}"
+	RouteBackChannelLogout    = RouteBase + "/backchannel-logout/{provider}"
+
+	RouteAdminIdentityAccessToken = "/identities/{id}/credentials/oidc/providers/{provider}/token"
 )
 
 var (
@@ -94,6 +98,7 @@ type Dependencies interface {
 
 	session.ManagementProvider
 	session.HandlerProvider
+	session.PersistenceProvider
 	sessiontokenexchange.PersistenceProvider
 
 	login.HookExecutorProvider
@@ -119,6 +124,9 @@ type Dependencies interface {
 	cipher.Provider
 
 	jsonnetsecure.VMProvider
+
+	PersistenceProvider
+	DiscoveryCacheProvider
 }
 
 func isForced(req interface{}) bool {
@@ -153,8 +161,13 @@ type Strategy struct {
 	credType                    identity.CredentialsType
 	handleUnknownProviderError  func(err error) error
 	handleMethodNotAllowedError func(err error) error
+	providerTypes               map[string]func(config *Configuration, reg Dependencies) Provider
 
 	conflictingIdentityPolicy ConflictingIdentityPolicy
+
+	// tokenRefreshes serializes the refreshes of upstream access tokens per
+	// identity and provider account.
+	tokenRefreshes singleflight.Group
 }
 type ConflictingIdentityPolicy func(ctx context.Context, existingIdentity, newIdentity *identity.Identity, provider Provider, claims *Claims) ConflictingIdentityVerdict
 
@@ -221,9 +234,20 @@ func (s *Strategy) RegisterPublicRoutes(r *httprouterx.RouterPublic) {
 	// by the browser. So here we just redirect the request to the same location rewriting the
 	// form fields to query params. This second GET request should have the cookies attached.
 	r.POST(RouteCallback, s.redirectToGET)
+
+	// The provider calls the back-channel logout endpoint directly, and the
+	// logout token is verified instead.
+	s.d.CSRFHandler().IgnoreGlob(RouteBase + "/backchannel-logout/*")
+	r.POST(RouteBackChannelLogout, strategy.IsDisabled(s.d, s.ID().String(), s.handleBackChannelLogout))
+
+	r.GET(RouteAdminIdentityAccessToken, redir.RedirectToAdminRoute(s.d))
+	s.registerPublicProviderRoutes(r)
 }
 
-func (s *Strategy) RegisterAdminRoutes(*httprouterx.RouterAdmin) {}
+func (s *Strategy) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteAdminIdentityAccessToken, strategy.IsDisabled(s.d, s.ID().String(), s.getIdentityOidcAccessToken))
+	s.registerAdminProviderRoutes(admin)
+}
 
 // Redirect POST request to GET rewriting form fields to query params.
 func (s *Strategy) redirectToGET(w http.ResponseWriter, r *http.Request) {
@@ -264,6 +288,12 @@ func WithHandleMethodNotAllowedError(handler func(error) error) NewStrategyOpt {
 	return func(s *Strategy) { s.handleMethodNotAllowedError = handler }
 }
 
+// WithProviderTypes overrides the provider types which can be configured for
+// this strategy.
+func WithProviderTypes(types map[string]func(config *Configuration, reg Dependencies) Provider) NewStrategyOpt {
+	return func(s *Strategy) { s.providerTypes = types }
+}
+
 // WithOnConflictingIdentity sets a policy handler for deciding what to do when a
 // new identity conflicts with an existing one during login.
 func WithOnConflictingIdentity(handler ConflictingIdentityPolicy) NewStrategyOpt {
@@ -287,6 +317,7 @@ func NewStrategy(d Dependencies, opts ...NewStrategyOpt) *Strategy {
 		credType:                    identity.CredentialsTypeOIDC,
 		handleUnknownProviderError:  func(err error) error { return err },
 		handleMethodNotAllowedError: func(err error) error { return err },
+		providerTypes:               supportedProviders,
 	}
 
 	for _, opt := range opts {
@@ -547,6 +578,14 @@ func (s *Strategy) HandleCallback(w http.ResponseWriter, r *http.Request) {
 			s.forwardError(ctx, w, r, req, s.HandleError(ctx, w, r, req, state.ProviderId, nil, oidcerr.Wrap(oidcerr.StepClaimsDecode, err)))
 			return
 		}
+	case AssertionProvider:
+		t0 := time.Now()
+		claims, err = p.Claims(ctx, r.URL.Query())
+		reqlog.AccumulateExternalLatency(ctx, time.Since(t0))
+		if err != nil {
+			s.forwardError(ctx, w, r, req, s.HandleError(ctx, w, r, req, state.ProviderId, nil, oidcerr.Wrap(oidcerr.StepClaimsDecode, err)))
+			return
+		}
 	}
 
 	if err = claims.Validate(); err != nil {
@@ -646,7 +685,32 @@ func (s *Strategy) populateMethod(r *http.Request, f flow.Flow, message func(pro
 	return nil
 }
 
+// Config returns the providers of the configuration file and the providers
+// which are managed through the admin API. The secrets of the latter are not
+// included, use Provider to get a provider which can be used to sign in.
+// Providers of the configuration file take precedence over the ones managed
+// through the admin API with the same ID.
 func (s *Strategy) Config(ctx context.Context) (*ConfigurationCollection, error) {
+	c, err := s.staticConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamic, err := s.dynamicProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range dynamic {
+		if slices.ContainsFunc(c.Providers, func(existing Configuration) bool { return existing.ID == p.ID }) {
+			continue
+		}
+		c.Providers = append(c.Providers, p)
+	}
+
+	return c, nil
+}
+
+func (s *Strategy) staticConfig(ctx context.Context) (*ConfigurationCollection, error) {
 	var c ConfigurationCollection
 
 	conf := s.d.Config().SelfServiceStrategy(ctx, string(s.ID())).Config
@@ -660,14 +724,29 @@ func (s *Strategy) Config(ctx context.Context) (*ConfigurationCollection, error)
 	return &c, nil
 }
 
+// Provider returns the provider with the given ID. Providers of the
+// configuration file take precedence over the ones managed through the admin
+// API.
 func (s *Strategy) Provider(ctx context.Context, id string) (Provider, error) {
-	if c, err := s.Config(ctx); err != nil {
+	c, err := s.staticConfig(ctx)
+	if err != nil {
 		return nil, err
-	} else if provider, err := c.Provider(id, s.d); err != nil {
+	}
+
+	if !slices.ContainsFunc(c.Providers, func(p Configuration) bool { return p.ID == id }) {
+		dynamic, err := s.dynamicProvider(ctx, id)
+		if err != nil {
+			return nil, err
+		} else if dynamic != nil {
+			c.Providers = append(c.Providers, *dynamic)
+		}
+	}
+
+	provider, err := c.provider(id, s.d, s.providerTypes)
+	if err != nil {
 		return nil, s.handleUnknownProviderError(err)
-	} else {
-		return provider, nil
 	}
+	return provider, nil
 }
 
 func (s *Strategy) forwardError(ctx context.Context, w http.ResponseWriter, r *http.Request, f flow.Flow, err error) {
@@ -930,12 +1009,13 @@ func (s *Strategy) linkCredentials(ctx context.Context, i *identity.Identity, to
 	} else {
 		creds.Identifiers = append(creds.Identifiers, identity.OIDCUniqueID(provider, subject))
 		conf.Providers = append(conf.Providers, identity.CredentialsOIDCProvider{
-			Subject:             subject,
-			Provider:            provider,
-			InitialAccessToken:  tokens.GetAccessToken(),
-			InitialRefreshToken: tokens.GetRefreshToken(),
-			InitialIDToken:      tokens.GetIDToken(),
-			Organization:        organization,
+			Subject:              subject,
+			Provider:             provider,
+			InitialAccessToken:   tokens.GetAccessToken(),
+			InitialRefreshToken:  tokens.GetRefreshToken(),
+			InitialIDToken:       tokens.GetIDToken(),
+			Organization:         organization,
+			AccessTokenExpiresAt: tokens.GetExpiresAt(),
 		})
 
 		creds.Config, err = json.Marshal(conf)
@@ -965,6 +1045,8 @@ func getAuthRedirectURL(ctx context.Context, provider Provider, req ider, state
 		return c.AuthCodeURL(state, opts...), nil
 	case OAuth1Provider:
 		return p.AuthURL(ctx, state)
+	case AssertionProvider:
+		return p.AuthURL(ctx, state)
 	default:
 		return "", errors.WithStack(herodot.ErrInternalServerError().WithReasonf("The provider %s does not support the OAuth 2.0 or OAuth 1.0 protocol", provider.Config().Provider))
 	}
@@ -988,10 +1070,15 @@ func (s *Strategy) encryptOAuth2Tokens(ctx context.Context, token *oauth2.Token)
 		return nil, err
 	}
 
-	et.RefreshToken, err = s.d.Cipher(ctx).Encrypt(ctx, []byte(token.RefreshToken))
-	if err != nil {
-		return nil, err
+	// An empty refresh token is not encrypted, so that it does not replace
+	// the stored one when the provider does not issue a new one.
+	if token.RefreshToken != "" {
+		et.RefreshToken, err = s.d.Cipher(ctx).Encrypt(ctx, []byte(token.RefreshToken))
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	et.ExpiresAt = token.Expiry.UTC()
 	return et, nil
 }
@@ -433,14 +433,7 @@ func (s *Strategy) ProcessLogin(ctx context.Context, w http.ResponseWriter, r *h
 	}
 
 	sess := session.NewInactiveSession()
-	sess.CompletedLoginForOIDC(
-		s.ID(),
-		provider.Config().AALForClaims(claims),
-		provider.Config().ID,
-		provider.Config().OrganizationID,
-		claims.ACR,
-		claims.AMR,
-	)
+	sess.CompletedLoginForMethod(s.authenticationMethod(provider, claims))
 
 	for _, c := range oidcCredentials.Providers {
 		if c.Subject == claims.Subject && c.Provider == provider.Config().ID {
@@ -450,12 +443,15 @@ func (s *Strategy) ProcessLogin(ctx context.Context, w http.ResponseWriter, r *h
 					return nil, x.WrapWithIdentityIDError(s.HandleError(ctx, w, r, loginFlow, provider.Config().ID, nil, err), i.ID)
 				}
 				if identityChanged {
-					if err := s.d.PrivilegedIdentityPool().UpdateIdentity(ctx, i); err != nil {
+					// The OIDC credentials are written by updateTokens below.
+					if err := s.d.PrivilegedIdentityPool().UpdateIdentity(ctx, i, identity.WithoutCredentialTypes(s.ID())); err != nil {
 						return nil, x.WrapWithIdentityIDError(s.HandleError(ctx, w, r, loginFlow, provider.Config().ID, nil, err), i.ID)
 					}
 				}
 			}
 
+			s.updateTokens(ctx, i, provider.Config().ID, claims.Subject, token)
+
 			if err = s.d.LoginHookExecutor().PostLoginHook(w, r, node.OpenIDConnectGroup, loginFlow, i, sess, provider.Config().ID); err != nil {
 				return nil, x.WrapWithIdentityIDError(s.HandleError(ctx, w, r, loginFlow, provider.Config().ID, nil, err), i.ID)
 			}
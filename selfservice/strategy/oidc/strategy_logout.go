@@ -0,0 +1,239 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/logout"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+)
+
+var _ logout.Strategy = (*Strategy)(nil)
+
+// authenticationMethod returns the authentication method of a session which
+// was authenticated by the provider with the given claims.
+func (s *Strategy) authenticationMethod(provider Provider, claims *Claims) session.AuthenticationMethod {
+	return session.AuthenticationMethod{
+		Method:            s.ID(),
+		AAL:               provider.Config().AALForClaims(claims),
+		Provider:          provider.Config().ID,
+		Organization:      provider.Config().OrganizationID,
+		UpstreamACR:       claims.ACR,
+		UpstreamAMR:       claims.AMR,
+		UpstreamSubject:   claims.Subject,
+		UpstreamSessionID: claims.SessionID,
+	}
+}
+
+// UpstreamLogoutURL returns the end session URL of the provider which
+// authenticated the session most recently, if RP-initiated logout is enabled
+// for it.
+func (s *Strategy) UpstreamLogoutURL(ctx context.Context, sess *session.Session, returnTo *url.URL) (_ *url.URL, err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.oidc.Strategy.UpstreamLogoutURL")
+	defer otelx.End(span, &err)
+
+	for _, method := range slices.Backward(sess.AMR) {
+		if method.Method != s.ID() || method.Provider == "" {
+			continue
+		}
+
+		provider, err := s.Provider(ctx, method.Provider)
+		if err != nil {
+			return nil, err
+		}
+		lp, ok := provider.(LogoutProvider)
+		if !ok || !provider.Config().RPInitiatedLogout {
+			return nil, nil
+		}
+
+		idTokenHint, err := s.idTokenHint(ctx, sess.IdentityID, method.Provider, method.UpstreamSubject)
+		if err != nil {
+			return nil, err
+		}
+		return lp.EndSessionURL(ctx, idTokenHint, returnTo)
+	}
+
+	return nil, nil
+}
+
+// idTokenHint returns the ID token which the provider issued when the
+// credentials were linked, or an empty string if there is none.
+func (s *Strategy) idTokenHint(ctx context.Context, identityID uuid.UUID, providerID, subject string) (string, error) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, identityID)
+	if err != nil {
+		return "", err
+	}
+
+	var conf identity.CredentialsOIDC
+	if _, err := i.ParseCredentials(s.ID(), &conf); errors.Is(err, herodot.ErrNotFound()) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	for _, c := range conf.Providers {
+		if c.Provider != providerID || (subject != "" && c.Subject != subject) || c.InitialIDToken == "" {
+			continue
+		}
+
+		idToken, err := s.d.Cipher(ctx).Decrypt(ctx, c.InitialIDToken)
+		if err != nil {
+			// Imported credentials may contain tokens which were not
+			// encrypted by us. The hint is optional, so it is omitted.
+			return "", nil
+		}
+		return string(idToken), nil
+	}
+
+	return "", nil
+}
+
+// Back-Channel Logout Parameters
+//
+// swagger:parameters performOidcBackChannelLogout
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type performOidcBackChannelLogout struct {
+	// The ID of the OpenID Connect provider.
+	//
+	// in: path
+	// required: true
+	Provider string `json:"provider"`
+
+	// The logout token issued by the provider.
+	//
+	// in: formData
+	// required: true
+	LogoutToken string `json:"logout_token"`
+}
+
+// swagger:route POST /self-service/methods/oidc/backchannel-logout/{provider} frontend performOidcBackChannelLogout
+//
+// # OpenID Connect Back-Channel Logout
+//
+// The OpenID Connect provider calls this endpoint when the user logged out at
+// the provider. All sessions of the user which were authenticated by the
+// provider are revoked. If the logout token contains a `sid` claim, only the
+// sessions of that upstream session are revoked.
+//
+// This endpoint is only available for providers with `backchannel_logout` enabled.
+//
+//	Consumes:
+//	- application/x-www-form-urlencoded
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (s *Strategy) handleBackChannelLogout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// See OpenID Connect Back-Channel Logout 1.0, Section 2.8.
+	w.Header().Set("Cache-Control", "no-store")
+
+	provider, err := s.Provider(ctx, r.PathValue("provider"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	lp, ok := provider.(LogoutProvider)
+	if !ok || !provider.Config().BackChannelLogout {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound().WithReasonf("Provider %q does not support back-channel logout.", provider.Config().ID)))
+		return
+	}
+
+	claims, err := lp.VerifyLogoutToken(ctx, r.PostFormValue("logout_token"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	if err := claims.Validate(s.d.Clock().Now()); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	// A logout token is too old after logoutTokenMaxAge, so it only needs to
+	// be recorded until then.
+	expiresAt := time.Unix(claims.IssuedAt, 0).Add(logoutTokenMaxAge)
+	if err := s.d.OIDCProviderPersister().ConsumeLogoutToken(ctx, provider.Config().ID, claims.JTI, expiresAt); errors.Is(err, sqlcon.ErrUniqueViolation()) {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason("The logout token was already used.")))
+		return
+	} else if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := s.revokeUpstreamSessions(ctx, provider.Config().ID, claims); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// revokeUpstreamSessions revokes the active sessions which were authenticated
+// by the provider for the subject and, if given, the upstream session of the
+// logout token.
+func (s *Strategy) revokeUpstreamSessions(ctx context.Context, providerID string, claims *LogoutTokenClaims) (err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.oidc.Strategy.revokeUpstreamSessions")
+	defer otelx.End(span, &err)
+
+	i, _, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, s.ID(), identity.OIDCUniqueID(providerID, claims.Subject))
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		// The subject never signed in, so there is nothing to revoke.
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	const perPage = 500
+	var revoke []uuid.UUID
+	for page := 1; ; page++ {
+		sessions, _, err := s.d.SessionPersister().ListSessionsByIdentity(ctx, i.ID, new(true), page, perPage, uuid.Nil, session.ExpandNothing)
+		if err != nil {
+			return err
+		}
+		for _, sess := range sessions {
+			if slices.ContainsFunc(sess.AMR, func(m session.AuthenticationMethod) bool {
+				return m.Method == s.ID() && m.Provider == providerID &&
+					(m.UpstreamSubject == "" || m.UpstreamSubject == claims.Subject) &&
+					(claims.SessionID == "" || m.UpstreamSessionID == "" || m.UpstreamSessionID == claims.SessionID)
+			}) {
+				revoke = append(revoke, sess.ID)
+			}
+		}
+		if len(sessions) < perPage {
+			break
+		}
+	}
+
+	if _, err := s.d.SessionPersister().RevokeSessionsByIDs(ctx, revoke); err != nil {
+		return err
+	}
+	for _, id := range revoke {
+		events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionRevoked(ctx, id, i.ID))
+	}
+	return nil
+}
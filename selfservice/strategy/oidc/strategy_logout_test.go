@@ -0,0 +1,196 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/session"
+	"github.com/ory/x/urlx"
+)
+
+func TestStrategyLogout(t *testing.T) {
+	t.Parallel()
+
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	issuer := newLogoutTestIssuer(t, "https://idp.example.org/logout")
+	setProviderConfig(t, conf,
+		oidc.Configuration{Provider: "generic", ID: "logout", ClientID: "client", IssuerURL: issuer.URL, Mapper: "file://./stub/oidc.hydra.jsonnet", RPInitiatedLogout: true, BackChannelLogout: true},
+		oidc.Configuration{Provider: "generic", ID: "no-logout", ClientID: "client", IssuerURL: issuer.URL, Mapper: "file://./stub/oidc.hydra.jsonnet"},
+	)
+	ts, _ := testhelpers.NewKratosServer(t, reg)
+	s := oidc.NewStrategy(reg)
+
+	newIdentity := func(t *testing.T, provider, subject string) *identity.Identity {
+		idToken, err := reg.Cipher(t.Context()).Encrypt(t.Context(), []byte("id-token"))
+		require.NoError(t, err)
+		creds, err := identity.NewCredentialsOIDC(&identity.CredentialsOIDCEncryptedTokens{IDToken: idToken}, provider, subject, "")
+		require.NoError(t, err)
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.SetCredentials(identity.CredentialsTypeOIDC, *creds)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(t.Context(), i))
+		return i
+	}
+	newSession := func(t *testing.T, i *identity.Identity, methods ...session.AuthenticationMethod) *session.Session {
+		req := testhelpers.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil)
+		sess := session.NewInactiveSession()
+		for _, m := range methods {
+			sess.CompletedLoginForMethod(m)
+		}
+		require.NoError(t, reg.SessionManager().ActivateSession(req, sess, i, time.Now().UTC()))
+		require.NoError(t, reg.SessionPersister().UpsertSession(t.Context(), sess))
+		return sess
+	}
+	oidcMethod := func(provider, subject, sid string) session.AuthenticationMethod {
+		return session.AuthenticationMethod{
+			Method:            identity.CredentialsTypeOIDC,
+			AAL:               identity.AuthenticatorAssuranceLevel1,
+			Provider:          provider,
+			UpstreamSubject:   subject,
+			UpstreamSessionID: sid,
+		}
+	}
+	isActive := func(t *testing.T, sess *session.Session) bool {
+		actual, err := reg.SessionPersister().GetSession(t.Context(), sess.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		return actual.IsActive()
+	}
+
+	t.Run("method=UpstreamLogoutURL", func(t *testing.T) {
+		returnTo := urlx.ParseOrPanic("https://www.ory.sh/logged-out")
+
+		t.Run("case=returns the end session URL with the id token hint", func(t *testing.T) {
+			i := newIdentity(t, "logout", "rp-subject")
+			sess := newSession(t, i, oidcMethod("logout", "rp-subject", ""))
+
+			actual, err := s.UpstreamLogoutURL(t.Context(), sess, returnTo)
+			require.NoError(t, err)
+			require.NotNil(t, actual)
+			assert.Equal(t, "https://idp.example.org/logout", actual.Scheme+"://"+actual.Host+actual.Path)
+			assert.Equal(t, "id-token", actual.Query().Get("id_token_hint"))
+			assert.Equal(t, returnTo.String(), actual.Query().Get("post_logout_redirect_uri"))
+		})
+
+		t.Run("case=returns nil if RP-initiated logout is disabled", func(t *testing.T) {
+			i := newIdentity(t, "no-logout", "rp-subject")
+			sess := newSession(t, i, oidcMethod("no-logout", "rp-subject", ""))
+
+			actual, err := s.UpstreamLogoutURL(t.Context(), sess, returnTo)
+			require.NoError(t, err)
+			assert.Nil(t, actual)
+		})
+
+		t.Run("case=returns nil for sessions without OpenID Connect", func(t *testing.T) {
+			sess := testhelpers.CreateSession(t, reg)
+
+			actual, err := s.UpstreamLogoutURL(t.Context(), sess, returnTo)
+			require.NoError(t, err)
+			assert.Nil(t, actual)
+		})
+	})
+
+	t.Run("method=handleBackChannelLogout", func(t *testing.T) {
+		logout := func(t *testing.T, provider string, claims jwt.MapClaims) *http.Response {
+			res, err := ts.Client().PostForm(ts.URL+"/self-service/methods/oidc/backchannel-logout/"+provider, url.Values{
+				"logout_token": {createLogoutToken(t, claims)},
+			})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = res.Body.Close() })
+			return res
+		}
+		newClaims := func(subject, sid string) jwt.MapClaims {
+			claims := jwt.MapClaims{
+				"iss":    issuer.URL,
+				"aud":    "client",
+				"sub":    subject,
+				"iat":    time.Now().Unix(),
+				"jti":    uuid.Must(uuid.NewV4()).String(),
+				"events": map[string]any{"http://schemas.openid.net/event/backchannel-logout": map[string]any{}},
+			}
+			if sid != "" {
+				claims["sid"] = sid
+			}
+			return claims
+		}
+
+		t.Run("case=revokes the sessions of the subject", func(t *testing.T) {
+			i := newIdentity(t, "logout", "bc-subject")
+			upstream := newSession(t, i, oidcMethod("logout", "bc-subject", "sid-1"))
+			other := newSession(t, i, oidcMethod("logout", "bc-subject", "sid-2"))
+			local := newSession(t, i, session.AuthenticationMethod{Method: identity.CredentialsTypePassword, AAL: identity.AuthenticatorAssuranceLevel1})
+
+			res := logout(t, "logout", newClaims("bc-subject", ""))
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Equal(t, "no-store", res.Header.Get("Cache-Control"))
+
+			assert.False(t, isActive(t, upstream))
+			assert.False(t, isActive(t, other))
+			assert.True(t, isActive(t, local), "sessions of other methods must not be revoked")
+		})
+
+		t.Run("case=only revokes the upstream session of the sid", func(t *testing.T) {
+			i := newIdentity(t, "logout", "sid-subject")
+			upstream := newSession(t, i, oidcMethod("logout", "sid-subject", "sid-1"))
+			other := newSession(t, i, oidcMethod("logout", "sid-subject", "sid-2"))
+
+			res := logout(t, "logout", newClaims("sid-subject", "sid-1"))
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+
+			assert.False(t, isActive(t, upstream))
+			assert.True(t, isActive(t, other))
+		})
+
+		t.Run("case=accepts unknown subjects", func(t *testing.T) {
+			res := logout(t, "logout", newClaims("unknown-subject", ""))
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		})
+
+		t.Run("case=rejects an invalid logout token", func(t *testing.T) {
+			i := newIdentity(t, "logout", "invalid-subject")
+			sess := newSession(t, i, oidcMethod("logout", "invalid-subject", ""))
+
+			claims := newClaims("invalid-subject", "")
+			claims["nonce"] = "nonce"
+			res := logout(t, "logout", claims)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+			assert.True(t, isActive(t, sess))
+		})
+
+		t.Run("case=rejects a replayed logout token", func(t *testing.T) {
+			claims := newClaims("replay-subject", "")
+			res := logout(t, "logout", claims)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+
+			i := newIdentity(t, "logout", "replay-subject")
+			sess := newSession(t, i, oidcMethod("logout", "replay-subject", ""))
+
+			res = logout(t, "logout", claims)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+			assert.True(t, isActive(t, sess))
+		})
+
+		t.Run("case=rejects providers without back-channel logout", func(t *testing.T) {
+			i := newIdentity(t, "no-logout", "disabled-subject")
+			sess := newSession(t, i, oidcMethod("no-logout", "disabled-subject", ""))
+
+			res := logout(t, "no-logout", newClaims("disabled-subject", ""))
+			assert.Equal(t, http.StatusNotFound, res.StatusCode)
+			assert.True(t, isActive(t, sess))
+		})
+	})
+}
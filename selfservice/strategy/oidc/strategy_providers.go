@@ -0,0 +1,567 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x/redir"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/otelx"
+	keysetpagination "github.com/ory/x/pagination/keysetpagination_v2"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	RouteAdminProviders = "/oidc/providers"
+	RouteAdminProvider  = RouteAdminProviders + "/{id}"
+)
+
+var providerIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,255}$`)
+
+func (s *Strategy) registerPublicProviderRoutes(r *httprouterx.RouterPublic) {
+	s.d.CSRFHandler().IgnoreGlobs(
+		RouteAdminProviders, RouteAdminProviders+"/*",
+		httprouterx.AdminPrefix+RouteAdminProviders, httprouterx.AdminPrefix+RouteAdminProviders+"/*",
+	)
+
+	r.GET(httprouterx.AdminPrefix+RouteAdminProviders, redir.RedirectToAdminRoute(s.d))
+	r.POST(httprouterx.AdminPrefix+RouteAdminProviders, redir.RedirectToAdminRoute(s.d))
+	r.GET(httprouterx.AdminPrefix+RouteAdminProvider, redir.RedirectToAdminRoute(s.d))
+	r.PUT(httprouterx.AdminPrefix+RouteAdminProvider, redir.RedirectToAdminRoute(s.d))
+	r.DELETE(httprouterx.AdminPrefix+RouteAdminProvider, redir.RedirectToAdminRoute(s.d))
+}
+
+func (s *Strategy) registerAdminProviderRoutes(admin *httprouterx.RouterAdmin) {
+	admin.GET(RouteAdminProviders, s.listOidcProviders)
+	admin.POST(RouteAdminProviders, s.createOidcProvider)
+	admin.GET(RouteAdminProvider, s.getOidcProvider)
+	admin.PUT(RouteAdminProvider, s.updateOidcProvider)
+	admin.DELETE(RouteAdminProvider, s.deleteOidcProvider)
+}
+
+// dynamicProvider returns the provider with the given ID which is managed
+// through the admin API, or nil if there is none.
+func (s *Strategy) dynamicProvider(ctx context.Context, id string) (*Configuration, error) {
+	if s.ID() != identity.CredentialsTypeOIDC {
+		return nil, nil
+	}
+
+	p, err := s.d.OIDCProviderPersister().GetOIDCProvider(ctx, id)
+	if errors.Is(err, sqlcon.ErrNoRows()) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return s.decryptProvider(ctx, p)
+}
+
+// dynamicProviders returns all providers which are managed through the admin
+// API. Their secrets are not decrypted, because the list is only used to
+// render and look up providers. Provider decrypts the secrets of the provider
+// which is used. Providers whose configuration can not be read are skipped, so
+// that a single broken provider does not break all flows.
+func (s *Strategy) dynamicProviders(ctx context.Context) (_ []Configuration, err error) {
+	if s.ID() != identity.CredentialsTypeOIDC {
+		return nil, nil
+	}
+
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.oidc.Strategy.dynamicProviders")
+	defer otelx.End(span, &err)
+
+	var providers []Configuration
+	var opts []keysetpagination.Option
+	for {
+		page, next, err := s.d.OIDCProviderPersister().ListOIDCProviders(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for k := range page {
+			c, err := page[k].configuration()
+			if err != nil {
+				s.d.Logger().WithError(err).WithField("provider", page[k].ProviderID).Error("Skipping an OpenID Connect provider whose configuration can not be read.")
+				continue
+			}
+			c.ClientSecret = ""
+			c.PrivateKey = ""
+			providers = append(providers, *c)
+		}
+		if next.IsLast() {
+			return providers, nil
+		}
+		opts = next.ToOptions()
+	}
+}
+
+// decryptProvider returns the configuration of the stored provider with its
+// secrets decrypted.
+func (s *Strategy) decryptProvider(ctx context.Context, p *DynamicProvider) (*Configuration, error) {
+	c, err := p.configuration()
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range []*string{&c.ClientSecret, &c.PrivateKey} {
+		if *secret == "" {
+			continue
+		}
+		plaintext, err := s.d.Cipher(ctx).Decrypt(ctx, *secret)
+		if err != nil {
+			return nil, err
+		}
+		*secret = string(plaintext)
+	}
+	return c, nil
+}
+
+// configuration returns the stored configuration, whose secrets are still
+// encrypted.
+func (p *DynamicProvider) configuration() (*Configuration, error) {
+	var c Configuration
+	if err := json.Unmarshal(p.Config, &c); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to decode the configuration of OpenID Connect Provider %q.", p.ProviderID).WithDebug(err.Error()))
+	}
+	c.ID = p.ProviderID
+	c.OrganizationID = ""
+	if p.OrganizationID.Valid {
+		c.OrganizationID = p.OrganizationID.UUID.String()
+	}
+	return &c, nil
+}
+
+// OpenID Connect Provider
+//
+// A provider which is managed through the admin API. Secrets are never
+// returned.
+//
+// swagger:model oidcProvider
+type oidcProvider struct {
+	Configuration
+
+	// CreatedAt is the time the provider was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the time the provider was last updated.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newOidcProvider(p *DynamicProvider) (*oidcProvider, error) {
+	c, err := p.configuration()
+	if err != nil {
+		return nil, err
+	}
+	c.ClientSecret = ""
+	c.PrivateKey = ""
+	return &oidcProvider{Configuration: *c, CreatedAt: p.CreatedAt, UpdatedAt: p.UpdatedAt}, nil
+}
+
+// validateProvider checks a provider configuration received through the admin
+// API.
+func (s *Strategy) validateProvider(ctx context.Context, c *Configuration) error {
+	if !providerIDPattern.MatchString(c.ID) {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The provider ID must consist of 1 to 255 letters, digits, dots, dashes, or underscores."))
+	}
+	if _, ok := s.providerTypes[c.Provider]; !ok {
+		return errors.WithStack(herodot.ErrBadRequest().WithReasonf("The provider type %q is not supported.", c.Provider))
+	}
+	if c.ClientID == "" {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The client ID must be set."))
+	}
+	if c.Mapper == "" {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The mapper URL must be set."))
+	}
+	// Providers managed through the API must not read files of the server.
+	if strings.HasPrefix(strings.ToLower(c.Mapper), "file://") {
+		return errors.WithStack(herodot.ErrBadRequest().WithReason("The mapper URL must not be a file URL. Use a base64:// or http(s):// URL instead."))
+	}
+	if c.OrganizationID != "" {
+		if _, err := uuid.FromString(c.OrganizationID); err != nil {
+			return errors.WithStack(herodot.ErrBadRequest().WithReason("The organization ID must be a valid UUID.").WithWrap(err))
+		}
+	}
+
+	static, err := s.staticConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(static.Providers, func(p Configuration) bool { return p.ID == c.ID }) {
+		return errors.WithStack(herodot.ErrConflict().WithReasonf("The provider %q is defined in the configuration file.", c.ID))
+	}
+	return nil
+}
+
+// encryptProvider returns the provider to store for the configuration. Empty
+// secrets are replaced by the ones of the existing provider, if given.
+func (s *Strategy) encryptProvider(ctx context.Context, c Configuration, existing *DynamicProvider) (*DynamicProvider, error) {
+	var previous Configuration
+	if existing != nil {
+		p, err := existing.configuration()
+		if err != nil {
+			return nil, err
+		}
+		previous = *p
+	}
+
+	for _, secret := range []struct{ value, previous *string }{
+		{&c.ClientSecret, &previous.ClientSecret},
+		{&c.PrivateKey, &previous.PrivateKey},
+	} {
+		if *secret.value == "" {
+			*secret.value = *secret.previous
+			continue
+		}
+		ciphertext, err := s.d.Cipher(ctx).Encrypt(ctx, []byte(*secret.value))
+		if err != nil {
+			return nil, err
+		}
+		*secret.value = ciphertext
+	}
+
+	p := &DynamicProvider{ProviderID: c.ID}
+	if c.OrganizationID != "" {
+		p.OrganizationID = uuid.NullUUID{UUID: uuid.FromStringOrNil(c.OrganizationID), Valid: true}
+	}
+
+	// The ID and organization are stored in their own columns.
+	c.ID = ""
+	c.OrganizationID = ""
+	config, err := json.Marshal(c)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	p.Config = config
+	return p, nil
+}
+
+// List OpenID Connect Providers Response
+//
+// swagger:response listOidcProviders
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listOidcProvidersResponse struct {
+	keysetpagination.ResponseHeaders
+
+	// in: body
+	Body []oidcProvider
+}
+
+// List OpenID Connect Providers Parameters
+//
+// swagger:parameters listOidcProviders
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listOidcProvidersParameters struct {
+	keysetpagination.RequestParameters
+}
+
+// swagger:route GET /admin/oidc/providers identity listOidcProviders
+//
+// # List OpenID Connect Providers
+//
+// Lists the OpenID Connect providers which are managed through the admin API,
+// ordered by their ID. Providers of the configuration file are not included.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listOidcProviders
+//	  400: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (s *Strategy) listOidcProviders(w http.ResponseWriter, r *http.Request) {
+	keys := s.d.Config().SecretsPagination(r.Context())
+	opts, err := keysetpagination.ParseQueryParams(keys, r.URL.Query())
+	if err != nil {
+		s.d.Writer().WriteErrorCode(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	stored, nextPage, err := s.d.OIDCProviderPersister().ListOIDCProviders(r.Context(), opts)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	providers := make([]oidcProvider, 0, len(stored))
+	for k := range stored {
+		p, err := newOidcProvider(&stored[k])
+		if err != nil {
+			s.d.Writer().WriteError(w, r, err)
+			return
+		}
+		providers = append(providers, *p)
+	}
+
+	u := *r.URL
+	keysetpagination.SetLinkHeader(w, keys, &u, nextPage)
+	s.d.Writer().Write(w, r, providers)
+}
+
+// Get OpenID Connect Provider Parameters
+//
+// swagger:parameters getOidcProvider deleteOidcProvider
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type oidcProviderIDParameters struct {
+	// ID is the provider's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/oidc/providers/{id} identity getOidcProvider
+//
+// # Get an OpenID Connect Provider
+//
+// Returns an OpenID Connect provider which is managed through the admin API.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: oidcProvider
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-medium
+func (s *Strategy) getOidcProvider(w http.ResponseWriter, r *http.Request) {
+	stored, err := s.d.OIDCProviderPersister().GetOIDCProvider(r.Context(), r.PathValue("id"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	p, err := newOidcProvider(stored)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	s.d.Writer().Write(w, r, p)
+}
+
+// Create OpenID Connect Provider Parameters
+//
+// swagger:parameters createOidcProvider
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createOidcProviderParameters struct {
+	// in: body
+	// required: true
+	Body Configuration
+}
+
+// swagger:route POST /admin/oidc/providers identity createOidcProvider
+//
+// # Create an OpenID Connect Provider
+//
+// Creates an OpenID Connect provider. The provider can be used for sign in
+// right away, without reloading the configuration. If `organization_id` is
+// set, the provider belongs to that organization.
+//
+// The `client_secret` and `apple_private_key` are encrypted at rest and never
+// returned.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: oidcProvider
+//	  400: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (s *Strategy) createOidcProvider(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var c Configuration
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&c); err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+	if err := s.validateProvider(ctx, &c); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	stored, err := s.encryptProvider(ctx, c, nil)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	if err := s.d.OIDCProviderPersister().CreateOIDCProvider(ctx, stored); errors.Is(err, sqlcon.ErrUniqueViolation()) {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict().WithReasonf("The provider %q exists already.", c.ID)))
+		return
+	} else if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	p, err := newOidcProvider(stored)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	s.d.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(s.d.Config().SelfAdminURL(ctx), httprouterx.AdminPrefix, RouteAdminProviders, c.ID).String(),
+		p,
+	)
+}
+
+// Update OpenID Connect Provider Parameters
+//
+// swagger:parameters updateOidcProvider
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type updateOidcProviderParameters struct {
+	// ID is the provider's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: body
+	// required: true
+	Body Configuration
+}
+
+// swagger:route PUT /admin/oidc/providers/{id} identity updateOidcProvider
+//
+// # Update an OpenID Connect Provider
+//
+// Replaces the configuration of an OpenID Connect provider. If the
+// `client_secret` or `apple_private_key` is empty, the stored one is kept.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: oidcProvider
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (s *Strategy) updateOidcProvider(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	var c Configuration
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&c); err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error())))
+		return
+	}
+	if c.ID == "" {
+		c.ID = id
+	} else if c.ID != id {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason("The provider ID can not be changed.")))
+		return
+	}
+
+	existing, err := s.d.OIDCProviderPersister().GetOIDCProvider(ctx, id)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	if err := s.validateProvider(ctx, &c); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	stored, err := s.encryptProvider(ctx, c, existing)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	stored.CreatedAt = existing.CreatedAt
+	if err := s.d.OIDCProviderPersister().UpdateOIDCProvider(ctx, stored); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	p, err := newOidcProvider(stored)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	s.d.Writer().Write(w, r, p)
+}
+
+// swagger:route DELETE /admin/oidc/providers/{id} identity deleteOidcProvider
+//
+// # Delete an OpenID Connect Provider
+//
+// Deletes an OpenID Connect provider which is managed through the admin API.
+// Identities keep their credentials of the provider, but can no longer sign in
+// with it.
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (s *Strategy) deleteOidcProvider(w http.ResponseWriter, r *http.Request) {
+	if err := s.d.OIDCProviderPersister().DeleteOIDCProvider(r.Context(), r.PathValue("id")); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,224 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+)
+
+func TestStrategyProviders(t *testing.T) {
+	t.Parallel()
+
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	setProviderConfig(t, conf,
+		oidc.Configuration{Provider: "generic", ID: "static", ClientID: "client", ClientSecret: "secret", IssuerURL: "https://static.example.com", Mapper: "file://./stub/oidc.hydra.jsonnet"},
+	)
+	_, admin := testhelpers.NewKratosServer(t, reg)
+
+	s, err := reg.AllLoginStrategies().Strategy(identity.CredentialsTypeOIDC)
+	require.NoError(t, err)
+	strategy := s.(*oidc.Strategy)
+
+	do := func(t *testing.T, method, path string, body any) (*http.Response, []byte) {
+		var b bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&b).Encode(body))
+		}
+		req, err := http.NewRequestWithContext(t.Context(), method, admin.URL+"/admin"+path, &b)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		res, err := admin.Client().Do(req)
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		actual, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res, actual
+	}
+	newProvider := func(id string) map[string]any {
+		return map[string]any{
+			"id":            id,
+			"provider":      "generic",
+			"client_id":     "client-" + id,
+			"client_secret": "secret-" + id,
+			"issuer_url":    "https://" + id + ".example.com",
+			"mapper_url":    "base64://bG9jYWwgY2xhaW1zID0gc3RkLmV4dFZhcignY2xhaW1zJyk7IHsgaWRlbnRpdHk6IHsgdHJhaXRzOiB7IGVtYWlsOiBjbGFpbXMuZW1haWwgfSB9IH0=",
+		}
+	}
+
+	t.Run("case=creates, updates, and deletes a provider", func(t *testing.T) {
+		res, body := do(t, http.MethodPost, oidc.RouteAdminProviders, newProvider("crud"))
+		require.Equal(t, http.StatusCreated, res.StatusCode, "%s", body)
+		assert.Contains(t, res.Header.Get("Location"), "/admin/oidc/providers/crud")
+		assert.Equal(t, "crud", gjson.GetBytes(body, "id").String())
+		assert.Equal(t, "client-crud", gjson.GetBytes(body, "client_id").String())
+		assert.Empty(t, gjson.GetBytes(body, "client_secret").String(), "%s", body)
+		assert.True(t, gjson.GetBytes(body, "created_at").Exists())
+
+		res, body = do(t, http.MethodGet, oidc.RouteAdminProviders+"/crud", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Equal(t, "client-crud", gjson.GetBytes(body, "client_id").String())
+		assert.Empty(t, gjson.GetBytes(body, "client_secret").String(), "%s", body)
+
+		update := newProvider("")
+		update["client_id"] = "updated-client"
+		delete(update, "client_secret")
+		res, body = do(t, http.MethodPut, oidc.RouteAdminProviders+"/crud", update)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Equal(t, "crud", gjson.GetBytes(body, "id").String())
+		assert.Equal(t, "updated-client", gjson.GetBytes(body, "client_id").String())
+
+		p, err := strategy.Provider(t.Context(), "crud")
+		require.NoError(t, err)
+		assert.Equal(t, "updated-client", p.Config().ClientID)
+		assert.Equal(t, "secret-crud", p.Config().ClientSecret, "omitting the secret keeps the stored one")
+
+		res, body = do(t, http.MethodDelete, oidc.RouteAdminProviders+"/crud", nil)
+		require.Equal(t, http.StatusNoContent, res.StatusCode, "%s", body)
+
+		res, body = do(t, http.MethodGet, oidc.RouteAdminProviders+"/crud", nil)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "%s", body)
+
+		_, err = strategy.Provider(t.Context(), "crud")
+		require.ErrorIs(t, err, herodot.ErrNotFound())
+	})
+
+	t.Run("case=encrypts secrets at rest", func(t *testing.T) {
+		res, body := do(t, http.MethodPost, oidc.RouteAdminProviders, newProvider("encrypted"))
+		require.Equal(t, http.StatusCreated, res.StatusCode, "%s", body)
+
+		stored, err := reg.OIDCProviderPersister().GetOIDCProvider(t.Context(), "encrypted")
+		require.NoError(t, err)
+		assert.NotContains(t, string(stored.Config), "secret-encrypted")
+
+		ciphertext := gjson.GetBytes(stored.Config, "client_secret").String()
+		plaintext, err := reg.Cipher(t.Context()).Decrypt(t.Context(), ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "secret-encrypted", string(plaintext))
+	})
+
+	t.Run("case=merges the providers with the configuration file", func(t *testing.T) {
+		organizationID := uuid.Must(uuid.NewV4())
+		p := newProvider("merged")
+		p["organization_id"] = organizationID.String()
+		res, body := do(t, http.MethodPost, oidc.RouteAdminProviders, p)
+		require.Equal(t, http.StatusCreated, res.StatusCode, "%s", body)
+		assert.Equal(t, organizationID.String(), gjson.GetBytes(body, "organization_id").String())
+
+		provider, err := strategy.Provider(t.Context(), "merged")
+		require.NoError(t, err)
+		assert.Equal(t, organizationID.String(), provider.Config().OrganizationID)
+
+		provider, err = strategy.Provider(t.Context(), "static")
+		require.NoError(t, err)
+		assert.Equal(t, "client", provider.Config().ClientID)
+
+		c, err := strategy.Config(t.Context())
+		require.NoError(t, err)
+		var ids []string
+		for _, p := range c.Providers {
+			ids = append(ids, p.ID)
+		}
+		assert.Contains(t, ids, "static")
+		assert.Contains(t, ids, "merged")
+	})
+
+	t.Run("case=prefers the providers of the configuration file and skips broken providers", func(t *testing.T) {
+		for id, config := range map[string]string{
+			"static":        `{"provider":"generic","client_id":"shadowed"}`,
+			"broken":        `{"provider":1}`,
+			"undecryptable": `{"provider":"generic","client_id":"client","client_secret":"not-encrypted"}`,
+		} {
+			require.NoError(t, reg.OIDCProviderPersister().CreateOIDCProvider(t.Context(), &oidc.DynamicProvider{ProviderID: id, Config: []byte(config)}))
+			t.Cleanup(func() { _ = reg.OIDCProviderPersister().DeleteOIDCProvider(context.Background(), id) })
+		}
+
+		c, err := strategy.Config(t.Context())
+		require.NoError(t, err)
+		var static []string
+		var ids []string
+		for _, p := range c.Providers {
+			ids = append(ids, p.ID)
+			if p.ID == "static" {
+				static = append(static, p.ClientID)
+			}
+		}
+		assert.Equal(t, []string{"client"}, static)
+		assert.NotContains(t, ids, "broken")
+		assert.Contains(t, ids, "undecryptable", "secrets are only decrypted when the provider is used")
+
+		_, err = strategy.Provider(t.Context(), "undecryptable")
+		require.Error(t, err)
+	})
+
+	t.Run("case=lists the providers", func(t *testing.T) {
+		for _, id := range []string{"list-a", "list-b"} {
+			res, body := do(t, http.MethodPost, oidc.RouteAdminProviders, newProvider(id))
+			require.Equal(t, http.StatusCreated, res.StatusCode, "%s", body)
+		}
+
+		res, body := do(t, http.MethodGet, oidc.RouteAdminProviders+"?page_size=1000", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		ids := gjson.GetBytes(body, "#.id").String()
+		assert.Contains(t, ids, `"list-a"`)
+		assert.Contains(t, ids, `"list-b"`)
+		assert.NotContains(t, ids, `"static"`, "providers of the configuration file are not listed")
+		for _, secret := range gjson.GetBytes(body, "#.client_secret").Array() {
+			assert.Empty(t, secret.String())
+		}
+	})
+
+	t.Run("case=rejects conflicting providers", func(t *testing.T) {
+		res, body := do(t, http.MethodPost, oidc.RouteAdminProviders, newProvider("static"))
+		assert.Equal(t, http.StatusConflict, res.StatusCode, "%s", body)
+
+		res, body = do(t, http.MethodPost, oidc.RouteAdminProviders, newProvider("duplicate"))
+		require.Equal(t, http.StatusCreated, res.StatusCode, "%s", body)
+		res, body = do(t, http.MethodPost, oidc.RouteAdminProviders, newProvider("duplicate"))
+		assert.Equal(t, http.StatusConflict, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=rejects invalid providers", func(t *testing.T) {
+		for name, mod := range map[string]func(map[string]any){
+			"invalid id":           func(p map[string]any) { p["id"] = "invalid/id" },
+			"unknown type":         func(p map[string]any) { p["provider"] = "unknown" },
+			"saml type":            func(p map[string]any) { p["provider"] = "saml" },
+			"missing client id":    func(p map[string]any) { delete(p, "client_id") },
+			"missing mapper":       func(p map[string]any) { delete(p, "mapper_url") },
+			"file mapper":          func(p map[string]any) { p["mapper_url"] = "file:///etc/passwd" },
+			"invalid organization": func(p map[string]any) { p["organization_id"] = "not-a-uuid" },
+			"unknown field":        func(p map[string]any) { p["unknown"] = true },
+		} {
+			t.Run("case="+name, func(t *testing.T) {
+				p := newProvider("invalid")
+				mod(p)
+				res, body := do(t, http.MethodPost, oidc.RouteAdminProviders, p)
+				assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+			})
+		}
+
+		res, body := do(t, http.MethodPut, oidc.RouteAdminProviders+"/unknown", newProvider("unknown"))
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "%s", body)
+
+		res, body = do(t, http.MethodPost, oidc.RouteAdminProviders, newProvider("renamed"))
+		require.Equal(t, http.StatusCreated, res.StatusCode, "%s", body)
+		res, body = do(t, http.MethodPut, oidc.RouteAdminProviders+"/renamed", newProvider("other"))
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+	})
+}
@@ -26,7 +26,6 @@ import (
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/registration"
-	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/x"
 	"github.com/ory/kratos/x/events"
@@ -380,14 +379,7 @@ func (s *Strategy) processRegistration(ctx context.Context, w http.ResponseWrite
 	}
 
 	i.SetCredentials(s.ID(), *creds)
-	if err := s.d.RegistrationExecutor().PostRegistrationHook(w, r, rf, i, session.AuthenticationMethod{
-		Method:       s.ID(),
-		AAL:          provider.Config().AALForClaims(claims),
-		Provider:     provider.Config().ID,
-		Organization: provider.Config().OrganizationID,
-		UpstreamACR:  claims.ACR,
-		UpstreamAMR:  claims.AMR,
-	}); err != nil {
+	if err := s.d.RegistrationExecutor().PostRegistrationHook(w, r, rf, i, s.authenticationMethod(provider, claims)); err != nil {
 		return nil, s.HandleError(ctx, w, r, rf, provider.Config().ID, i.Traits, err)
 	}
 
@@ -411,7 +403,7 @@ func (s *Strategy) EvaluateClaimsMapper(ctx context.Context, claims *Claims, pro
 
 	defer func() {
 		if err != nil {
-			trace.SpanFromContext(ctx).AddEvent(events.NewJsonnetMappingFailed(
+			events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewJsonnetMappingFailed(
 				ctx, err, jsonClaims.Bytes(), evaluated, provider.Config().Provider, s.ID().String(),
 			))
 		}
@@ -26,6 +26,7 @@ import (
 	"github.com/ory/x/sqlxx"
 
 	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/courier/template"
 	oidcv1 "github.com/ory/kratos/gen/oidc/v1"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow"
@@ -101,7 +102,7 @@ func (s *Strategy) linkedProviders(conf *ConfigurationCollection, confidential *
 
 	var result []Provider
 	for _, p := range available.Providers {
-		prov, err := conf.Provider(p.Provider, s.d)
+		prov, err := conf.provider(p.Provider, s.d, s.providerTypes)
 		if errors.Is(err, herodot.ErrNotFound()) {
 			continue
 		} else if err != nil {
@@ -133,7 +134,7 @@ func (s *Strategy) linkableProviders(conf *ConfigurationCollection, confidential
 		}
 
 		if !found {
-			prov, err := conf.Provider(p.ID, s.d)
+			prov, err := conf.provider(p.ID, s.d, s.providerTypes)
 			if err != nil {
 				return nil, err
 			}
@@ -512,14 +513,7 @@ func (s *Strategy) linkProvider(ctx context.Context, w http.ResponseWriter, r *h
 	if err := s.d.SessionManager().SessionAddAuthenticationMethods(
 		ctx,
 		ctxUpdate.Session.ID,
-		session.AuthenticationMethod{
-			Method:       s.ID(),
-			AAL:          provider.Config().AALForClaims(claims),
-			Provider:     provider.Config().ID,
-			Organization: provider.Config().OrganizationID,
-			UpstreamACR:  claims.ACR,
-			UpstreamAMR:  claims.AMR,
-		}); err != nil {
+		s.authenticationMethod(provider, claims)); err != nil {
 		return s.handleSettingsError(ctx, w, r, ctxUpdate, p, err)
 	}
 
@@ -595,6 +589,7 @@ func (s *Strategy) unlinkProvider(ctx context.Context, w http.ResponseWriter, r
 	}
 
 	i.Credentials[s.ID()] = *creds
+	ctxUpdate.AddSecurityNotification(identity.SecurityNotification{TemplateType: template.TypeOIDCProviderUnlinked, Provider: p.Unlink})
 	if err := s.d.SettingsHookExecutor().PostSettingsHook(ctx, w, r, s.SettingsStrategyID(), ctxUpdate, i, settings.WithCallback(func(ctxUpdate *settings.UpdateContext) error {
 		// Credential population is done by PostSettingsHook on ctxUpdate.Session.Identity
 		return s.PopulateSettingsMethod(ctx, r, ctxUpdate.Session.Identity, ctxUpdate.Flow)
@@ -0,0 +1,273 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/x/otelx"
+)
+
+// accessTokenLeeway is the minimum remaining lifetime of a stored access token
+// for it to be returned without refreshing it first.
+const accessTokenLeeway = time.Minute
+
+// updateTokens stores the tokens which the provider issued on login. The user
+// is signed in already and the tokens are only used to call the provider on
+// their behalf, so errors are logged, not returned.
+func (s *Strategy) updateTokens(ctx context.Context, i *identity.Identity, providerID, subject string, tokens *identity.CredentialsOIDCEncryptedTokens) {
+	// OAuth 1.0 and SAML providers do not issue OAuth 2.0 tokens.
+	if tokens.GetAccessToken() == "" {
+		return
+	}
+
+	err := s.d.PrivilegedIdentityPool().UpdateCredentialsConfig(ctx, i.ID, s.ID(),
+		identity.UpdateConfig(func(conf *identity.CredentialsOIDC) error {
+			for k := range conf.Providers {
+				if conf.Providers[k].Provider == providerID && conf.Providers[k].Subject == subject {
+					conf.Providers[k].UpdateTokens(tokens)
+				}
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		s.d.Logger().WithError(err).WithField("identity_id", i.ID).WithField("provider", providerID).
+			Warn("Unable to store the tokens issued by the OpenID Connect provider. The login succeeded but the stored tokens are outdated.")
+	}
+}
+
+// Upstream Access Token
+//
+// swagger:model identityOidcAccessToken
+type UpstreamAccessToken struct {
+	// The access token issued by the OpenID Connect provider.
+	//
+	// required: true
+	AccessToken string `json:"access_token"`
+
+	// The time at which the access token expires. It is not set if the
+	// provider did not report an expiry.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Get Identity OpenID Connect Access Token Parameters
+//
+// swagger:parameters getIdentityOidcAccessToken
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getIdentityOidcAccessToken struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// The ID of the OpenID Connect provider.
+	//
+	// required: true
+	// in: path
+	Provider string `json:"provider"`
+
+	// The subject of the identity at the provider. It is required if the
+	// identity linked more than one account of the provider.
+	//
+	// in: query
+	Subject string `json:"subject"`
+}
+
+// swagger:route GET /admin/identities/{id}/credentials/oidc/providers/{provider}/token identity getIdentityOidcAccessToken
+//
+// # Get an Identity's Upstream Access Token
+//
+// Returns a currently valid access token which the OpenID Connect provider
+// issued for the identity. If the stored access token has expired, it is
+// refreshed using the stored refresh token first.
+//
+// If the identity linked more than one account of the provider, the account
+// must be selected with the subject query parameter.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: identityOidcAccessToken
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  502: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (s *Strategy) getIdentityOidcAccessToken(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.FromString(r.PathValue("id"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason("The identity ID is not a valid UUID.").WithWrap(err)))
+		return
+	}
+
+	token, err := s.upstreamAccessToken(r.Context(), id, r.PathValue("provider"), r.URL.Query().Get("subject"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	s.d.Writer().Write(w, r, token)
+}
+
+// upstreamAccessToken returns the stored access token of the identity for the
+// provider, refreshing it if it expired. The subject selects the account of
+// the provider and may be empty if the identity linked only one.
+func (s *Strategy) upstreamAccessToken(ctx context.Context, identityID uuid.UUID, providerID, subject string) (_ *UpstreamAccessToken, err error) {
+	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.oidc.Strategy.upstreamAccessToken")
+	defer otelx.End(span, &err)
+
+	provider, err := s.Provider(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	op, ok := provider.(OAuth2Provider)
+	if !ok {
+		return nil, errors.WithStack(herodot.ErrNotFound().WithReasonf("Provider %q does not issue OAuth 2.0 access tokens.", providerID))
+	}
+
+	stored, err := s.storedUpstreamTokens(ctx, identityID, providerID, subject)
+	if err != nil {
+		return nil, err
+	}
+	if token, err := s.validUpstreamAccessToken(ctx, stored); err != nil || token != nil {
+		return token, err
+	}
+
+	// Refresh tokens may only be used once if the provider rotates them, so
+	// concurrent requests for the same account wait for a single refresh.
+	key := identityID.String() + " " + providerID + " " + stored.Subject
+	token, err, _ := s.tokenRefreshes.Do(key, func() (any, error) {
+		return s.refreshUpstreamAccessToken(context.WithoutCancel(ctx), op, identityID, providerID, stored.Subject)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return token.(*UpstreamAccessToken), nil
+}
+
+// refreshUpstreamAccessToken refreshes the access token of the identity for
+// the provider account and stores the new tokens. The tokens are read again
+// first, because another request may have refreshed them in the meantime.
+func (s *Strategy) refreshUpstreamAccessToken(ctx context.Context, op OAuth2Provider, identityID uuid.UUID, providerID, subject string) (*UpstreamAccessToken, error) {
+	stored, err := s.storedUpstreamTokens(ctx, identityID, providerID, subject)
+	if err != nil {
+		return nil, err
+	}
+	if token, err := s.validUpstreamAccessToken(ctx, stored); err != nil || token != nil {
+		return token, err
+	}
+
+	if stored.InitialRefreshToken == "" {
+		return nil, errors.WithStack(herodot.ErrConflict().WithReasonf("The access token of provider %q has expired and no refresh token is stored. The user has to sign in again.", providerID))
+	}
+	refreshToken, err := s.d.Cipher(ctx).Decrypt(ctx, stored.InitialRefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := op.OAuth2(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := c.TokenSource(context.WithValue(ctx, oauth2.HTTPClient, s.d.HTTPClient(ctx).HTTPClient), &oauth2.Token{RefreshToken: string(refreshToken)}).Token()
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrUpstreamError().WithReasonf("Unable to refresh the access token of provider %q.", providerID).WithDebug(err.Error()).WithWrap(err))
+	}
+
+	tokens, err := s.encryptOAuth2Tokens(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.d.PrivilegedIdentityPool().UpdateCredentialsConfig(ctx, identityID, s.ID(),
+		identity.UpdateConfig(func(conf *identity.CredentialsOIDC) error {
+			for k := range conf.Providers {
+				// If the refresh token changed, another instance or a login
+				// stored newer tokens already.
+				if conf.Providers[k].Provider == stored.Provider && conf.Providers[k].Subject == stored.Subject &&
+					conf.Providers[k].InitialRefreshToken == stored.InitialRefreshToken {
+					conf.Providers[k].UpdateTokens(tokens)
+				}
+			}
+			return nil
+		}),
+	); err != nil {
+		return nil, err
+	}
+
+	return newUpstreamAccessToken(token.AccessToken, tokens.ExpiresAt), nil
+}
+
+// storedUpstreamTokens returns the stored credentials of the identity for the
+// provider account. Without a subject, the identity must have linked exactly
+// one account of the provider.
+func (s *Strategy) storedUpstreamTokens(ctx context.Context, identityID uuid.UUID, providerID, subject string) (*identity.CredentialsOIDCProvider, error) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, identityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var conf identity.CredentialsOIDC
+	if _, err := i.ParseCredentials(s.ID(), &conf); err != nil {
+		return nil, err
+	}
+
+	var stored *identity.CredentialsOIDCProvider
+	for k := range conf.Providers {
+		if conf.Providers[k].Provider != providerID || (subject != "" && conf.Providers[k].Subject != subject) {
+			continue
+		}
+		if stored != nil {
+			return nil, errors.WithStack(herodot.ErrConflict().WithReasonf("The identity has linked more than one account of provider %q. Select the account with the subject query parameter.", providerID))
+		}
+		stored = &conf.Providers[k]
+	}
+	if stored == nil {
+		return nil, errors.WithStack(herodot.ErrNotFound().WithReasonf("The identity has no credentials for provider %q.", providerID))
+	}
+	return stored, nil
+}
+
+// validUpstreamAccessToken returns the stored access token if it is still
+// valid, and nil if it has to be refreshed.
+func (s *Strategy) validUpstreamAccessToken(ctx context.Context, stored *identity.CredentialsOIDCProvider) (*UpstreamAccessToken, error) {
+	if stored.InitialAccessToken == "" || (!stored.AccessTokenExpiresAt.IsZero() && !stored.AccessTokenExpiresAt.After(s.d.Clock().Now().Add(accessTokenLeeway))) {
+		return nil, nil
+	}
+	accessToken, err := s.d.Cipher(ctx).Decrypt(ctx, stored.InitialAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	return newUpstreamAccessToken(string(accessToken), stored.AccessTokenExpiresAt), nil
+}
+
+func newUpstreamAccessToken(accessToken string, expiresAt time.Time) *UpstreamAccessToken {
+	t := &UpstreamAccessToken{AccessToken: accessToken}
+	if !expiresAt.IsZero() {
+		t.ExpiresAt = &expiresAt
+	}
+	return t
+}
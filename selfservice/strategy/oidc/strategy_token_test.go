@@ -0,0 +1,224 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/pkg"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+)
+
+func TestStrategyAccessToken(t *testing.T) {
+	t.Parallel()
+
+	var refreshes atomic.Int32
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			refreshes.Add(1)
+			require.NoError(t, r.ParseForm())
+			if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != "refresh-token" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid_grant"})
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"access_token":  "refreshed-access-token",
+				"refresh_token": "rotated-refresh-token",
+				"token_type":    "bearer",
+				"expires_in":    3600,
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"issuer":                 "http://" + r.Host,
+				"authorization_endpoint": "http://" + r.Host + "/auth",
+				"token_endpoint":         "http://" + r.Host + "/token",
+				"jwks_uri":               "http://" + r.Host + "/jwks",
+			})
+		}
+	}))
+	t.Cleanup(issuer.Close)
+
+	conf, reg := pkg.NewFastRegistryWithMocks(t)
+	setProviderConfig(t, conf,
+		oidc.Configuration{Provider: "generic", ID: "valid", ClientID: "client", ClientSecret: "secret", IssuerURL: issuer.URL, Mapper: "file://./stub/oidc.hydra.jsonnet"},
+	)
+	_, admin := testhelpers.NewKratosServer(t, reg)
+
+	encrypt := func(t *testing.T, token string) string {
+		if token == "" {
+			return ""
+		}
+		ct, err := reg.Cipher(t.Context()).Encrypt(t.Context(), []byte(token))
+		require.NoError(t, err)
+		return ct
+	}
+	newIdentity := func(t *testing.T, accessToken, refreshToken string, expiresAt time.Time) *identity.Identity {
+		creds, err := identity.NewCredentialsOIDC(&identity.CredentialsOIDCEncryptedTokens{
+			AccessToken:  encrypt(t, accessToken),
+			RefreshToken: encrypt(t, refreshToken),
+			ExpiresAt:    expiresAt,
+		}, "valid", testhelpers.RandomEmail(), "")
+		require.NoError(t, err)
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.SetCredentials(identity.CredentialsTypeOIDC, *creds)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(t.Context(), i))
+		return i
+	}
+	getToken := func(t *testing.T, id, provider string) (*http.Response, []byte) {
+		res, err := admin.Client().Get(admin.URL + "/admin/identities/" + id + "/credentials/oidc/providers/" + provider + "/token")
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res, body
+	}
+	storedTokens := func(t *testing.T, i *identity.Identity) (accessToken, refreshToken string) {
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(t.Context(), i.ID)
+		require.NoError(t, err)
+		var conf identity.CredentialsOIDC
+		_, err = actual.ParseCredentials(identity.CredentialsTypeOIDC, &conf)
+		require.NoError(t, err)
+		require.Len(t, conf.Providers, 1)
+
+		at, err := reg.Cipher(t.Context()).Decrypt(t.Context(), conf.Providers[0].InitialAccessToken)
+		require.NoError(t, err)
+		rt, err := reg.Cipher(t.Context()).Decrypt(t.Context(), conf.Providers[0].InitialRefreshToken)
+		require.NoError(t, err)
+		return string(at), string(rt)
+	}
+
+	t.Run("case=returns the stored access token if it is valid", func(t *testing.T) {
+		before := refreshes.Load()
+		i := newIdentity(t, "access-token", "refresh-token", time.Now().Add(time.Hour))
+
+		res, body := getToken(t, i.ID.String(), "valid")
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Equal(t, "no-store", res.Header.Get("Cache-Control"))
+		assert.Equal(t, "access-token", gjson.GetBytes(body, "access_token").String())
+		assert.True(t, gjson.GetBytes(body, "expires_at").Exists())
+		assert.Equal(t, before, refreshes.Load())
+	})
+
+	t.Run("case=refreshes an expired access token", func(t *testing.T) {
+		i := newIdentity(t, "access-token", "refresh-token", time.Now().Add(-time.Hour))
+
+		res, body := getToken(t, i.ID.String(), "valid")
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Equal(t, "refreshed-access-token", gjson.GetBytes(body, "access_token").String())
+		assert.WithinDuration(t, time.Now().Add(time.Hour), gjson.GetBytes(body, "expires_at").Time(), time.Minute)
+
+		accessToken, refreshToken := storedTokens(t, i)
+		assert.Equal(t, "refreshed-access-token", accessToken)
+		assert.Equal(t, "rotated-refresh-token", refreshToken)
+	})
+
+	t.Run("case=refreshes concurrent requests once", func(t *testing.T) {
+		before := refreshes.Load()
+		i := newIdentity(t, "access-token", "refresh-token", time.Now().Add(-time.Hour))
+
+		var eg errgroup.Group
+		for range 10 {
+			eg.Go(func() error {
+				res, err := admin.Client().Get(admin.URL + "/admin/identities/" + i.ID.String() + "/credentials/oidc/providers/valid/token")
+				if err != nil {
+					return err
+				}
+				defer func() { _ = res.Body.Close() }()
+				body, err := io.ReadAll(res.Body)
+				if err != nil {
+					return err
+				}
+				if res.StatusCode != http.StatusOK {
+					return errors.Errorf("unexpected status code %d: %s", res.StatusCode, body)
+				}
+				if token := gjson.GetBytes(body, "access_token").String(); token != "refreshed-access-token" {
+					return errors.Errorf("unexpected access token %q", token)
+				}
+				return nil
+			})
+		}
+		require.NoError(t, eg.Wait())
+		assert.Equal(t, before+1, refreshes.Load())
+	})
+
+	t.Run("case=selects the account by subject", func(t *testing.T) {
+		subject, otherSubject := testhelpers.RandomEmail(), testhelpers.RandomEmail()
+		conf, err := json.Marshal(identity.CredentialsOIDC{Providers: []identity.CredentialsOIDCProvider{
+			{Provider: "valid", Subject: subject, InitialAccessToken: encrypt(t, "access-token"), AccessTokenExpiresAt: time.Now().Add(time.Hour)},
+			{Provider: "valid", Subject: otherSubject, InitialAccessToken: encrypt(t, "other-access-token"), AccessTokenExpiresAt: time.Now().Add(time.Hour)},
+		}})
+		require.NoError(t, err)
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.SetCredentials(identity.CredentialsTypeOIDC, identity.Credentials{
+			Type:        identity.CredentialsTypeOIDC,
+			Identifiers: []string{identity.OIDCUniqueID("valid", subject), identity.OIDCUniqueID("valid", otherSubject)},
+			Config:      conf,
+		})
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(t.Context(), i))
+
+		res, body := getToken(t, i.ID.String(), "valid")
+		assert.Equal(t, http.StatusConflict, res.StatusCode, "%s", body)
+
+		res, body = getToken(t, i.ID.String(), "valid?subject="+url.QueryEscape(otherSubject))
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Equal(t, "other-access-token", gjson.GetBytes(body, "access_token").String())
+
+		res, body = getToken(t, i.ID.String(), "valid?subject="+url.QueryEscape(subject))
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Equal(t, "access-token", gjson.GetBytes(body, "access_token").String())
+
+		res, body = getToken(t, i.ID.String(), "valid?subject=unknown-subject")
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=fails if the access token expired and there is no refresh token", func(t *testing.T) {
+		i := newIdentity(t, "access-token", "", time.Now().Add(-time.Hour))
+
+		res, body := getToken(t, i.ID.String(), "valid")
+		assert.Equal(t, http.StatusConflict, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=fails if the provider rejects the refresh token", func(t *testing.T) {
+		i := newIdentity(t, "access-token", "revoked-refresh-token", time.Now().Add(-time.Hour))
+
+		res, body := getToken(t, i.ID.String(), "valid")
+		assert.Equal(t, http.StatusBadGateway, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=fails for unknown providers", func(t *testing.T) {
+		i := newIdentity(t, "access-token", "refresh-token", time.Now().Add(time.Hour))
+
+		res, body := getToken(t, i.ID.String(), "unknown")
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=fails for identities without OpenID Connect credentials", func(t *testing.T) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(t.Context(), i))
+
+		res, body := getToken(t, i.ID.String(), "valid")
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "%s", body)
+	})
+}
@@ -75,8 +75,13 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	}
 
 	identifier := cmp.Or(p.Identifier, p.LegacyIdentifier)
+	if err := s.d.LockoutManager().Check(ctx, r, identifier); err != nil {
+		return nil, s.handleLoginError(r, f, p, err)
+	}
+
 	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, s.ID(), identifier)
 	if err != nil {
+		s.recordLoginFailure(ctx, r, identifier, uuid.NullUUID{})
 		time.Sleep(x.RandomDelay(s.d.Config().HasherArgon2(ctx).ExpectedDuration, s.d.Config().HasherArgon2(ctx).ExpectedDeviation))
 		return nil, s.handleLoginError(r, f, p, errors.WithStack(schema.NewInvalidCredentialsError()))
 	}
@@ -108,6 +113,7 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		}
 	} else {
 		if err := hash.Compare(ctx, []byte(p.Password), []byte(o.HashedPassword)); err != nil {
+			s.recordLoginFailure(ctx, r, identifier, uuid.NullUUID{UUID: i.ID, Valid: true})
 			return nil, s.handleLoginError(r, f, p, errors.WithStack(x.WrapWithIdentityIDError(schema.NewInvalidCredentialsError(), i.ID)))
 		}
 
@@ -118,6 +124,10 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		}
 	}
 
+	if err := s.d.LockoutManager().RecordSuccess(ctx, identifier); err != nil {
+		s.d.Logger().WithError(err).Warn("Unable to reset failed login attempts.")
+	}
+
 	f.Active = s.ID()
 	if err = s.d.LoginFlowPersister().UpdateLoginFlow(ctx, f); err != nil {
 		return nil, s.handleLoginError(r, f, p, errors.WithStack(x.WrapWithIdentityIDError(herodot.ErrInternalServerError().WithReason("Could not update flow").WithDebug(err.Error()), i.ID)))
@@ -126,6 +136,15 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	return i, nil
 }
 
+// recordLoginFailure counts a failed login attempt for brute-force protection.
+// Errors are only logged because the caller must respond with the same error
+// regardless of whether the attempt could be recorded.
+func (s *Strategy) recordLoginFailure(ctx context.Context, r *http.Request, identifier string, identityID uuid.NullUUID) {
+	if err := s.d.LockoutManager().RecordFailure(ctx, r, identifier, identityID); err != nil {
+		s.d.Logger().WithError(err).Warn("Unable to record failed login attempt.")
+	}
+}
+
 func (s *Strategy) migratePasswordHash(ctx context.Context, identifier uuid.UUID, password []byte) (err error) {
 	ctx, span := s.d.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.password.Strategy.migratePasswordHash")
 	defer otelx.End(span, &err)
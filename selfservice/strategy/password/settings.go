@@ -25,6 +25,7 @@ import (
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -209,6 +210,9 @@ func (s *Strategy) continueSettingsFlow(ctx context.Context, r *http.Request, ct
 		return errors.WithStack(herodot.ErrInternalServerError().WithReasonf("Unable to encode password options to JSON: %s", err))
 	}
 	i.UpsertCredentialsConfig(s.ID(), co, 0)
+	if oldHashedPassword != "" {
+		ctxUpdate.AddSecurityNotification(identity.SecurityNotification{TemplateType: template.TypePasswordChanged})
+	}
 	ctxUpdate.UpdateIdentity(i)
 
 	return nil
@@ -26,6 +26,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/lockout"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
@@ -76,6 +77,8 @@ type dependencies interface {
 
 	session.HandlerProvider
 	session.ManagementProvider
+
+	lockout.ManagementProvider
 }
 
 type Strategy struct{ d dependencies }
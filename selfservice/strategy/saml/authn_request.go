@@ -0,0 +1,126 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// requestID derives the ID of the AuthnRequest from the state which is sent
+// as the RelayState. The identity provider returns the ID in the InResponseTo
+// attribute of the response, which binds the response to the state without
+// storing the request.
+func requestID(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return "id-" + hex.EncodeToString(sum[:])
+}
+
+// authnRequest is an AuthnRequest (SAML 2.0 core, section 3.4.1).
+type authnRequest struct {
+	id           string
+	issueInstant time.Time
+	destination  string
+	acsURL       string
+	issuer       string
+	nameIDFormat string
+}
+
+func (a *authnRequest) marshal() []byte {
+	attr := func(v string) string {
+		var b strings.Builder
+		_ = xml.EscapeText(&b, []byte(v))
+		return b.String()
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`<samlp:AuthnRequest xmlns:samlp="` + nsProtocol + `" xmlns:saml="` + nsAssertion + `"`)
+	b.WriteString(` ID="` + attr(a.id) + `" Version="2.0" IssueInstant="` + a.issueInstant.UTC().Format(time.RFC3339) + `"`)
+	b.WriteString(` Destination="` + attr(a.destination) + `" AssertionConsumerServiceURL="` + attr(a.acsURL) + `"`)
+	b.WriteString(` ProtocolBinding="` + bindingHTTPPost + `">`)
+	b.WriteString(`<saml:Issuer>` + attr(a.issuer) + `</saml:Issuer>`)
+	if a.nameIDFormat != "" {
+		b.WriteString(`<samlp:NameIDPolicy Format="` + attr(a.nameIDFormat) + `" AllowCreate="true"/>`)
+	} else {
+		b.WriteString(`<samlp:NameIDPolicy AllowCreate="true"/>`)
+	}
+	b.WriteString(`</samlp:AuthnRequest>`)
+	return b.Bytes()
+}
+
+// redirectURL returns the URL of the HTTP-Redirect binding (SAML 2.0
+// bindings, section 3.4) for the request. If a key is given, the query is
+// signed as described in section 3.4.4.1.
+func (a *authnRequest) redirectURL(relayState string, key crypto.Signer) (string, error) {
+	var deflated bytes.Buffer
+	w, err := flate.NewWriter(&deflated, flate.BestCompression)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if _, err := w.Write(a.marshal()); err != nil {
+		return "", errors.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	// The signature is calculated over the query in this exact order, so it
+	// is built by hand instead of with url.Values.
+	query := "SAMLRequest=" + url.QueryEscape(base64.StdEncoding.EncodeToString(deflated.Bytes()))
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+
+	if key != nil {
+		algorithm := algRSASHA256
+		if _, ok := key.(*ecdsa.PrivateKey); ok {
+			algorithm = algECDSASHA256
+		} else if _, ok := key.(*rsa.PrivateKey); !ok {
+			return "", errors.Errorf("unsupported signing key %T", key)
+		}
+		query += "&SigAlg=" + url.QueryEscape(algorithm)
+
+		digest := sha256.Sum256([]byte(query))
+		signature, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		if key, ok := key.(*ecdsa.PrivateKey); ok {
+			// XML signatures encode ECDSA signatures as the concatenation of r
+			// and s instead of ASN.1 (RFC 4050, section 3.3).
+			var rs struct{ R, S *big.Int }
+			if _, err := asn1.Unmarshal(signature, &rs); err != nil {
+				return "", errors.WithStack(err)
+			}
+			size := (key.Curve.Params().BitSize + 7) / 8
+			signature = append(rs.R.FillBytes(make([]byte, size)), rs.S.FillBytes(make([]byte, size))...)
+		}
+		query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+	}
+
+	destination, err := url.Parse(a.destination)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if destination.RawQuery != "" {
+		query = destination.RawQuery + "&" + query
+	}
+	destination.RawQuery = query
+	return destination.String(), nil
+}
@@ -0,0 +1,184 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	nsMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+	nsAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	nsProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+
+	bindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	bindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	nameIDFormatTransient     = "urn:oasis:names:tc:SAML:2.0:nameid-format:transient"
+	nameIDFormatEmail         = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	statusSuccess             = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	subjectConfirmationBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+)
+
+type (
+	// The SAML 2.0 metadata (https://docs.oasis-open.org/security/saml/v2.0/saml-metadata-2.0-os.pdf)
+	// elements which are read from the identity provider metadata and written
+	// to the service provider metadata.
+	entitiesDescriptor struct {
+		XMLName            xml.Name             `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntitiesDescriptor"`
+		EntityDescriptors  []entityDescriptor   `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+		EntitiesDescriptor []entitiesDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntitiesDescriptor"`
+	}
+	entityDescriptor struct {
+		XMLName           xml.Name           `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+		EntityID          string             `xml:"entityID,attr"`
+		IDPSSODescriptors []idpSSODescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor,omitempty"`
+		SPSSODescriptor   *spSSODescriptor   `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor,omitempty"`
+	}
+	idpSSODescriptor struct {
+		WantAuthnRequestsSigned bool            `xml:"WantAuthnRequestsSigned,attr"`
+		KeyDescriptors          []keyDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+		SingleSignOnServices    []endpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+	}
+	spSSODescriptor struct {
+		ProtocolSupportEnumeration string          `xml:"protocolSupportEnumeration,attr"`
+		AuthnRequestsSigned        bool            `xml:"AuthnRequestsSigned,attr"`
+		WantAssertionsSigned       bool            `xml:"WantAssertionsSigned,attr"`
+		KeyDescriptors             []keyDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor,omitempty"`
+		NameIDFormats              []string        `xml:"urn:oasis:names:tc:SAML:2.0:metadata NameIDFormat,omitempty"`
+		AssertionConsumerServices  []endpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+	}
+	keyDescriptor struct {
+		Use     string  `xml:"use,attr,omitempty"`
+		KeyInfo keyInfo `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+	}
+	// The namespace is repeated on every element, because encoding/xml only
+	// applies it to the last element of a path.
+	keyInfo struct {
+		X509Data x509Data `xml:"http://www.w3.org/2000/09/xmldsig# X509Data"`
+	}
+	x509Data struct {
+		X509Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# X509Certificate"`
+	}
+	endpoint struct {
+		Binding  string `xml:"Binding,attr"`
+		Location string `xml:"Location,attr"`
+		Index    *int   `xml:"index,attr,omitempty"`
+	}
+)
+
+// identityProvider is the part of the identity provider metadata which is
+// needed to sign in.
+type identityProvider struct {
+	entityID                string
+	ssoURL                  string
+	wantAuthnRequestsSigned bool
+	certificates            []*x509.Certificate
+}
+
+// parseIdentityProviderMetadata parses the metadata of an identity provider.
+// The metadata can either be an EntityDescriptor, or an EntitiesDescriptor
+// with exactly one identity provider.
+func parseIdentityProviderMetadata(data []byte) (*identityProvider, error) {
+	// The metadata is trusted configuration, but it must not declare entities
+	// either.
+	if _, err := parseXML(data); err != nil {
+		return nil, err
+	}
+
+	var candidates []entityDescriptor
+	var ed entityDescriptor
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&ed); err == nil {
+		candidates = append(candidates, ed)
+	} else {
+		var eds entitiesDescriptor
+		if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&eds); err != nil {
+			return nil, errors.New("the metadata is neither an EntityDescriptor nor an EntitiesDescriptor")
+		}
+		for stack := []entitiesDescriptor{eds}; len(stack) > 0; {
+			current := stack[0]
+			stack = append(stack[1:], current.EntitiesDescriptor...)
+			candidates = append(candidates, current.EntityDescriptors...)
+		}
+	}
+
+	var found []entityDescriptor
+	for _, c := range candidates {
+		if len(c.IDPSSODescriptors) > 0 {
+			found = append(found, c)
+		}
+	}
+	if len(found) != 1 {
+		return nil, errors.Errorf("the metadata must describe exactly one identity provider, but it describes %d", len(found))
+	}
+	entity, descriptor := found[0], found[0].IDPSSODescriptors[0]
+
+	idp := &identityProvider{entityID: entity.EntityID, wantAuthnRequestsSigned: descriptor.WantAuthnRequestsSigned}
+	if idp.entityID == "" {
+		return nil, errors.New("the identity provider has no entity ID")
+	}
+
+	for _, s := range descriptor.SingleSignOnServices {
+		if s.Binding == bindingHTTPRedirect {
+			idp.ssoURL = s.Location
+			break
+		}
+	}
+	if idp.ssoURL == "" {
+		return nil, errors.New("the identity provider has no single sign-on service with the HTTP-Redirect binding")
+	}
+
+	for _, k := range descriptor.KeyDescriptors {
+		if k.Use != "" && k.Use != "signing" {
+			continue
+		}
+		for _, encoded := range k.KeyInfo.X509Data.X509Certificates {
+			der, err := decodeBase64(encoded)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to decode the identity provider certificate")
+			}
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to parse the identity provider certificate")
+			}
+			idp.certificates = append(idp.certificates, cert)
+		}
+	}
+	if len(idp.certificates) == 0 {
+		return nil, errors.New("the identity provider has no signing certificate")
+	}
+
+	return idp, nil
+}
+
+// serviceProviderMetadata returns the metadata of the service provider.
+func serviceProviderMetadata(entityID, acsURL, nameIDFormat string, cert *x509.Certificate) ([]byte, error) {
+	index := 0
+	descriptor := &spSSODescriptor{
+		ProtocolSupportEnumeration: nsProtocol,
+		WantAssertionsSigned:       true,
+		AssertionConsumerServices:  []endpoint{{Binding: bindingHTTPPost, Location: acsURL, Index: &index}},
+	}
+	if nameIDFormat != "" {
+		descriptor.NameIDFormats = []string{nameIDFormat}
+	}
+	if cert != nil {
+		descriptor.AuthnRequestsSigned = true
+		descriptor.KeyDescriptors = []keyDescriptor{{
+			Use:     "signing",
+			KeyInfo: keyInfo{X509Data: x509Data{X509Certificates: []string{base64.StdEncoding.EncodeToString(cert.Raw)}}},
+		}}
+	}
+
+	out, err := xml.MarshalIndent(entityDescriptor{EntityID: entityID, SPSSODescriptor: descriptor}, "", "  ")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,197 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdentityProviderMetadata(t *testing.T) {
+	cert := newTestCertificate(t, newTestRSAKey(t))
+	descriptor := `<md:EntityDescriptor xmlns:md="` + nsMetadata + `" xmlns:ds="` + dsig.Namespace + `" entityID="` + testIDPEntityID + `">` +
+		`<md:IDPSSODescriptor WantAuthnRequestsSigned="true" protocolSupportEnumeration="` + nsProtocol + `">` +
+		`<md:KeyDescriptor use="encryption"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>invalid</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>` +
+		`<md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>` +
+		base64.StdEncoding.EncodeToString(cert.Raw) +
+		`</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>` +
+		`<md:SingleSignOnService Binding="` + bindingHTTPPost + `" Location="https://idp.example.org/sso/post"/>` +
+		`<md:SingleSignOnService Binding="` + bindingHTTPRedirect + `" Location="https://idp.example.org/sso/redirect"/>` +
+		`</md:IDPSSODescriptor></md:EntityDescriptor>`
+
+	for _, tc := range []struct {
+		name, metadata string
+	}{
+		{name: "entity descriptor", metadata: descriptor},
+		{
+			name: "entities descriptor",
+			metadata: `<md:EntitiesDescriptor xmlns:md="` + nsMetadata + `"><md:EntitiesDescriptor>` + descriptor + `</md:EntitiesDescriptor>` +
+				`<md:EntityDescriptor entityID="https://sp.example.org"><md:SPSSODescriptor protocolSupportEnumeration="` + nsProtocol + `"/></md:EntityDescriptor>` +
+				`</md:EntitiesDescriptor>`,
+		},
+	} {
+		t.Run("case=parses an "+tc.name, func(t *testing.T) {
+			idp, err := parseIdentityProviderMetadata([]byte(tc.metadata))
+			require.NoError(t, err)
+			assert.Equal(t, testIDPEntityID, idp.entityID)
+			assert.Equal(t, "https://idp.example.org/sso/redirect", idp.ssoURL)
+			assert.True(t, idp.wantAuthnRequestsSigned)
+			require.Len(t, idp.certificates, 1)
+			assert.True(t, cert.Equal(idp.certificates[0]))
+		})
+	}
+
+	for _, tc := range []struct {
+		name, metadata, expected string
+	}{
+		{
+			name:     "two identity providers",
+			metadata: `<md:EntitiesDescriptor xmlns:md="` + nsMetadata + `">` + descriptor + descriptor + `</md:EntitiesDescriptor>`,
+			expected: "exactly one identity provider",
+		},
+		{
+			name:     "no redirect binding",
+			metadata: strings.Replace(descriptor, bindingHTTPRedirect, bindingHTTPPost, 1),
+			expected: "HTTP-Redirect",
+		},
+		{
+			name:     "no signing certificate",
+			metadata: strings.Replace(descriptor, `use="signing"`, `use="encryption"`, 1),
+			expected: "no signing certificate",
+		},
+		{
+			name:     "entity declarations",
+			metadata: `<!DOCTYPE md:EntityDescriptor [<!ENTITY e "entity">]>` + descriptor,
+		},
+	} {
+		t.Run("case=rejects "+tc.name, func(t *testing.T) {
+			_, err := parseIdentityProviderMetadata([]byte(tc.metadata))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestServiceProviderMetadata(t *testing.T) {
+	cert := newTestCertificate(t, newTestRSAKey(t))
+	out, err := serviceProviderMetadata(testSPEntityID, testACSURL, nameIDFormatEmail, cert)
+	require.NoError(t, err)
+
+	root, err := parseXML(out)
+	require.NoError(t, err)
+	require.True(t, isElement(root, nsMetadata, "EntityDescriptor"))
+	assert.Equal(t, testSPEntityID, root.SelectAttrValue("entityID", ""))
+
+	descriptor := childElement(root, nsMetadata, "SPSSODescriptor")
+	require.NotNil(t, descriptor)
+	assert.Equal(t, "true", descriptor.SelectAttrValue("AuthnRequestsSigned", ""))
+	assert.Equal(t, "true", descriptor.SelectAttrValue("WantAssertionsSigned", ""))
+	assert.Equal(t, nameIDFormatEmail, elementText(childElement(descriptor, nsMetadata, "NameIDFormat")))
+
+	acs := childElement(descriptor, nsMetadata, "AssertionConsumerService")
+	require.NotNil(t, acs)
+	assert.Equal(t, bindingHTTPPost, acs.SelectAttrValue("Binding", ""))
+	assert.Equal(t, testACSURL, acs.SelectAttrValue("Location", ""))
+
+	certificate := childElement(childElement(childElement(childElement(descriptor, nsMetadata, "KeyDescriptor"),
+		dsig.Namespace, "KeyInfo"), dsig.Namespace, "X509Data"), dsig.Namespace, "X509Certificate")
+	require.NotNil(t, certificate)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(cert.Raw), elementText(certificate))
+}
+
+func TestAuthnRequest(t *testing.T) {
+	request := &authnRequest{
+		id:           requestID("state"),
+		issueInstant: time.Now(),
+		destination:  "https://idp.example.org/sso?tenant=example",
+		acsURL:       testACSURL,
+		issuer:       testSPEntityID,
+		nameIDFormat: nameIDFormatEmail,
+	}
+
+	decode := func(t *testing.T, query url.Values) *etree.Element {
+		deflated, err := base64.StdEncoding.DecodeString(query.Get("SAMLRequest"))
+		require.NoError(t, err)
+		raw, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+		require.NoError(t, err)
+		e, err := parseXML(raw)
+		require.NoError(t, err)
+		return e
+	}
+
+	t.Run("case=creates an unsigned request", func(t *testing.T) {
+		location, err := request.redirectURL("state", nil)
+		require.NoError(t, err)
+		u, err := url.Parse(location)
+		require.NoError(t, err)
+		assert.Equal(t, "idp.example.org", u.Host)
+		assert.Equal(t, "example", u.Query().Get("tenant"))
+		assert.Equal(t, "state", u.Query().Get("RelayState"))
+		assert.Empty(t, u.Query().Get("Signature"))
+
+		e := decode(t, u.Query())
+		assert.True(t, isElement(e, nsProtocol, "AuthnRequest"))
+		assert.Equal(t, requestID("state"), e.SelectAttrValue("ID", ""))
+		assert.Equal(t, testACSURL, e.SelectAttrValue("AssertionConsumerServiceURL", ""))
+		assert.Equal(t, testSPEntityID, elementText(childElement(e, nsAssertion, "Issuer")))
+		assert.Equal(t, nameIDFormatEmail, childElement(e, nsProtocol, "NameIDPolicy").SelectAttrValue("Format", ""))
+	})
+
+	rsaKey := newTestRSAKey(t)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	for _, key := range []crypto.Signer{rsaKey, ecKey} {
+		t.Run(fmt.Sprintf("case=signs the request with %T", key), func(t *testing.T) {
+			location, err := request.redirectURL("state", key)
+			require.NoError(t, err)
+			u, err := url.Parse(location)
+			require.NoError(t, err)
+			assert.Equal(t, algorithmOf(key), u.Query().Get("SigAlg"))
+
+			// The signature covers the query without the destination's own
+			// parameters and the signature itself.
+			signed := strings.TrimPrefix(u.RawQuery, "tenant=example&")
+			signed = signed[:strings.Index(signed, "&Signature=")]
+			digest := sha256.Sum256([]byte(signed))
+			signature, err := base64.StdEncoding.DecodeString(u.Query().Get("Signature"))
+			require.NoError(t, err)
+
+			switch key := key.(type) {
+			case *rsa.PrivateKey:
+				assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+			case *ecdsa.PrivateKey:
+				require.Len(t, signature, 64)
+				r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+				assert.True(t, ecdsa.Verify(&key.PublicKey, digest[:], r, s))
+			}
+		})
+	}
+}
+
+func algorithmOf(key crypto.Signer) string {
+	if _, ok := key.(*ecdsa.PrivateKey); ok {
+		return algECDSASHA256
+	}
+	return algRSASHA256
+}
@@ -0,0 +1,52 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// ConsumedAssertion records that an assertion of an identity provider was
+// used to sign in. It is kept until the assertion expires, so that a replayed
+// assertion is rejected.
+//
+// swagger:ignore
+type ConsumedAssertion struct {
+	ID  uuid.UUID `json:"id" db:"id"`
+	NID uuid.UUID `json:"-" db:"nid"`
+
+	// ProviderID is the ID of the provider which received the assertion.
+	ProviderID string `json:"provider_id" db:"provider_id"`
+
+	// AssertionID is the ID of the assertion as issued by the identity
+	// provider.
+	AssertionID string `json:"assertion_id" db:"assertion_id"`
+
+	// ExpiresAt is the time after which the assertion is rejected anyway.
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (ConsumedAssertion) TableName() string { return "selfservice_saml_consumed_assertions" }
+
+type (
+	Persister interface {
+		// ConsumeSAMLAssertion records that the assertion was used. It returns
+		// sqlcon.ErrUniqueViolation if the assertion was used before.
+		ConsumeSAMLAssertion(ctx context.Context, providerID, assertionID string, expiresAt time.Time) error
+
+		// DeleteExpiredSAMLAssertions removes consumed assertions which expired
+		// before the given time.
+		DeleteExpiredSAMLAssertions(ctx context.Context, before time.Time, limit int) error
+	}
+
+	PersistenceProvider interface {
+		SAMLPersister() Persister
+	}
+)
@@ -0,0 +1,310 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/urlx"
+)
+
+// codeLifespan is how long the claims of a validated assertion can be
+// redeemed at the callback.
+const codeLifespan = 5 * time.Minute
+
+var metadataCache, _ = ristretto.NewCache(&ristretto.Config[[]byte, []byte]{
+	MaxCost:     10 << 20, // 10MB
+	NumCounters: 100_000,
+	BufferItems: 64,
+})
+
+var _ oidc.AssertionProvider = (*Provider)(nil)
+
+// Provider is a SAML 2.0 identity provider. It signs in users with the Web
+// Browser SSO profile: the AuthnRequest is sent with the HTTP-Redirect
+// binding, and the response is received with the HTTP-POST binding.
+type Provider struct {
+	config *oidc.Configuration
+	reg    oidc.Dependencies
+}
+
+func NewProvider(config *oidc.Configuration, reg oidc.Dependencies) oidc.Provider {
+	return &Provider{config: config, reg: reg}
+}
+
+func (p *Provider) Config() *oidc.Configuration {
+	return p.config
+}
+
+// EntityID returns the entity ID of the service provider, which is also the
+// URL of its metadata.
+func (p *Provider) EntityID(ctx context.Context) string {
+	return urlx.AppendPaths(p.reg.Config().SAMLRedirectURIBase(ctx), strings.Replace(RouteMetadata, "{provider}", p.config.ID, 1)).String()
+}
+
+// ACSURL returns the URL of the assertion consumer service.
+func (p *Provider) ACSURL(ctx context.Context) string {
+	return urlx.AppendPaths(p.reg.Config().SAMLRedirectURIBase(ctx), strings.Replace(RouteACS, "{provider}", p.config.ID, 1)).String()
+}
+
+func (p *Provider) callbackURL(ctx context.Context) *url.URL {
+	return urlx.AppendPaths(p.reg.Config().SAMLRedirectURIBase(ctx), strings.Replace(RouteCallback, "{provider}", p.config.ID, 1))
+}
+
+func (p *Provider) identityProvider(ctx context.Context) (*identityProvider, error) {
+	if p.config.SAMLIDPMetadataURL == "" {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("SAML provider %q has no identity provider metadata URL.", p.config.ID))
+	}
+
+	fetch := fetcher.NewFetcher(fetcher.WithClient(p.reg.HTTPClient(ctx)), fetcher.WithCache(metadataCache, 10*time.Minute))
+	metadata, err := fetch.FetchContext(ctx, p.config.SAMLIDPMetadataURL)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrUpstreamError().WithReasonf("Unable to fetch the metadata of SAML provider %q: %s", p.config.ID, err))
+	}
+
+	idp, err := parseIdentityProviderMetadata(metadata.Bytes())
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("The metadata of SAML provider %q is invalid: %s", p.config.ID, err))
+	}
+	return idp, nil
+}
+
+// keyPair returns the certificate and private key of the service provider, or
+// nil if none are configured.
+func (p *Provider) keyPair() (*x509.Certificate, crypto.Signer, error) {
+	if p.config.SAMLSPCertificate == "" && p.config.SAMLSPPrivateKey == "" {
+		return nil, nil, nil
+	}
+
+	misconfigured := func(reason string) error {
+		return errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("The service provider key pair of SAML provider %q is invalid: %s", p.config.ID, reason))
+	}
+
+	block, _ := pem.Decode([]byte(p.config.SAMLSPCertificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, nil, misconfigured("sp_certificate must be a PEM encoded certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, misconfigured(err.Error())
+	}
+
+	block, _ = pem.Decode([]byte(p.config.SAMLSPPrivateKey))
+	if block == nil {
+		return nil, nil, misconfigured("sp_private_key must be a PEM encoded private key")
+	}
+	var key any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, nil, misconfigured(err.Error())
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		if !key.PublicKey.Equal(cert.PublicKey) {
+			return nil, nil, misconfigured("sp_private_key does not belong to sp_certificate")
+		}
+		return cert, key, nil
+	case *ecdsa.PrivateKey:
+		if !key.PublicKey.Equal(cert.PublicKey) {
+			return nil, nil, misconfigured("sp_private_key does not belong to sp_certificate")
+		}
+		return cert, key, nil
+	default:
+		return nil, nil, misconfigured("only RSA and ECDSA keys are supported")
+	}
+}
+
+// AuthURL returns the URL which sends the AuthnRequest to the identity
+// provider. The state is sent as the RelayState.
+func (p *Provider) AuthURL(ctx context.Context, state string) (string, error) {
+	idp, err := p.identityProvider(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	_, key, err := p.keyPair()
+	if err != nil {
+		return "", err
+	}
+	if key == nil && idp.wantAuthnRequestsSigned {
+		return "", errors.WithStack(herodot.ErrMisconfiguration().WithReasonf("SAML provider %q requires signed authentication requests, but no service provider key pair is configured.", p.config.ID))
+	}
+
+	request := &authnRequest{
+		id:           requestID(state),
+		issueInstant: p.reg.Clock().Now(),
+		destination:  idp.ssoURL,
+		acsURL:       p.ACSURL(ctx),
+		issuer:       p.EntityID(ctx),
+		nameIDFormat: p.config.SAMLNameIDFormat,
+	}
+	return request.redirectURL(state, key)
+}
+
+// validate validates the SAML response which was posted to the assertion
+// consumer service for the given state.
+func (p *Provider) validate(ctx context.Context, encoded, state string) (*identityProvider, *assertion, error) {
+	idp, err := p.identityProvider(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := &responseValidator{
+		idp:       idp,
+		entityID:  p.EntityID(ctx),
+		acsURL:    p.ACSURL(ctx),
+		requestID: requestID(state),
+		now:       p.reg.Clock().Now(),
+	}
+	a, err := v.validate(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+	return idp, a, nil
+}
+
+// code is the payload of the code query parameter, which carries the claims
+// of a validated assertion from the assertion consumer service to the
+// callback.
+type code struct {
+	Claims *oidc.Claims `json:"claims"`
+	// State is the hash of the state the assertion was issued for.
+	State     string    `json:"state"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func stateHash(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+// encryptCode encrypts the claims, so that they can only be redeemed at the
+// callback together with the state.
+func (p *Provider) encryptCode(ctx context.Context, claims *oidc.Claims, state string) (string, error) {
+	payload, err := json.Marshal(&code{
+		Claims:    claims,
+		State:     stateHash(state),
+		ExpiresAt: p.reg.Clock().Now().Add(codeLifespan),
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return p.reg.Cipher(ctx).Encrypt(ctx, payload)
+}
+
+// Claims returns the claims which the assertion consumer service stored in the
+// code query parameter of the callback.
+func (p *Provider) Claims(ctx context.Context, query url.Values) (*oidc.Claims, error) {
+	payload, err := p.reg.Cipher(ctx).Decrypt(ctx, query.Get("code"))
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReason("The SAML code is invalid."))
+	}
+
+	var c code
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReason("The SAML code is invalid."))
+	}
+	if subtle.ConstantTimeCompare([]byte(c.State), []byte(stateHash(query.Get("state")))) != 1 {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReason("The SAML code was issued for another state."))
+	}
+	if p.reg.Clock().Now().After(c.ExpiresAt) {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReason("The SAML code has expired."))
+	}
+	if c.Claims == nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest().WithReason("The SAML code is invalid."))
+	}
+	return c.Claims, nil
+}
+
+// Well known attribute names of the profile claims. SAML does not define
+// attribute names, so the names of the common identity providers are tried in
+// order: short names, LDAP and eduPerson OIDs, and the claim types of Active
+// Directory Federation Services and Microsoft Entra ID.
+var (
+	emailAttributes = []string{
+		"email", "mail", "emailAddress",
+		"urn:oid:0.9.2342.19200300.100.1.3",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+	}
+	givenNameAttributes = []string{
+		"given_name", "givenName", "firstName",
+		"urn:oid:2.5.4.42",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname",
+	}
+	familyNameAttributes = []string{
+		"family_name", "sn", "surname", "lastName",
+		"urn:oid:2.5.4.4",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname",
+	}
+	nameAttributes = []string{
+		"name", "displayName", "cn",
+		"urn:oid:2.16.840.1.113730.3.1.241",
+		"http://schemas.microsoft.com/identity/claims/displayname",
+	}
+)
+
+func firstAttribute(attributes map[string][]string, names []string) string {
+	for _, name := range names {
+		for _, value := range attributes[name] {
+			if value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// claimsFromAssertion maps the assertion to claims. The attributes are
+// available to the Jsonnet mapper in `claims.raw_claims.attributes`.
+func claimsFromAssertion(idp *identityProvider, a *assertion) *oidc.Claims {
+	claims := &oidc.Claims{
+		Issuer:     idp.entityID,
+		Subject:    a.nameID,
+		Email:      firstAttribute(a.attributes, emailAttributes),
+		GivenName:  firstAttribute(a.attributes, givenNameAttributes),
+		FamilyName: firstAttribute(a.attributes, familyNameAttributes),
+		Name:       firstAttribute(a.attributes, nameAttributes),
+	}
+	if claims.Email == "" && a.nameIDFormat == nameIDFormatEmail {
+		claims.Email = a.nameID
+	}
+
+	attributes := make(map[string]any, len(a.attributes))
+	for name, values := range a.attributes {
+		attributes[name] = values
+	}
+	claims.RawClaims = map[string]any{
+		"name_id":        a.nameID,
+		"name_id_format": a.nameIDFormat,
+		"session_index":  a.sessionIndex,
+		"attributes":     attributes,
+	}
+	return claims
+}
@@ -0,0 +1,250 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/pkg/errors"
+)
+
+// maxClockSkew is the tolerated clock skew between the identity provider and
+// this service provider when checking the validity periods of an assertion.
+const maxClockSkew = 3 * time.Minute
+
+// assertion is the verified content of a SAML assertion.
+type assertion struct {
+	id           string
+	nameID       string
+	nameIDFormat string
+	sessionIndex string
+	// attributes are keyed by attribute name. Attributes with a friendly name
+	// are also available under the friendly name.
+	attributes map[string][]string
+	// expiresAt is the time after which the assertion can not be used
+	// anymore. It must be remembered until then to detect replays.
+	expiresAt time.Time
+}
+
+// responseValidator validates a SAML response of the Web Browser SSO profile
+// (SAML 2.0 profiles, section 4.1) which was sent to the assertion consumer
+// service with the HTTP-POST binding.
+type responseValidator struct {
+	idp       *identityProvider
+	entityID  string
+	acsURL    string
+	requestID string
+	now       time.Time
+}
+
+func (v *responseValidator) validate(encoded string) (*assertion, error) {
+	raw, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode the response")
+	}
+	response, err := parseXML(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse the response")
+	}
+
+	if !isElement(response, nsProtocol, "Response") || response.SelectAttrValue("Version", "") != "2.0" {
+		return nil, errors.New("the document is not a SAML 2.0 response")
+	}
+	if destination := response.SelectAttrValue("Destination", ""); destination != "" && destination != v.acsURL {
+		return nil, errors.Errorf("the response was sent to %s instead of this service provider", destination)
+	}
+	if inResponseTo := response.SelectAttrValue("InResponseTo", ""); inResponseTo != "" && inResponseTo != v.requestID {
+		return nil, errors.New("the response does not belong to this sign in")
+	}
+	if issuer := childElement(response, nsAssertion, "Issuer"); issuer != nil && elementText(issuer) != v.idp.entityID {
+		return nil, errors.Errorf("the response was issued by %s instead of the identity provider", elementText(issuer))
+	}
+
+	if err := checkStatus(response); err != nil {
+		return nil, err
+	}
+
+	if len(childElements(response, nsAssertion, "EncryptedAssertion")) > 0 {
+		return nil, errors.New("encrypted assertions are not supported")
+	}
+	assertions := childElements(response, nsAssertion, "Assertion")
+	if len(assertions) != 1 {
+		return nil, errors.New("the response must contain exactly one assertion")
+	}
+
+	// Either the response or the assertion must be signed. Only the verified
+	// canonical form is used afterwards.
+	var verified *etree.Element
+	canonical, err := verifySignature(response, v.idp.certificates)
+	switch {
+	case err == nil:
+		signedResponse, err := parseXML(canonical)
+		if err != nil {
+			return nil, err
+		}
+		signedAssertions := childElements(signedResponse, nsAssertion, "Assertion")
+		if len(signedAssertions) != 1 {
+			return nil, errors.New("the response must contain exactly one assertion")
+		}
+		verified = signedAssertions[0]
+	case errors.Is(err, errNotSigned):
+		canonical, err := verifySignature(assertions[0], v.idp.certificates)
+		if errors.Is(err, errNotSigned) {
+			return nil, errors.New("neither the response nor the assertion is signed")
+		} else if err != nil {
+			return nil, errors.Wrap(err, "the assertion signature is invalid")
+		}
+		if verified, err = parseXML(canonical); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Wrap(err, "the response signature is invalid")
+	}
+
+	return v.validateAssertion(verified)
+}
+
+func checkStatus(response *etree.Element) error {
+	status := childElement(response, nsProtocol, "Status")
+	if status == nil {
+		return errors.New("the response has no status")
+	}
+	code := childElement(status, nsProtocol, "StatusCode")
+	if code == nil {
+		return errors.New("the response has no status code")
+	}
+	if code.SelectAttrValue("Value", "") == statusSuccess {
+		return nil
+	}
+
+	reason := code.SelectAttrValue("Value", "")
+	if nested := childElement(code, nsProtocol, "StatusCode"); nested != nil {
+		reason += " (" + nested.SelectAttrValue("Value", "") + ")"
+	}
+	if message := childElement(status, nsProtocol, "StatusMessage"); message != nil && elementText(message) != "" {
+		reason += ": " + elementText(message)
+	}
+	return errors.Errorf("the identity provider did not sign in the user: %s", reason)
+}
+
+func (v *responseValidator) validateAssertion(a *etree.Element) (*assertion, error) {
+	result := &assertion{id: a.SelectAttrValue("ID", ""), attributes: map[string][]string{}}
+	if result.id == "" || a.SelectAttrValue("Version", "") != "2.0" {
+		return nil, errors.New("the assertion is not a SAML 2.0 assertion")
+	}
+
+	if issuer := childElement(a, nsAssertion, "Issuer"); issuer == nil || elementText(issuer) != v.idp.entityID {
+		return nil, errors.New("the assertion was not issued by the identity provider")
+	}
+
+	if err := v.checkConditions(childElement(a, nsAssertion, "Conditions")); err != nil {
+		return nil, err
+	}
+
+	subject := childElement(a, nsAssertion, "Subject")
+	if subject == nil {
+		return nil, errors.New("the assertion has no subject")
+	}
+	nameID := childElement(subject, nsAssertion, "NameID")
+	if nameID == nil || elementText(nameID) == "" {
+		return nil, errors.New("the assertion subject has no NameID")
+	}
+	result.nameID, result.nameIDFormat = elementText(nameID), nameID.SelectAttrValue("Format", "")
+	if result.nameIDFormat == nameIDFormatTransient {
+		return nil, errors.New("transient NameIDs can not identify a user across sign ins")
+	}
+
+	expiresAt, err := v.checkSubjectConfirmation(subject)
+	if err != nil {
+		return nil, err
+	}
+	result.expiresAt = expiresAt
+
+	statements := childElements(a, nsAssertion, "AuthnStatement")
+	if len(statements) == 0 {
+		return nil, errors.New("the assertion has no authentication statement")
+	}
+	result.sessionIndex = statements[0].SelectAttrValue("SessionIndex", "")
+
+	for _, statement := range childElements(a, nsAssertion, "AttributeStatement") {
+		for _, attr := range childElements(statement, nsAssertion, "Attribute") {
+			var values []string
+			for _, value := range childElements(attr, nsAssertion, "AttributeValue") {
+				values = append(values, elementText(value))
+			}
+			name := attr.SelectAttrValue("Name", "")
+			result.attributes[name] = append(result.attributes[name], values...)
+			if friendly := attr.SelectAttrValue("FriendlyName", ""); friendly != "" && friendly != name {
+				result.attributes[friendly] = append(result.attributes[friendly], values...)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (v *responseValidator) checkConditions(conditions *etree.Element) error {
+	if conditions == nil {
+		return errors.New("the assertion has no conditions")
+	}
+
+	if notBefore := conditions.SelectAttrValue("NotBefore", ""); notBefore != "" {
+		t, err := time.Parse(time.RFC3339, notBefore)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse the NotBefore condition")
+		}
+		if v.now.Add(maxClockSkew).Before(t) {
+			return errors.New("the assertion is not valid yet")
+		}
+	}
+	if notOnOrAfter := conditions.SelectAttrValue("NotOnOrAfter", ""); notOnOrAfter != "" {
+		t, err := time.Parse(time.RFC3339, notOnOrAfter)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse the NotOnOrAfter condition")
+		}
+		if !v.now.Add(-maxClockSkew).Before(t) {
+			return errors.New("the assertion has expired")
+		}
+	}
+
+	// Every audience restriction must include this service provider (SAML
+	// 2.0 core, section 2.5.1.4).
+	restrictions := childElements(conditions, nsAssertion, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return errors.New("the assertion has no audience restriction")
+	}
+	for _, restriction := range restrictions {
+		var found bool
+		for _, audience := range childElements(restriction, nsAssertion, "Audience") {
+			found = found || elementText(audience) == v.entityID
+		}
+		if !found {
+			return errors.New("the assertion is not intended for this service provider")
+		}
+	}
+
+	return nil
+}
+
+// checkSubjectConfirmation checks that the subject can be confirmed with the
+// bearer method (SAML 2.0 profiles, section 4.1.4.2), and returns the time
+// until which the confirmation is valid.
+func (v *responseValidator) checkSubjectConfirmation(subject *etree.Element) (time.Time, error) {
+	for _, confirmation := range childElements(subject, nsAssertion, "SubjectConfirmation") {
+		if confirmation.SelectAttrValue("Method", "") != subjectConfirmationBearer {
+			continue
+		}
+		data := childElement(confirmation, nsAssertion, "SubjectConfirmationData")
+		if data == nil || data.SelectAttrValue("Recipient", "") != v.acsURL || data.SelectAttrValue("InResponseTo", "") != v.requestID {
+			continue
+		}
+		notOnOrAfter, err := time.Parse(time.RFC3339, data.SelectAttrValue("NotOnOrAfter", ""))
+		if err != nil || !v.now.Add(-maxClockSkew).Before(notOnOrAfter) {
+			continue
+		}
+		return notOnOrAfter.Add(maxClockSkew), nil
+	}
+	return time.Time{}, errors.New("the assertion subject can not be confirmed for this sign in")
+}
@@ -0,0 +1,185 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIDPEntityID = "https://idp.example.org/metadata"
+	testSPEntityID  = "https://kratos.example.org/self-service/methods/saml/metadata/idp"
+	testACSURL      = "https://kratos.example.org/self-service/methods/saml/acs/idp"
+)
+
+// testResponse is a SAML response as an identity provider sends it.
+type testResponse struct {
+	requestID    string
+	issuer       string
+	audience     string
+	recipient    string
+	status       string
+	nameIDFormat string
+	notBefore    time.Time
+	notOnOrAfter time.Time
+}
+
+func newTestResponse(requestID string, now time.Time) *testResponse {
+	return &testResponse{
+		requestID:    requestID,
+		issuer:       testIDPEntityID,
+		audience:     testSPEntityID,
+		recipient:    testACSURL,
+		status:       statusSuccess,
+		nameIDFormat: "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent",
+		notBefore:    now.Add(-time.Minute),
+		notOnOrAfter: now.Add(5 * time.Minute),
+	}
+}
+
+func (r *testResponse) xml() string {
+	f := func(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+	return `<samlp:Response xmlns:samlp="` + nsProtocol + `" xmlns:saml="` + nsAssertion + `" xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"` +
+		` ID="response-id" Version="2.0" IssueInstant="` + f(r.notBefore) + `" Destination="` + testACSURL + `" InResponseTo="` + r.requestID + `">` +
+		`<saml:Issuer>` + r.issuer + `</saml:Issuer>` +
+		`<samlp:Status><samlp:StatusCode Value="` + r.status + `"/></samlp:Status>` +
+		`<saml:Assertion ID="assertion-id" Version="2.0" IssueInstant="` + f(r.notBefore) + `">` +
+		`<saml:Issuer>` + r.issuer + `</saml:Issuer>` +
+		`<saml:Subject><saml:NameID Format="` + r.nameIDFormat + `">alice-1234</saml:NameID>` +
+		`<saml:SubjectConfirmation Method="` + subjectConfirmationBearer + `"><saml:SubjectConfirmationData NotOnOrAfter="` + f(r.notOnOrAfter) + `" Recipient="` + r.recipient + `" InResponseTo="` + r.requestID + `"/></saml:SubjectConfirmation>` +
+		`</saml:Subject>` +
+		`<saml:Conditions NotBefore="` + f(r.notBefore) + `" NotOnOrAfter="` + f(r.notOnOrAfter) + `"><saml:AudienceRestriction><saml:Audience>` + r.audience + `</saml:Audience></saml:AudienceRestriction></saml:Conditions>` +
+		`<saml:AuthnStatement AuthnInstant="` + f(r.notBefore) + `" SessionIndex="session-1"><saml:AuthnContext><saml:AuthnContextClassRef>urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport</saml:AuthnContextClassRef></saml:AuthnContext></saml:AuthnStatement>` +
+		`<saml:AttributeStatement>` +
+		`<saml:Attribute Name="urn:oid:0.9.2342.19200300.100.1.3" FriendlyName="mail"><saml:AttributeValue xsi:type="xs:string">alice@example.org</saml:AttributeValue></saml:Attribute>` +
+		`<saml:Attribute Name="groups"><saml:AttributeValue>admins</saml:AttributeValue><saml:AttributeValue>users</saml:AttributeValue></saml:Attribute>` +
+		`</saml:AttributeStatement>` +
+		`</saml:Assertion></samlp:Response>`
+}
+
+func (r *testResponse) signAssertion(t *testing.T, key crypto.Signer) string {
+	return base64.StdEncoding.EncodeToString([]byte(signTestElement(t, r.xml(), "assertion-id", key)))
+}
+
+func TestResponseValidator(t *testing.T) {
+	key := newTestRSAKey(t)
+	idp := &identityProvider{entityID: testIDPEntityID, certificates: []*x509.Certificate{newTestCertificate(t, key)}}
+	now := time.Now()
+	requestID := requestID("state")
+
+	validate := func(t *testing.T, encoded string) (*assertion, error) {
+		v := &responseValidator{idp: idp, entityID: testSPEntityID, acsURL: testACSURL, requestID: requestID, now: now}
+		return v.validate(encoded)
+	}
+
+	t.Run("case=accepts a signed assertion", func(t *testing.T) {
+		a, err := validate(t, newTestResponse(requestID, now).signAssertion(t, key))
+		require.NoError(t, err)
+		assert.Equal(t, "assertion-id", a.id)
+		assert.Equal(t, "alice-1234", a.nameID)
+		assert.Equal(t, "session-1", a.sessionIndex)
+		assert.Equal(t, []string{"alice@example.org"}, a.attributes["mail"])
+		assert.Equal(t, []string{"alice@example.org"}, a.attributes["urn:oid:0.9.2342.19200300.100.1.3"])
+		assert.Equal(t, []string{"admins", "users"}, a.attributes["groups"])
+		assert.WithinDuration(t, now.Add(5*time.Minute+maxClockSkew), a.expiresAt, time.Second)
+	})
+
+	t.Run("case=accepts a signed response", func(t *testing.T) {
+		signed := signTestElement(t, newTestResponse(requestID, now).xml(), "response-id", key)
+		a, err := validate(t, base64.StdEncoding.EncodeToString([]byte(signed)))
+		require.NoError(t, err)
+		assert.Equal(t, "alice-1234", a.nameID)
+	})
+
+	t.Run("case=rejects an unsigned response", func(t *testing.T) {
+		_, err := validate(t, base64.StdEncoding.EncodeToString([]byte(newTestResponse(requestID, now).xml())))
+		assert.ErrorContains(t, err, "neither the response nor the assertion is signed")
+	})
+
+	t.Run("case=rejects a wrapped assertion", func(t *testing.T) {
+		// The signed assertion is moved into an extension of the response,
+		// and an unsigned assertion of another user takes its place.
+		signed := signTestElement(t, newTestResponse(requestID, now).xml(), "assertion-id", key)
+		start, end := strings.Index(signed, "<saml:Assertion "), strings.Index(signed, "</saml:Assertion>")+len("</saml:Assertion>")
+		original := signed[start:end]
+		evil := strings.Replace(strings.Replace(original, "alice-1234", "mallory", 1), `ID="assertion-id"`, `ID="evil"`, 1)
+		wrapped := signed[:start] + `<samlp:Extensions>` + original + `</samlp:Extensions>` + evil + signed[end:]
+		_, err := validate(t, base64.StdEncoding.EncodeToString([]byte(wrapped)))
+		assert.Error(t, err)
+
+		// The signature of the original assertion is rejected as well when it
+		// is placed in the evil assertion.
+		evil = strings.Replace(original, "alice-1234", "mallory", 1)
+		_, err = validate(t, base64.StdEncoding.EncodeToString([]byte(signed[:start]+evil+signed[end:])))
+		assert.ErrorContains(t, err, "Signature could not be verified")
+	})
+
+	for _, tc := range []struct {
+		name     string
+		modify   func(r *testResponse)
+		expected string
+	}{
+		{
+			name:     "wrong audience",
+			modify:   func(r *testResponse) { r.audience = "https://other.example.org" },
+			expected: "not intended for this service provider",
+		},
+		{
+			name:     "wrong issuer",
+			modify:   func(r *testResponse) { r.issuer = "https://evil.example.org" },
+			expected: "instead of the identity provider",
+		},
+		{
+			name:     "expired assertion",
+			modify:   func(r *testResponse) { r.notOnOrAfter = now.Add(-time.Hour) },
+			expected: "expired",
+		},
+		{
+			name:     "future assertion",
+			modify:   func(r *testResponse) { r.notBefore = now.Add(time.Hour) },
+			expected: "not valid yet",
+		},
+		{
+			name:     "other request",
+			modify:   func(r *testResponse) { r.requestID = "id-other" },
+			expected: "does not belong to this sign in",
+		},
+		{
+			name:     "wrong recipient",
+			modify:   func(r *testResponse) { r.recipient = "https://other.example.org/acs" },
+			expected: "can not be confirmed",
+		},
+		{
+			name:     "failed status",
+			modify:   func(r *testResponse) { r.status = "urn:oasis:names:tc:SAML:2.0:status:Responder" },
+			expected: "did not sign in the user",
+		},
+		{
+			name:     "transient name ID",
+			modify:   func(r *testResponse) { r.nameIDFormat = nameIDFormatTransient },
+			expected: "transient",
+		},
+	} {
+		t.Run("case=rejects "+tc.name, func(t *testing.T) {
+			r := newTestResponse(requestID, now)
+			tc.modify(r)
+			_, err := validate(t, r.signAssertion(t, key))
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+
+	t.Run("case=rejects encrypted assertions", func(t *testing.T) {
+		doc := strings.Replace(newTestResponse(requestID, now).xml(), "<saml:Assertion ", "<saml:EncryptedAssertion/><saml:Assertion ", 1)
+		_, err := validate(t, base64.StdEncoding.EncodeToString([]byte(doc)))
+		assert.ErrorContains(t, err, "encrypted assertions are not supported")
+	})
+}
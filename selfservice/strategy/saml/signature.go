@@ -0,0 +1,100 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"slices"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/pkg/errors"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+const (
+	algSHA256 = "http://www.w3.org/2001/04/xmlenc#sha256"
+	algSHA512 = "http://www.w3.org/2001/04/xmlenc#sha512"
+
+	algRSASHA256   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algRSASHA512   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	algECDSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	algECDSASHA512 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha512"
+)
+
+var (
+	// digestAlgorithms and signatureAlgorithms are the algorithms which are
+	// accepted in XML signatures. goxmldsig also accepts SHA-1.
+	digestAlgorithms    = []string{algSHA256, algSHA512}
+	signatureAlgorithms = []string{algRSASHA256, algRSASHA512, algECDSASHA256, algECDSASHA512}
+)
+
+// errNotSigned is returned by verifySignature if the element has no
+// signature.
+var errNotSigned = errors.New("the element is not signed")
+
+// verifySignature verifies the enveloped XML signature of the element with
+// one of the certificates. It returns the canonical form of the signed element
+// without its signature. Only that form must be used after the verification,
+// because the signature does not cover the rest of the document.
+//
+// The signature must be a child of the element and reference the element
+// itself, which rules out signature wrapping attacks where the signature
+// references another element with the same ID. SHA-1 is not supported.
+func verifySignature(e *etree.Element, certificates []*x509.Certificate) ([]byte, error) {
+	signatures := childElements(e, dsig.Namespace, dsig.SignatureTag)
+	switch {
+	case len(signatures) == 0:
+		return nil, errNotSigned
+	case len(signatures) > 1:
+		return nil, errors.New("the element has more than one signature")
+	}
+
+	signedInfo := childElement(signatures[0], dsig.Namespace, dsig.SignedInfoTag)
+	if signedInfo == nil {
+		return nil, errors.New("the signature has no SignedInfo")
+	}
+	if method := childElement(signedInfo, dsig.Namespace, dsig.SignatureMethodTag); method == nil || !slices.Contains(signatureAlgorithms, method.SelectAttrValue(dsig.AlgorithmAttr, "")) {
+		return nil, errors.New("the signature algorithm is not supported")
+	}
+	references := childElements(signedInfo, dsig.Namespace, dsig.ReferenceTag)
+	if len(references) != 1 {
+		return nil, errors.New("the signature must have exactly one reference")
+	}
+	if id := e.SelectAttrValue("ID", ""); id == "" || references[0].SelectAttrValue(dsig.URIAttr, "") != "#"+id {
+		return nil, errors.New("the signature does not reference the signed element")
+	}
+	if method := childElement(references[0], dsig.Namespace, dsig.DigestMethodTag); method == nil || !slices.Contains(digestAlgorithms, method.SelectAttrValue(dsig.AlgorithmAttr, "")) {
+		return nil, errors.New("the digest algorithm is not supported")
+	}
+
+	// goxmldsig only takes the namespaces which are declared by the ancestors
+	// into account if the element is detached from the document.
+	ns, err := etreeutils.NSBuildParentContext(e)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	detached, err := etreeutils.NSDetatch(ns, e)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	v := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: certificates})
+	v.IdAttribute = "ID"
+	signed, err := v.Validate(detached)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// The canonical form drops comments, which could otherwise split the
+	// text of an element without changing the digest.
+	canonical, err := dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("").Canonicalize(signed)
+	return canonical, errors.WithStack(err)
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
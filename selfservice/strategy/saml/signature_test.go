@@ -0,0 +1,169 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificate(t *testing.T, key crypto.Signer) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.org"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func newTestRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+// signTestElement adds an enveloped signature to the element with the ID. It
+// is inserted after the element's Issuer, where SAML expects it.
+func signTestElement(t *testing.T, doc, id string, key crypto.Signer) string {
+	d := etree.NewDocument()
+	require.NoError(t, d.ReadFromString(doc))
+	signed := d.FindElement("//[@ID='" + id + "']")
+	require.NotNil(t, signed)
+	issuer := signed.SelectElement("Issuer")
+	require.NotNil(t, issuer)
+
+	// goxmldsig only takes the namespaces of the ancestors into account if
+	// the element is detached from the document. The document itself is not
+	// changed apart from the signature.
+	ns, err := etreeutils.NSBuildParentContext(signed)
+	require.NoError(t, err)
+	detached, err := etreeutils.NSDetatch(ns, signed)
+	require.NoError(t, err)
+
+	ctx, err := dsig.NewSigningContext(key, nil)
+	require.NoError(t, err)
+	// goxmldsig does not write the InclusiveNamespaces of a prefix list into
+	// the signature, so the prefix list must be empty.
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	signature, err := ctx.ConstructSignature(detached, true)
+	require.NoError(t, err)
+	// Without certificates, goxmldsig writes an empty KeyInfo. Identity
+	// providers omit it instead, and the certificate is taken from their
+	// metadata.
+	signature.RemoveChild(signature.SelectElement("KeyInfo"))
+	signed.InsertChildAt(issuer.Index()+1, signature)
+
+	out, err := d.WriteToString()
+	require.NoError(t, err)
+	return out
+}
+
+func findByID(e *etree.Element, id string) *etree.Element {
+	return e.FindElement("//[@ID='" + id + "']")
+}
+
+func TestParseXML(t *testing.T) {
+	for _, doc := range []string{
+		`<!DOCTYPE root [<!ENTITY e "entity">]><root>&e;</root>`,
+		`<root><child></root>`,
+		`<root/><root/>`,
+		`<root>`,
+	} {
+		_, err := parseXML([]byte(doc))
+		assert.Error(t, err, doc)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	rsaKey := newTestRSAKey(t)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	doc := `<samlp:Response xmlns:samlp="` + nsProtocol + `" xmlns:xs="http://www.w3.org/2001/XMLSchema" ID="response"><saml:Issuer xmlns:saml="` + nsAssertion + `">idp</saml:Issuer>
+  <saml:Assertion xmlns:saml="` + nsAssertion + `" ID="assertion"><saml:Issuer>idp</saml:Issuer>
+    <saml:Subject><saml:NameID>alice</saml:NameID></saml:Subject>
+  </saml:Assertion>
+</samlp:Response>`
+
+	verify := func(t *testing.T, doc, id string, certs ...*x509.Certificate) ([]byte, error) {
+		root, err := parseXML([]byte(doc))
+		require.NoError(t, err)
+		return verifySignature(findByID(root, id), certs)
+	}
+
+	for _, key := range []crypto.Signer{rsaKey, ecKey} {
+		cert := newTestCertificate(t, key)
+
+		t.Run(fmt.Sprintf("case=verifies the signature with %T", key), func(t *testing.T) {
+			canonical, err := verify(t, signTestElement(t, doc, "assertion", key), "assertion", cert)
+			require.NoError(t, err)
+			assert.Contains(t, string(canonical), "<saml:NameID>alice</saml:NameID>")
+			assert.NotContains(t, string(canonical), "Signature")
+		})
+	}
+
+	cert := newTestCertificate(t, rsaKey)
+
+	t.Run("case=rejects a modified element", func(t *testing.T) {
+		signed := strings.Replace(signTestElement(t, doc, "assertion", rsaKey), "alice", "mallory", 1)
+		_, err := verify(t, signed, "assertion", cert)
+		assert.ErrorContains(t, err, "Signature could not be verified")
+	})
+
+	t.Run("case=rejects an untrusted certificate", func(t *testing.T) {
+		_, err := verify(t, signTestElement(t, doc, "assertion", newTestRSAKey(t)), "assertion", cert)
+		assert.ErrorContains(t, err, "verification error")
+	})
+
+	t.Run("case=returns the text which is split by comments", func(t *testing.T) {
+		signed := signTestElement(t, strings.Replace(doc, ">alice<", ">alice@example.org.evil.example<", 1), "assertion", rsaKey)
+		signed = strings.Replace(signed, "alice@example.org", "alice@example.org<!---->", 1)
+
+		canonical, err := verify(t, signed, "assertion", cert)
+		require.NoError(t, err)
+		e, err := parseXML(canonical)
+		require.NoError(t, err)
+		nameID := childElement(childElement(e, nsAssertion, "Subject"), nsAssertion, "NameID")
+		assert.Equal(t, "alice@example.org.evil.example", elementText(nameID))
+	})
+
+	t.Run("case=rejects an unsigned element", func(t *testing.T) {
+		_, err := verify(t, doc, "assertion", cert)
+		assert.ErrorIs(t, err, errNotSigned)
+	})
+
+	t.Run("case=rejects a signature which references another element", func(t *testing.T) {
+		signed := signTestElement(t, doc, "assertion", rsaKey)
+		signed = strings.Replace(signed, `ID="assertion"`, `ID="evil"`, 1)
+		_, err := verify(t, signed, "evil", cert)
+		assert.ErrorContains(t, err, "does not reference")
+	})
+
+	t.Run("case=rejects SHA-1", func(t *testing.T) {
+		signed := strings.Replace(signTestElement(t, doc, "assertion", rsaKey), algRSASHA256, "http://www.w3.org/2000/09/xmldsig#rsa-sha1", 1)
+		_, err := verify(t, signed, "assertion", cert)
+		assert.ErrorContains(t, err, "not supported")
+	})
+}
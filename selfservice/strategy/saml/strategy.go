@@ -0,0 +1,307 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/selfservice/strategy/idfirst"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/httprouterx"
+	"github.com/ory/x/sqlcon"
+)
+
+const (
+	RouteBase = "/self-service/methods/saml"
+
+	RouteMetadata = RouteBase + "/metadata/{provider}"
+	RouteACS      = RouteBase + "/acs/{provider}"
+	RouteCallback = RouteBase + "/callback/{provider}"
+)
+
+var (
+	_ login.Strategy                    = (*Strategy)(nil)
+	_ registration.Strategy             = (*Strategy)(nil)
+	_ settings.Strategy                 = (*Strategy)(nil)
+	_ flow.OrganizationImplementor      = (*Strategy)(nil)
+	_ identity.ActiveCredentialsCounter = (*Strategy)(nil)
+)
+
+type Dependencies interface {
+	oidc.Dependencies
+	PersistenceProvider
+}
+
+// Strategy implements login, registration and settings via SAML 2.0 identity
+// providers. It reuses the OpenID Connect strategy for the flows, and only
+// adds the SAML endpoints: the service provider metadata, and the assertion
+// consumer service which validates the SAML response and hands its claims to
+// the callback.
+//
+// Providers of type `jackson` sign in through a BoxyHQ Jackson proxy instead.
+type Strategy struct {
+	*oidc.Strategy
+	d Dependencies
+}
+
+func NewStrategy(d Dependencies) *Strategy {
+	return &Strategy{
+		Strategy: oidc.NewStrategy(d,
+			oidc.ForCredentialType(identity.CredentialsTypeSAML),
+			oidc.WithProviderTypes(map[string]func(config *oidc.Configuration, reg oidc.Dependencies) oidc.Provider{
+				"saml":    NewProvider,
+				"jackson": oidc.NewProviderJackson,
+			}),
+		),
+		d: d,
+	}
+}
+
+func (s *Strategy) RegisterPublicRoutes(r *httprouterx.RouterPublic) {
+	r.GET(RouteMetadata, strategy.IsDisabled(s.d, s.ID().String(), s.handleMetadata))
+	r.GET(RouteCallback, strategy.IsDisabled(s.d, s.ID().String(), s.HandleCallback))
+
+	// The identity provider posts the response from its own origin, so the
+	// assertion consumer service can not be protected by the CSRF cookie. The
+	// response is bound to the flow by the InResponseTo attribute instead.
+	s.d.CSRFHandler().IgnoreGlob(RouteBase + "/acs/*")
+	r.POST(RouteACS, strategy.IsDisabled(s.d, s.ID().String(), s.handleACS))
+}
+
+// RegisterAdminRoutes does not register the admin routes of the OpenID
+// Connect strategy, because SAML identity providers do not issue access
+// tokens.
+func (s *Strategy) RegisterAdminRoutes(*httprouterx.RouterAdmin) {}
+
+// SupportsOrganizations is true because SAML providers can belong to an
+// organization. Flows of an organization only show its providers.
+func (s *Strategy) SupportsOrganizations() bool {
+	return true
+}
+
+func (s *Strategy) samlProvider(ctx context.Context, id string) (*Provider, error) {
+	provider, err := s.Provider(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := provider.(*Provider)
+	if !ok {
+		return nil, errors.WithStack(herodot.ErrNotFound().WithReasonf("Provider %q is not a SAML 2.0 identity provider.", id))
+	}
+	return p, nil
+}
+
+// Get SAML 2.0 Service Provider Metadata Parameters
+//
+// swagger:parameters getSamlServiceProviderMetadata
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getSamlServiceProviderMetadata struct {
+	// The ID of the SAML identity provider.
+	//
+	// in: path
+	// required: true
+	Provider string `json:"provider"`
+}
+
+// swagger:route GET /self-service/methods/saml/metadata/{provider} frontend getSamlServiceProviderMetadata
+//
+// # Get SAML 2.0 Service Provider Metadata
+//
+// Returns the metadata of the service provider for the given SAML identity
+// provider. The identity provider is configured with this metadata.
+//
+//	Produces:
+//	- application/samlmetadata+xml
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: emptyResponse
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (s *Strategy) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	p, err := s.samlProvider(ctx, r.PathValue("provider"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	cert, _, err := p.keyPair()
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	metadata, err := serviceProviderMetadata(p.EntityID(ctx), p.ACSURL(ctx), p.Config().SAMLNameIDFormat, cert)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(metadata)
+}
+
+// handleACS is the assertion consumer service. It validates the SAML response
+// and redirects to the callback, which continues the flow like an OpenID
+// Connect callback. The redirect is necessary because the identity provider
+// posts the response cross-site, so the browser does not send the continuity
+// cookie of the flow.
+func (s *Strategy) handleACS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(herodot.ErrBadRequest().WithReasonf("Unable to parse the SAML response: %s", err)))
+		return
+	}
+	state := r.PostForm.Get("RelayState")
+	if state == "" {
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, errors.WithStack(herodot.ErrBadRequest().WithReason("The SAML response has no RelayState. Sign ins initiated by the identity provider are not supported.")))
+		return
+	}
+
+	p, err := s.samlProvider(ctx, r.PathValue("provider"))
+	if err != nil {
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	callback := p.callbackURL(ctx)
+	query := callback.Query()
+	query.Set("state", state)
+	redirect := func() {
+		callback.RawQuery = query.Encode()
+		http.Redirect(w, r, callback.String(), http.StatusSeeOther)
+	}
+	rejected := func(err error) {
+		s.d.Logger().WithRequest(r).WithError(err).WithField("provider", p.Config().ID).Info("Rejected a SAML response.")
+		query.Set("error", "invalid_saml_response")
+		query.Set("error_description", err.Error())
+		redirect()
+	}
+
+	idp, a, err := p.validate(ctx, r.PostForm.Get("SAMLResponse"), state)
+	if errors.Is(err, herodot.ErrMisconfiguration()) || errors.Is(err, herodot.ErrUpstreamError()) {
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	} else if err != nil {
+		rejected(err)
+		return
+	}
+
+	if err := s.d.SAMLPersister().ConsumeSAMLAssertion(ctx, p.Config().ID, a.id, a.expiresAt); errors.Is(err, sqlcon.ErrUniqueViolation()) {
+		rejected(errors.New("the assertion was already used"))
+		return
+	} else if err != nil {
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	encrypted, err := p.encryptCode(ctx, claimsFromAssertion(idp, a), state)
+	if err != nil {
+		s.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+	query.Set("code", encrypted)
+	redirect()
+}
+
+// enabled returns whether the method is enabled. The strategy is asked to
+// populate flows even if it is disabled, because it supports organizations.
+func (s *Strategy) enabled(ctx context.Context) bool {
+	return s.d.Config().SelfServiceStrategy(ctx, s.ID().String()).Enabled
+}
+
+// populate adds the providers of the organization to flows of an
+// organization, and delegates all other flows to the OpenID Connect strategy.
+func (s *Strategy) populate(r *http.Request, f flow.Flow, organizationID uuid.NullUUID, message func(provider string, providerID string) *text.Message, populate func() error) error {
+	if !s.enabled(r.Context()) {
+		return nil
+	}
+	if !organizationID.Valid {
+		return populate()
+	}
+
+	conf, err := s.Config(r.Context())
+	if err != nil {
+		return err
+	}
+
+	f.GetUI().SetCSRF(s.d.GenerateCSRFToken(r))
+	for _, p := range conf.Providers {
+		if p.OrganizationID == organizationID.UUID.String() {
+			oidc.AddProvider(f.GetUI(), p.ID, message(cmp.Or(p.Label, p.ID), p.ID), s.ID())
+		}
+	}
+	return nil
+}
+
+func (s *Strategy) PopulateLoginMethodFirstFactor(r *http.Request, f *login.Flow) error {
+	return s.populate(r, f, f.OrganizationID, text.NewInfoLoginWith, func() error {
+		return s.Strategy.PopulateLoginMethodFirstFactor(r, f)
+	})
+}
+
+func (s *Strategy) PopulateLoginMethodFirstFactorRefresh(r *http.Request, f *login.Flow, sess *session.Session) error {
+	if !s.enabled(r.Context()) {
+		return nil
+	}
+	return s.Strategy.PopulateLoginMethodFirstFactorRefresh(r, f, sess)
+}
+
+func (s *Strategy) PopulateLoginMethodIdentifierFirstIdentification(r *http.Request, f *login.Flow) error {
+	return s.populate(r, f, f.OrganizationID, text.NewInfoLoginWith, func() error {
+		return s.Strategy.PopulateLoginMethodIdentifierFirstIdentification(r, f)
+	})
+}
+
+func (s *Strategy) PopulateLoginMethodIdentifierFirstCredentials(r *http.Request, f *login.Flow, mods ...login.FormHydratorModifier) error {
+	if !s.enabled(r.Context()) {
+		return errors.WithStack(idfirst.ErrNoCredentialsFound)
+	}
+	return s.Strategy.PopulateLoginMethodIdentifierFirstCredentials(r, f, mods...)
+}
+
+func (s *Strategy) PopulateRegistrationMethod(r *http.Request, f *registration.Flow) error {
+	return s.populate(r, f, f.OrganizationID, text.NewInfoRegistrationWith, func() error {
+		return s.Strategy.PopulateRegistrationMethod(r, f)
+	})
+}
+
+func (s *Strategy) PopulateRegistrationMethodProfile(r *http.Request, f *registration.Flow, options ...registration.FormHydratorModifier) error {
+	return s.populate(r, f, f.OrganizationID, text.NewInfoRegistrationWith, func() error {
+		return s.Strategy.PopulateRegistrationMethodProfile(r, f, options...)
+	})
+}
+
+func (s *Strategy) PopulateRegistrationMethodCredentials(r *http.Request, f *registration.Flow, options ...registration.FormHydratorModifier) error {
+	return s.populate(r, f, f.OrganizationID, text.NewInfoRegistrationWith, func() error {
+		return s.Strategy.PopulateRegistrationMethodCredentials(r, f, options...)
+	})
+}
+
+func (s *Strategy) PopulateSettingsMethod(ctx context.Context, r *http.Request, id *identity.Identity, f *settings.Flow) error {
+	if !s.enabled(ctx) {
+		return nil
+	}
+	return s.Strategy.PopulateSettingsMethod(ctx, r, id, f)
+}
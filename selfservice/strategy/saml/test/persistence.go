@@ -0,0 +1,68 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/pkg/testhelpers"
+	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlcon"
+)
+
+func TestPersister(ctx context.Context, p interface {
+	persistence.Persister
+},
+) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, p := testhelpers.NewNetworkUnlessExisting(t, ctx, p)
+
+		newAssertionID := func() string {
+			return "id-" + randx.MustString(32, randx.AlphaLowerNum)
+		}
+		expiresAt := func() time.Time {
+			return time.Now().Add(time.Hour)
+		}
+
+		t.Run("case=rejects a replayed assertion", func(t *testing.T) {
+			id := newAssertionID()
+
+			require.NoError(t, p.ConsumeSAMLAssertion(ctx, "provider-a", id, expiresAt()))
+			require.ErrorIs(t, p.ConsumeSAMLAssertion(ctx, "provider-a", id, expiresAt()), sqlcon.ErrUniqueViolation())
+		})
+
+		t.Run("case=scopes assertions to the provider", func(t *testing.T) {
+			id := newAssertionID()
+
+			require.NoError(t, p.ConsumeSAMLAssertion(ctx, "provider-a", id, expiresAt()))
+			require.NoError(t, p.ConsumeSAMLAssertion(ctx, "provider-b", id, expiresAt()))
+		})
+
+		t.Run("case=deletes expired assertions", func(t *testing.T) {
+			expired, valid := newAssertionID(), newAssertionID()
+
+			require.NoError(t, p.ConsumeSAMLAssertion(ctx, "provider-a", expired, time.Now().Add(-time.Minute)))
+			require.NoError(t, p.ConsumeSAMLAssertion(ctx, "provider-a", valid, expiresAt()))
+
+			require.NoError(t, p.DeleteExpiredSAMLAssertions(ctx, time.Now(), 1000))
+
+			require.NoError(t, p.ConsumeSAMLAssertion(ctx, "provider-a", expired, expiresAt()))
+			require.ErrorIs(t, p.ConsumeSAMLAssertion(ctx, "provider-a", valid, expiresAt()), sqlcon.ErrUniqueViolation(), "valid assertions must be kept")
+		})
+
+		t.Run("case=network isolation", func(t *testing.T) {
+			id := newAssertionID()
+
+			require.NoError(t, p.ConsumeSAMLAssertion(ctx, "provider-a", id, expiresAt()))
+
+			_, other := testhelpers.NewNetwork(t, ctx, p)
+			require.NoError(t, other.ConsumeSAMLAssertion(ctx, "provider-a", id, expiresAt()))
+		})
+	}
+}
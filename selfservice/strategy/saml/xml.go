@@ -0,0 +1,95 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/pkg/errors"
+)
+
+// parseXML parses a document and returns its root element. Documents with a
+// document type declaration are rejected, so that they can not declare
+// entities.
+func parseXML(data []byte) (*etree.Element, error) {
+	// etree does not check that the elements are balanced, so the document is
+	// checked with encoding/xml first.
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = true
+	var roots int
+	for depth := 0; ; {
+		token, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && len(bytes.TrimSpace(t)) > 0 {
+				return nil, errors.New("the document has text outside of the root element")
+			}
+		case xml.Directive:
+			return nil, errors.New("documents with a document type declaration are not supported")
+		}
+	}
+	if roots != 1 {
+		return nil, errors.New("the document must have exactly one root element")
+	}
+
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return doc.Root(), nil
+}
+
+// isElement returns true if the element has the namespace and local name.
+func isElement(e *etree.Element, ns, local string) bool {
+	return e.Tag == local && e.NamespaceURI() == ns
+}
+
+// childElements returns the child elements with the namespace and local name.
+func childElements(e *etree.Element, ns, local string) (children []*etree.Element) {
+	for _, c := range e.ChildElements() {
+		if isElement(c, ns, local) {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+// childElement returns the first child element with the namespace and local
+// name, or nil if there is none.
+func childElement(e *etree.Element, ns, local string) *etree.Element {
+	if children := childElements(e, ns, local); len(children) > 0 {
+		return children[0]
+	}
+	return nil
+}
+
+// elementText returns the whitespace trimmed text content of the element.
+// Unlike etree's Text, it includes the text after comments and child
+// elements.
+func elementText(e *etree.Element) string {
+	var b strings.Builder
+	for _, c := range e.Child {
+		if c, ok := c.(*etree.CharData); ok {
+			b.WriteString(c.Data)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
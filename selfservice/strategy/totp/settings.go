@@ -28,6 +28,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/settings"
@@ -238,6 +239,7 @@ func (s *Strategy) continueSettingsFlowRemoveTOTP(ctx context.Context, ctxUpdate
 	}
 
 	i.DeleteCredentialsType(identity.CredentialsTypeTOTP)
+	ctxUpdate.AddSecurityNotification(identity.SecurityNotification{TemplateType: template.TypeTOTPRemoved})
 	return i, nil
 }
 
@@ -0,0 +1,215 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/geoip"
+)
+
+// BindingMode configures what happens if a session is used from a client
+// which does not match the session's binding.
+type BindingMode string
+
+const (
+	// BindingModeOff disables session binding.
+	BindingModeOff BindingMode = "off"
+
+	// BindingModeWarn reports the mismatch but accepts the session.
+	BindingModeWarn BindingMode = "warn"
+
+	// BindingModeReauthenticate rejects the session for the mismatching
+	// request, so that the client has to sign in again. The session stays
+	// valid for the client it is bound to.
+	BindingModeReauthenticate BindingMode = "reauthenticate"
+
+	// BindingModeRevoke rejects and revokes the session.
+	BindingModeRevoke BindingMode = "revoke"
+)
+
+const (
+	BindingAttributeCountry     = "country"
+	BindingAttributeASN         = "asn"
+	BindingAttributeUserAgent   = "user_agent"
+	BindingAttributeClientHints = "client_hints"
+)
+
+// Binding captures properties of the client which signed in. They are
+// compared against the requests which use the session to detect stolen
+// session cookies and tokens.
+//
+// The values are coarse on purpose: browser updates or a changed IP address
+// within the same network must not invalidate the session.
+type Binding struct {
+	// Country is the ISO 3166-1 alpha-2 code of the client IP's country.
+	Country string `json:"country,omitempty"`
+
+	// ASN is the autonomous system number of the client IP's network.
+	ASN string `json:"asn,omitempty"`
+
+	// UserAgentFamily is the browser and operating system family, without
+	// versions, derived from the User-Agent header.
+	UserAgentFamily string `json:"user_agent_family,omitempty"`
+
+	// ClientHints is a hash of the low-entropy User-Agent client hints,
+	// without versions.
+	ClientHints string `json:"client_hints,omitempty"`
+}
+
+// NewBinding returns the binding of the client which sent the request. loc is
+// the resolved location of the client.
+func NewBinding(r *http.Request, loc *geoip.Location) Binding {
+	return Binding{
+		Country:         strings.ToUpper(loc.Country),
+		ASN:             loc.ASN,
+		UserAgentFamily: userAgentFamily(r.Header.Get("User-Agent")),
+		ClientHints:     clientHintsFingerprint(r.Header),
+	}
+}
+
+func (b Binding) IsZero() bool {
+	return b == Binding{}
+}
+
+// Changes returns the given attributes whose values differ between the
+// binding and the other binding. Attributes which are unknown for the binding
+// are not compared. The location attributes are not compared either if the
+// server could not resolve them for the other binding, so that a GeoIP failure
+// does not invalidate sessions. The client attributes are derived from
+// headers the client can leave out to evade the comparison, so a missing
+// client attribute is a change.
+func (b Binding) Changes(other Binding, attributes []string) []string {
+	var changes []string
+	for _, attribute := range attributes {
+		var expected, actual string
+		switch attribute {
+		case BindingAttributeCountry:
+			if other.Country == "" {
+				continue
+			}
+			expected, actual = b.Country, other.Country
+		case BindingAttributeASN:
+			if other.ASN == "" {
+				continue
+			}
+			expected, actual = b.ASN, other.ASN
+		case BindingAttributeUserAgent:
+			expected, actual = b.UserAgentFamily, other.UserAgentFamily
+		case BindingAttributeClientHints:
+			expected, actual = b.ClientHints, other.ClientHints
+		default:
+			continue
+		}
+		if expected != "" && expected != actual {
+			changes = append(changes, attribute)
+		}
+	}
+	return changes
+}
+
+// Scan implements the Scanner interface.
+func (b *Binding) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	v := fmt.Sprintf("%s", value)
+	if len(v) == 0 {
+		return nil
+	}
+	return errors.WithStack(json.Unmarshal([]byte(v), b))
+}
+
+// Value implements the driver Valuer interface.
+func (b Binding) Value() (driver.Value, error) {
+	if b.IsZero() {
+		return nil, nil
+	}
+	value, err := json.Marshal(b)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return string(value), nil
+}
+
+// userAgentFamily returns the browser and operating system family of the
+// User-Agent header, e.g. "chrome/windows".
+func userAgentFamily(ua string) string {
+	if ua == "" {
+		return ""
+	}
+
+	var browser string
+	switch {
+	case strings.Contains(ua, "Edg/"), strings.Contains(ua, "EdgA/"), strings.Contains(ua, "EdgiOS/"):
+		browser = "edge"
+	case strings.Contains(ua, "OPR/"), strings.Contains(ua, "Opera"):
+		browser = "opera"
+	case strings.Contains(ua, "SamsungBrowser/"):
+		browser = "samsung"
+	case strings.Contains(ua, "Firefox/"), strings.Contains(ua, "FxiOS/"):
+		browser = "firefox"
+	case strings.Contains(ua, "Chrome/"), strings.Contains(ua, "CriOS/"):
+		browser = "chrome"
+	case strings.Contains(ua, "Safari/"):
+		browser = "safari"
+	default:
+		browser = "other"
+	}
+
+	var os string
+	switch {
+	case strings.Contains(ua, "Windows"):
+		os = "windows"
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"), strings.Contains(ua, "iPod"):
+		os = "ios"
+	case strings.Contains(ua, "Android"):
+		os = "android"
+	case strings.Contains(ua, "Mac OS X"), strings.Contains(ua, "Macintosh"):
+		os = "macos"
+	case strings.Contains(ua, "CrOS"):
+		os = "chromeos"
+	case strings.Contains(ua, "Linux"):
+		os = "linux"
+	default:
+		os = "other"
+	}
+
+	return browser + "/" + os
+}
+
+// clientHintsFingerprint hashes the brands, platform, and mobile hint of the
+// request. Brand versions and GREASE brands change with browser updates and
+// are left out.
+func clientHintsFingerprint(h http.Header) string {
+	platform := strings.Trim(h.Get("Sec-Ch-Ua-Platform"), `"`)
+	mobile := h.Get("Sec-Ch-Ua-Mobile")
+
+	var brands []string
+	for _, brand := range strings.Split(h.Get("Sec-Ch-Ua"), ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(brand), ";")
+		name = strings.Trim(name, `"`)
+		if name == "" || strings.HasPrefix(strings.ToLower(name), "not") {
+			continue
+		}
+		brands = append(brands, name)
+	}
+
+	if platform == "" && mobile == "" && len(brands) == 0 {
+		return ""
+	}
+
+	slices.Sort(brands)
+	sum := sha256.Sum256([]byte(strings.Join(brands, ",") + "|" + platform + "|" + mobile))
+	return hex.EncodeToString(sum[:16])
+}
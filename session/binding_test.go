@@ -0,0 +1,78 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAgentFamily(t *testing.T) {
+	for _, tc := range []struct {
+		ua, expected string
+	}{
+		{"", ""},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36", "chrome/windows"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51", "edge/windows"},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4_1) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4.1 Safari/605.1.15", "safari/macos"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/124.0.6367.88 Mobile/15E148 Safari/604.1", "chrome/ios"},
+		{"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36", "chrome/android"},
+		{"Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0", "firefox/linux"},
+		{"curl/8.7.1", "other/other"},
+	} {
+		t.Run("ua="+tc.ua, func(t *testing.T) {
+			assert.Equal(t, tc.expected, userAgentFamily(tc.ua))
+		})
+	}
+}
+
+func TestClientHintsFingerprint(t *testing.T) {
+	newHeader := func(ua, platform, mobile string) http.Header {
+		h := http.Header{}
+		h.Set("Sec-Ch-Ua", ua)
+		h.Set("Sec-Ch-Ua-Platform", platform)
+		h.Set("Sec-Ch-Ua-Mobile", mobile)
+		return h
+	}
+
+	assert.Empty(t, clientHintsFingerprint(http.Header{}))
+
+	expected := clientHintsFingerprint(newHeader(`"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`, `"Windows"`, "?0"))
+	require.NotEmpty(t, expected)
+
+	assert.Equal(t, expected, clientHintsFingerprint(newHeader(`"Not/A)Brand";v="8", "Google Chrome";v="125", "Chromium";v="125"`, `"Windows"`, "?0")),
+		"versions, GREASE brands, and the order of brands must not change the fingerprint")
+	assert.NotEqual(t, expected, clientHintsFingerprint(newHeader(`"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`, `"macOS"`, "?0")))
+	assert.NotEqual(t, expected, clientHintsFingerprint(newHeader(`"Chromium";v="124", "Microsoft Edge";v="124", "Not-A.Brand";v="99"`, `"Windows"`, "?0")))
+}
+
+func TestBindingChanges(t *testing.T) {
+	all := []string{BindingAttributeCountry, BindingAttributeASN, BindingAttributeUserAgent, BindingAttributeClientHints}
+	b := Binding{Country: "DE", ASN: "3320", UserAgentFamily: "chrome/windows", ClientHints: "abc"}
+
+	assert.Empty(t, b.Changes(b, all))
+	assert.Empty(t, Binding{}.Changes(b, all), "values unknown for the session are not compared")
+	assert.Equal(t, []string{BindingAttributeUserAgent, BindingAttributeClientHints}, b.Changes(Binding{}, all),
+		"client values missing from the request are a change, unresolved locations are not compared")
+	assert.Equal(t, all, b.Changes(Binding{Country: "US", ASN: "7922", UserAgentFamily: "firefox/linux", ClientHints: "def"}, all))
+	assert.Equal(t, []string{BindingAttributeUserAgent}, b.Changes(Binding{Country: "US", UserAgentFamily: "firefox/linux"}, []string{BindingAttributeUserAgent}),
+		"only the configured attributes are compared")
+}
+
+func TestBindingValue(t *testing.T) {
+	v, err := Binding{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	b := Binding{Country: "DE", UserAgentFamily: "chrome/windows"}
+	v, err = b.Value()
+	require.NoError(t, err)
+
+	var actual Binding
+	require.NoError(t, actual.Scan(v))
+	assert.Equal(t, b, actual)
+}
@@ -32,9 +32,13 @@ import (
 
 	"github.com/ory/herodot"
 
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/x"
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/urlx"
 )
 
 type (
@@ -60,6 +64,9 @@ type (
 		sessiontokenexchange.PersistenceProvider
 		FlowForTokenExchangeProvider
 		TokenizerProvider
+		identity.PrivilegedPoolProvider
+		identity.ManagementProvider
+		errorx.ManagementProvider
 	}
 	HandlerProvider interface {
 		SessionHandler() *Handler
@@ -74,12 +81,14 @@ const (
 	RouteExchangeCodeForSessionToken = RouteCollection + "/token-exchange" // #nosec G101
 	RouteWhoami                      = RouteCollection + "/whoami"
 	RouteSession                     = RouteCollection + "/{id}"
+	RouteRevokeUnrecognized          = RouteCollection + "/revoke-unrecognized"
 )
 
 const (
-	AdminRouteIdentity           = "/identities"
-	AdminRouteIdentitiesSessions = AdminRouteIdentity + "/{id}/sessions"
-	AdminRouteSessionExtendId    = RouteSession + "/extend"
+	AdminRouteIdentity            = "/identities"
+	AdminRouteIdentitiesSessions  = AdminRouteIdentity + "/{id}/sessions"
+	AdminRouteSessionExtendId     = RouteSession + "/extend"
+	AdminRouteIdentityImpersonate = AdminRouteIdentity + "/{id}/impersonate"
 
 	// ManageSessionsMaxIDs caps the number of explicit IDs accepted per call.
 	// Picked defensively — not validated against a production dataset.
@@ -102,6 +111,7 @@ func (h *Handler) RegisterAdminRoutes(admin *httprouterx.RouterAdmin) {
 	admin.GET(AdminRouteIdentitiesSessions, h.listIdentitySessions)
 	admin.DELETE(AdminRouteIdentitiesSessions, h.deleteIdentitySessions)
 	admin.PATCH(AdminRouteSessionExtendId, h.adminSessionExtend)
+	admin.POST(AdminRouteIdentityImpersonate, h.adminImpersonateIdentity)
 	admin.POST(RouteCollection, h.manageSessions)
 
 	admin.DELETE(RouteCollection, redir.RedirectToPublicRoute(h.r))
@@ -115,6 +125,7 @@ func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
 	h.r.CSRFHandler().IgnoreGlob(RouteCollection + "/*")
 	h.r.CSRFHandler().IgnoreGlob(RouteCollection + "/*/extend")
 	h.r.CSRFHandler().IgnoreGlob(AdminRouteIdentity + "/*/sessions")
+	h.r.CSRFHandler().IgnoreGlob(AdminRouteIdentity + "/*/impersonate")
 
 	for _, m := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodConnect, http.MethodOptions, http.MethodTrace} {
 		public.Handle(m+" "+RouteWhoami, http.HandlerFunc(h.whoami))
@@ -125,8 +136,10 @@ func (h *Handler) RegisterPublicRoutes(public *httprouterx.RouterPublic) {
 	public.GET(RouteCollection, h.listMySessions)
 
 	public.GET(RouteExchangeCodeForSessionToken, h.exchangeCode)
+	public.POST(RouteRevokeUnrecognized, h.revokeUnrecognizedSession)
 
 	public.DELETE(AdminRouteIdentitiesSessions, redir.RedirectToAdminRoute(h.r))
+	public.POST(AdminRouteIdentityImpersonate, redir.RedirectToAdminRoute(h.r))
 }
 
 // Check Session Request Parameters
@@ -289,6 +302,11 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request) {
 		if c.SessionWhoAmICachingMaxAge(ctx) > 0 && expiry > c.SessionWhoAmICachingMaxAge(ctx) {
 			expiry = c.SessionWhoAmICachingMaxAge(ctx)
 		}
+		// Cached responses do not keep the session alive. Caching them for longer than the last seen
+		// update interval would let sessions which are in use run into their idle timeout.
+		if s.IdleExpiresAt != nil {
+			expiry = min(expiry, time.Until(time.Time(*s.IdleExpiresAt)), lastSeenUpdateInterval)
+		}
 
 		w.Header().Set("Ory-Session-Cache-For", fmt.Sprintf("%0.f", expiry.Seconds()))
 	}
@@ -738,9 +756,29 @@ func (h *Handler) deleteMySessions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if n > 0 && h.r.Config().CourierSecurityNotificationsEnabled(r.Context()) {
+		h.notifySessionsRevoked(r, s.IdentityID)
+	}
+
 	h.r.Writer().WriteCode(w, r, http.StatusOK, &deleteMySessionsCount{Count: n})
 }
 
+// notifySessionsRevoked queues the sessions revoked security notification to
+// the identity. Errors are only logged because the sessions were revoked
+// successfully.
+func (h *Handler) notifySessionsRevoked(r *http.Request, identityID uuid.UUID) {
+	ctx := r.Context()
+	i, err := h.r.PrivilegedIdentityPool().GetIdentity(ctx, identityID, identity.ExpandDefault)
+	if err == nil {
+		err = h.r.IdentityManager().SendSecurityNotification(ctx, i, identity.SecurityNotification{TemplateType: template.TypeSessionsRevoked})
+	}
+	if err != nil {
+		h.r.Logger().WithRequest(r).WithError(err).
+			WithField("identity_id", identityID).
+			Warn("Failed to queue one or more security notifications.")
+	}
+}
+
 // Disable My Session Parameters
 //
 // swagger:parameters disableMySession
@@ -1006,6 +1044,133 @@ func (h *Handler) adminSessionExtend(w http.ResponseWriter, r *http.Request) {
 	h.r.Writer().Write(w, r, s)
 }
 
+// Impersonate Identity Request Body
+//
+// swagger:model impersonateIdentityBody
+type ImpersonateIdentityBody struct {
+	// Actor identifies the administrator who impersonates the identity, for
+	// example the support agent's email address. It is recorded in the
+	// session's authentication methods and in the emitted event.
+	//
+	// required: true
+	Actor string `json:"actor"`
+}
+
+// Impersonate Identity Parameters
+//
+// swagger:parameters impersonateIdentity
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type impersonateIdentity struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: body
+	Body ImpersonateIdentityBody
+}
+
+// The Response for Impersonation Sessions
+//
+// swagger:model successfulImpersonation
+type ImpersonationResponse struct {
+	// The Session Token
+	//
+	// The session token is sent in the HTTP Authorization header to act as the identity:
+	//
+	// 		Authorization: bearer ${session-token}
+	//
+	// required: true
+	Token string `json:"session_token"`
+
+	// The Session
+	//
+	// required: true
+	Session *Session `json:"session"`
+}
+
+// swagger:route POST /admin/identities/{id}/impersonate identity impersonateIdentity
+//
+// # Impersonate an Identity
+//
+// Calling this endpoint issues a session for the given identity, which allows support staff to see the
+// product as the identity sees it. The session's authentication methods contain the `impersonation`
+// method together with the administrator given in the `actor` field.
+//
+// Impersonation sessions expire after `session.impersonation.lifespan`, can not be extended, and are
+// never privileged. They can therefore not be used to change the identity's credentials.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: successfulImpersonation
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-admin-low
+func (h *Handler) adminImpersonateIdentity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.FromString(r.PathValue("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()).WithDebug("could not parse UUID")))
+		return
+	}
+
+	var body ImpersonateIdentityBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithError(err.Error()).WithReason("Invalid JSON body.")))
+		return
+	}
+	if body.Actor == "" {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest().WithReason("The 'actor' field must identify the administrator who impersonates the identity.")))
+		return
+	}
+
+	i, err := h.r.PrivilegedIdentityPool().GetIdentity(ctx, id, identity.ExpandDefault)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s := NewInactiveSession()
+	s.CompletedImpersonationBy(body.Actor)
+	if err := h.r.SessionManager().ActivateSession(r, s, i, time.Now().UTC()); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+	s.ExpiresAt = s.AuthenticatedAt.Add(h.r.Config().SessionImpersonationLifespan(ctx))
+	// The session is used by the administrator's browser, not by the client
+	// which called this endpoint.
+	s.Binding = Binding{}
+
+	if err := h.r.SessionPersister().UpsertSession(ctx, s); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionImpersonated(ctx, s.ID, s.IdentityID, body.Actor, s.ExpiresAt))
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(h.r.Config().SelfAdminURL(ctx), "admin", "sessions", s.ID.String()).String(),
+		&ImpersonationResponse{Token: s.Token, Session: s},
+	)
+}
+
 func (h *Handler) IsNotAuthenticated(wrap http.HandlerFunc, onAuthenticated http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if err := h.r.SessionManager().SessionActiveForRequest(r.Context(), r); err != nil {
@@ -1161,6 +1326,89 @@ func (h *Handler) exchangeCode(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// Revoke Unrecognized Session Parameters
+//
+// swagger:parameters revokeUnrecognizedSession
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type revokeUnrecognizedSession struct {
+	// in: body
+	// required: true
+	Body revokeUnrecognizedSessionBody
+}
+
+// Revoke Unrecognized Session Body
+//
+// swagger:model revokeUnrecognizedSessionBody
+type revokeUnrecognizedSessionBody struct {
+	// The token of the link which was sent in the new device notification.
+	//
+	// required: true
+	Token string `json:"token"`
+}
+
+// swagger:route POST /sessions/revoke-unrecognized frontend revokeUnrecognizedSession
+//
+// # Revoke a Session the User Does Not Recognize
+//
+// The "this wasn't me" link of the `login_new_device` notification opens the session revocation UI with the
+// link's token in the `token` query parameter. The UI asks the user to confirm, because mail scanners and link
+// previews follow links in notifications, and submits the token to this endpoint. It revokes the session the
+// notification is about and redirects the browser to the recovery UI, so that the user can regain control of the
+// account. If account recovery is disabled, the browser is redirected to the default return URL.
+//
+// The signed token of the link authorizes the request, which is why it does not require a CSRF token.
+//
+// This endpoint is not meant to be called by applications.
+//
+//	Consumes:
+//	- application/x-www-form-urlencoded
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  303: emptyResponse
+//	  default: errorGeneric
+//
+//	Extensions:
+//	  x-ory-ratelimit-bucket: kratos-public-low
+func (h *Handler) revokeUnrecognizedSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sessionID, err := ParseRevocationToken(h.r.Config().SecretsSession(ctx), r.PostFormValue("token"))
+	if err != nil {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	s, err := h.r.SessionPersister().GetSession(ctx, sessionID, ExpandNothing)
+	if err != nil {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	// Revoking is idempotent, so that following the link again still starts
+	// the recovery.
+	if err := h.r.SessionPersister().RevokeSession(ctx, s.IdentityID, s.ID); err != nil {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionReportedByUser(ctx, s.ID, s.IdentityID))
+	h.r.Logger().
+		WithRequest(r).
+		WithField("session_id", s.ID).
+		WithField("identity_id", s.IdentityID).
+		Info("Revoked a session which the user did not recognize.")
+
+	returnTo := h.r.Config().SelfServiceBrowserDefaultReturnTo(ctx)
+	if h.r.Config().SelfServiceFlowRecoveryEnabled(ctx) {
+		returnTo = h.r.Config().SelfServiceFlowRecoveryUI(ctx)
+	}
+	http.Redirect(w, r, returnTo.String(), http.StatusSeeOther)
+}
+
 // ManageSessionsAction enumerates the supported actions for the manage-sessions
 // endpoint.
 //
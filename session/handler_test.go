@@ -1332,6 +1332,165 @@ func TestHandlerRefreshSessionBySessionID(t *testing.T) {
 	})
 }
 
+func TestHandlerAdminImpersonateIdentity(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+		configx.WithValues(map[string]any{
+			config.ViperKeySessionLifespan:                                  "24h",
+			config.ViperKeySessionImpersonationLifespan:                     "15m",
+			config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter: "0s",
+		}),
+	)
+	publicServer, adminServer, _, _ := testhelpers.NewKratosServerWithCSRFAndRouters(t, reg)
+
+	i := identity.NewIdentity("")
+	require.NoError(t, reg.IdentityManager().Create(t.Context(), i))
+
+	impersonate := func(t *testing.T, id string, body string) (*http.Response, []byte) {
+		req, err := http.NewRequest("POST", adminServer.URL+"/admin/identities/"+id+"/impersonate", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		res, err := adminServer.Client().Do(req)
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		return res, ioutilx.MustReadAll(res.Body)
+	}
+
+	t.Run("case=issues an impersonation session", func(t *testing.T) {
+		res, body := impersonate(t, i.ID.String(), `{"actor":"support@example.com"}`)
+		require.Equal(t, http.StatusCreated, res.StatusCode, "%s", body)
+
+		token := gjson.GetBytes(body, "session_token").String()
+		require.NotEmpty(t, token, "%s", body)
+		assert.Equal(t, i.ID.String(), gjson.GetBytes(body, "session.identity.id").String(), "%s", body)
+		assert.Equal(t, string(identity.CredentialsTypeImpersonation), gjson.GetBytes(body, "session.authentication_methods.0.method").String(), "%s", body)
+		assert.Equal(t, "support@example.com", gjson.GetBytes(body, "session.authentication_methods.0.impersonated_by").String(), "%s", body)
+
+		expiresAt := gjson.GetBytes(body, "session.expires_at").Time()
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, time.Minute, "%s", body)
+
+		req := testhelpers.NewTestHTTPRequest(t, "GET", publicServer.URL+"/sessions/whoami", nil)
+		req.Header.Set("X-Session-Token", token)
+		whoami, err := publicServer.Client().Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, whoami.StatusCode)
+
+		s, err := reg.SessionPersister().GetSession(t.Context(), uuid.FromStringOrNil(gjson.GetBytes(body, "session.id").String()), ExpandEverything)
+		require.NoError(t, err)
+		assert.True(t, s.IsImpersonated())
+		assert.False(t, reg.SessionManager().IsPrivileged(t.Context(), s), "impersonation sessions must never be privileged")
+
+		require.NoError(t, reg.SessionPersister().ExtendSession(t.Context(), s.ID))
+		extended, err := reg.SessionPersister().GetSession(t.Context(), s.ID, ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, s.ExpiresAt, extended.ExpiresAt, "impersonation sessions must not be extended")
+	})
+
+	t.Run("case=requires an actor", func(t *testing.T) {
+		res, body := impersonate(t, i.ID.String(), `{}`)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=should return 400 when bad UUID is sent", func(t *testing.T) {
+		res, body := impersonate(t, "BADUUID", `{"actor":"support@example.com"}`)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=should return 404 for unknown identities", func(t *testing.T) {
+		res, body := impersonate(t, x.NewUUID().String(), `{"actor":"support@example.com"}`)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=should not issue sessions for inactive identities", func(t *testing.T) {
+		inactive := identity.NewIdentity("")
+		inactive.State = identity.StateInactive
+		require.NoError(t, reg.IdentityManager().Create(t.Context(), inactive))
+
+		res, body := impersonate(t, inactive.ID.String(), `{"actor":"support@example.com"}`)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+	})
+
+	t.Run("case=should redirect from the public server", func(t *testing.T) {
+		client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+		req := testhelpers.NewTestHTTPRequest(t, "POST", publicServer.URL+"/identities/"+i.ID.String()+"/impersonate", strings.NewReader(`{"actor":"support@example.com"}`))
+		res, err := client.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+		assert.Equal(t, reg.Config().SelfAdminURL(t.Context()).Host, urlx.ParseOrPanic(res.Header.Get("Location")).Host)
+	})
+}
+
+func TestHandlerRevokeUnrecognizedSession(t *testing.T) {
+	t.Parallel()
+
+	conf, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+		configx.WithValues(map[string]any{
+			config.ViperKeySelfServiceRecoveryEnabled:     true,
+			config.ViperKeySelfServiceRecoveryUI:          "https://www.ory.sh/recovery",
+			config.ViperKeySelfServiceErrorUI:             "https://www.ory.sh/error",
+			config.ViperKeySelfServiceSessionRevocationUI: "https://www.ory.sh/revoke",
+		}),
+	)
+	publicServer, _, _, _ := testhelpers.NewKratosServerWithCSRFAndRouters(t, reg)
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	submit := func(t *testing.T, u *url.URL) *http.Response {
+		res, err := client.PostForm(publicServer.URL+RouteRevokeUnrecognized, url.Values{"token": {u.Query().Get("token")}})
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		return res
+	}
+
+	t.Run("case=links to the revocation UI", func(t *testing.T) {
+		u := NewRevocationURL(t.Context(), conf, x.NewUUID(), time.Now().Add(time.Hour))
+		assert.Equal(t, "www.ory.sh", u.Host)
+		assert.Equal(t, "/revoke", u.Path)
+		assert.NotEmpty(t, u.Query().Get("token"))
+	})
+
+	t.Run("case=revokes the session and starts recovery", func(t *testing.T) {
+		s := testhelpers.CreateSession(t, reg)
+		u := NewRevocationURL(t.Context(), conf, s.ID, time.Now().Add(time.Hour))
+
+		for range 2 {
+			res := submit(t, u)
+			require.Equal(t, http.StatusSeeOther, res.StatusCode)
+			assert.Equal(t, "https://www.ory.sh/recovery", res.Header.Get("Location"))
+		}
+
+		actual, err := reg.SessionPersister().GetSession(t.Context(), s.ID, ExpandNothing)
+		require.NoError(t, err)
+		assert.False(t, actual.Active)
+	})
+
+	t.Run("case=rejects expired links", func(t *testing.T) {
+		s := testhelpers.CreateSession(t, reg)
+		u := NewRevocationURL(t.Context(), conf, s.ID, time.Now().Add(-time.Minute))
+		res := submit(t, u)
+		require.Equal(t, http.StatusSeeOther, res.StatusCode)
+		assert.Equal(t, "/error", urlx.ParseOrPanic(res.Header.Get("Location")).Path)
+
+		actual, err := reg.SessionPersister().GetSession(t.Context(), s.ID, ExpandNothing)
+		require.NoError(t, err)
+		assert.True(t, actual.Active)
+	})
+
+	t.Run("case=rejects tampered links", func(t *testing.T) {
+		u := NewRevocationURL(t.Context(), conf, x.NewUUID(), time.Now().Add(time.Hour))
+		q := u.Query()
+		q.Set("token", q.Get("token")[:20])
+		u.RawQuery = q.Encode()
+
+		res := submit(t, u)
+		require.Equal(t, http.StatusSeeOther, res.StatusCode)
+		assert.Equal(t, "/error", urlx.ParseOrPanic(res.Header.Get("Location")).Path)
+	})
+}
+
 func TestExchangeCode(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,69 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/sqlxx"
+)
+
+// lastSeenUpdateInterval is how often the last seen timestamp of a session
+// is written at most. It keeps the writes caused by frequent session checks
+// cheap.
+const lastSeenUpdateInterval = time.Minute
+
+// idleTimeoutProvider supplies the session idle timeout, with optional
+// per-organization overrides.
+type idleTimeoutProvider interface {
+	OrganizationSessionIdleTimeout(ctx context.Context, orgID uuid.UUID) time.Duration
+}
+
+// IsIdle returns true if the session was not used within its idle timeout.
+func (s *Session) IsIdle() bool {
+	return s.IdleExpiresAt != nil && !time.Time(*s.IdleExpiresAt).After(time.Now())
+}
+
+// SetLastSeen records that the session was used at the given time and moves
+// the idle expiry accordingly. If no idle timeout applies to the session, the
+// last seen timestamp and the idle expiry are cleared.
+func (s *Session) SetLastSeen(ctx context.Context, c idleTimeoutProvider, at time.Time) *Session {
+	timeout := c.OrganizationSessionIdleTimeout(ctx, s.OrganizationID())
+	if timeout <= 0 {
+		s.LastSeenAt, s.IdleExpiresAt = nil, nil
+		return s
+	}
+
+	at = at.UTC()
+	s.LastSeenAt = new(sqlxx.NullTime(at))
+	s.IdleExpiresAt = new(sqlxx.NullTime(at.Add(timeout)))
+	return s
+}
+
+// NeedsLastSeenUpdate returns true if the last seen timestamp of the session
+// is outdated and has to be written. To keep checking sessions cheap, the
+// timestamp is written at most once per lastSeenUpdateInterval, or more often
+// for idle timeouts shorter than ten times that interval.
+func (s *Session) NeedsLastSeenUpdate(ctx context.Context, c idleTimeoutProvider, now time.Time) bool {
+	timeout := c.OrganizationSessionIdleTimeout(ctx, s.OrganizationID())
+	if timeout <= 0 {
+		// Clears the idle expiry if the idle timeout was disabled.
+		return s.LastSeenAt != nil || s.IdleExpiresAt != nil
+	}
+	if s.LastSeenAt == nil || s.IdleExpiresAt == nil {
+		return true
+	}
+
+	lastSeenAt := time.Time(*s.LastSeenAt)
+	// Some databases store timestamps with second precision only.
+	if drift := lastSeenAt.Add(timeout).Sub(time.Time(*s.IdleExpiresAt)); drift > time.Second || drift < -time.Second {
+		// The idle timeout was changed since the session was last seen.
+		return true
+	}
+
+	return now.Sub(lastSeenAt) >= min(lastSeenUpdateInterval, timeout/10)
+}
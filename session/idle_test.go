@@ -0,0 +1,112 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/session"
+	"github.com/ory/x/sqlxx"
+)
+
+type idleTimeoutProvider time.Duration
+
+func (p idleTimeoutProvider) OrganizationSessionIdleTimeout(context.Context, uuid.UUID) time.Duration {
+	return time.Duration(p)
+}
+
+func TestSessionIdle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	t.Run("case=is idle after the idle expiry", func(t *testing.T) {
+		s := &session.Session{Active: true, ExpiresAt: now.Add(time.Hour)}
+		assert.False(t, s.IsIdle())
+		assert.True(t, s.IsActive())
+
+		s.SetLastSeen(ctx, idleTimeoutProvider(time.Minute), now.Add(-2*time.Minute))
+		assert.True(t, s.IsIdle())
+		assert.False(t, s.IsActive())
+
+		s.SetLastSeen(ctx, idleTimeoutProvider(time.Minute), now)
+		assert.False(t, s.IsIdle())
+		assert.True(t, s.IsActive())
+	})
+
+	t.Run("case=clears the idle expiry if disabled", func(t *testing.T) {
+		s := new(session.Session).SetLastSeen(ctx, idleTimeoutProvider(time.Minute), now.Add(-2*time.Minute))
+		require.NotNil(t, s.IdleExpiresAt)
+
+		s.SetLastSeen(ctx, idleTimeoutProvider(0), now)
+		assert.Nil(t, s.LastSeenAt)
+		assert.Nil(t, s.IdleExpiresAt)
+		assert.False(t, s.IsIdle())
+	})
+
+	t.Run("case=throttles last seen updates", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			timeout  time.Duration
+			session  *session.Session
+			expected bool
+		}{
+			{
+				name:    "idle timeout disabled",
+				session: new(session.Session),
+			},
+			{
+				name:     "idle timeout disabled after the session was seen",
+				session:  new(session.Session).SetLastSeen(ctx, idleTimeoutProvider(time.Hour), now),
+				expected: true,
+			},
+			{
+				name:     "never seen",
+				timeout:  time.Hour,
+				session:  new(session.Session),
+				expected: true,
+			},
+			{
+				name:    "seen recently",
+				timeout: time.Hour,
+				session: new(session.Session).SetLastSeen(ctx, idleTimeoutProvider(time.Hour), now.Add(-30*time.Second)),
+			},
+			{
+				name:     "seen more than a minute ago",
+				timeout:  time.Hour,
+				session:  new(session.Session).SetLastSeen(ctx, idleTimeoutProvider(time.Hour), now.Add(-61*time.Second)),
+				expected: true,
+			},
+			{
+				name:     "seen recently with a short idle timeout",
+				timeout:  time.Minute,
+				session:  new(session.Session).SetLastSeen(ctx, idleTimeoutProvider(time.Minute), now.Add(-10*time.Second)),
+				expected: true,
+			},
+			{
+				name:     "idle timeout changed",
+				timeout:  30 * time.Minute,
+				session:  new(session.Session).SetLastSeen(ctx, idleTimeoutProvider(time.Hour), now),
+				expected: true,
+			},
+			{
+				name:    "stored with second precision",
+				timeout: time.Hour,
+				session: &session.Session{
+					LastSeenAt:    new(sqlxx.NullTime(now.Truncate(time.Second))),
+					IdleExpiresAt: new(sqlxx.NullTime(now.Add(time.Hour).Round(time.Second))),
+				},
+			},
+		} {
+			t.Run("case="+tc.name, func(t *testing.T) {
+				assert.Equal(t, tc.expected, tc.session.NeedsLastSeenUpdate(ctx, idleTimeoutProvider(tc.timeout), now))
+			})
+		}
+	})
+}
@@ -27,6 +27,10 @@ type ErrNoActiveSessionFound struct {
 
 	// True when the request had no credentials in it.
 	CredentialsMissing bool
+
+	// True when the session was used from a client which does not match the
+	// session's binding.
+	BindingViolated bool
 }
 
 // NewErrNoActiveSessionFound creates a new ErrNoActiveSessionFound
@@ -43,6 +47,27 @@ func NewErrNoCredentialsForSession() *ErrNoActiveSessionFound {
 	return e
 }
 
+// NewErrSessionBindingViolated creates a new ErrNoActiveSessionFound for sessions which are used from a client
+// that does not match the session's binding.
+func NewErrSessionBindingViolated(changes []string) *ErrNoActiveSessionFound {
+	e := NewErrNoActiveSessionFound()
+	e.DefaultError = e.DefaultError.
+		WithID(text.ErrIDSessionBindingViolated).
+		WithReason("The session was used from a different device or network than it was issued to. Please sign in again.").
+		WithDetail("binding_changes", changes)
+	e.BindingViolated = true
+	return e
+}
+
+// NewErrSessionIdle creates a new ErrNoActiveSessionFound for sessions which were not used within
+// their idle timeout.
+func NewErrSessionIdle() *ErrNoActiveSessionFound {
+	e := NewErrNoActiveSessionFound()
+	e.DefaultError = e.DefaultError.
+		WithReason("The session expired because it was not used for too long. Please sign in again.")
+	return e
+}
+
 func (e *ErrNoActiveSessionFound) EnhanceJSONError() interface{} {
 	return e
 }
@@ -181,6 +206,11 @@ type Manager interface {
 	// the session in the database or on the client device.
 	ActivateSession(r *http.Request, session *Session, i *identity.Identity, authenticatedAt time.Time) error
 
+	// UpdateLastSeen records that the session was used now and moves its idle expiry. The
+	// timestamp is only tracked if an idle timeout is configured and is written at most about
+	// once per minute to keep session checks cheap.
+	UpdateLastSeen(ctx context.Context, s *Session) error
+
 	// IsPrivileged checks if a session can be considered privileged.
 	// https://ory.com/docs/kratos/session-management/session-lifespan#privileged-sessions
 	IsPrivileged(ctx context.Context, session *Session) bool
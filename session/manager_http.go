@@ -41,6 +41,7 @@ import (
 
 	"github.com/ory/herodot"
 
+	"github.com/ory/kratos/geoip"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/x"
 )
@@ -62,6 +63,7 @@ type (
 		x.TransactionPersistenceProvider
 		PersistenceProvider
 		sessiontokenexchange.PersistenceProvider
+		geoip.Provider
 	}
 	ManagerHTTP struct {
 		cookieName func(ctx context.Context) string
@@ -278,15 +280,80 @@ func (s *ManagerHTTP) FetchFromRequest(ctx context.Context, r *http.Request, ses
 		return nil, err
 	}
 
-	trace.SpanFromContext(ctx).AddEvent(events.NewSessionChecked(ctx, se.ID, se.IdentityID))
+	events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionChecked(ctx, se.ID, se.IdentityID))
+
+	if se.Active && se.IsIdle() {
+		return nil, errors.WithStack(NewErrSessionIdle())
+	}
 
 	if !se.IsActive() {
 		return nil, errors.WithStack(NewErrNoActiveSessionFound())
 	}
 
+	if err := s.checkBinding(ctx, r, se); err != nil {
+		return nil, err
+	}
+
+	// Every authenticated request keeps sessions with an idle timeout alive,
+	// not only calls to whoami.
+	if err := s.UpdateLastSeen(ctx, se); err != nil {
+		s.r.Logger().WithRequest(r).WithError(err).Warn("Unable to update the last seen timestamp of the session.")
+	}
+
 	return se, nil
 }
 
+// checkBinding compares the request with the session's binding. Depending on
+// the configured mode, a mismatch is only reported, rejects the session for
+// this request, or revokes the session. Sessions issued before binding was
+// enabled have no binding and are not checked.
+func (s *ManagerHTTP) checkBinding(ctx context.Context, r *http.Request, se *Session) error {
+	c := s.r.Config().SessionBinding(ctx)
+	mode := BindingMode(c.Mode)
+	if mode == BindingModeOff || se.Binding.IsZero() {
+		return nil
+	}
+
+	changes := se.Binding.Changes(NewBinding(r, s.resolveLocation(ctx, r)), c.Attributes)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionBindingViolated(ctx, se.ID, se.IdentityID, c.Mode, changes))
+	logger := s.r.Logger().
+		WithRequest(r).
+		WithField("session_id", se.ID).
+		WithField("identity_id", se.IdentityID).
+		WithField("binding_changes", changes)
+
+	switch mode {
+	case BindingModeWarn:
+		logger.Warn("The session is used from a client which does not match the session's binding.")
+		return nil
+	case BindingModeRevoke:
+		if err := s.r.SessionPersister().RevokeSession(ctx, se.IdentityID, se.ID); err != nil {
+			return err
+		}
+		logger.Warn("Revoked the session because it is used from a client which does not match the session's binding.")
+	default:
+		logger.Warn("Rejected the session because it is used from a client which does not match the session's binding.")
+	}
+
+	return errors.WithStack(NewErrSessionBindingViolated(changes))
+}
+
+// resolveLocation returns the location of the client which sent the request.
+// Resolution errors are logged and result in an empty location, because a
+// missing location must not prevent signing in.
+func (s *ManagerHTTP) resolveLocation(ctx context.Context, r *http.Request) *geoip.Location {
+	loc, err := s.r.GeoIPResolver(ctx).Resolve(ctx, r)
+	if err != nil {
+		s.r.Logger().WithRequest(r).WithError(err).Warn("Unable to resolve the location of the client.")
+		return new(geoip.Location)
+	}
+	return loc
+}
+
 // endSpanIgnoreNoActiveSession ends the span without recording ErrNoActiveSessionFound as a span
 // error, because a request without an active session is an expected condition on these paths.
 func endSpanIgnoreNoActiveSession(span trace.Span, err *error) {
@@ -337,6 +404,11 @@ func (s *ManagerHTTP) DoesSessionSatisfy(ctx context.Context, sess *Session, req
 	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.DoesSessionSatisfy")
 	defer otelx.End(span, &err)
 
+	// Sessions which were not used within their idle timeout satisfy no AAL.
+	if sess.IsIdle() {
+		return errors.WithStack(NewErrSessionIdle())
+	}
+
 	sess.SetAuthenticatorAssuranceLevel()
 
 	// If we already have AAL2 there is no need to check further because it is the highest AAL.
@@ -514,8 +586,11 @@ func (s *ManagerHTTP) ActivateSession(r *http.Request, session *Session, i *iden
 	session.IssuedAt = authenticatedAt
 	session.ExpiresAt = authenticatedAt.Add(s.r.Config().OrganizationSessionLifespan(ctx, session.OrganizationID()))
 	session.AuthenticatedAt = authenticatedAt
+	session.SetLastSeen(ctx, s.r.Config(), authenticatedAt)
 
-	session.SetSessionDeviceInformation(r.WithContext(ctx))
+	loc := s.resolveLocation(ctx, r)
+	session.SetSessionDeviceInformation(r.WithContext(ctx), loc)
+	session.Binding = NewBinding(r, loc)
 	session.SetAuthenticatorAssuranceLevel()
 
 	span.SetAttributes(
@@ -525,6 +600,18 @@ func (s *ManagerHTTP) ActivateSession(r *http.Request, session *Session, i *iden
 	return nil
 }
 
+func (s *ManagerHTTP) UpdateLastSeen(ctx context.Context, session *Session) (err error) {
+	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.UpdateLastSeen")
+	defer otelx.End(span, &err)
+
+	now := time.Now()
+	if !session.NeedsLastSeenUpdate(ctx, s.r.Config(), now) {
+		return nil
+	}
+
+	return s.r.SessionPersister().UpdateSessionLastSeen(ctx, session.SetLastSeen(ctx, s.r.Config(), now))
+}
+
 func (s *ManagerHTTP) IsPrivileged(ctx context.Context, session *Session) bool {
 	if session == nil {
 		return false
@@ -538,6 +625,12 @@ func (s *ManagerHTTP) IsPrivileged(ctx context.Context, session *Session) bool {
 		return false
 	}
 
+	// Impersonation sessions must not be used to change the identity's
+	// credentials, regardless of the privileged session max age.
+	if session.IsImpersonated() {
+		return false
+	}
+
 	privilegedSessionLifespan := s.r.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(ctx)
 	if privilegedSessionLifespan <= 0 {
 		return true
@@ -10,9 +10,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -1297,3 +1299,234 @@ func TestFetchFromRequestExpandEverythingButCredentials(t *testing.T) {
 		require.Truef(t, ok, "expected *session.ErrNoActiveSessionFound but got %v", err)
 	})
 }
+
+func TestFetchFromRequestSessionBinding(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/fake-session.schema.json")),
+	)
+
+	const (
+		chromeOnWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+		firefoxOnLinux  = "Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0"
+	)
+
+	newRequest := func(t *testing.T, userAgent, country string) *http.Request {
+		req := testhelpers.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil)
+		req.Header.Set("User-Agent", userAgent)
+		req.Header.Set("Cf-Ipcountry", country)
+		return req
+	}
+
+	newSession := func(t *testing.T) *session.Session {
+		i := identity.Identity{Traits: []byte("{}")}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(t.Context(), &i))
+		s, err := testhelpers.NewActiveSession(newRequest(t, chromeOnWindows, "DE"), reg, &i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		require.NoError(t, reg.SessionPersister().UpsertSession(t.Context(), s))
+		return s
+	}
+
+	fetch := func(t *testing.T, mode string, s *session.Session, userAgent, country string) error {
+		ctx := contextx.WithConfigValues(t.Context(), map[string]any{config.ViperKeySessionBindingMode: mode})
+		req := newRequest(t, userAgent, country)
+		req.Header.Set("X-Session-Token", s.Token)
+		_, err := reg.SessionManager().FetchFromRequest(ctx, req, session.ExpandNothing, identity.ExpandNothing)
+		return err
+	}
+
+	t.Run("case=stores the binding of the client which signed in", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession(t)
+		actual, err := reg.SessionPersister().GetSession(t.Context(), s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, "DE", actual.Binding.Country)
+		assert.Equal(t, "chrome/windows", actual.Binding.UserAgentFamily)
+	})
+
+	t.Run("case=accepts the session if the client matches", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession(t)
+		assert.NoError(t, fetch(t, "revoke", s, chromeOnWindows, "DE"))
+		// Browser updates do not change the user agent family.
+		assert.NoError(t, fetch(t, "revoke", s, strings.ReplaceAll(chromeOnWindows, "124.0.0.0", "125.0.0.0"), "DE"))
+	})
+
+	t.Run("case=rejects the session if the client leaves out headers", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession(t)
+		for _, tc := range []struct {
+			userAgent, country string
+			expected           []string
+		}{
+			{userAgent: "", country: "DE", expected: []string{session.BindingAttributeUserAgent}},
+			{userAgent: "", country: "", expected: []string{session.BindingAttributeUserAgent}},
+		} {
+			err := fetch(t, "reauthenticate", s, tc.userAgent, tc.country)
+			noSess, ok := errors.AsType[*session.ErrNoActiveSessionFound](err)
+			require.Truef(t, ok, "expected *session.ErrNoActiveSessionFound but got %v", err)
+			assert.True(t, noSess.BindingViolated)
+			assert.Equal(t, tc.expected, noSess.DetailsField["binding_changes"])
+		}
+	})
+
+	t.Run("case=does not compare locations which could not be resolved", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession(t)
+		assert.NoError(t, fetch(t, "revoke", s, chromeOnWindows, ""))
+		assert.NoError(t, fetch(t, "revoke", s, chromeOnWindows, "DE"), "the session must not be revoked")
+	})
+
+	t.Run("case=ignores changes if disabled", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession(t)
+		assert.NoError(t, fetch(t, "off", s, firefoxOnLinux, "US"))
+	})
+
+	t.Run("case=accepts the session in warn mode", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession(t)
+		assert.NoError(t, fetch(t, "warn", s, firefoxOnLinux, "US"))
+	})
+
+	t.Run("case=rejects the session in reauthenticate mode", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession(t)
+		err := fetch(t, "reauthenticate", s, chromeOnWindows, "US")
+		noSess, ok := errors.AsType[*session.ErrNoActiveSessionFound](err)
+		require.Truef(t, ok, "expected *session.ErrNoActiveSessionFound but got %v", err)
+		assert.True(t, noSess.BindingViolated)
+		assert.Equal(t, []string{session.BindingAttributeCountry}, noSess.DetailsField["binding_changes"])
+
+		assert.NoError(t, fetch(t, "reauthenticate", s, chromeOnWindows, "DE"), "the session stays valid for the client it is bound to")
+	})
+
+	t.Run("case=revokes the session in revoke mode", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSession(t)
+		err := fetch(t, "revoke", s, firefoxOnLinux, "DE")
+		noSess, ok := errors.AsType[*session.ErrNoActiveSessionFound](err)
+		require.Truef(t, ok, "expected *session.ErrNoActiveSessionFound but got %v", err)
+		assert.True(t, noSess.BindingViolated)
+
+		err = fetch(t, "revoke", s, chromeOnWindows, "DE")
+		noSess, ok = errors.AsType[*session.ErrNoActiveSessionFound](err)
+		require.Truef(t, ok, "expected *session.ErrNoActiveSessionFound but got %v", err)
+		assert.False(t, noSess.BindingViolated, "the session must be revoked")
+	})
+}
+
+func TestFetchFromRequestSessionIdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	_, reg := pkg.NewFastRegistryWithMocks(t,
+		configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/fake-session.schema.json")),
+	)
+
+	withIdleTimeout := func(ctx context.Context, timeout string) context.Context {
+		return contextx.WithConfigValues(ctx, map[string]any{config.ViperKeySessionIdleTimeout: timeout})
+	}
+
+	newSession := func(t *testing.T, ctx context.Context) *session.Session {
+		i := identity.Identity{Traits: []byte("{}")}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, &i))
+		req := testhelpers.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil).WithContext(ctx)
+		s, err := testhelpers.NewActiveSession(req, reg, &i, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+		return s
+	}
+
+	fetch := func(t *testing.T, ctx context.Context, s *session.Session) (*session.Session, error) {
+		req := testhelpers.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil).WithContext(ctx)
+		req.Header.Set("X-Session-Token", s.Token)
+		return reg.SessionManager().FetchFromRequest(ctx, req, session.ExpandNothing, identity.ExpandNothing)
+	}
+
+	t.Run("case=does not track sessions without idle timeout", func(t *testing.T) {
+		t.Parallel()
+		ctx := t.Context()
+
+		s := newSession(t, ctx)
+		assert.Nil(t, s.LastSeenAt)
+		assert.Nil(t, s.IdleExpiresAt)
+
+		require.NoError(t, reg.SessionManager().UpdateLastSeen(ctx, s))
+		actual, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.Nil(t, actual.LastSeenAt)
+		assert.True(t, actual.IsActive())
+	})
+
+	t.Run("case=rejects idle sessions", func(t *testing.T) {
+		t.Parallel()
+		ctx := withIdleTimeout(t.Context(), "15m")
+
+		s := newSession(t, ctx)
+		require.NotNil(t, s.IdleExpiresAt)
+		_, err := fetch(t, ctx, s)
+		require.NoError(t, err)
+
+		// The session was not used for longer than the idle timeout.
+		s.SetLastSeen(ctx, reg.Config(), time.Now().Add(-16*time.Minute))
+		require.NoError(t, reg.SessionPersister().UpdateSessionLastSeen(ctx, s))
+
+		_, err = fetch(t, ctx, s)
+		_, ok := errors.AsType[*session.ErrNoActiveSessionFound](err)
+		require.Truef(t, ok, "expected *session.ErrNoActiveSessionFound but got %v", err)
+
+		err = reg.SessionManager().DoesSessionSatisfy(ctx, s, config.HighestAvailableAAL)
+		_, ok = errors.AsType[*session.ErrNoActiveSessionFound](err)
+		require.Truef(t, ok, "expected *session.ErrNoActiveSessionFound but got %v", err)
+
+		actual, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.False(t, actual.IsActive())
+
+		active := true
+		sessions, _, err := reg.SessionPersister().ListSessionsByIdentity(ctx, s.IdentityID, &active, 1, 10, uuid.Nil, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+
+	t.Run("case=using the session keeps it alive", func(t *testing.T) {
+		t.Parallel()
+		ctx := withIdleTimeout(t.Context(), "15m")
+
+		s := newSession(t, ctx)
+		s.SetLastSeen(ctx, reg.Config(), time.Now().Add(-10*time.Minute))
+		require.NoError(t, reg.SessionPersister().UpdateSessionLastSeen(ctx, s))
+
+		_, err := fetch(t, ctx, s)
+		require.NoError(t, err)
+
+		actual, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		require.NotNil(t, actual.IdleExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), time.Time(*actual.IdleExpiresAt), 5*time.Second)
+	})
+
+	t.Run("case=does not write recently seen sessions", func(t *testing.T) {
+		t.Parallel()
+		ctx := withIdleTimeout(t.Context(), "15m")
+
+		s := newSession(t, ctx)
+		expected := *s.IdleExpiresAt
+
+		_, err := fetch(t, ctx, s)
+		require.NoError(t, err)
+
+		actual, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Time(expected), time.Time(*actual.IdleExpiresAt), time.Second)
+	})
+}
@@ -31,6 +31,8 @@ type RevokedSession struct {
 }
 
 type Persister interface {
+	DevicePersister
+
 	GetConnection(ctx context.Context) *pop.Connection
 
 	// GetSession retrieves a session from the store.
@@ -45,9 +47,12 @@ type Persister interface {
 	// UpsertSession inserts or updates a session into / in the store.
 	UpsertSession(ctx context.Context, s *Session) error
 
-	// ExtendSession updates the expiry of a session.
+	// ExtendSession updates the expiry and the idle expiry of a session.
 	ExtendSession(ctx context.Context, sessionID uuid.UUID) error
 
+	// UpdateSessionLastSeen writes the last seen timestamp and the idle expiry of a session.
+	UpdateSessionLastSeen(ctx context.Context, s *Session) error
+
 	// DeleteSession removes a session from the store.
 	DeleteSession(ctx context.Context, id uuid.UUID) error
 
@@ -113,4 +118,8 @@ type Persister interface {
 
 type DevicePersister interface {
 	CreateDevice(ctx context.Context, d *Device) error
+
+	// ListDevicesByIdentity returns up to limit devices of the identity's
+	// sessions, the most recent first.
+	ListDevicesByIdentity(ctx context.Context, identityID uuid.UUID, limit int) ([]Device, error)
 }
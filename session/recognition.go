@@ -0,0 +1,101 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+const (
+	UnrecognizedDevice   = "device"
+	UnrecognizedIPRange  = "ip_range"
+	UnrecognizedLocation = "location"
+)
+
+// Unrecognized returns which of the device's browser and operating system
+// family, IP range, and location none of the known devices share. Values
+// which are unknown for the device are not compared.
+func (d *Device) Unrecognized(known []Device) []string {
+	var family, ipRange, location []string
+	for _, k := range known {
+		family = append(family, k.family())
+		ipRange = append(ipRange, k.ipRange())
+		location = append(location, k.location())
+	}
+
+	var unrecognized []string
+	for _, c := range []struct {
+		attribute string
+		value     string
+		known     []string
+	}{
+		{UnrecognizedDevice, d.family(), family},
+		{UnrecognizedIPRange, d.ipRange(), ipRange},
+		{UnrecognizedLocation, d.location(), location},
+	} {
+		if c.value == "" {
+			continue
+		}
+		if !slices.Contains(c.known, c.value) {
+			unrecognized = append(unrecognized, c.attribute)
+		}
+	}
+	return unrecognized
+}
+
+// DisplayLocation returns the device's location for humans, e.g.
+// "Munich, Bavaria, DE".
+func (d *Device) DisplayLocation() string {
+	var parts []string
+	for _, p := range []*string{d.City, d.Region, d.Country} {
+		if p != nil && *p != "" {
+			parts = append(parts, *p)
+		}
+	}
+	if len(parts) == 0 && d.Location != nil {
+		return *d.Location
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (d *Device) family() string {
+	if d.UserAgent == nil {
+		return ""
+	}
+	return userAgentFamily(*d.UserAgent)
+}
+
+// ipRange returns the /24 network of IPv4 and the /48 network of IPv6
+// addresses, so that addresses which are reassigned within a provider's
+// network are recognized.
+func (d *Device) ipRange() string {
+	if d.IPAddress == nil {
+		return ""
+	}
+	addr, err := netip.ParseAddr(*d.IPAddress)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return ""
+	}
+	return prefix.String()
+}
+
+// location returns the city and country of the device. It is also set for
+// devices which were stored before the structured location fields existed.
+func (d *Device) location() string {
+	if d.Location == nil {
+		return ""
+	}
+	return *d.Location
+}
@@ -0,0 +1,64 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ory/kratos/session"
+)
+
+func TestDeviceUnrecognized(t *testing.T) {
+	const (
+		chromeWindows    = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+		chromeWindows125 = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/125.0.0.0 Safari/537.36"
+		firefoxLinux     = "Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0"
+	)
+
+	known := []session.Device{
+		{IPAddress: new("54.155.246.232"), UserAgent: new(chromeWindows), Location: new("Munich, DE")},
+		{IPAddress: new("2001:db8:1234:1::1"), UserAgent: new(chromeWindows), Location: new("")},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		device   session.Device
+		expected []string
+	}{
+		{
+			name:   "same device in the same network",
+			device: session.Device{IPAddress: new("54.155.246.7"), UserAgent: new(chromeWindows125), Location: new("Munich, DE")},
+		},
+		{
+			name:   "reassigned IPv6 address",
+			device: session.Device{IPAddress: new("2001:db8:1234:ffff::2"), UserAgent: new(chromeWindows)},
+		},
+		{
+			name:     "new browser",
+			device:   session.Device{IPAddress: new("54.155.246.232"), UserAgent: new(firefoxLinux), Location: new("Munich, DE")},
+			expected: []string{session.UnrecognizedDevice},
+		},
+		{
+			name:     "new network and location",
+			device:   session.Device{IPAddress: new("203.0.113.9"), UserAgent: new(chromeWindows), Location: new("Paris, FR")},
+			expected: []string{session.UnrecognizedIPRange, session.UnrecognizedLocation},
+		},
+		{
+			name:   "unknown values are not compared",
+			device: session.Device{Location: new("")},
+		},
+	} {
+		t.Run("case="+tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.device.Unrecognized(known))
+		})
+	}
+}
+
+func TestDeviceDisplayLocation(t *testing.T) {
+	assert.Equal(t, "Munich, Bavaria, DE", (&session.Device{City: new("Munich"), Region: new("Bavaria"), Country: new("DE"), Location: new("Munich, DE")}).DisplayLocation())
+	assert.Equal(t, "Munich, Germany", (&session.Device{Location: new("Munich, Germany")}).DisplayLocation())
+	assert.Empty(t, (&session.Device{}).DisplayLocation())
+}
@@ -0,0 +1,83 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"net/url"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/x/urlx"
+)
+
+const (
+	revocationTokenPayloadLength = 16 + 8
+	revocationTokenMACLength     = 16
+)
+
+// NewRevocationToken returns a token which revokes the session until
+// expiresAt without signing in. It is sent in notifications about the session
+// so that users can revoke sessions they do not recognize.
+//
+// The token is kept short because it is also sent by SMS.
+func NewRevocationToken(secret []byte, sessionID uuid.UUID, expiresAt time.Time) string {
+	payload := make([]byte, revocationTokenPayloadLength, revocationTokenPayloadLength+revocationTokenMACLength)
+	copy(payload, sessionID.Bytes())
+	binary.BigEndian.PutUint64(payload[16:], uint64(expiresAt.Unix())) //#nosec G115 -- expiry is after the epoch
+	return base64.RawURLEncoding.EncodeToString(append(payload, revocationTokenMAC(secret, payload)...))
+}
+
+// ParseRevocationToken returns the session ID of a revocation token which was
+// signed with one of the secrets and has not expired.
+func ParseRevocationToken(secrets [][]byte, token string) (uuid.UUID, error) {
+	invalid := errors.WithStack(herodot.ErrBadRequest().WithReason("The link is invalid or has expired."))
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != revocationTokenPayloadLength+revocationTokenMACLength {
+		return uuid.Nil, invalid
+	}
+
+	payload, mac := raw[:revocationTokenPayloadLength], raw[revocationTokenPayloadLength:]
+	var valid bool
+	for _, secret := range secrets {
+		if hmac.Equal(mac, revocationTokenMAC(secret, payload)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return uuid.Nil, invalid
+	}
+
+	if time.Now().Unix() > int64(binary.BigEndian.Uint64(payload[16:])) { //#nosec G115 -- the value was signed by us
+		return uuid.Nil, invalid
+	}
+
+	return uuid.FromBytesOrNil(payload[:16]), nil
+}
+
+// NewRevocationURL returns the URL of the session revocation UI, which asks
+// the user to confirm revoking the session if they do not recognize it. The
+// UI submits the token to RouteRevokeUnrecognized, so that following the link
+// alone does not revoke the session. See NewRevocationToken.
+func NewRevocationURL(ctx context.Context, c *config.Config, sessionID uuid.UUID, expiresAt time.Time) *url.URL {
+	token := NewRevocationToken(c.SecretsSession(ctx)[0], sessionID, expiresAt)
+	return urlx.CopyWithQuery(c.SelfServiceFlowSessionRevocationUI(ctx), url.Values{"token": {token}})
+}
+
+func revocationTokenMAC(secret, payload []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	_, _ = h.Write([]byte("session-revocation"))
+	_, _ = h.Write(payload)
+	return h.Sum(nil)[:revocationTokenMACLength]
+}
@@ -0,0 +1,65 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+func TestRevocationToken(t *testing.T) {
+	current, previous := []byte("current-secret-0123456789"), []byte("previous-secret-0123456789")
+	sessionID := x.NewUUID()
+
+	t.Run("case=parses valid tokens", func(t *testing.T) {
+		token := session.NewRevocationToken(current, sessionID, time.Now().Add(time.Hour))
+		assert.LessOrEqual(t, len(token), 64, "the token must stay short enough for SMS")
+
+		actual, err := session.ParseRevocationToken([][]byte{current}, token)
+		require.NoError(t, err)
+		assert.Equal(t, sessionID, actual)
+	})
+
+	t.Run("case=accepts rotated secrets", func(t *testing.T) {
+		token := session.NewRevocationToken(previous, sessionID, time.Now().Add(time.Hour))
+
+		actual, err := session.ParseRevocationToken([][]byte{current, previous}, token)
+		require.NoError(t, err)
+		assert.Equal(t, sessionID, actual)
+	})
+
+	for _, tc := range []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"malformed", "not-a-token!"},
+		{"expired", session.NewRevocationToken(current, sessionID, time.Now().Add(-time.Second))},
+		{"unknown secret", session.NewRevocationToken([]byte("other-secret-0123456789"), sessionID, time.Now().Add(time.Hour))},
+		{"truncated", session.NewRevocationToken(current, sessionID, time.Now().Add(time.Hour))[:30]},
+	} {
+		t.Run("case=rejects "+tc.name+" tokens", func(t *testing.T) {
+			_, err := session.ParseRevocationToken([][]byte{current}, tc.token)
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("case=rejects tokens for other sessions", func(t *testing.T) {
+		token := []byte(session.NewRevocationToken(current, sessionID, time.Now().Add(time.Hour)))
+		// Flip a character of the encoded session ID.
+		if token[0] == 'A' {
+			token[0] = 'B'
+		} else {
+			token[0] = 'A'
+		}
+		_, err := session.ParseRevocationToken([][]byte{current}, string(token))
+		assert.Error(t, err)
+	})
+}
@@ -16,12 +16,14 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/herodot"
+	"github.com/ory/kratos/geoip"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/httpx"
 	"github.com/ory/x/pagination/keysetpagination"
 	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlxx"
 )
 
 func ErrIdentityDisabled() *herodot.DefaultError {
@@ -59,6 +61,19 @@ type Device struct {
 	// Geo Location corresponding to the IP Address
 	Location *string `json:"location" faker:"ptr_geo_location" db:"location"`
 
+	// Country is the ISO 3166-1 alpha-2 code of the country corresponding to
+	// the IP Address
+	Country *string `json:"country" faker:"-" db:"country"`
+
+	// Region corresponding to the IP Address, e.g. the state or province
+	Region *string `json:"region" faker:"-" db:"region"`
+
+	// City corresponding to the IP Address
+	City *string `json:"city" faker:"-" db:"city"`
+
+	// ASN is the number of the autonomous system the IP Address belongs to
+	ASN *string `json:"asn" faker:"-" db:"asn"`
+
 	// Time of capture
 	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
 
@@ -88,6 +103,19 @@ type Session struct {
 	// When this session expires at.
 	ExpiresAt time.Time `json:"expires_at" db:"expires_at" faker:"time_type"`
 
+	// The Session Last Seen Timestamp
+	//
+	// When this session was last used. It is only tracked if an idle timeout is configured and is
+	// updated at most about once per minute.
+	LastSeenAt *sqlxx.NullTime `json:"last_seen_at,omitempty" faker:"-" db:"last_seen_at"`
+
+	// The Session Idle Expiry
+	//
+	// When this session expires because it was not used. It is only set if an idle timeout is
+	// configured. The session is no longer active after this time, even if it has not reached
+	// `expires_at` yet.
+	IdleExpiresAt *sqlxx.NullTime `json:"idle_expires_at,omitempty" faker:"-" db:"idle_expires_at"`
+
 	// The Session Authentication Timestamp
 	//
 	// When this session was authenticated at. If multi-factor authentication was used this
@@ -131,6 +159,10 @@ type Session struct {
 	// Devices has history of all endpoints where the session was used
 	Devices []Device `json:"devices" faker:"-" has_many:"session_devices" fk_id:"session_id"`
 
+	// Binding holds the properties of the client which signed in. It is
+	// compared against later requests if session binding is enabled.
+	Binding Binding `json:"-" faker:"-" db:"binding"`
+
 	// IdentityID is a helper struct field for gobuffalo.pop.
 	IdentityID uuid.UUID `json:"-" faker:"-" db:"identity_id"`
 
@@ -231,6 +263,23 @@ func (s *Session) CompletedLoginForOIDC(method identity.CredentialsType, aal ide
 	})
 }
 
+// CompletedImpersonationBy appends the impersonation authentication method
+// to the session and records the administrator who issued it.
+func (s *Session) CompletedImpersonationBy(actor string) {
+	s.CompletedLoginForMethod(AuthenticationMethod{
+		Method:         identity.CredentialsTypeImpersonation,
+		AAL:            identity.AuthenticatorAssuranceLevel1,
+		ImpersonatedBy: actor,
+	})
+}
+
+// IsImpersonated returns true if the session was issued to an administrator
+// through the admin API. Such sessions are never privileged and can not be
+// extended.
+func (s *Session) IsImpersonated() bool {
+	return s.AuthenticatedVia(identity.CredentialsTypeImpersonation)
+}
+
 func (s *Session) AuthenticatedVia(method identity.CredentialsType) bool {
 	for _, authMethod := range s.AMR {
 		if authMethod.Method == method {
@@ -283,7 +332,9 @@ func NewInactiveSession() *Session {
 	}
 }
 
-func (s *Session) SetSessionDeviceInformation(r *http.Request) {
+// SetSessionDeviceInformation adds the device which sent the request to the
+// session. loc is the resolved location of the client and may be nil.
+func (s *Session) SetSessionDeviceInformation(r *http.Request, loc *geoip.Location) {
 	device := Device{
 		SessionID:  s.ID,
 		IdentityID: new(s.IdentityID),
@@ -295,15 +346,26 @@ func (s *Session) SetSessionDeviceInformation(r *http.Request) {
 		device.UserAgent = new(strings.Join(agent, " "))
 	}
 
+	if loc == nil {
+		loc = new(geoip.Location)
+	}
+
 	var clientGeoLocation []string
-	if r.Header.Get("Cf-Ipcity") != "" {
-		clientGeoLocation = append(clientGeoLocation, r.Header.Get("Cf-Ipcity"))
+	if loc.City != "" {
+		clientGeoLocation = append(clientGeoLocation, loc.City)
+		device.City = new(loc.City)
+	}
+	if loc.Region != "" {
+		device.Region = new(loc.Region)
 	}
-	if r.Header.Get("Cf-Ipcountry") != "" {
-		clientGeoLocation = append(clientGeoLocation, r.Header.Get("Cf-Ipcountry"))
+	if loc.Country != "" {
+		clientGeoLocation = append(clientGeoLocation, loc.Country)
+		device.Country = new(loc.Country)
 	}
-	loc := strings.Join(clientGeoLocation, ", ")
-	device.Location = &loc
+	if loc.ASN != "" {
+		device.ASN = new(loc.ASN)
+	}
+	device.Location = new(strings.Join(clientGeoLocation, ", "))
 
 	s.Devices = append(s.Devices, device)
 }
@@ -314,7 +376,7 @@ func (s Session) Declassified() *Session {
 }
 
 func (s *Session) IsActive() bool {
-	return s.Active && s.ExpiresAt.After(time.Now()) && (s.Identity == nil || s.Identity.IsActive())
+	return s.Active && s.ExpiresAt.After(time.Now()) && !s.IsIdle() && (s.Identity == nil || s.Identity.IsActive())
 }
 
 func (s *Session) Refresh(ctx context.Context, c lifespanProvider) *Session {
@@ -351,6 +413,9 @@ func (s *Session) MarshalJSON() ([]byte, error) {
 }
 
 func (s *Session) CanBeRefreshed(ctx context.Context, c refreshWindowProvider) bool {
+	if s.IsImpersonated() {
+		return false
+	}
 	return s.ExpiresAt.Add(-c.SessionRefreshMinTimeLeft(ctx)).Before(time.Now())
 }
 
@@ -391,6 +456,20 @@ type AuthenticationMethod struct {
 	// provider, if any. Populated only for OIDC login methods when the
 	// upstream ID token contained an `amr` claim.
 	UpstreamAMR []string `json:"upstream_amr,omitempty"`
+
+	// UpstreamSubject is the subject of the identity at the upstream OIDC
+	// or SAML provider. It is used to find the sessions to revoke when the
+	// provider signals a logout.
+	UpstreamSubject string `json:"upstream_subject,omitempty"`
+
+	// UpstreamSessionID is the `sid` claim reported by the upstream OIDC
+	// provider, if any. If set, a back-channel logout for another upstream
+	// session does not revoke this session.
+	UpstreamSessionID string `json:"upstream_sid,omitempty"`
+
+	// ImpersonatedBy identifies the administrator who issued the session
+	// through the admin API. Populated only for the `impersonation` method.
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
 }
 
 // Scan implements the Scanner interface.
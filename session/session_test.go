@@ -243,6 +243,42 @@ func TestSession(t *testing.T) {
 		assert.Equal(t, "Munich, Germany", *s.Devices[0].Location)
 	})
 
+	t.Run("case=client information structured location", func(t *testing.T) {
+		req := testhelpers.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil)
+		req.Header.Set("True-Client-IP", "54.155.246.232")
+		req.Header.Set("Cf-Ipcity", "Munich")
+		req.Header.Set("Cf-Region", "Bavaria")
+		req.Header.Set("Cf-Ipcountry", "DE")
+
+		s := session.NewInactiveSession()
+		require.NoError(t, reg.SessionManager().ActivateSession(req, s, &identity.Identity{NID: x.NewUUID(), State: identity.StateActive}, authAt))
+		require.Len(t, s.Devices, 1)
+		assert.Equal(t, "Munich, DE", *s.Devices[0].Location)
+		assert.Equal(t, "DE", *s.Devices[0].Country)
+		assert.Equal(t, "Bavaria", *s.Devices[0].Region)
+		assert.Equal(t, "Munich", *s.Devices[0].City)
+		assert.Nil(t, s.Devices[0].ASN, "the location headers do not contain the ASN")
+		assert.Equal(t, "DE", s.Binding.Country)
+	})
+
+	t.Run("case=client information ignores location headers if disabled", func(t *testing.T) {
+		_, reg := pkg.NewFastRegistryWithMocks(t,
+			configx.WithValues(testhelpers.DefaultIdentitySchemaConfig("file://./stub/identity.schema.json")),
+			configx.WithValue(config.ViperKeySessionGeoIPProvider, "none"),
+		)
+
+		req := testhelpers.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil)
+		req.Header.Set("Cf-Ipcity", "Munich")
+		req.Header.Set("Cf-Ipcountry", "DE")
+
+		s := session.NewInactiveSession()
+		require.NoError(t, reg.SessionManager().ActivateSession(req, s, &identity.Identity{NID: x.NewUUID(), State: identity.StateActive}, authAt))
+		require.Len(t, s.Devices, 1)
+		assert.Equal(t, "", *s.Devices[0].Location)
+		assert.Nil(t, s.Devices[0].Country)
+		assert.Empty(t, s.Binding.Country)
+	})
+
 	for k, tc := range []struct {
 		d        string
 		methods  []session.AuthenticationMethod
@@ -543,6 +579,26 @@ func TestSession(t *testing.T) {
 		assert.True(t, s.CanBeRefreshed(ctx, reg.Config()), "session is refreshable after 12hrs")
 	})
 
+	t.Run("case=impersonation", func(t *testing.T) {
+		ctx := contextx.WithConfigValues(t.Context(), map[string]any{
+			config.ViperKeySessionLifespan:           "24h",
+			config.ViperKeySessionRefreshMinTimeLeft: "12h",
+		})
+
+		s := session.NewInactiveSession()
+		s.CompletedLoginFor(identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		assert.False(t, s.IsImpersonated())
+
+		s.CompletedImpersonationBy("support@example.com")
+		assert.True(t, s.IsImpersonated())
+		assert.Equal(t, identity.CredentialsTypeImpersonation, s.AMR[1].Method)
+		assert.Equal(t, "support@example.com", s.AMR[1].ImpersonatedBy)
+		assert.False(t, s.AMR[1].CompletedAt.IsZero())
+
+		s.ExpiresAt = time.Now().Add(time.Minute)
+		assert.False(t, s.CanBeRefreshed(ctx, reg.Config()), "impersonation sessions are never refreshable")
+	})
+
 	t.Run("case=organization id", func(t *testing.T) {
 		t.Run("case=returns uuid.Nil for nil session", func(t *testing.T) {
 			var s *session.Session
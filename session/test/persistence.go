@@ -53,6 +53,10 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 
 			var expectedSessionDevice session.Device
 			require.NoError(t, faker.FakeData(&expectedSessionDevice))
+			expectedSessionDevice.Country = new("DE")
+			expectedSessionDevice.Region = new("Bavaria")
+			expectedSessionDevice.City = new("Munich")
+			expectedSessionDevice.ASN = new("3320")
 			expected.Devices = []session.Device{
 				expectedSessionDevice,
 			}
@@ -86,6 +90,10 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 					assert.Equal(t, *expected.Devices[i].IPAddress, *d.IPAddress)
 					assert.Equal(t, expected.Devices[i].UserAgent, d.UserAgent)
 					assert.Equal(t, *expected.Devices[i].Location, *d.Location)
+					assert.Equal(t, expected.Devices[i].Country, d.Country)
+					assert.Equal(t, expected.Devices[i].Region, d.Region)
+					assert.Equal(t, expected.Devices[i].City, d.City)
+					assert.Equal(t, expected.Devices[i].ASN, d.ASN)
 					assert.Equal(t, *expected.Devices[i].IdentityID, *d.IdentityID)
 				}
 			}
@@ -114,6 +122,21 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 				})
 			})
 
+			t.Run("method=list devices by identity", func(t *testing.T) {
+				checkDevices(p.ListDevicesByIdentity(ctx, expected.Identity.ID, 10))
+
+				actual, err := p.ListDevicesByIdentity(ctx, x.NewUUID(), 10)
+				require.NoError(t, err)
+				assert.Empty(t, actual)
+
+				t.Run("on another network", func(t *testing.T) {
+					_, p := testhelpers.NewNetwork(t, ctx, p)
+					actual, err := p.ListDevicesByIdentity(ctx, expected.Identity.ID, 10)
+					require.NoError(t, err)
+					assert.Empty(t, actual)
+				})
+			})
+
 			t.Run("case=update session", func(t *testing.T) {
 				expected.AuthenticatorAssuranceLevel = identity.AuthenticatorAssuranceLevel1
 				require.NoError(t, p.UpsertSession(ctx, &expected))
@@ -719,6 +742,27 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			assert.GreaterOrEqual(t, 10*time.Second, expectedExpiry.Sub(actual.ExpiresAt).Abs())
 		})
 
+		t.Run("extend session resets the idle expiry", func(t *testing.T) {
+			ctx := contextx.WithConfigValues(ctx, map[string]any{
+				config.ViperKeySessionRefreshMinTimeLeft: time.Hour,
+				config.ViperKeySessionIdleTimeout:        "15m",
+			})
+
+			var expected session.Session
+			require.NoError(t, faker.FakeData(&expected))
+			expected.ExpiresAt = time.Now().Add(time.Hour * 10).Round(time.Second).UTC()
+			expected.SetLastSeen(ctx, conf, time.Now().Add(-10*time.Minute))
+			require.NoError(t, p.CreateIdentity(ctx, expected.Identity))
+			require.NoError(t, p.UpsertSession(ctx, &expected))
+
+			require.NoError(t, p.ExtendSession(ctx, expected.ID))
+			actual, err := p.GetSession(ctx, expected.ID, session.ExpandNothing)
+			require.NoError(t, err)
+			assert.Equal(t, expected.ExpiresAt, actual.ExpiresAt, "the lifespan is not extended before the min time left is reached")
+			require.NotNil(t, actual.IdleExpiresAt)
+			assert.WithinDuration(t, time.Now().Add(15*time.Minute), time.Time(*actual.IdleExpiresAt), 5*time.Second)
+		})
+
 		t.Run("extend session lifespan on CockroachDB", func(t *testing.T) {
 			if p.GetConnection(ctx).Dialect.Name() != dbal.DriverCockroachDB {
 				t.Skip("Skipping test because driver is not CockroachDB")
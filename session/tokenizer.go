@@ -142,7 +142,7 @@ func (s *Tokenizer) TokenizeSession(ctx context.Context, template string, sessio
 		}
 		evaluated, err := vm.EvaluateAnonymousSnippet(tpl.ClaimsMapperURL, jsonnet.String())
 		if err != nil {
-			trace.SpanFromContext(ctx).AddEvent(events.NewJsonnetMappingFailed(
+			events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewJsonnetMappingFailed(
 				ctx, err, jsonnet.Bytes(), evaluated, "", "",
 			))
 			return errors.WithStack(herodot.ErrBadRequest().WithWrap(err).WithDebug(err.Error()).WithReasonf("Unable to execute tokenizer JsonNet."))
@@ -150,7 +150,7 @@ func (s *Tokenizer) TokenizeSession(ctx context.Context, template string, sessio
 
 		evaluatedClaims := gjson.Get(evaluated, "claims")
 		if !evaluatedClaims.IsObject() {
-			trace.SpanFromContext(ctx).AddEvent(events.NewJsonnetMappingFailed(
+			events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewJsonnetMappingFailed(
 				ctx, err, jsonnet.Bytes(), evaluated, "", "",
 			))
 			return errors.WithStack(herodot.ErrBadRequest().WithWrap(err).WithReasonf("Expected tokenizer JsonNet to return a claims object but it did not."))
@@ -175,7 +175,7 @@ func (s *Tokenizer) TokenizeSession(ctx context.Context, template string, sessio
 		return errors.WithStack(herodot.ErrBadRequest().WithWrap(err).WithReasonf("Unable to sign JSON Web Token."))
 	}
 
-	trace.SpanFromContext(ctx).AddEvent(events.NewSessionJWTIssued(ctx, session.ID, session.IdentityID, tpl.TTL))
+	events.Audit(ctx, trace.SpanFromContext(ctx)).AddEvent(events.NewSessionJWTIssued(ctx, session.ID, session.IdentityID, tpl.TTL))
 	session.Tokenized = result
 	return nil
 }
@@ -175,6 +175,7 @@ const (
 	ErrorValidationDeviceAuthnVerifierWrong
 	ErrorValidationDeviceAuthnRelaxedAttestationNoLongerValid
 	ErrorValidationDeviceAuthnKeyReenrollmentRequired
+	ErrorValidationTooManyMessages
 )
 
 const (
@@ -190,6 +191,8 @@ const (
 	ErrorValidationLoginLinkedCredentialsDoNotMatch                     // 4010009
 	ErrorValidationLoginAddressUnknown                                  // 4010010
 	ErrorValidationIdentityDisabled                                     // 4010011
+	ErrorValidationLoginLockedOut                                       // 4010012
+	ErrorValidationLoginTooManyAttempts                                 // 4010013
 )
 
 const (
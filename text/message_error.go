@@ -18,6 +18,7 @@ const (
 	ErrIDSessionRequiredForHigherAAL = "session_aal1_required"
 	ErrIDHigherAALRequired           = "session_aal2_required"
 	ErrIDNoActiveSession             = "session_inactive"
+	ErrIDSessionBindingViolated      = "session_binding_violated"
 	ErrIDRedirectURLNotAllowed       = "self_service_flow_return_to_forbidden"
 	ErrIDInitiatedBySomeoneElse      = "security_identity_mismatch"
 
@@ -322,3 +322,27 @@ func NewErrorValidationIdentityDisabled() *Message {
 		Type: Error,
 	}
 }
+
+func NewErrorValidationLoginLockedOut(c clock.Clock, lockedUntil time.Time) *Message {
+	return &Message{
+		ID:   ErrorValidationLoginLockedOut,
+		Text: fmt.Sprintf("Too many failed sign in attempts. This account is temporarily locked, please try again in %.2f minutes.", lockedUntil.Sub(c.Now()).Minutes()),
+		Type: Error,
+		Context: context(map[string]any{
+			"locked_until":      lockedUntil,
+			"locked_until_unix": lockedUntil.Unix(),
+		}),
+	}
+}
+
+func NewErrorValidationLoginTooManyAttempts(c clock.Clock, lockedUntil time.Time) *Message {
+	return &Message{
+		ID:   ErrorValidationLoginTooManyAttempts,
+		Text: fmt.Sprintf("Too many failed sign in attempts from your network. Please try again in %.2f minutes.", lockedUntil.Sub(c.Now()).Minutes()),
+		Type: Error,
+		Context: context(map[string]any{
+			"locked_until":      lockedUntil,
+			"locked_until_unix": lockedUntil.Unix(),
+		}),
+	}
+}
@@ -414,6 +414,14 @@ func NewErrorValidationDeviceAuthnKeyReenrollmentRequired() *Message {
 	}
 }
 
+func NewErrorValidationTooManyMessages() *Message {
+	return &Message{
+		ID:   ErrorValidationTooManyMessages,
+		Text: "Too many messages have been sent to this address. Please try again later.",
+		Type: Error,
+	}
+}
+
 func NewErrorValidationLookupAlreadyUsed() *Message {
 	return &Message{
 		ID:   ErrorValidationLookupAlreadyUsed,
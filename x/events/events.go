@@ -26,7 +26,9 @@ const (
 	IdentityUpdated          semconv.Event = "IdentityUpdated"
 	JsonnetMappingFailed     semconv.Event = "JsonnetMappingFailed"
 	LoginFailed              semconv.Event = "LoginFailed"
+	LoginFromNewDevice       semconv.Event = "LoginFromNewDevice"
 	LoginInitiated           semconv.Event = "LoginInitiated"
+	LoginLockedOut           semconv.Event = "LoginLockedOut"
 	LoginSucceeded           semconv.Event = "LoginSucceeded"
 	RecoveryFailed           semconv.Event = "RecoveryFailed"
 	RecoveryInitiatedByAdmin semconv.Event = "RecoveryInitiatedByAdmin"
@@ -34,10 +36,13 @@ const (
 	RegistrationFailed       semconv.Event = "RegistrationFailed"
 	RegistrationInitiated    semconv.Event = "RegistrationInitiated"
 	RegistrationSucceeded    semconv.Event = "RegistrationSucceeded"
+	SessionBindingViolated   semconv.Event = "SessionBindingViolated"
 	SessionChanged           semconv.Event = "SessionChanged"
 	SessionChecked           semconv.Event = "SessionChecked"
 	SessionIssued            semconv.Event = "SessionIssued"
+	SessionImpersonated      semconv.Event = "SessionImpersonated"
 	SessionLifespanExtended  semconv.Event = "SessionLifespanExtended"
+	SessionReportedByUser    semconv.Event = "SessionReportedByUser"
 	SessionRevoked           semconv.Event = "SessionRevoked"
 	SessionTokenizedAsJWT    semconv.Event = "SessionTokenizedAsJWT"
 	SettingsFailed           semconv.Event = "SettingsFailed"
@@ -60,6 +65,9 @@ const (
 	AttributeKeyJsonnetOutput                   semconv.AttributeKey = "JsonnetOutput"
 	AttributeKeyLoginRequestedAAL               semconv.AttributeKey = "LoginRequestedAAL"
 	AttributeKeyLoginRequestedPrivilegedSession semconv.AttributeKey = "LoginRequestedPrivilegedSession"
+	AttributeKeyLoginLockoutKind                semconv.AttributeKey = "LoginLockoutKind"
+	AttributeKeyLoginLockedUntil                semconv.AttributeKey = "LoginLockedUntil"
+	AttributeKeyLoginUnrecognized               semconv.AttributeKey = "LoginUnrecognized"
 	AttributeKeyOrganizationID                  semconv.AttributeKey = "OrganizationID"
 	AttributeKeyReason                          semconv.AttributeKey = "Reason" // Deprecated, use AttributeKeyErrorReason
 	// AttributeKeySelfServiceFlowType is the type of self-service flow, e.g. "api" or "browser".
@@ -74,8 +82,11 @@ const (
 	// Deprecated: use AttributeKeySelfServiceFlowName instead.
 	AttributeKeySelfServiceStrategyUsed    semconv.AttributeKey = "SelfServiceStrategyUsed"
 	AttributeKeySessionAAL                 semconv.AttributeKey = "SessionAAL"
+	AttributeKeySessionBindingChanges      semconv.AttributeKey = "SessionBindingChanges"
+	AttributeKeySessionBindingMode         semconv.AttributeKey = "SessionBindingMode"
 	AttributeKeySessionExpiresAt           semconv.AttributeKey = "SessionExpiresAt"
 	AttributeKeySessionID                  semconv.AttributeKey = "SessionID"
+	AttributeKeySessionImpersonatedBy      semconv.AttributeKey = "SessionImpersonatedBy"
 	AttributeKeyTokenizedSessionTTL        semconv.AttributeKey = "TokenizedSessionTTL"
 	AttributeKeyWebhookAttemptNumber       semconv.AttributeKey = "WebhookAttemptNumber"
 	AttributeKeyWebhookID                  semconv.AttributeKey = "WebhookID"
@@ -94,6 +105,18 @@ func attrSessionID(val uuid.UUID) otelattr.KeyValue {
 	return otelattr.String(AttributeKeySessionID.String(), val.String())
 }
 
+func attrSessionBindingChanges(changes []string) otelattr.KeyValue {
+	return otelattr.StringSlice(AttributeKeySessionBindingChanges.String(), changes)
+}
+
+func attrSessionBindingMode(mode string) otelattr.KeyValue {
+	return otelattr.String(AttributeKeySessionBindingMode.String(), mode)
+}
+
+func attrSessionImpersonatedBy(actor string) otelattr.KeyValue {
+	return otelattr.String(AttributeKeySessionImpersonatedBy.String(), actor)
+}
+
 func attrTokenizedSessionTTL(ttl time.Duration) otelattr.KeyValue {
 	return otelattr.String(AttributeKeyTokenizedSessionTTL.String(), ttl.String())
 }
@@ -110,6 +133,18 @@ func attrFlowRefresh(val bool) otelattr.KeyValue {
 	return otelattr.Bool(AttributeKeyFlowRefresh.String(), val)
 }
 
+func attrLoginLockoutKind(val string) otelattr.KeyValue {
+	return otelattr.String(AttributeKeyLoginLockoutKind.String(), val)
+}
+
+func attrLoginLockedUntil(val time.Time) otelattr.KeyValue {
+	return otelattr.String(AttributeKeyLoginLockedUntil.String(), val.String())
+}
+
+func attrLoginUnrecognized(val []string) otelattr.KeyValue {
+	return otelattr.StringSlice(AttributeKeyLoginUnrecognized.String(), val)
+}
+
 func attrOrganizationID(val string) otelattr.KeyValue {
 	return otelattr.String(AttributeKeyOrganizationID.String(), val)
 }
@@ -227,6 +262,19 @@ func NewSessionChanged(ctx context.Context, aal string, sessionID, identityID uu
 		)
 }
 
+func NewSessionImpersonated(ctx context.Context, sessionID, identityID uuid.UUID, actor string, expiresAt time.Time) (string, trace.EventOption) {
+	return SessionImpersonated.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrSessionID(sessionID),
+				attrSessionImpersonatedBy(actor),
+				attSessionExpiresAt(expiresAt),
+			)...,
+		)
+}
+
 func NewSessionLifespanExtended(ctx context.Context, sessionID, identityID uuid.UUID, newExpiry time.Time) (string, trace.EventOption) {
 	return SessionLifespanExtended.String(),
 		trace.WithAttributes(
@@ -446,6 +494,46 @@ func NewSessionRevoked(ctx context.Context, sessionID, identityID uuid.UUID) (st
 		)
 }
 
+func NewSessionBindingViolated(ctx context.Context, sessionID, identityID uuid.UUID, mode string, changes []string) (string, trace.EventOption) {
+	return SessionBindingViolated.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrSessionID(sessionID),
+				attrSessionBindingMode(mode),
+				attrSessionBindingChanges(changes),
+			)...,
+		)
+}
+
+// NewLoginFromNewDevice is emitted if a session is issued to a device, IP
+// range, or location which the identity did not use before.
+func NewLoginFromNewDevice(ctx context.Context, sessionID, identityID uuid.UUID, unrecognized []string) (string, trace.EventOption) {
+	return LoginFromNewDevice.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrSessionID(sessionID),
+				attrLoginUnrecognized(unrecognized),
+			)...,
+		)
+}
+
+// NewSessionReportedByUser is emitted if a user revoked a session they do not
+// recognize through the link of a new device notification.
+func NewSessionReportedByUser(ctx context.Context, sessionID, identityID uuid.UUID) (string, trace.EventOption) {
+	return SessionReportedByUser.String(),
+		trace.WithAttributes(
+			append(
+				semconv.AttributesFromContext(ctx),
+				semconv.AttrIdentityID(identityID),
+				attrSessionID(sessionID),
+			)...,
+		)
+}
+
 func NewSessionChecked(ctx context.Context, sessionID, identityID uuid.UUID) (string, trace.EventOption) {
 	return SessionChecked.String(),
 		trace.WithAttributes(
@@ -546,6 +634,22 @@ func NewLoginInitiated(ctx context.Context, flowID uuid.UUID, flowType string, r
 		trace.WithAttributes(attrs...)
 }
 
+// NewLoginLockedOut is emitted when too many failed login attempts lock an
+// identifier or a client IP address.
+func NewLoginLockedOut(ctx context.Context, identityID uuid.NullUUID, kind string, lockedUntil time.Time) (string, trace.EventOption) {
+	attrs := append(semconv.AttributesFromContext(ctx),
+		attrLoginLockoutKind(kind),
+		attrLoginLockedUntil(lockedUntil),
+	)
+
+	if identityID.Valid {
+		attrs = append(attrs, semconv.AttrIdentityID(identityID.UUID))
+	}
+
+	return LoginLockedOut.String(),
+		trace.WithAttributes(attrs...)
+}
+
 func NewRegistrationInitiated(ctx context.Context, flowID uuid.UUID, flowType string, organizationID uuid.NullUUID) (string, trace.EventOption) {
 	attrs := append(semconv.AttributesFromContext(ctx),
 		attrFlowID(flowID),
@@ -0,0 +1,56 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// Sink records events in addition to the span they are added to. Unlike
+	// span events, recorded events are kept even if no tracing backend is
+	// configured.
+	Sink interface {
+		RecordEvent(ctx context.Context, name string, attrs []otelattr.KeyValue)
+	}
+
+	sinkContextKey struct{}
+
+	sinkSpan struct {
+		trace.Span
+		ctx  context.Context
+		sink Sink
+	}
+)
+
+// ContextWithSink returns a context whose events are recorded by the sink.
+func ContextWithSink(ctx context.Context, sink Sink) context.Context {
+	return context.WithValue(ctx, sinkContextKey{}, sink)
+}
+
+// SinkFromContext returns the sink of the context, if any.
+func SinkFromContext(ctx context.Context) (Sink, bool) {
+	sink, ok := ctx.Value(sinkContextKey{}).(Sink)
+	return sink, ok && sink != nil
+}
+
+// Audit wraps the span so that events added to it are also recorded by the
+// sink of the context. If the context has no sink, the span is returned as is.
+//
+//	events.Audit(ctx, span).AddEvent(events.NewIdentityDeleted(ctx, id))
+func Audit(ctx context.Context, span trace.Span) trace.Span {
+	sink, ok := SinkFromContext(ctx)
+	if !ok {
+		return span
+	}
+	return &sinkSpan{Span: span, ctx: ctx, sink: sink}
+}
+
+func (s *sinkSpan) AddEvent(name string, opts ...trace.EventOption) {
+	s.Span.AddEvent(name, opts...)
+	s.sink.RecordEvent(s.ctx, name, trace.NewEventConfig(opts...).Attributes())
+}
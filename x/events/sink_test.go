@@ -0,0 +1,52 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/ory/kratos/x/events"
+	"github.com/ory/x/otelx/semconv"
+)
+
+type recordedEvent struct {
+	name  string
+	attrs []attribute.KeyValue
+}
+
+type recordingSink struct {
+	events []recordedEvent
+}
+
+func (s *recordingSink) RecordEvent(_ context.Context, name string, attrs []attribute.KeyValue) {
+	s.events = append(s.events, recordedEvent{name: name, attrs: attrs})
+}
+
+func TestAudit(t *testing.T) {
+	_, span := noop.NewTracerProvider().Tracer("").Start(context.Background(), "test")
+	id := uuid.Must(uuid.NewV4())
+
+	t.Run("case=without sink", func(t *testing.T) {
+		ctx := context.Background()
+		assert.Equal(t, span, events.Audit(ctx, span))
+	})
+
+	t.Run("case=with sink", func(t *testing.T) {
+		sink := new(recordingSink)
+		ctx := events.ContextWithSink(context.Background(), sink)
+
+		events.Audit(ctx, span).AddEvent(events.NewIdentityDeleted(ctx, id))
+
+		require.Len(t, sink.events, 1)
+		assert.Equal(t, events.IdentityDeleted.String(), sink.events[0].name)
+		assert.Contains(t, sink.events[0].attrs, semconv.AttrIdentityID(id))
+	})
+}
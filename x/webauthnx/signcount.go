@@ -5,6 +5,7 @@ package webauthnx
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-webauthn/webauthn/webauthn"
 
@@ -36,7 +37,8 @@ type SignCountDependencies interface {
 //     persist the counter must not turn a successful login into an error.
 //
 // The clone warning is durably stored on the credential and is inspectable via
-// the admin identity API, so there is no separate security log event.
+// the admin identity API, so there is no separate security log event. The same
+// write also records when the credential was last used.
 //
 // The write goes through UpdateCredentialsConfig, which re-reads the stored
 // config under an exclusive row lock and applies UpdateFromLogin to the latest
@@ -56,6 +58,9 @@ func UpdateSignCountAfterLogin(
 	err := d.PrivilegedIdentityPool().UpdateCredentialsConfig(ctx, i.ID, credentialsType,
 		identity.UpdateConfig(func(conf *identity.CredentialsWebAuthnConfig) error {
 			conf.Credentials.UpdateFromLogin(validated)
+			if validated != nil {
+				conf.Credentials.MarkUsed(validated.ID, time.Now())
+			}
 			return nil
 		}),
 	)